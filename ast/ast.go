@@ -35,6 +35,7 @@ func (*Program) node() {}
 
 func (*BlockStatement) node()      {}
 func (*ExpressionStatement) node() {}
+func (*ImportDeclaration) node()   {}
 func (*ReturnStatement) node()     {}
 func (*VariableDeclaration) node() {}
 func (*VariableDeclarator) node()  {}
@@ -100,6 +101,7 @@ type Statement interface {
 
 func (*BlockStatement) stmt()      {}
 func (*ExpressionStatement) stmt() {}
+func (*ImportDeclaration) stmt()   {}
 func (*ReturnStatement) stmt()     {}
 func (*VariableDeclaration) stmt() {}
 
@@ -146,6 +148,27 @@ func (s *ExpressionStatement) Copy() Node {
 	return ns
 }
 
+// ImportDeclaration imports the variables of a package, e.g. `import "team/helpers"`
+type ImportDeclaration struct {
+	*BaseNode
+	Path *StringLiteral `json:"path"`
+}
+
+// Type is the abstract type
+func (*ImportDeclaration) Type() string { return "ImportDeclaration" }
+
+func (d *ImportDeclaration) Copy() Node {
+	if d == nil {
+		return d
+	}
+	nd := new(ImportDeclaration)
+	*nd = *d
+
+	nd.Path = d.Path.Copy().(*StringLiteral)
+
+	return nd
+}
+
 // ReturnStatement defines an Expression to return
 type ReturnStatement struct {
 	*BaseNode
@@ -367,6 +390,8 @@ const (
 	EmptyOperator
 	EqualOperator
 	NotEqualOperator
+	RegexpMatchOperator
+	NotRegexpMatchOperator
 	opEnd
 )
 
@@ -822,6 +847,8 @@ var OperatorTokens = map[OperatorKind]string{
 	StartsWithOperator:       "startswith",
 	EqualOperator:            "==",
 	NotEqualOperator:         "!=",
+	RegexpMatchOperator:      "=~",
+	NotRegexpMatchOperator:   "!~",
 }
 
 // LogicalOperatorTokens converts LogicalOperatorKind to string
@@ -107,6 +107,17 @@ func (s *ExpressionStatement) UnmarshalJSON(data []byte) error {
 	s.Expression = e
 	return nil
 }
+func (d *ImportDeclaration) MarshalJSON() ([]byte, error) {
+	type Alias ImportDeclaration
+	raw := struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  d.Type(),
+		Alias: (*Alias)(d),
+	}
+	return json.Marshal(raw)
+}
 func (s *ReturnStatement) MarshalJSON() ([]byte, error) {
 	type Alias ReturnStatement
 	raw := struct {
@@ -832,6 +843,8 @@ func unmarshalNode(msg json.RawMessage) (Node, error) {
 		node = new(BlockStatement)
 	case "ExpressionStatement":
 		node = new(ExpressionStatement)
+	case "ImportDeclaration":
+		node = new(ImportDeclaration)
 	case "ReturnStatement":
 		node = new(ReturnStatement)
 	case "VariableDeclaration":
@@ -47,6 +47,13 @@ func TestJSONMarshal(t *testing.T) {
 			},
 			want: `{"type":"ExpressionStatement","expression":{"type":"StringLiteral","value":"hello"}}`,
 		},
+		{
+			name: "import declaration",
+			node: &ast.ImportDeclaration{
+				Path: &ast.StringLiteral{Value: "team/helpers"},
+			},
+			want: `{"type":"ImportDeclaration","path":{"type":"StringLiteral","value":"team/helpers"}}`,
+		},
 		{
 			name: "return statement",
 			node: &ast.ReturnStatement{
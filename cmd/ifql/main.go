@@ -13,6 +13,7 @@ import (
 	"sort"
 
 	"github.com/influxdata/ifql"
+	"github.com/influxdata/ifql/query/csv"
 	"github.com/influxdata/ifql/query/execute"
 	"github.com/influxdata/ifql/tracing"
 	"github.com/opentracing/opentracing-go"
@@ -26,11 +27,14 @@ var cpuprofile = flag.String("cpuprofile", "", "write cpu profile `file`")
 var memprofile = flag.String("memprofile", "", "write memory profile to `file`")
 var verbose = flag.Bool("v", false, "print verbose output")
 var trace = flag.Bool("trace", false, "print trace output")
+var format = flag.String("format", "table", "output format of the results, one of: table, csv")
 
 var hosts = make(hostList, 0)
+var files = make(hostList, 0)
 
 func init() {
 	flag.Var(&hosts, "host", "An InfluxDB host to connect to. Can be provided multiple times.")
+	flag.Var(&files, "file", "A line protocol file to query instead of InfluxDB hosts. Can be provided multiple times.")
 }
 
 type hostList []string
@@ -95,13 +99,16 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Println("Running query:")
-	fmt.Println(queryStr)
+	if *format != "csv" {
+		fmt.Println("Running query:")
+		fmt.Println(queryStr)
+	}
 	if len(hosts) == 0 {
 		hosts = defaultStorageHosts
 	}
 	c, err := ifql.NewController(ifql.Config{
 		Hosts:            hosts,
+		Files:            files,
 		ConcurrencyQuota: runtime.NumCPU() * 2,
 		MemoryBytesQuota: math.MaxInt64,
 		Verbose:          *verbose,
@@ -127,16 +134,29 @@ func main() {
 	}
 	sort.Strings(names)
 
-	for _, name := range names {
-		r := results[name]
-		blocks := r.Blocks()
-		fmt.Println("Result:", name)
-		err := blocks.Do(func(b execute.Block) error {
-			execute.NewFormatter(b, nil).WriteTo(os.Stdout)
-			return nil
-		})
-		if err != nil {
-			fmt.Println("Error:", err)
+	switch *format {
+	case "csv":
+		enc := csv.NewResultEncoder(os.Stdout)
+		for _, name := range names {
+			if err := enc.Encode(name, results[name]); err != nil {
+				fmt.Println("Error:", err)
+			}
+		}
+	default:
+		for _, name := range names {
+			r := results[name]
+			fmt.Println("Result:", name)
+			err := r.DoWithRetractions(func(b execute.Block) error {
+				execute.NewFormatter(b, nil).WriteTo(os.Stdout)
+				return nil
+			}, func(meta execute.BlockMetadata) error {
+				bounds := meta.Bounds()
+				fmt.Printf("Retracted: Bounds: %v - %v Tags: %v\n", bounds.Start, bounds.Stop, meta.Tags())
+				return nil
+			})
+			if err != nil {
+				fmt.Println("Error:", err)
+			}
 		}
 	}
 
@@ -1,13 +1,14 @@
 /*
 IFQLD is a basic HTTP server that exposes a sinle endpoint
 for processing IFQL queries to 1 or more InfluxDB servers.
-It can return data in either line protocol or a new JSON
-lines format. Requests go here:
+It can return data in either line protocol, a new JSON
+lines format or annotated CSV. Requests go here:
 
 http://localhost:8080/query?q=...&verbose=true&trace=true&format=line|json
 
 q is the IFQL query string. Format specifies what the response
-format should be. JSON is the default. verbose and trace are optional
+format should be. JSON is the default. Annotated CSV is
+returned when the request has an Accept header of text/csv. verbose and trace are optional
 parameters that will make the server output additional log
 information.
 */
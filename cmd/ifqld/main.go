@@ -1,69 +1,59 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
-	"strconv"
-	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/influxdata/ifql"
 	"github.com/influxdata/ifql/idfile"
-	"github.com/influxdata/ifql/query"
-	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/server"
 	"github.com/influxdata/ifql/tracing"
-	"github.com/influxdata/influxdb/models"
 	client "github.com/influxdata/usage-client/v1"
 	"github.com/jessevdk/go-flags"
-	opentracing "github.com/opentracing/opentracing-go"
 	uuid "github.com/satori/go.uuid"
-
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var version string
 var commit string
 var date string
 var startTime = time.Now()
-var queryCount int64
 
 type options struct {
 	Hosts             []string       `long:"host" short:"h" description:"influx hosts to query from. Can be specified more than once for multiple hosts." default:"localhost:8082" env:"HOSTS" env-delim:","`
+	Files             []string       `long:"file" description:"line protocol files to query instead of influx hosts. Can be specified more than once for multiple files." env:"FILES" env-delim:","`
+	ShardMapFile      string         `long:"shard-map-file" description:"File of the shards of each database on the influx hosts, used to read only the hosts and shards a query needs" env:"SHARD_MAP_FILE"`
+	ShardMapRefresh   time.Duration  `long:"shard-map-refresh" description:"How often the shard map file is reloaded, 0 means it is loaded once" default:"1m" env:"SHARD_MAP_REFRESH"`
 	Addr              string         `long:"bind-address" short:"b" description:"The address to listen on for HTTP requests" default:":8093" env:"BIND_ADDRESS"`
 	IDFile            flags.Filename `long:"id-file" description:"Path to file that persists ifqld id" env:"ID_FILE" default:"./ifqld.id"`
 	ReportingDisabled bool           `short:"r" long:"reporting-disabled" description:"Disable reporting of usage stats (os,arch,version,cluster_id,uptime,queryCount) once every 4hrs" env:"REPORTING_DISABLED"`
 	Verbose           bool           `short:"v" long:"verbose" description:"Log more verbose debugging output"`
 	ConcurrencyQuota  int            `short:"c" long:"concurrency-quota" description:"Maximum concurrency allowed" env:"CONCURRENCY_QUOTA"`
 	MemoryBytesQuota  int            `short:"m" long:"memory-quota" description:"Approximate maximum memory usage allowed in bytes" env:"MEMORY_BYTES_QUOTA"`
+	SpillDir          string         `long:"spill-dir" description:"Directory where queries write data to disk when they near their memory quota, queries fail instead when empty" env:"SPILL_DIR"`
+	CacheMaxRows      int            `long:"cache-max-rows" description:"Maximum number of rows held in the result cache, 0 disables the cache" env:"CACHE_MAX_ROWS"`
+	CacheTTL          time.Duration  `long:"cache-ttl" description:"Duration for which cached results are served" default:"5m" env:"CACHE_TTL"`
+	CacheChunk        time.Duration  `long:"cache-chunk" description:"Minimum duration of the chunks of time in which results are cached, rounded up to a multiple of the window of a query" default:"1h" env:"CACHE_CHUNK"`
+	LibDirs           []string       `long:"lib-dir" description:"Directory searched for the source files of imported IFQL packages. Can be specified more than once for multiple directories." env:"LIB_DIRS" env-delim:","`
+	QueueTimeout      time.Duration  `long:"queue-timeout" description:"Default maximum duration a query waits for resources before it starts executing, 0 means no timeout" env:"QUEUE_TIMEOUT"`
+	ExecuteTimeout    time.Duration  `long:"execute-timeout" description:"Default maximum duration a query executes, 0 means no timeout. Does not apply to continuous queries" env:"EXECUTE_TIMEOUT"`
+	ShutdownTimeout   time.Duration  `long:"shutdown-timeout" description:"Maximum duration queries are given to finish on shutdown before they are canceled" default:"30s" env:"SHUTDOWN_TIMEOUT"`
+	TokenFile         string         `long:"token-file" description:"File of the bearer tokens of users and the databases and hosts they may read, requests must be authenticated when set" env:"TOKEN_FILE"`
+	UsersFile         string         `long:"users-file" description:"File of the HTTP basic authentication users and the databases and hosts they may read, requests must be authenticated when set" env:"USERS_FILE"`
+	TenantConcurrency int            `long:"tenant-concurrency-quota" description:"Maximum concurrency allowed per tenant, 0 means the concurrency quota" env:"TENANT_CONCURRENCY_QUOTA"`
+	TenantMemoryBytes int64          `long:"tenant-memory-quota" description:"Approximate maximum memory usage allowed per tenant in bytes, 0 means no limit" env:"TENANT_MEMORY_BYTES_QUOTA"`
+	TenantMaxQueued   int            `long:"tenant-max-queued" description:"Maximum number of queries a tenant may have waiting for resources, further queries are rejected. 0 means no limit" default:"100" env:"TENANT_MAX_QUEUED"`
+	TenantsFile       string         `long:"tenants-file" description:"File of the quotas and scheduling weights of tenants, overriding the tenant defaults" env:"TENANTS_FILE"`
 }
 
 var opts = options{
 	ConcurrencyQuota: runtime.NumCPU() * 2,
 }
-var controller *ifql.Controller
-
-var functionCounter = prometheus.NewCounterVec(
-	prometheus.CounterOpts{
-		Name: "ifql_function_count",
-		Help: "How times a function was used in a query",
-	},
-	[]string{"function"},
-)
-
-var queryCounter = prometheus.NewCounter(prometheus.CounterOpts{
-	Name: "ifql_query_count",
-	Help: "Number of queries executed",
-})
-
-func init() {
-	prometheus.MustRegister(functionCounter)
-	prometheus.MustRegister(queryCounter)
-}
 
 func main() {
 	parser := flags.NewParser(&opts, flags.Default)
@@ -79,313 +69,107 @@ func main() {
 		}
 		os.Exit(code)
 	}
-	c, err := ifql.NewController(ifql.Config{
-		Hosts:            opts.Hosts,
-		ConcurrencyQuota: opts.ConcurrencyQuota,
-		MemoryBytesQuota: opts.MemoryBytesQuota,
-	})
-	if err != nil {
-		log.Fatal(err)
-	}
-	controller = c
-
-	http.Handle("/metrics", promhttp.Handler())
-	http.Handle("/query", http.HandlerFunc(HandleQuery))
-	http.Handle("/queries", http.HandlerFunc(HandleQueries))
-
-	if !opts.ReportingDisabled {
-		id := ID(string(opts.IDFile))
-		go reportUsageStats(id)
+	var authenticators server.MultiAuthenticator
+	if opts.TokenFile != "" {
+		a, err := server.NewTokenAuthenticator(opts.TokenFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		authenticators = append(authenticators, a)
 	}
-
-	if tr := tracing.Open("ifqld"); tr != nil {
-		defer tr.Close()
+	if opts.UsersFile != "" {
+		a, err := server.NewBasicAuthenticator(opts.UsersFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		authenticators = append(authenticators, a)
 	}
-
-	log.Printf("Starting version %s on %s\n", version, opts.Addr)
-	log.Fatal(http.ListenAndServe(opts.Addr, nil))
-}
-
-// TODO (pauldix): pull all this out into a server object that can
-//                 be tested. Alas, demo day waits for no person.
-
-// HandleQuery interprets and executes ifql syntax and returns results
-func HandleQuery(w http.ResponseWriter, req *http.Request) {
-	span, ctx := opentracing.StartSpanFromContext(req.Context(), "query")
-	defer span.Finish()
-
-	atomic.AddInt64(&queryCount, 1)
-	queryCounter.Inc()
-
 	var (
-		q   *ifql.Query
-		err error
+		authenticator server.Authenticator
+		authorizer    ifql.Authorizer
 	)
-	if req.Header.Get("Content-type") == "application/json" {
-		spec := new(query.Spec)
-		if err := json.NewDecoder(req.Body).Decode(spec); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Sprintf("Error parsing query spec %s", err.Error())))
-			log.Println("Error:", err)
-			return
-		}
-
-		q, err = controller.Query(ctx, spec)
-	} else {
-		queryStr := req.FormValue("q")
-		if queryStr == "" {
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte("must pass query in q parameter"))
-			return
-		}
-		if opts.Verbose {
-			log.Print(queryStr)
-		}
-
-		analyze := req.FormValue("analyze") != ""
-		if analyze {
-			spec, err := query.Compile(ctx, queryStr)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(fmt.Sprintf("Error compiling query %s", err.Error())))
-				return
-			}
-			encodeJSON(w, http.StatusOK, spec)
-			return
-		}
-
-		q, err = controller.QueryWithCompile(ctx, queryStr)
-	}
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Sprintf("Error constructing query %s", err.Error())))
-		return
+	if len(authenticators) > 0 {
+		authenticator = authenticators
+		authorizer = server.UserAuthorizer{}
 	}
-	defer q.Done()
 
-	funcs, err := q.Spec.Functions()
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Sprintf("Error analyzing query %s", err.Error())))
-		return
+	defaultTenantQuota := ifql.TenantQuota{
+		ConcurrencyQuota: opts.TenantConcurrency,
+		MemoryBytesQuota: opts.TenantMemoryBytes,
+		MaxQueued:        opts.TenantMaxQueued,
 	}
-
-	if opts.Verbose {
-		if octets, err := json.MarshalIndent(q.Spec, "", "    "); err == nil {
-			log.Print(string(octets))
+	var tenantQuotas map[string]ifql.TenantQuota
+	if opts.TenantsFile != "" {
+		var err error
+		tenantQuotas, err = readTenantQuotas(opts.TenantsFile, defaultTenantQuota)
+		if err != nil {
+			log.Fatal(err)
 		}
 	}
 
-	for _, f := range funcs {
-		functionCounter.WithLabelValues(f).Inc()
-	}
-
-	results, ok := <-q.Ready
-	if !ok {
-		err := q.Err()
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Sprintf("Error executing query %s", err.Error())))
-		return
-	}
-	switch req.Header.Get("Accept") {
-	case "application/json":
-		writeJSONChunks(results, w)
-	default:
-		writeLineResults(results, w)
-	}
-}
-
-type QueriesResponse struct {
-	Queries []Query
-}
-
-type Query struct {
-	ID    string
-	State string
-}
-
-// HandleQueries returns the running queries
-func HandleQueries(w http.ResponseWriter, req *http.Request) {
-	qs := controller.Queries()
-	var queries QueriesResponse
-	queries.Queries = make([]Query, len(qs))
-	for i, q := range qs {
-		queries.Queries[i] = Query{
-			ID:    strconv.FormatUint(uint64(q.ID()), 10),
-			State: q.State().String(),
-		}
-	}
-	err := json.NewEncoder(w).Encode(queries)
+	sr, err := ifql.NewStorageReader(opts.Hosts, opts.Files)
 	if err != nil {
-		log.Println(err)
+		log.Fatal(err)
 	}
-}
-
-func iterateResults(r execute.Result, f func(measurement, fieldName string, tags map[string]string, value interface{}, t time.Time)) {
-	blocks := r.Blocks()
-
-	err := blocks.Do(func(b execute.Block) error {
-
-		times := b.Times()
-		times.DoTime(func(ts []execute.Time, rr execute.RowReader) {
-			for i, time := range ts {
-				var measurement, fieldName string
-				tags := map[string]string{}
-				var value interface{}
-
-				for j, c := range rr.Cols() {
-					if c.IsTag() {
-						if c.Label == "_measurement" {
-							measurement = rr.AtString(i, j)
-						} else if c.Label == "_field" {
-							fieldName = rr.AtString(i, j)
-						} else {
-							tags[c.Label] = rr.AtString(i, j)
-						}
-					} else {
-						switch c.Type {
-						case execute.TBool:
-							value = rr.AtBool(i, j)
-						case execute.TInt:
-							value = rr.AtInt(i, j)
-						case execute.TUInt:
-							value = rr.AtUInt(i, j)
-						case execute.TFloat:
-							value = rr.AtFloat(i, j)
-						case execute.TString:
-							value = rr.AtString(i, j)
-						case execute.TTime:
-							value = rr.AtTime(i, j)
-						default:
-							value = "unknown"
-						}
-					}
-				}
-
-				if measurement == "" {
-					measurement = "measurement"
-				}
-				if fieldName == "" {
-					fieldName = "value"
-				}
-				f(measurement, fieldName, tags, value, time.Time())
-			}
-		})
-		return nil
+	c, err := ifql.NewController(ifql.Config{
+		Hosts:              opts.Hosts,
+		Files:              opts.Files,
+		StorageReader:      sr,
+		ShardMapFile:       opts.ShardMapFile,
+		ShardMapRefresh:    opts.ShardMapRefresh,
+		ConcurrencyQuota:   opts.ConcurrencyQuota,
+		MemoryBytesQuota:   opts.MemoryBytesQuota,
+		SpillDir:           opts.SpillDir,
+		CacheMaxRows:       opts.CacheMaxRows,
+		CacheTTL:           opts.CacheTTL,
+		CacheChunk:         opts.CacheChunk,
+		LibDirs:            opts.LibDirs,
+		QueueTimeout:       opts.QueueTimeout,
+		ExecuteTimeout:     opts.ExecuteTimeout,
+		Authorizer:         authorizer,
+		TenantQuotas:       tenantQuotas,
+		DefaultTenantQuota: defaultTenantQuota,
 	})
 	if err != nil {
-		log.Println("Error iterating through results:", err)
+		log.Fatal(err)
 	}
-}
-
-type header struct {
-	Result   string            `json:"result"`
-	SeriesID int64             `json:"seriesID"`
-	Tags     map[string]string `json:"tags"`
-}
-
-type chunk struct {
-	Points []point `json:"points"`
-}
-
-type point struct {
-	Value   interface{}       `json:"value"`
-	Time    int64             `json:"time"`
-	Context map[string]string `json:"context,omitempty"`
-}
-
-func writeJSONChunks(results map[string]execute.Result, w http.ResponseWriter) {
-	seriesID := int64(0)
-	for name, r := range results {
-		blocks := r.Blocks()
-
-		err := blocks.Do(func(b execute.Block) error {
-			seriesID++
-
-			// output header
-			h := header{Result: name, SeriesID: seriesID, Tags: b.Tags()}
-			bb, err := json.Marshal(h)
-			if err != nil {
-				return err
-			}
-			_, err = w.Write(bb)
-			if err != nil {
-				return err
-			}
-			_, err = w.Write([]byte("\n"))
-			if err != nil {
-				return err
-			}
+	srv := server.New(server.Config{
+		Controller:    c,
+		StorageReader: sr,
+		LibDirs:       opts.LibDirs,
+		Authenticator: authenticator,
+		Verbose:       opts.Verbose,
+	})
 
-			times := b.Times()
-			times.DoTime(func(ts []execute.Time, rr execute.RowReader) {
-				ch := chunk{Points: make([]point, len(ts))}
-				for i, time := range ts {
-					ch.Points[i].Time = time.Time().UnixNano()
+	if !opts.ReportingDisabled {
+		id := ID(string(opts.IDFile))
+		go reportUsageStats(id, srv)
+	}
 
-					for j, c := range rr.Cols() {
-						if !c.Common && c.Type == execute.TString {
-							if ch.Points[i].Context == nil {
-								ch.Points[i].Context = make(map[string]string)
-							}
-							ch.Points[i].Context[c.Label] = rr.AtString(i, j)
-						} else if c.IsValue() {
-							switch c.Type {
-							case execute.TFloat:
-								ch.Points[i].Value = rr.AtFloat(i, j)
-							case execute.TInt:
-								ch.Points[i].Value = rr.AtInt(i, j)
-							case execute.TString:
-								ch.Points[i].Value = rr.AtString(i, j)
-							case execute.TUInt:
-								ch.Points[i].Value = rr.AtUInt(i, j)
-							case execute.TBool:
-								ch.Points[i].Value = rr.AtBool(i, j)
-							default:
-								ch.Points[i].Value = "unknown"
-							}
-						}
-					}
-				}
+	if tr := tracing.Open("ifqld"); tr != nil {
+		defer tr.Close()
+	}
 
-				// write it out
-				b, err := json.Marshal(ch)
-				if err != nil {
-					log.Println("error marshaling chunk: ", err.Error())
-					return
-				}
-				_, err = w.Write(b)
-				if err != nil {
-					log.Println("error writing chunk: ", err.Error())
-					return
-				}
-				_, err = w.Write([]byte("\n"))
-				if err != nil {
-					log.Println("error writing newline: ", err.Error())
-					return
-				}
-				w.(http.Flusher).Flush()
-			})
-			return nil
-		})
-		if err != nil {
-			log.Println("Error iterating through results:", err)
+	// Let the queries being served finish when the process is asked to stop.
+	shutdown := make(chan struct{})
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		log.Println("Shutting down")
+		ctx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Println("Error shutting down:", err)
 		}
-	}
-}
+		close(shutdown)
+	}()
 
-func writeLineResults(results map[string]execute.Result, w http.ResponseWriter) {
-	for _, r := range results {
-		iterateResults(r, func(m, f string, tags map[string]string, val interface{}, t time.Time) {
-			p, err := models.NewPoint(m, models.NewTags(tags), map[string]interface{}{f: val}, t)
-			if err != nil {
-				log.Println("error creating new point", err)
-				return
-			}
-			w.Write([]byte(p.String()))
-			w.Write([]byte("\n"))
-		})
+	log.Printf("Starting version %s on %s\n", version, opts.Addr)
+	if err := srv.ListenAndServe(opts.Addr); err != http.ErrServerClosed {
+		log.Fatal(err)
 	}
+	<-shutdown
 }
 
 // ID returns the id of the running ifqld process
@@ -398,7 +182,7 @@ func ID(filepath string) string {
 }
 
 // reportUsageStats starts periodic server reporting.
-func reportUsageStats(id string) {
+func reportUsageStats(id string, srv *server.Server) {
 	reporter := client.New("")
 	u := &client.Usage{
 		Product: "ifqld",
@@ -411,7 +195,7 @@ func reportUsageStats(id string) {
 				},
 				Values: client.Values{
 					"cluster_id": id,
-					"queryCount": atomic.LoadInt64(&queryCount),
+					"queryCount": srv.QueryCount(),
 					"uptime":     time.Since(startTime).Seconds(),
 				},
 			},
@@ -424,14 +208,7 @@ func reportUsageStats(id string) {
 	for {
 		<-ticker.C
 		u.Data[0].Values["uptime"] = time.Since(startTime).Seconds()
-		u.Data[0].Values["queryCount"] = atomic.LoadInt64(&queryCount)
+		u.Data[0].Values["queryCount"] = srv.QueryCount()
 		go reporter.Save(u)
 	}
 }
-
-func encodeJSON(w http.ResponseWriter, status int, v interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	// already wrote to client
-	_ = json.NewEncoder(w).Encode(v)
-}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/ifql"
+)
+
+// readTenantQuotas reads the quotas of tenants from the file at path.
+// Each line of the file is the name of a tenant followed by space separated key=value quotas,
+// of the keys concurrency, memory, queued and weight. Quotas that are not set are those of def.
+// Empty lines and lines starting with # are ignored.
+func readTenantQuotas(path string, def ifql.TenantQuota) (map[string]ifql.TenantQuota, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	quotas := make(map[string]ifql.TenantQuota)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		name := fields[0]
+		if _, ok := quotas[name]; ok {
+			return nil, fmt.Errorf("%s:%d: duplicate tenant %q", path, n, name)
+		}
+		quota := def
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("%s:%d: expected key=value, got %q", path, n, field)
+			}
+			v, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil || v < 0 {
+				return nil, fmt.Errorf("%s:%d: invalid %s quota %q", path, n, kv[0], kv[1])
+			}
+			switch kv[0] {
+			case "concurrency":
+				quota.ConcurrencyQuota = int(v)
+			case "memory":
+				quota.MemoryBytesQuota = v
+			case "queued":
+				quota.MaxQueued = int(v)
+			case "weight":
+				quota.Weight = int(v)
+			default:
+				return nil, fmt.Errorf("%s:%d: unknown quota %q", path, n, kv[0])
+			}
+		}
+		quotas[name] = quota
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return quotas, nil
+}
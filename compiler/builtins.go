@@ -0,0 +1,258 @@
+package compiler
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/influxdata/ifql/semantic"
+)
+
+// BuiltinFunc implements a builtin function.
+// The arguments have already been checked against the function's signature.
+// An error fails the evaluation of the function that calls the builtin.
+type BuiltinFunc func(args Scope) (Value, error)
+
+type builtin struct {
+	sig semantic.FunctionSignature
+	fn  BuiltinFunc
+}
+
+var builtins = make(map[string]builtin)
+
+// RegisterBuiltin registers a pure function that can be called from compiled functions.
+// The signature is registered with the semantic package so that calls can be type checked.
+func RegisterBuiltin(name string, sig semantic.FunctionSignature, fn BuiltinFunc) {
+	if _, ok := builtins[name]; ok {
+		panic(fmt.Errorf("duplicate registration for builtin %q", name))
+	}
+	semantic.RegisterBuiltinFunction(name, sig)
+	builtins[name] = builtin{
+		sig: sig,
+		fn:  fn,
+	}
+}
+
+func init() {
+	// Math
+	RegisterBuiltin("abs", floatSignature("x"), func(args Scope) (Value, error) {
+		return NewFloat(math.Abs(args.GetFloat("x"))), nil
+	})
+	RegisterBuiltin("pow", floatSignature("x", "y"), func(args Scope) (Value, error) {
+		return NewFloat(math.Pow(args.GetFloat("x"), args.GetFloat("y"))), nil
+	})
+	RegisterBuiltin("log", floatSignature("x"), func(args Scope) (Value, error) {
+		return NewFloat(math.Log(args.GetFloat("x"))), nil
+	})
+	RegisterBuiltin("round", floatSignature("x"), func(args Scope) (Value, error) {
+		return NewFloat(math.Round(args.GetFloat("x"))), nil
+	})
+	RegisterBuiltin("floor", floatSignature("x"), func(args Scope) (Value, error) {
+		return NewFloat(math.Floor(args.GetFloat("x"))), nil
+	})
+
+	// Strings
+	RegisterBuiltin("toUpper", semantic.FunctionSignature{
+		Params:     map[string]semantic.Type{"v": semantic.String},
+		ReturnType: semantic.String,
+	}, func(args Scope) (Value, error) {
+		return NewString(strings.ToUpper(args.GetString("v"))), nil
+	})
+	RegisterBuiltin("hasPrefix", semantic.FunctionSignature{
+		Params: map[string]semantic.Type{
+			"v":      semantic.String,
+			"prefix": semantic.String,
+		},
+		ReturnType: semantic.Bool,
+	}, func(args Scope) (Value, error) {
+		return NewBool(strings.HasPrefix(args.GetString("v"), args.GetString("prefix"))), nil
+	})
+	RegisterBuiltin("replace", semantic.FunctionSignature{
+		Params: map[string]semantic.Type{
+			"v":   semantic.String,
+			"old": semantic.String,
+			"new": semantic.String,
+		},
+		ReturnType: semantic.String,
+	}, func(args Scope) (Value, error) {
+		return NewString(strings.Replace(args.GetString("v"), args.GetString("old"), args.GetString("new"), -1)), nil
+	})
+	RegisterBuiltin("strlen", semantic.FunctionSignature{
+		Params:     map[string]semantic.Type{"v": semantic.String},
+		ReturnType: semantic.Int,
+	}, func(args Scope) (Value, error) {
+		return NewInt(int64(utf8.RuneCountInString(args.GetString("v")))), nil
+	})
+	RegisterBuiltin("substring", semantic.FunctionSignature{
+		Params: map[string]semantic.Type{
+			"v":     semantic.String,
+			"start": semantic.Int,
+			"end":   semantic.Int,
+		},
+		ReturnType: semantic.String,
+	}, func(args Scope) (Value, error) {
+		return NewString(substring(args.GetString("v"), args.GetInt("start"), args.GetInt("end"))), nil
+	})
+
+	// Time
+	RegisterBuiltin("truncate", semantic.FunctionSignature{
+		Params: map[string]semantic.Type{
+			"t":    semantic.Time,
+			"unit": semantic.Duration,
+		},
+		ReturnType: semantic.Time,
+	}, func(args Scope) (Value, error) {
+		return NewTime(truncate(args.GetTime("t"), args.GetDuration("unit"))), nil
+	})
+	RegisterBuiltin("hour", semantic.FunctionSignature{
+		Params:     map[string]semantic.Type{"t": semantic.Time},
+		ReturnType: semantic.Int,
+	}, func(args Scope) (Value, error) {
+		return NewInt(int64(utcTime(args.GetTime("t")).Hour())), nil
+	})
+	RegisterBuiltin("weekday", semantic.FunctionSignature{
+		Params:     map[string]semantic.Type{"t": semantic.Time},
+		ReturnType: semantic.Int,
+	}, func(args Scope) (Value, error) {
+		return NewInt(int64(utcTime(args.GetTime("t")).Weekday())), nil
+	})
+
+	// Conversions
+	for name, k := range map[string]semantic.Kind{
+		"toBool":   semantic.Bool,
+		"toInt":    semantic.Int,
+		"toUInt":   semantic.UInt,
+		"toFloat":  semantic.Float,
+		"toString": semantic.String,
+		"toTime":   semantic.Time,
+	} {
+		k := k
+		RegisterBuiltin(name, semantic.FunctionSignature{
+			Params:     map[string]semantic.Type{"v": semantic.Invalid},
+			ReturnType: k,
+		}, func(args Scope) (Value, error) {
+			return Convert(args["v"], k)
+		})
+	}
+}
+
+// floatSignature returns the signature of a function of float parameters that returns a float.
+func floatSignature(params ...string) semantic.FunctionSignature {
+	sig := semantic.FunctionSignature{
+		Params:     make(map[string]semantic.Type, len(params)),
+		ReturnType: semantic.Float,
+	}
+	for _, p := range params {
+		sig.Params[p] = semantic.Float
+	}
+	return sig
+}
+
+// substring returns the characters of s in the range [start, end).
+// The range is clamped to the bounds of the string.
+func substring(s string, start, end int64) string {
+	runes := []rune(s)
+	n := int64(len(runes))
+	if start < 0 {
+		start = 0
+	}
+	if end > n {
+		end = n
+	}
+	if start >= end {
+		return ""
+	}
+	return string(runes[start:end])
+}
+
+// truncate rounds t down to a multiple of unit since the Unix epoch.
+func truncate(t Time, unit Duration) Time {
+	if unit <= 0 {
+		return t
+	}
+	r := Time(int64(t) % int64(unit))
+	if r < 0 {
+		r += Time(unit)
+	}
+	return t - r
+}
+
+func utcTime(t Time) time.Time {
+	return time.Unix(0, int64(t)).UTC()
+}
+
+type callEvaluator struct {
+	t      semantic.Type
+	name   string
+	params map[string]semantic.Type
+	args   map[string]Evaluator
+	fn     BuiltinFunc
+}
+
+func (e *callEvaluator) Type() semantic.Type {
+	return e.t
+}
+
+func (e *callEvaluator) eval(scope Scope) Value {
+	args := make(Scope, len(e.args))
+	for k, node := range e.args {
+		v := eval(node, scope)
+		// Integer arguments are converted when passed to float parameters
+		if e.params[k] == semantic.Float {
+			switch v.Type().Kind() {
+			case semantic.Int:
+				v = NewFloat(float64(v.Int()))
+			case semantic.UInt:
+				v = NewFloat(float64(v.UInt()))
+			}
+		}
+		args[k] = v
+	}
+	v, err := e.fn(args)
+	if err != nil {
+		panic(evalError{err: fmt.Errorf("%s: %v", e.name, err)})
+	}
+	return v
+}
+
+func (e *callEvaluator) EvalBool(scope Scope) bool {
+	checkKind(e.t.Kind(), semantic.Bool)
+	return e.eval(scope).Bool()
+}
+
+func (e *callEvaluator) EvalInt(scope Scope) int64 {
+	checkKind(e.t.Kind(), semantic.Int)
+	return e.eval(scope).Int()
+}
+
+func (e *callEvaluator) EvalUInt(scope Scope) uint64 {
+	checkKind(e.t.Kind(), semantic.UInt)
+	return e.eval(scope).UInt()
+}
+
+func (e *callEvaluator) EvalFloat(scope Scope) float64 {
+	checkKind(e.t.Kind(), semantic.Float)
+	return e.eval(scope).Float()
+}
+
+func (e *callEvaluator) EvalString(scope Scope) string {
+	checkKind(e.t.Kind(), semantic.String)
+	return e.eval(scope).Str()
+}
+
+func (e *callEvaluator) EvalTime(scope Scope) Time {
+	checkKind(e.t.Kind(), semantic.Time)
+	return e.eval(scope).Time()
+}
+
+func (e *callEvaluator) EvalDuration(scope Scope) Duration {
+	checkKind(e.t.Kind(), semantic.Duration)
+	return e.eval(scope).Duration()
+}
+
+func (e *callEvaluator) EvalObject(scope Scope) *Object {
+	checkKind(e.t.Kind(), semantic.Object)
+	return e.eval(scope).Object()
+}
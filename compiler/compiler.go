@@ -3,22 +3,31 @@ package compiler
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"sync"
 
+	"github.com/influxdata/ifql/ast"
 	"github.com/influxdata/ifql/semantic"
 )
 
 func Compile(f *semantic.FunctionExpression, inTypes map[string]semantic.Type) (Func, error) {
-	declarations := make(map[string]semantic.VariableDeclaration, len(inTypes))
+	return compileFunction(f, inTypes, newRegexpCache())
+}
+
+func compileFunction(f *semantic.FunctionExpression, inTypes map[string]semantic.Type, regexps *regexpCache) (Func, error) {
+	// Builtin functions may be shadowed by the parameters of the function.
+	declarations := semantic.BuiltinFunctionDeclarations()
 	for k, t := range inTypes {
 		declarations[k] = semantic.NewExternalVariableDeclaration(k, t)
 	}
 	f = f.Copy().(*semantic.FunctionExpression)
 	semantic.ApplyNewDeclarations(f, declarations)
 
-	root, err := compile(f.Body)
+	root, err := compile(f.Body, regexps)
 	if err != nil {
 		return nil, err
 	}
+	root = nullable(f.Body, root)
 	cpy := make(map[string]semantic.Type)
 	for k, v := range inTypes {
 		cpy[k] = v
@@ -29,12 +38,12 @@ func Compile(f *semantic.FunctionExpression, inTypes map[string]semantic.Type) (
 	}, nil
 }
 
-func compile(n semantic.Node) (Evaluator, error) {
+func compile(n semantic.Node, regexps *regexpCache) (Evaluator, error) {
 	switch n := n.(type) {
 	case *semantic.BlockStatement:
 		body := make([]Evaluator, len(n.Body))
 		for i, s := range n.Body {
-			node, err := compile(s)
+			node, err := compile(s, regexps)
 			if err != nil {
 				return nil, err
 			}
@@ -47,31 +56,31 @@ func compile(n semantic.Node) (Evaluator, error) {
 	case *semantic.ExpressionStatement:
 		return nil, errors.New("statement does nothing, sideffects are not supported by the compiler")
 	case *semantic.ReturnStatement:
-		node, err := compile(n.Argument)
+		node, err := compile(n.Argument, regexps)
 		if err != nil {
 			return nil, err
 		}
-		return returnEvaluator{
+		return nullable(n.Argument, returnEvaluator{
 			Evaluator: node,
-		}, nil
+		}), nil
 	case *semantic.NativeVariableDeclaration:
-		node, err := compile(n.Init)
+		node, err := compile(n.Init, regexps)
 		if err != nil {
 			return nil, err
 		}
 		return &declarationEvaluator{
 			t:    n.Init.Type(),
 			id:   n.Identifier.Name,
-			init: node,
+			init: nullable(n.Init, node),
 		}, nil
 	case *semantic.ObjectExpression:
 		properties := make(map[string]Evaluator, len(n.Properties))
 		for _, p := range n.Properties {
-			node, err := compile(p.Value)
+			node, err := compile(p.Value, regexps)
 			if err != nil {
 				return nil, err
 			}
-			properties[p.Key.Name] = node
+			properties[p.Key.Name] = nullable(p.Value, node)
 		}
 		return &mapEvaluator{
 			t:          n.Type(),
@@ -83,7 +92,7 @@ func compile(n semantic.Node) (Evaluator, error) {
 			name: n.Name,
 		}, nil
 	case *semantic.MemberExpression:
-		object, err := compile(n.Object)
+		object, err := compile(n.Object, regexps)
 		if err != nil {
 			return nil, err
 		}
@@ -117,8 +126,13 @@ func compile(n semantic.Node) (Evaluator, error) {
 			t:    n.Type(),
 			time: Time(n.Value.UnixNano()),
 		}, nil
+	case *semantic.DurationLiteral:
+		return &durationEvaluator{
+			t:        n.Type(),
+			duration: Duration(n.Value),
+		}, nil
 	case *semantic.UnaryExpression:
-		node, err := compile(n.Argument)
+		node, err := compile(n.Argument, regexps)
 		if err != nil {
 			return nil, err
 		}
@@ -127,11 +141,11 @@ func compile(n semantic.Node) (Evaluator, error) {
 			node: node,
 		}, nil
 	case *semantic.LogicalExpression:
-		l, err := compile(n.Left)
+		l, err := compile(n.Left, regexps)
 		if err != nil {
 			return nil, err
 		}
-		r, err := compile(n.Right)
+		r, err := compile(n.Right, regexps)
 		if err != nil {
 			return nil, err
 		}
@@ -141,13 +155,74 @@ func compile(n semantic.Node) (Evaluator, error) {
 			left:     l,
 			right:    r,
 		}, nil
+	case *semantic.ConditionalExpression:
+		test, err := compile(n.Test, regexps)
+		if err != nil {
+			return nil, err
+		}
+		if k := test.Type().Kind(); k != semantic.Bool {
+			return nil, fmt.Errorf("test of conditional expression must be a boolean, got %v", k)
+		}
+		c, err := compile(n.Consequent, regexps)
+		if err != nil {
+			return nil, err
+		}
+		a, err := compile(n.Alternate, regexps)
+		if err != nil {
+			return nil, err
+		}
+		t := n.Type()
+		if t.Kind() == semantic.Invalid {
+			return nil, fmt.Errorf("branches of conditional expression have incompatible types %v and %v", c.Type(), a.Type())
+		}
+		return &conditionalEvaluator{
+			t:          t,
+			test:       test,
+			consequent: c,
+			alternate:  a,
+		}, nil
+	case *semantic.CallExpression:
+		callee, ok := n.Callee.(*semantic.IdentifierExpression)
+		if !ok {
+			return nil, fmt.Errorf("unsupported callee of type %T, only builtin functions can be called", n.Callee)
+		}
+		if k := callee.Type().Kind(); k != semantic.Function {
+			return nil, fmt.Errorf("cannot call %q of kind %v", callee.Name, k)
+		}
+		b, ok := builtins[callee.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown builtin function %q", callee.Name)
+		}
+		args := make(map[string]Evaluator, len(n.Arguments.Properties))
+		types := make(map[string]semantic.Type, len(n.Arguments.Properties))
+		for _, p := range n.Arguments.Properties {
+			node, err := compile(p.Value, regexps)
+			if err != nil {
+				return nil, err
+			}
+			args[p.Key.Name] = node
+			types[p.Key.Name] = node.Type()
+		}
+		if err := b.sig.CheckArguments(types); err != nil {
+			return nil, fmt.Errorf("invalid call to %q: %v", callee.Name, err)
+		}
+		return &callEvaluator{
+			t:      b.sig.ReturnType,
+			name:   callee.Name,
+			params: b.sig.Params,
+			args:   args,
+			fn:     b.fn,
+		}, nil
 	case *semantic.BinaryExpression:
-		l, err := compile(n.Left)
+		l, err := compile(n.Left, regexps)
 		if err != nil {
 			return nil, err
 		}
 		lt := l.Type()
-		r, err := compile(n.Right)
+		if re, ok := n.Right.(*semantic.RegexpLiteral); ok {
+			return compileRegexpMatch(n.Operator, l, re, regexps)
+		}
+		r, err := compile(n.Right, regexps)
 		if err != nil {
 			return nil, err
 		}
@@ -172,23 +247,111 @@ func compile(n semantic.Node) (Evaluator, error) {
 	}
 }
 
+// nullable wraps the evaluator of an expression so that it evaluates to null,
+// when any of the values referenced by the expression is null.
+// Objects and blocks are not wrapped, their properties and statements are wrapped individually.
+func nullable(n semantic.Node, e Evaluator) Evaluator {
+	switch n.(type) {
+	case *semantic.ObjectExpression, *semantic.BlockStatement:
+		return e
+	}
+	v := new(nullRefVisitor)
+	semantic.Walk(v, n)
+	if len(v.refs) == 0 {
+		return e
+	}
+	return &nullableEvaluator{
+		Evaluator: e,
+		refs:      v.refs,
+	}
+}
+
+// nullRefVisitor finds the values an expression references.
+type nullRefVisitor struct {
+	refs []nullRef
+}
+
+func (v *nullRefVisitor) Visit(n semantic.Node) semantic.Visitor {
+	switch n := n.(type) {
+	case *semantic.MemberExpression:
+		if ref, ok := memberRef(n); ok {
+			v.refs = append(v.refs, ref)
+			return nil
+		}
+	case *semantic.IdentifierExpression:
+		v.refs = append(v.refs, nullRef{name: n.Name})
+	case *semantic.CallExpression:
+		// The callee is a builtin function and cannot be null.
+		semantic.Walk(v, n.Arguments)
+		return nil
+	}
+	return v
+}
+
+func (v *nullRefVisitor) Done() {}
+
+// memberRef returns a reference to the value of a chain of member expressions on an identifier.
+func memberRef(m *semantic.MemberExpression) (nullRef, bool) {
+	switch o := m.Object.(type) {
+	case *semantic.IdentifierExpression:
+		return nullRef{name: o.Name, path: []string{m.Property}}, true
+	case *semantic.MemberExpression:
+		ref, ok := memberRef(o)
+		if !ok {
+			return nullRef{}, false
+		}
+		ref.path = append(ref.path, m.Property)
+		return ref, true
+	default:
+		return nullRef{}, false
+	}
+}
+
+func compileRegexpMatch(op ast.OperatorKind, l Evaluator, re *semantic.RegexpLiteral, regexps *regexpCache) (Evaluator, error) {
+	if k := l.Type().Kind(); k != semantic.String {
+		return nil, fmt.Errorf("cannot match regular expression against kind %v", k)
+	}
+	var negate bool
+	switch op {
+	// Equality with a regular expression matches the same way as it does in storage predicates.
+	case ast.RegexpMatchOperator, ast.EqualOperator:
+	case ast.NotRegexpMatchOperator, ast.NotEqualOperator:
+		negate = true
+	default:
+		return nil, fmt.Errorf("unsupported operator %v with regular expression", op)
+	}
+	compiled, err := regexps.lookup(re.Value.String())
+	if err != nil {
+		return nil, err
+	}
+	return &regexpMatchEvaluator{
+		t:      semantic.Bool,
+		left:   l,
+		regexp: compiled,
+		negate: negate,
+	}, nil
+}
+
 // CompilationCache caches compilation results based on the types of the input parameters.
+// Regular expressions are compiled once and shared by all compilation results.
 type CompilationCache struct {
-	fn   *semantic.FunctionExpression
-	root *compilationCacheNode
+	fn      *semantic.FunctionExpression
+	root    *compilationCacheNode
+	regexps *regexpCache
 }
 
 func NewCompilationCache(fn *semantic.FunctionExpression) *CompilationCache {
 	return &CompilationCache{
-		fn:   fn,
-		root: new(compilationCacheNode),
+		fn:      fn,
+		root:    new(compilationCacheNode),
+		regexps: newRegexpCache(),
 	}
 }
 
 // Compile returnes a compiled function bsaed on the provided types.
 // The result will be cached for subsequent calls.
 func (c *CompilationCache) Compile(types map[string]semantic.Type) (Func, error) {
-	return c.root.compile(c.fn, 0, types)
+	return c.root.compile(c.fn, 0, types, c.regexps)
 }
 
 type compilationCacheNode struct {
@@ -200,11 +363,11 @@ type compilationCacheNode struct {
 
 // compile recursively searches for a matching child node that has compiled the function.
 // If the compilation has not been performed previously its result is cached and returned.
-func (c *compilationCacheNode) compile(fn *semantic.FunctionExpression, idx int, types map[string]semantic.Type) (Func, error) {
+func (c *compilationCacheNode) compile(fn *semantic.FunctionExpression, idx int, types map[string]semantic.Type, regexps *regexpCache) (Func, error) {
 	if idx == len(fn.Params) {
 		// We are the matching child, return the cached result or do the compilation.
 		if c.fn == nil && c.err == nil {
-			c.fn, c.err = Compile(fn, types)
+			c.fn, c.err = compileFunction(fn, types, regexps)
 		}
 		return c.fn, c.err
 	}
@@ -219,5 +382,32 @@ func (c *compilationCacheNode) compile(fn *semantic.FunctionExpression, idx int,
 		}
 		c.children[t] = child
 	}
-	return child.compile(fn, idx+1, types)
+	return child.compile(fn, idx+1, types, regexps)
+}
+
+// regexpCache caches compiled regular expressions by their pattern.
+type regexpCache struct {
+	mu      sync.Mutex
+	regexps map[string]*regexp.Regexp
+}
+
+func newRegexpCache() *regexpCache {
+	return &regexpCache{
+		regexps: make(map[string]*regexp.Regexp),
+	}
+}
+
+// lookup returns the compiled regular expression for the pattern, compiling it if needed.
+func (c *regexpCache) lookup(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if re, ok := c.regexps[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	c.regexps[pattern] = re
+	return re, nil
 }
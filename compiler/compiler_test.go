@@ -2,7 +2,9 @@ package compiler_test
 
 import (
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/ifql/ast"
@@ -36,6 +38,8 @@ func ValueEqual(x, y compiler.Value) bool {
 		return x.Time() == y.Time()
 	case semantic.Object:
 		return cmp.Equal(x.Object(), y.Object(), CmpOptions...)
+	case semantic.Nil:
+		return true
 	default:
 		return false
 	}
@@ -136,12 +140,13 @@ func TestCompilationCache(t *testing.T) {
 
 func TestCompile(t *testing.T) {
 	testCases := []struct {
-		name    string
-		fn      *semantic.FunctionExpression
-		types   map[string]semantic.Type
-		scope   map[string]compiler.Value
-		want    compiler.Value
-		wantErr bool
+		name        string
+		fn          *semantic.FunctionExpression
+		types       map[string]semantic.Type
+		scope       map[string]compiler.Value
+		want        compiler.Value
+		wantErr     bool
+		wantEvalErr bool
 	}{
 		{
 			name: "simple ident return",
@@ -160,6 +165,366 @@ func TestCompile(t *testing.T) {
 			want:    compiler.NewInt(4),
 			wantErr: false,
 		},
+		{
+			name: "conditional expression",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.ConditionalExpression{
+					Test: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left:     &semantic.IdentifierExpression{Name: "r"},
+						Right:    &semantic.FloatLiteral{Value: 90},
+					},
+					Consequent: &semantic.StringLiteral{Value: "crit"},
+					Alternate:  &semantic.StringLiteral{Value: "ok"},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewFloat(95),
+			},
+			want: compiler.NewString("crit"),
+		},
+		{
+			name: "conditional expression with numeric branches",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.ConditionalExpression{
+					Test: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left:     &semantic.IdentifierExpression{Name: "r"},
+						Right:    &semantic.FloatLiteral{Value: 90},
+					},
+					Consequent: &semantic.IntegerLiteral{Value: 1},
+					Alternate:  &semantic.IdentifierExpression{Name: "r"},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewFloat(95),
+			},
+			want: compiler.NewFloat(1),
+		},
+		{
+			name: "conditional expression with incompatible branches",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.ConditionalExpression{
+					Test: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left:     &semantic.IdentifierExpression{Name: "r"},
+						Right:    &semantic.FloatLiteral{Value: 90},
+					},
+					Consequent: &semantic.StringLiteral{Value: "crit"},
+					Alternate:  &semantic.IntegerLiteral{Value: 0},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			wantErr: true,
+		},
+		{
+			name: "builtin call with integer argument",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.CallExpression{
+					Callee: &semantic.IdentifierExpression{Name: "abs"},
+					Arguments: &semantic.ObjectExpression{
+						Properties: []*semantic.Property{{
+							Key:   &semantic.Identifier{Name: "x"},
+							Value: &semantic.IdentifierExpression{Name: "r"},
+						}},
+					},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Int,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewInt(-4),
+			},
+			want: compiler.NewFloat(4),
+		},
+		{
+			name: "builtin string call",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.CallExpression{
+					Callee: &semantic.IdentifierExpression{Name: "substring"},
+					Arguments: &semantic.ObjectExpression{
+						Properties: []*semantic.Property{
+							{
+								Key: &semantic.Identifier{Name: "v"},
+								Value: &semantic.CallExpression{
+									Callee: &semantic.IdentifierExpression{Name: "toUpper"},
+									Arguments: &semantic.ObjectExpression{
+										Properties: []*semantic.Property{{
+											Key:   &semantic.Identifier{Name: "v"},
+											Value: &semantic.IdentifierExpression{Name: "r"},
+										}},
+									},
+								},
+							},
+							{
+								Key:   &semantic.Identifier{Name: "start"},
+								Value: &semantic.IntegerLiteral{Value: 1},
+							},
+							{
+								Key:   &semantic.Identifier{Name: "end"},
+								Value: &semantic.IntegerLiteral{Value: 10},
+							},
+						},
+					},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.String,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewString("host"),
+			},
+			want: compiler.NewString("OST"),
+		},
+		{
+			name: "builtin time call",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.CallExpression{
+					Callee: &semantic.IdentifierExpression{Name: "truncate"},
+					Arguments: &semantic.ObjectExpression{
+						Properties: []*semantic.Property{
+							{
+								Key:   &semantic.Identifier{Name: "t"},
+								Value: &semantic.IdentifierExpression{Name: "r"},
+							},
+							{
+								Key:   &semantic.Identifier{Name: "unit"},
+								Value: &semantic.DurationLiteral{Value: time.Hour},
+							},
+						},
+					},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Time,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewTime(compiler.Time(90 * time.Minute)),
+			},
+			want: compiler.NewTime(compiler.Time(time.Hour)),
+		},
+		{
+			name: "builtin call with wrong argument type",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.CallExpression{
+					Callee: &semantic.IdentifierExpression{Name: "strlen"},
+					Arguments: &semantic.ObjectExpression{
+						Properties: []*semantic.Property{{
+							Key:   &semantic.Identifier{Name: "v"},
+							Value: &semantic.IdentifierExpression{Name: "r"},
+						}},
+					},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown function call",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.CallExpression{
+					Callee:    &semantic.IdentifierExpression{Name: "nope"},
+					Arguments: &semantic.ObjectExpression{},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			wantErr: true,
+		},
+		{
+			name: "regexp match",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.RegexpMatchOperator,
+					Left:     &semantic.IdentifierExpression{Name: "r"},
+					Right:    &semantic.RegexpLiteral{Value: regexp.MustCompile("^web")},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.String,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewString("webserver01"),
+			},
+			want: compiler.NewBool(true),
+		},
+		{
+			name: "regexp not match",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.NotRegexpMatchOperator,
+					Left:     &semantic.IdentifierExpression{Name: "r"},
+					Right:    &semantic.RegexpLiteral{Value: regexp.MustCompile("^web")},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.String,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewString("webserver01"),
+			},
+			want: compiler.NewBool(false),
+		},
+		{
+			name: "regexp match on non string",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.RegexpMatchOperator,
+					Left:     &semantic.IdentifierExpression{Name: "r"},
+					Right:    &semantic.RegexpLiteral{Value: regexp.MustCompile("^web")},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			wantErr: true,
+		},
+		{
+			name: "conversion call",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.AdditionOperator,
+					Left: &semantic.CallExpression{
+						Callee: &semantic.IdentifierExpression{Name: "toInt"},
+						Arguments: &semantic.ObjectExpression{
+							Properties: []*semantic.Property{{
+								Key:   &semantic.Identifier{Name: "v"},
+								Value: &semantic.IdentifierExpression{Name: "r"},
+							}},
+						},
+					},
+					Right: &semantic.IntegerLiteral{Value: 1},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.String,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewString("41"),
+			},
+			want: compiler.NewInt(42),
+		},
+		{
+			name: "failed conversion",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.CallExpression{
+					Callee: &semantic.IdentifierExpression{Name: "toBool"},
+					Arguments: &semantic.ObjectExpression{
+						Properties: []*semantic.Property{{
+							Key:   &semantic.Identifier{Name: "v"},
+							Value: &semantic.IdentifierExpression{Name: "r"},
+						}},
+					},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewFloat(0.5),
+			},
+			wantEvalErr: true,
+		},
+		{
+			name: "null value",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.MultiplicationOperator,
+					Left:     &semantic.IdentifierExpression{Name: "r"},
+					Right:    &semantic.FloatLiteral{Value: 2},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.Float,
+			},
+			scope: map[string]compiler.Value{
+				"r": compiler.NewNil(),
+			},
+			want: compiler.NewNil(),
+		},
+		{
+			name: "null property",
+			fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{
+					{Key: &semantic.Identifier{Name: "r"}},
+				},
+				Body: &semantic.LogicalExpression{
+					Operator: ast.OrOperator,
+					Left: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left: &semantic.MemberExpression{
+							Object:   &semantic.IdentifierExpression{Name: "r"},
+							Property: "_value",
+						},
+						Right: &semantic.FloatLiteral{Value: 0},
+					},
+					Right: &semantic.BooleanLiteral{Value: true},
+				},
+			},
+			types: map[string]semantic.Type{
+				"r": semantic.NewObjectType(map[string]semantic.Type{
+					"_value": semantic.Float,
+				}),
+			},
+			scope: map[string]compiler.Value{
+				"r": nullObject("_value", semantic.Float),
+			},
+			want: compiler.NewNil(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -169,9 +534,12 @@ func TestCompile(t *testing.T) {
 			if tc.wantErr != (err != nil) {
 				t.Errorf("unexpected error %s", err)
 			}
+			if err != nil {
+				return
+			}
 
 			got, err := f.Eval(tc.scope)
-			if tc.wantErr != (err != nil) {
+			if tc.wantEvalErr != (err != nil) {
 				t.Errorf("unexpected error %s", err)
 			}
 
@@ -181,3 +549,11 @@ func TestCompile(t *testing.T) {
 		})
 	}
 }
+
+// nullObject returns an object with a single null property of the given type.
+func nullObject(property string, t semantic.Type) *compiler.Object {
+	obj := compiler.NewObject()
+	obj.SetPropertyType(property, t)
+	obj.Set(property, compiler.NewNil())
+	return obj
+}
@@ -0,0 +1,193 @@
+package compiler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/ifql/semantic"
+)
+
+// Convert converts the value to a value of kind k.
+// Null values convert to null values of any kind.
+// Strings are parsed, times are represented as nanoseconds since the Unix epoch or as RFC3339 strings,
+// and numbers convert to booleans only from 0 and 1.
+func Convert(v Value, k semantic.Kind) (Value, error) {
+	from := v.Type().Kind()
+	if from == semantic.Nil || from == k {
+		return v, nil
+	}
+	switch k {
+	case semantic.Bool:
+		return toBool(v)
+	case semantic.Int:
+		return toInt(v)
+	case semantic.UInt:
+		return toUInt(v)
+	case semantic.Float:
+		return toFloat(v)
+	case semantic.String:
+		return toString(v)
+	case semantic.Time:
+		return toTime(v)
+	default:
+		return nil, fmt.Errorf("cannot convert to kind %v", k)
+	}
+}
+
+func conversionError(v Value, k semantic.Kind) error {
+	return fmt.Errorf("cannot convert %v %v to %v", v.Type().Kind(), valueString(v), k)
+}
+
+func toBool(v Value) (Value, error) {
+	switch v.Type().Kind() {
+	case semantic.Int:
+		switch v.Int() {
+		case 0:
+			return NewBool(false), nil
+		case 1:
+			return NewBool(true), nil
+		}
+	case semantic.UInt:
+		switch v.UInt() {
+		case 0:
+			return NewBool(false), nil
+		case 1:
+			return NewBool(true), nil
+		}
+	case semantic.Float:
+		switch v.Float() {
+		case 0:
+			return NewBool(false), nil
+		case 1:
+			return NewBool(true), nil
+		}
+	case semantic.String:
+		if b, err := strconv.ParseBool(v.Str()); err == nil {
+			return NewBool(b), nil
+		}
+	}
+	return nil, conversionError(v, semantic.Bool)
+}
+
+func toInt(v Value) (Value, error) {
+	switch v.Type().Kind() {
+	case semantic.Bool:
+		if v.Bool() {
+			return NewInt(1), nil
+		}
+		return NewInt(0), nil
+	case semantic.UInt:
+		if u := v.UInt(); u <= math.MaxInt64 {
+			return NewInt(int64(u)), nil
+		}
+	case semantic.Float:
+		if f := v.Float(); f >= math.MinInt64 && f < math.MaxInt64 {
+			return NewInt(int64(f)), nil
+		}
+	case semantic.String:
+		if i, err := strconv.ParseInt(v.Str(), 10, 64); err == nil {
+			return NewInt(i), nil
+		}
+	case semantic.Time:
+		return NewInt(int64(v.Time())), nil
+	}
+	return nil, conversionError(v, semantic.Int)
+}
+
+func toUInt(v Value) (Value, error) {
+	switch v.Type().Kind() {
+	case semantic.Bool:
+		if v.Bool() {
+			return NewUInt(1), nil
+		}
+		return NewUInt(0), nil
+	case semantic.Int:
+		if i := v.Int(); i >= 0 {
+			return NewUInt(uint64(i)), nil
+		}
+	case semantic.Float:
+		if f := v.Float(); f >= 0 && f < math.MaxUint64 {
+			return NewUInt(uint64(f)), nil
+		}
+	case semantic.String:
+		if u, err := strconv.ParseUint(v.Str(), 10, 64); err == nil {
+			return NewUInt(u), nil
+		}
+	case semantic.Time:
+		if t := v.Time(); t >= 0 {
+			return NewUInt(uint64(t)), nil
+		}
+	}
+	return nil, conversionError(v, semantic.UInt)
+}
+
+func toFloat(v Value) (Value, error) {
+	switch v.Type().Kind() {
+	case semantic.Bool:
+		if v.Bool() {
+			return NewFloat(1), nil
+		}
+		return NewFloat(0), nil
+	case semantic.Int:
+		return NewFloat(float64(v.Int())), nil
+	case semantic.UInt:
+		return NewFloat(float64(v.UInt())), nil
+	case semantic.String:
+		if f, err := strconv.ParseFloat(v.Str(), 64); err == nil {
+			return NewFloat(f), nil
+		}
+	case semantic.Time:
+		return NewFloat(float64(v.Time())), nil
+	}
+	return nil, conversionError(v, semantic.Float)
+}
+
+func toString(v Value) (Value, error) {
+	switch v.Type().Kind() {
+	case semantic.Bool, semantic.Int, semantic.UInt, semantic.Float, semantic.Time:
+		return NewString(valueString(v)), nil
+	}
+	return nil, conversionError(v, semantic.String)
+}
+
+func toTime(v Value) (Value, error) {
+	switch v.Type().Kind() {
+	case semantic.Int:
+		return NewTime(Time(v.Int())), nil
+	case semantic.UInt:
+		if u := v.UInt(); u <= math.MaxInt64 {
+			return NewTime(Time(u)), nil
+		}
+	case semantic.Float:
+		if f := v.Float(); f >= math.MinInt64 && f < math.MaxInt64 {
+			return NewTime(Time(f)), nil
+		}
+	case semantic.String:
+		if t, err := time.Parse(time.RFC3339Nano, v.Str()); err == nil {
+			return NewTime(Time(t.UnixNano())), nil
+		}
+	}
+	return nil, conversionError(v, semantic.Time)
+}
+
+// valueString formats a value the way it is converted to a string.
+func valueString(v Value) string {
+	switch v.Type().Kind() {
+	case semantic.Bool:
+		return strconv.FormatBool(v.Bool())
+	case semantic.Int:
+		return strconv.FormatInt(v.Int(), 10)
+	case semantic.UInt:
+		return strconv.FormatUint(v.UInt(), 10)
+	case semantic.Float:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case semantic.String:
+		return strconv.Quote(v.Str())
+	case semantic.Time:
+		return utcTime(v.Time()).Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
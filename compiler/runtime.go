@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/influxdata/ifql/ast"
 	"github.com/influxdata/ifql/semantic"
@@ -15,6 +16,7 @@ type Evaluator interface {
 	EvalFloat(scope Scope) float64
 	EvalString(scope Scope) string
 	EvalTime(scope Scope) Time
+	EvalDuration(scope Scope) Duration
 	EvalObject(scope Scope) *Object
 }
 
@@ -32,14 +34,19 @@ type Func interface {
 
 type Time int64
 
+type Duration int64
+
 type compiledFn struct {
 	root    Evaluator
 	inTypes map[string]semantic.Type
 }
 
 func (c compiledFn) validate(scope Scope) error {
-	// Validate scope
+	// Validate scope, a null value may be passed for any type
 	for k, t := range c.inTypes {
+		if v, ok := scope[k]; ok && v.Type() == semantic.Nil {
+			continue
+		}
 		if scope.Type(k) != t {
 			return fmt.Errorf("missing or incorrectly typed value found in scope for name %q", k)
 		}
@@ -51,78 +58,85 @@ func (c compiledFn) Type() semantic.Type {
 	return c.root.Type()
 }
 
-func (c compiledFn) Eval(scope Scope) (Value, error) {
+// Eval evaluates the function, the result is a nil value if any value it reads is null.
+func (c compiledFn) Eval(scope Scope) (_ Value, err error) {
+	defer recoverEvalError(&err)
 	if err := c.validate(scope); err != nil {
 		return nil, err
 	}
-	var val interface{}
-	switch c.Type().Kind() {
-	case semantic.Bool:
-		val = c.root.EvalBool(scope)
-	case semantic.Int:
-		val = c.root.EvalInt(scope)
-	case semantic.UInt:
-		val = c.root.EvalUInt(scope)
-	case semantic.Float:
-		val = c.root.EvalFloat(scope)
-	case semantic.String:
-		val = c.root.EvalString(scope)
-	case semantic.Time:
-		val = c.root.EvalTime(scope)
-	case semantic.Object:
-		val = c.root.EvalObject(scope)
-	default:
+	v := eval(c.root, scope)
+	if v == nil {
 		return nil, fmt.Errorf("unsupported kind %s", c.Type().Kind())
 	}
-	return value{
-		typ:   c.Type(),
-		Value: val,
-	}, nil
+	return v, nil
 }
 
-func (c compiledFn) EvalBool(scope Scope) (bool, error) {
+func (c compiledFn) EvalBool(scope Scope) (_ bool, err error) {
+	defer recoverEvalError(&err)
 	if err := c.validate(scope); err != nil {
 		return false, err
 	}
 	return c.root.EvalBool(scope), nil
 }
-func (c compiledFn) EvalInt(scope Scope) (int64, error) {
+func (c compiledFn) EvalInt(scope Scope) (_ int64, err error) {
+	defer recoverEvalError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalInt(scope), nil
 }
-func (c compiledFn) EvalUInt(scope Scope) (uint64, error) {
+func (c compiledFn) EvalUInt(scope Scope) (_ uint64, err error) {
+	defer recoverEvalError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalUInt(scope), nil
 }
-func (c compiledFn) EvalFloat(scope Scope) (float64, error) {
+func (c compiledFn) EvalFloat(scope Scope) (_ float64, err error) {
+	defer recoverEvalError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalFloat(scope), nil
 }
-func (c compiledFn) EvalString(scope Scope) (string, error) {
+func (c compiledFn) EvalString(scope Scope) (_ string, err error) {
+	defer recoverEvalError(&err)
 	if err := c.validate(scope); err != nil {
 		return "", err
 	}
 	return c.root.EvalString(scope), nil
 }
-func (c compiledFn) EvalTime(scope Scope) (Time, error) {
+func (c compiledFn) EvalTime(scope Scope) (_ Time, err error) {
+	defer recoverEvalError(&err)
 	if err := c.validate(scope); err != nil {
 		return 0, err
 	}
 	return c.root.EvalTime(scope), nil
 }
-func (c compiledFn) EvalObject(scope Scope) (*Object, error) {
+func (c compiledFn) EvalObject(scope Scope) (_ *Object, err error) {
+	defer recoverEvalError(&err)
 	if err := c.validate(scope); err != nil {
 		return nil, err
 	}
 	return c.root.EvalObject(scope), nil
 }
 
+// evalError is raised as a panic by an evaluator that fails for the values in scope.
+// The compiled function recovers it and returns the error.
+type evalError struct {
+	err error
+}
+
+func recoverEvalError(err *error) {
+	if r := recover(); r != nil {
+		e, ok := r.(evalError)
+		if !ok {
+			panic(r)
+		}
+		*err = e.err
+	}
+}
+
 type Value interface {
 	Type() semantic.Type
 	Bool() bool
@@ -131,6 +145,7 @@ type Value interface {
 	Float() float64
 	Str() string
 	Time() Time
+	Duration() Duration
 	Object() *Object
 }
 
@@ -160,6 +175,9 @@ func (v value) Str() string {
 func (v value) Time() Time {
 	return v.Value.(Time)
 }
+func (v value) Duration() Duration {
+	return v.Value.(Duration)
+}
 func (v value) Object() *Object {
 	return v.Value.(*Object)
 }
@@ -201,6 +219,20 @@ func NewTime(v Time) Value {
 	}
 }
 
+// NewNil returns a null value.
+// Null values may be assigned to properties of any type.
+func NewNil() Value {
+	return value{
+		typ: semantic.Nil,
+	}
+}
+func NewDuration(v Duration) Value {
+	return value{
+		typ:   semantic.Duration,
+		Value: v,
+	}
+}
+
 type Scope map[string]Value
 
 func (s Scope) Type(name string) semantic.Type {
@@ -227,11 +259,28 @@ func (s Scope) GetString(name string) string {
 func (s Scope) GetTime(name string) Time {
 	return s[name].Time()
 }
+func (s Scope) GetDuration(name string) Duration {
+	return s[name].Duration()
+}
 func (s Scope) GetObject(name string) *Object {
 	return s[name].Object()
 }
 
 func eval(e Evaluator, scope Scope) Value {
+	// Evaluators whose result can be null produce a nil value.
+	switch e := e.(type) {
+	case *nullableEvaluator:
+		if e.isNull(scope) {
+			return NewNil()
+		}
+		return eval(e.Evaluator, scope)
+	case *blockEvaluator:
+		e.eval(scope)
+		return e.value
+	case *declarationEvaluator:
+		e.eval(scope)
+		return scope[e.id]
+	}
 	switch e.Type().Kind() {
 	case semantic.Bool:
 		return NewBool(e.EvalBool(scope))
@@ -245,6 +294,10 @@ func eval(e Evaluator, scope Scope) Value {
 		return NewString(e.EvalString(scope))
 	case semantic.Time:
 		return NewTime(e.EvalTime(scope))
+	case semantic.Duration:
+		return NewDuration(e.EvalDuration(scope))
+	case semantic.Object:
+		return e.EvalObject(scope)
 	default:
 		return nil
 	}
@@ -260,6 +313,104 @@ func unexpectedKind(act, exp semantic.Kind) error {
 	return fmt.Errorf("unexpected kind: got %q want %q", act, exp)
 }
 
+// nullRef is a reference to a value in scope, or to a property of an object in scope.
+// nullRef references a value in scope or a property of an object in scope.
+type nullRef struct {
+	name string
+	path []string
+}
+
+// nullableEvaluator evaluates to null when any of the values it references is null.
+// A missing property of an object is null.
+// The typed Eval methods return the zero value for a null result.
+type nullableEvaluator struct {
+	Evaluator
+	refs []nullRef
+}
+
+func (e *nullableEvaluator) isNull(scope Scope) bool {
+	for _, ref := range e.refs {
+		v, ok := scope[ref.name]
+		if !ok {
+			continue
+		}
+		if isNullPath(v, ref.path) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNullPath(v Value, path []string) bool {
+	for _, p := range path {
+		obj, ok := v.(*Object)
+		if !ok {
+			return false
+		}
+		v = obj.Get(p)
+		if v == nil {
+			return true
+		}
+	}
+	return v.Type() == semantic.Nil
+}
+
+func (e *nullableEvaluator) EvalBool(scope Scope) bool {
+	if e.isNull(scope) {
+		return false
+	}
+	return e.Evaluator.EvalBool(scope)
+}
+
+func (e *nullableEvaluator) EvalInt(scope Scope) int64 {
+	if e.isNull(scope) {
+		return 0
+	}
+	return e.Evaluator.EvalInt(scope)
+}
+
+func (e *nullableEvaluator) EvalUInt(scope Scope) uint64 {
+	if e.isNull(scope) {
+		return 0
+	}
+	return e.Evaluator.EvalUInt(scope)
+}
+
+func (e *nullableEvaluator) EvalFloat(scope Scope) float64 {
+	if e.isNull(scope) {
+		return 0
+	}
+	return e.Evaluator.EvalFloat(scope)
+}
+
+func (e *nullableEvaluator) EvalString(scope Scope) string {
+	if e.isNull(scope) {
+		return ""
+	}
+	return e.Evaluator.EvalString(scope)
+}
+
+func (e *nullableEvaluator) EvalTime(scope Scope) Time {
+	if e.isNull(scope) {
+		return 0
+	}
+	return e.Evaluator.EvalTime(scope)
+}
+
+func (e *nullableEvaluator) EvalDuration(scope Scope) Duration {
+	if e.isNull(scope) {
+		return 0
+	}
+	return e.Evaluator.EvalDuration(scope)
+}
+
+func (e *nullableEvaluator) EvalObject(scope Scope) *Object {
+	if e.isNull(scope) {
+		return nil
+	}
+	return e.Evaluator.EvalObject(scope)
+}
+
 type blockEvaluator struct {
 	t     semantic.Type
 	body  []Evaluator
@@ -311,6 +462,12 @@ func (e *blockEvaluator) EvalTime(scope Scope) Time {
 	e.eval(scope)
 	return e.value.Time()
 }
+
+func (e *blockEvaluator) EvalDuration(scope Scope) Duration {
+	checkKind(e.t.Kind(), semantic.Duration)
+	e.eval(scope)
+	return e.value.Duration()
+}
 func (e *blockEvaluator) EvalObject(scope Scope) *Object {
 	checkKind(e.t.Kind(), semantic.Object)
 	e.eval(scope)
@@ -365,6 +522,11 @@ func (e *declarationEvaluator) EvalTime(scope Scope) Time {
 	return scope.GetTime(e.id)
 }
 
+func (e *declarationEvaluator) EvalDuration(scope Scope) Duration {
+	e.eval(scope)
+	return scope.GetDuration(e.id)
+}
+
 func (e *declarationEvaluator) EvalObject(scope Scope) *Object {
 	e.eval(scope)
 	return scope.GetObject(e.id)
@@ -402,11 +564,15 @@ func (e *mapEvaluator) EvalString(scope Scope) string {
 func (e *mapEvaluator) EvalTime(scope Scope) Time {
 	panic(unexpectedKind(e.t.Kind(), semantic.Time))
 }
+
+func (e *mapEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
 func (e *mapEvaluator) EvalObject(scope Scope) *Object {
 	obj := NewObject()
 	for k, node := range e.properties {
-		v := eval(node, scope)
-		obj.Set(k, v)
+		obj.SetPropertyType(k, node.Type())
+		obj.Set(k, eval(node, scope))
 	}
 	return obj
 }
@@ -426,6 +592,10 @@ func NewObject() *Object {
 
 func (o *Object) Set(name string, v Value) {
 	o.values[name] = v
+	// A null value does not change the type of a known property.
+	if _, ok := o.propertyTypes[name]; ok && v.Type() == semantic.Nil {
+		return
+	}
 	if o.propertyTypes[name] != v.Type() {
 		o.SetPropertyType(name, v.Type())
 	}
@@ -467,6 +637,10 @@ func (o *Object) Time() Time {
 	panic("map is not a time")
 }
 
+func (o *Object) Duration() Duration {
+	panic("map is not a duration")
+}
+
 func (o *Object) Object() *Object {
 	return o
 }
@@ -511,10 +685,72 @@ func (e *logicalEvaluator) EvalString(scope Scope) string {
 func (e *logicalEvaluator) EvalTime(scope Scope) Time {
 	panic(unexpectedKind(e.t.Kind(), semantic.Time))
 }
+
+func (e *logicalEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
 func (e *logicalEvaluator) EvalObject(scope Scope) *Object {
 	panic(unexpectedKind(e.t.Kind(), semantic.Object))
 }
 
+type conditionalEvaluator struct {
+	t                     semantic.Type
+	test                  Evaluator
+	consequent, alternate Evaluator
+}
+
+func (e *conditionalEvaluator) Type() semantic.Type {
+	return e.t
+}
+
+// branch evaluates the test and returns the evaluator of the selected branch.
+func (e *conditionalEvaluator) branch(scope Scope) Evaluator {
+	if e.test.EvalBool(scope) {
+		return e.consequent
+	}
+	return e.alternate
+}
+
+func (e *conditionalEvaluator) EvalBool(scope Scope) bool {
+	return e.branch(scope).EvalBool(scope)
+}
+
+func (e *conditionalEvaluator) EvalInt(scope Scope) int64 {
+	return e.branch(scope).EvalInt(scope)
+}
+
+func (e *conditionalEvaluator) EvalUInt(scope Scope) uint64 {
+	return e.branch(scope).EvalUInt(scope)
+}
+
+func (e *conditionalEvaluator) EvalFloat(scope Scope) float64 {
+	// Branches may have been unified to float
+	b := e.branch(scope)
+	switch b.Type().Kind() {
+	case semantic.Int:
+		return float64(b.EvalInt(scope))
+	case semantic.UInt:
+		return float64(b.EvalUInt(scope))
+	default:
+		return b.EvalFloat(scope)
+	}
+}
+
+func (e *conditionalEvaluator) EvalString(scope Scope) string {
+	return e.branch(scope).EvalString(scope)
+}
+
+func (e *conditionalEvaluator) EvalTime(scope Scope) Time {
+	return e.branch(scope).EvalTime(scope)
+}
+
+func (e *conditionalEvaluator) EvalDuration(scope Scope) Duration {
+	return e.branch(scope).EvalDuration(scope)
+}
+func (e *conditionalEvaluator) EvalObject(scope Scope) *Object {
+	return e.branch(scope).EvalObject(scope)
+}
+
 type binaryFunc func(scope Scope, left, right Evaluator) Value
 
 type binarySignature struct {
@@ -555,10 +791,56 @@ func (e *binaryEvaluator) EvalString(scope Scope) string {
 func (e *binaryEvaluator) EvalTime(scope Scope) Time {
 	return e.f(scope, e.left, e.right).Time()
 }
+
+func (e *binaryEvaluator) EvalDuration(scope Scope) Duration {
+	return e.f(scope, e.left, e.right).Duration()
+}
 func (e *binaryEvaluator) EvalObject(scope Scope) *Object {
 	panic(unexpectedKind(e.t.Kind(), semantic.Object))
 }
 
+type regexpMatchEvaluator struct {
+	t      semantic.Type
+	left   Evaluator
+	regexp *regexp.Regexp
+	negate bool
+}
+
+func (e *regexpMatchEvaluator) Type() semantic.Type {
+	return e.t
+}
+
+func (e *regexpMatchEvaluator) EvalBool(scope Scope) bool {
+	return e.regexp.MatchString(e.left.EvalString(scope)) != e.negate
+}
+
+func (e *regexpMatchEvaluator) EvalInt(scope Scope) int64 {
+	panic(unexpectedKind(e.t.Kind(), semantic.Int))
+}
+
+func (e *regexpMatchEvaluator) EvalUInt(scope Scope) uint64 {
+	panic(unexpectedKind(e.t.Kind(), semantic.UInt))
+}
+
+func (e *regexpMatchEvaluator) EvalFloat(scope Scope) float64 {
+	panic(unexpectedKind(e.t.Kind(), semantic.Float))
+}
+
+func (e *regexpMatchEvaluator) EvalString(scope Scope) string {
+	panic(unexpectedKind(e.t.Kind(), semantic.String))
+}
+
+func (e *regexpMatchEvaluator) EvalTime(scope Scope) Time {
+	panic(unexpectedKind(e.t.Kind(), semantic.Time))
+}
+
+func (e *regexpMatchEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
+func (e *regexpMatchEvaluator) EvalObject(scope Scope) *Object {
+	panic(unexpectedKind(e.t.Kind(), semantic.Object))
+}
+
 type unaryEvaluator struct {
 	t    semantic.Type
 	node Evaluator
@@ -594,6 +876,10 @@ func (e *unaryEvaluator) EvalString(scope Scope) string {
 func (e *unaryEvaluator) EvalTime(scope Scope) Time {
 	panic(unexpectedKind(e.t.Kind(), semantic.Time))
 }
+
+func (e *unaryEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
 func (e *unaryEvaluator) EvalObject(scope Scope) *Object {
 	panic(unexpectedKind(e.t.Kind(), semantic.Object))
 }
@@ -630,6 +916,10 @@ func (e *integerEvaluator) EvalString(scope Scope) string {
 func (e *integerEvaluator) EvalTime(scope Scope) Time {
 	panic(unexpectedKind(e.t.Kind(), semantic.Time))
 }
+
+func (e *integerEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
 func (e *integerEvaluator) EvalObject(scope Scope) *Object {
 	panic(unexpectedKind(e.t.Kind(), semantic.Object))
 }
@@ -666,6 +956,10 @@ func (e *stringEvaluator) EvalString(scope Scope) string {
 func (e *stringEvaluator) EvalTime(scope Scope) Time {
 	panic(unexpectedKind(e.t.Kind(), semantic.Time))
 }
+
+func (e *stringEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
 func (e *stringEvaluator) EvalObject(scope Scope) *Object {
 	panic(unexpectedKind(e.t.Kind(), semantic.Object))
 }
@@ -702,6 +996,10 @@ func (e *booleanEvaluator) EvalString(scope Scope) string {
 func (e *booleanEvaluator) EvalTime(scope Scope) Time {
 	panic(unexpectedKind(e.t.Kind(), semantic.Time))
 }
+
+func (e *booleanEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
 func (e *booleanEvaluator) EvalObject(scope Scope) *Object {
 	panic(unexpectedKind(e.t.Kind(), semantic.Object))
 }
@@ -738,6 +1036,10 @@ func (e *floatEvaluator) EvalString(scope Scope) string {
 func (e *floatEvaluator) EvalTime(scope Scope) Time {
 	panic(unexpectedKind(e.t.Kind(), semantic.Time))
 }
+
+func (e *floatEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
 func (e *floatEvaluator) EvalObject(scope Scope) *Object {
 	panic(unexpectedKind(e.t.Kind(), semantic.Object))
 }
@@ -774,10 +1076,54 @@ func (e *timeEvaluator) EvalString(scope Scope) string {
 func (e *timeEvaluator) EvalTime(scope Scope) Time {
 	return e.time
 }
+
+func (e *timeEvaluator) EvalDuration(scope Scope) Duration {
+	panic(unexpectedKind(e.t.Kind(), semantic.Duration))
+}
 func (e *timeEvaluator) EvalObject(scope Scope) *Object {
 	panic(unexpectedKind(e.t.Kind(), semantic.Object))
 }
 
+type durationEvaluator struct {
+	t        semantic.Type
+	duration Duration
+}
+
+func (e *durationEvaluator) Type() semantic.Type {
+	return e.t
+}
+
+func (e *durationEvaluator) EvalBool(scope Scope) bool {
+	panic(unexpectedKind(e.t.Kind(), semantic.Bool))
+}
+
+func (e *durationEvaluator) EvalInt(scope Scope) int64 {
+	panic(unexpectedKind(e.t.Kind(), semantic.Int))
+}
+
+func (e *durationEvaluator) EvalUInt(scope Scope) uint64 {
+	panic(unexpectedKind(e.t.Kind(), semantic.UInt))
+}
+
+func (e *durationEvaluator) EvalFloat(scope Scope) float64 {
+	panic(unexpectedKind(e.t.Kind(), semantic.Float))
+}
+
+func (e *durationEvaluator) EvalString(scope Scope) string {
+	panic(unexpectedKind(e.t.Kind(), semantic.String))
+}
+
+func (e *durationEvaluator) EvalTime(scope Scope) Time {
+	panic(unexpectedKind(e.t.Kind(), semantic.Time))
+}
+
+func (e *durationEvaluator) EvalDuration(scope Scope) Duration {
+	return e.duration
+}
+func (e *durationEvaluator) EvalObject(scope Scope) *Object {
+	panic(unexpectedKind(e.t.Kind(), semantic.Object))
+}
+
 type identifierEvaluator struct {
 	t    semantic.Type
 	name string
@@ -810,6 +1156,10 @@ func (e *identifierEvaluator) EvalString(scope Scope) string {
 func (e *identifierEvaluator) EvalTime(scope Scope) Time {
 	return scope.GetTime(e.name)
 }
+
+func (e *identifierEvaluator) EvalDuration(scope Scope) Duration {
+	return scope.GetDuration(e.name)
+}
 func (e *identifierEvaluator) EvalObject(scope Scope) *Object {
 	return scope.GetObject(e.name)
 }
@@ -847,6 +1197,10 @@ func (e *memberEvaluator) EvalString(scope Scope) string {
 func (e *memberEvaluator) EvalTime(scope Scope) Time {
 	return e.object.EvalObject(scope).Get(e.property).Time()
 }
+
+func (e *memberEvaluator) EvalDuration(scope Scope) Duration {
+	return e.object.EvalObject(scope).Get(e.property).Duration()
+}
 func (e *memberEvaluator) EvalObject(scope Scope) *Object {
 	return e.object.EvalObject(scope).Get(e.property).Object()
 }
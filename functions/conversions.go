@@ -0,0 +1,203 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/influxdata/ifql/compiler"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const (
+	ToBoolKind   = "toBool"
+	ToIntKind    = "toInt"
+	ToUIntKind   = "toUInt"
+	ToFloatKind  = "toFloat"
+	ToStringKind = "toString"
+	ToTimeKind   = "toTime"
+)
+
+// conversionTypes maps each conversion function to the type it converts to.
+var conversionTypes = map[query.OperationKind]execute.DataType{
+	ToBoolKind:   execute.TBool,
+	ToIntKind:    execute.TInt,
+	ToUIntKind:   execute.TUInt,
+	ToFloatKind:  execute.TFloat,
+	ToStringKind: execute.TString,
+	ToTimeKind:   execute.TTime,
+}
+
+func conversionKind(t execute.DataType) query.OperationKind {
+	for k, ct := range conversionTypes {
+		if ct == t {
+			return k
+		}
+	}
+	return ""
+}
+
+// ConvertOpSpec converts the values of a value column to another type.
+// The type is implied by the kind of the operation and is not part of the spec's JSON encoding.
+type ConvertOpSpec struct {
+	Column string           `json:"column"`
+	Type   execute.DataType `json:"-"`
+}
+
+var convertSignature = query.DefaultFunctionSignature()
+
+func init() {
+	convertSignature.Params["column"] = semantic.String
+
+	for kind, typ := range conversionTypes {
+		kind, typ := kind, typ
+		query.RegisterFunction(string(kind), func(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+			return createConvertOpSpec(typ, args, a)
+		}, convertSignature)
+		query.RegisterOpSpec(kind, func() query.OperationSpec {
+			return &ConvertOpSpec{Type: typ}
+		})
+		plan.RegisterProcedureSpec(plan.ProcedureKind(kind), newConvertProcedure, kind)
+		execute.RegisterTransformation(plan.ProcedureKind(kind), createConvertTransformation)
+	}
+}
+
+func createConvertOpSpec(typ execute.DataType, args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &ConvertOpSpec{
+		Column: execute.DefaultValueColLabel,
+		Type:   typ,
+	}
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	}
+	return spec, nil
+}
+
+func (s *ConvertOpSpec) Kind() query.OperationKind {
+	return conversionKind(s.Type)
+}
+
+type ConvertProcedureSpec struct {
+	Column string
+	Type   execute.DataType
+}
+
+func newConvertProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ConvertOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ConvertProcedureSpec{
+		Column: spec.Column,
+		Type:   spec.Type,
+	}, nil
+}
+
+func (s *ConvertProcedureSpec) Kind() plan.ProcedureKind {
+	return plan.ProcedureKind(conversionKind(s.Type))
+}
+func (s *ConvertProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ConvertProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createConvertTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ConvertProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewConvertTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type convertTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+
+	column string
+	typ    execute.DataType
+}
+
+func NewConvertTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *ConvertProcedureSpec) *convertTransformation {
+	return &convertTransformation{
+		d:      d,
+		cache:  cache,
+		column: spec.Column,
+		typ:    spec.Type,
+	}
+}
+
+func (t *convertTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+func (t *convertTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	cols := b.Cols()
+	convertIdx := execute.ColIdx(t.column, cols)
+	if convertIdx < 0 {
+		return fmt.Errorf("convert column %q does not exist", t.column)
+	}
+	if cols[convertIdx].Kind != execute.ValueColKind {
+		return fmt.Errorf("cannot convert column %q, only value columns can be converted", t.column)
+	}
+
+	builder, new := t.cache.BlockBuilder(b)
+	if new {
+		for j, c := range cols {
+			if j == convertIdx {
+				c.Type = t.typ
+			}
+			builder.AddCol(c)
+			if c.IsTag() && c.Common {
+				builder.SetCommonString(j, b.Tags()[c.Label])
+			}
+		}
+	}
+
+	k := execute.ConvertToKind(t.typ)
+	var err error
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		if err != nil {
+			return
+		}
+		for i := range ts {
+			// Convert the row before appending any of its values so a failed row leaves the block untouched.
+			converted, cerr := compiler.Convert(execute.ValueForRow(i, convertIdx, rr), k)
+			if cerr != nil {
+				err = fmt.Errorf("cannot convert column %q of the row at time %v: %v", t.column, ts[i], cerr)
+				return
+			}
+			for j, c := range cols {
+				if c.Common {
+					continue
+				}
+				if j == convertIdx {
+					execute.AppendValue(builder, j, converted)
+					continue
+				}
+				execute.AppendValue(builder, j, execute.ValueForRow(i, j, rr))
+			}
+		}
+	})
+	return err
+}
+
+func (t *convertTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *convertTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *convertTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,254 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestConvert_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "to float",
+			Raw:  `from(db:"mydb") |> toFloat()`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "toFloat1",
+						Spec: &functions.ConvertOpSpec{
+							Column: "_value",
+							Type:   execute.TFloat,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "toFloat1"},
+				},
+			},
+		},
+		{
+			Name: "to string with column",
+			Raw:  `from(db:"mydb") |> toString(column: "code")`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "toString1",
+						Spec: &functions.ConvertOpSpec{
+							Column: "code",
+							Type:   execute.TString,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "toString1"},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestConvertOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"toInt","kind":"toInt","spec":{"column":"_value"}}`)
+	op := &query.Operation{
+		ID: "toInt",
+		Spec: &functions.ConvertOpSpec{
+			Column: "_value",
+			Type:   execute.TInt,
+		},
+	}
+
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestConvert_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *functions.ConvertProcedureSpec
+		data    []execute.Block
+		want    []*executetest.Block
+		wantErr bool
+	}{
+		{
+			name: "int to float",
+			spec: &functions.ConvertProcedureSpec{
+				Column: "_value",
+				Type:   execute.TFloat,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(2), "a"},
+					{execute.Time(2), nil, "a"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0, "a"},
+					{execute.Time(2), nil, "a"},
+				},
+			}},
+		},
+		{
+			name: "string to int with invalid row",
+			spec: &functions.ConvertProcedureSpec{
+				Column: "code",
+				Type:   execute.TInt,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "code", Type: execute.TString, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "200"},
+					{execute.Time(2), "OK"},
+					{execute.Time(3), "-1"},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "missing column",
+			spec: &functions.ConvertProcedureSpec{
+				Column: "code",
+				Type:   execute.TInt,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  2,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TString, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "200"},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "time to string",
+			spec: &functions.ConvertProcedureSpec{
+				Column: "_value",
+				Type:   execute.TString,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  2,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TTime, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), execute.Time(1500000000000000000)},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  2,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TString, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "2017-07-14T02:40:00Z"},
+				},
+			}},
+		},
+		{
+			name: "tag column",
+			spec: &functions.ConvertProcedureSpec{
+				Column: "host",
+				Type:   execute.TInt,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  2,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "1"},
+				},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.wantErr {
+				d := executetest.NewDataset(executetest.RandomDatasetID())
+				c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+				c.SetTriggerSpec(execute.DefaultTriggerSpec)
+				tx := functions.NewConvertTransformation(d, c, tc.spec)
+				if err := tx.Process(executetest.RandomDatasetID(), tc.data[0]); err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewConvertTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
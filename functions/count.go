@@ -50,6 +50,13 @@ func (s *CountProcedureSpec) Copy() plan.ProcedureSpec {
 	return new(CountProcedureSpec)
 }
 
+func (s *CountProcedureSpec) PartialSpec() plan.ProcedureSpec {
+	return &PartialProcedureSpec{AggregateKind: CountKind}
+}
+func (s *CountProcedureSpec) CombineSpec() plan.ProcedureSpec {
+	return &CombineProcedureSpec{AggregateKind: CountKind}
+}
+
 func (s *CountProcedureSpec) AggregateMethod() string {
 	return CountKind
 }
@@ -91,24 +98,19 @@ func createCountTransformation(id execute.DatasetID, mode execute.AccumulationMo
 }
 
 func (a *CountAgg) NewBoolAgg() execute.DoBoolAgg {
-	a.count = 0
-	return a
+	return new(CountAgg)
 }
 func (a *CountAgg) NewIntAgg() execute.DoIntAgg {
-	a.count = 0
-	return a
+	return new(CountAgg)
 }
 func (a *CountAgg) NewUIntAgg() execute.DoUIntAgg {
-	a.count = 0
-	return a
+	return new(CountAgg)
 }
 func (a *CountAgg) NewFloatAgg() execute.DoFloatAgg {
-	a.count = 0
-	return a
+	return new(CountAgg)
 }
 func (a *CountAgg) NewStringAgg() execute.DoStringAgg {
-	a.count = 0
-	return a
+	return new(CountAgg)
 }
 
 func (a *CountAgg) DoBool(vs []bool) {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/influxdata/ifql/functions"
 	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
 	"github.com/influxdata/ifql/query/execute/executetest"
 	"github.com/influxdata/ifql/query/plan"
 	"github.com/influxdata/ifql/query/plan/plantest"
@@ -77,6 +78,98 @@ func TestCount_Process(t *testing.T) {
 		int64(10),
 	)
 }
+func TestCount_Process_Blocks(t *testing.T) {
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+	}
+	// Each block is counted on its own.
+	data := []execute.Block{
+		&executetest.Block{
+			Bnds:    execute.Bounds{Start: 0, Stop: 10},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1), 1.0},
+				{execute.Time(2), 2.0},
+				{execute.Time(3), 3.0},
+			},
+		},
+		&executetest.Block{
+			Bnds:    execute.Bounds{Start: 10, Stop: 20},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(11), 4.0},
+			},
+		},
+	}
+	want := []*executetest.Block{{
+		Bnds: execute.Bounds{Start: 0, Stop: 20},
+		ColMeta: []execute.ColMeta{
+			{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+			{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+		},
+		Data: [][]interface{}{
+			{execute.Time(10), int64(3)},
+			{execute.Time(20), int64(1)},
+		},
+	}}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		want,
+		func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+			return execute.NewAggregateTransformation(d, c, execute.AccumulatingMode, execute.Bounds{Start: 0, Stop: 20}, new(functions.CountAgg))
+		},
+	)
+}
+func TestCount_Process_Windows(t *testing.T) {
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+	}
+	data := []execute.Block{
+		&executetest.Block{
+			Bnds:    execute.Bounds{Start: 0, Stop: 10},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1), 1.0},
+				{execute.Time(2), 2.0},
+			},
+		},
+		// An empty window counts no values.
+		&executetest.Block{
+			Bnds:    execute.Bounds{Start: 10, Stop: 20},
+			ColMeta: cols,
+		},
+		&executetest.Block{
+			Bnds:    execute.Bounds{Start: 20, Stop: 30},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(21), 3.0},
+			},
+		},
+	}
+	want := []*executetest.Block{{
+		Bnds: execute.Bounds{Start: 0, Stop: 30},
+		ColMeta: []execute.ColMeta{
+			{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+			{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+		},
+		Data: [][]interface{}{
+			{execute.Time(10), int64(2)},
+			{execute.Time(20), int64(0)},
+			{execute.Time(30), int64(1)},
+		},
+	}}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		want,
+		func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+			return execute.NewAggregateTransformation(d, c, execute.AccumulatingMode, execute.Bounds{Start: 0, Stop: 30}, new(functions.CountAgg))
+		},
+	)
+}
 func BenchmarkCount(b *testing.B) {
 	executetest.AggFuncBenchmarkHelper(
 		b,
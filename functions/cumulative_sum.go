@@ -0,0 +1,153 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+)
+
+const CumulativeSumKind = "cumulativeSum"
+
+type CumulativeSumOpSpec struct{}
+
+var cumulativeSumSignature = query.DefaultFunctionSignature()
+
+func init() {
+	query.RegisterFunction(CumulativeSumKind, createCumulativeSumOpSpec, cumulativeSumSignature)
+	query.RegisterOpSpec(CumulativeSumKind, newCumulativeSumOp)
+	plan.RegisterProcedureSpec(CumulativeSumKind, newCumulativeSumProcedure, CumulativeSumKind)
+	execute.RegisterTransformation(CumulativeSumKind, createCumulativeSumTransformation)
+}
+
+func createCumulativeSumOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	return new(CumulativeSumOpSpec), nil
+}
+
+func newCumulativeSumOp() query.OperationSpec {
+	return new(CumulativeSumOpSpec)
+}
+
+func (s *CumulativeSumOpSpec) Kind() query.OperationKind {
+	return CumulativeSumKind
+}
+
+type CumulativeSumProcedureSpec struct{}
+
+func newCumulativeSumProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	if _, ok := qs.(*CumulativeSumOpSpec); !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return new(CumulativeSumProcedureSpec), nil
+}
+
+func (s *CumulativeSumProcedureSpec) Kind() plan.ProcedureKind {
+	return CumulativeSumKind
+}
+func (s *CumulativeSumProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(CumulativeSumProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createCumulativeSumTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*CumulativeSumProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewCumulativeSumTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type cumulativeSumTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+}
+
+func NewCumulativeSumTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *CumulativeSumProcedureSpec) *cumulativeSumTransformation {
+	return &cumulativeSumTransformation{
+		d:     d,
+		cache: cache,
+	}
+}
+
+func (t *cumulativeSumTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+// Process replaces the numeric value columns with their running sum.
+// Null values remain null and do not change the sum.
+func (t *cumulativeSumTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	builder, new := t.cache.BlockBuilder(b)
+	cols := b.Cols()
+	if new {
+		for j, c := range cols {
+			builder.AddCol(c)
+			if c.IsTag() && c.Common {
+				builder.SetCommonString(j, b.Tags()[c.Label])
+			}
+		}
+	}
+
+	sums := make([]*cumulativeSum, len(cols))
+	for j, c := range cols {
+		if isNumericValueCol(c) {
+			sums[j] = &cumulativeSum{}
+		}
+	}
+
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i := range ts {
+			for j, c := range cols {
+				if !isNumericValueCol(c) || rr.IsNull(i, j) {
+					appendValue(builder, rr, i, j)
+					continue
+				}
+				switch c.Type {
+				case execute.TInt:
+					builder.AppendInt(j, sums[j].addInt(rr.AtInt(i, j)))
+				case execute.TUInt:
+					builder.AppendUInt(j, sums[j].addUInt(rr.AtUInt(i, j)))
+				case execute.TFloat:
+					builder.AppendFloat(j, sums[j].addFloat(rr.AtFloat(i, j)))
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func (t *cumulativeSumTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *cumulativeSumTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *cumulativeSumTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+type cumulativeSum struct {
+	intSum   int64
+	uintSum  uint64
+	floatSum float64
+}
+
+func (s *cumulativeSum) addInt(v int64) int64 {
+	s.intSum += v
+	return s.intSum
+}
+func (s *cumulativeSum) addUInt(v uint64) uint64 {
+	s.uintSum += v
+	return s.uintSum
+}
+func (s *cumulativeSum) addFloat(v float64) float64 {
+	s.floatSum += v
+	return s.floatSum
+}
@@ -0,0 +1,162 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestCumulativeSumOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"cumulativeSum","kind":"cumulativeSum","spec":{}}`)
+	op := &query.Operation{
+		ID:   "cumulativeSum",
+		Spec: &functions.CumulativeSumOpSpec{},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestCumulativeSum_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+		s := functions.NewCumulativeSumTransformation(
+			d,
+			c,
+			&functions.CumulativeSumProcedureSpec{},
+		)
+		return s
+	})
+}
+
+func TestCumulativeSum_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.CumulativeSumProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "float",
+			spec: &functions.CumulativeSumProcedureSpec{},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), 1.0},
+					{execute.Time(3), 3.5},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), 3.0},
+					{execute.Time(3), 6.5},
+				},
+			}},
+		},
+		{
+			name: "int and uint with nulls",
+			spec: &functions.CumulativeSumProcedureSpec{},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "x", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "y", Type: execute.TUInt, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(2), uint64(1)},
+					{execute.Time(2), nil, uint64(4)},
+					{execute.Time(3), int64(-5), nil},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "x", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "y", Type: execute.TUInt, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(2), uint64(1)},
+					{execute.Time(2), nil, uint64(5)},
+					{execute.Time(3), int64(-3), nil},
+				},
+			}},
+		},
+		{
+			name: "tags and strings",
+			spec: &functions.CumulativeSumProcedureSpec{},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "msg", Type: execute.TString, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a", "x", "y"},
+					{execute.Time(2), 1.0, "b", "x", "z"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "msg", Type: execute.TString, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a", "x", "y"},
+					{execute.Time(2), 2.0, "b", "x", "z"},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewCumulativeSumTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -516,6 +516,40 @@ func TestDerivative_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "nulls",
+			spec: &functions.DerivativeProcedureSpec{
+				Unit: 1,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), nil},
+					{execute.Time(3), 6.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), 2.0},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -157,6 +157,10 @@ func (t *differenceTransformation) Process(id execute.DatasetID, b execute.Block
 				}
 				var ok bool
 				j := d.col
+				if rr.IsNull(i, j) {
+					// Null values are skipped, the next difference is computed from the previous value.
+					continue
+				}
 				switch cols[j].Type {
 				case execute.TInt:
 					ok = d.updateInt(rr.AtInt(i, j))
@@ -173,9 +177,12 @@ func (t *differenceTransformation) Process(id execute.DatasetID, b execute.Block
 					case execute.TimeColKind:
 						builder.AppendTime(j, rr.AtTime(i, j))
 					case execute.TagColKind:
-						builder.AppendString(j, rr.AtString(i, j))
+						appendValue(builder, rr, i, j)
 					case execute.ValueColKind:
-						//TODO(nathanielc): Write null markers when we have support for null values.
+						if rr.IsNull(i, j) {
+							builder.AppendNil(j)
+							continue
+						}
 						switch c.Type {
 						case execute.TInt:
 							builder.AppendInt(j, differences[j].valueInt())
@@ -109,6 +109,9 @@ func (t *distinctTransformation) RetractBlock(id execute.DatasetID, meta execute
 }
 
 func (t *distinctTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	if err := execute.SpillIfNeeded(t.cache); err != nil {
+		return err
+	}
 	builder, new := t.cache.BlockBuilder(b)
 	if new {
 		execute.AddBlockCols(b, builder)
@@ -187,6 +190,10 @@ func (t *distinctTransformation) Process(id execute.DatasetID, b execute.Block)
 				if c.Common {
 					continue
 				}
+				if rr.IsNull(i, j) {
+					builder.AppendNil(j)
+					continue
+				}
 				switch c.Type {
 				case execute.TBool:
 					builder.AppendBool(j, rr.AtBool(i, j))
@@ -0,0 +1,201 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const ExponentialMovingAverageKind = "exponentialMovingAverage"
+
+type ExponentialMovingAverageOpSpec struct {
+	N int64 `json:"n"`
+}
+
+var exponentialMovingAverageSignature = query.DefaultFunctionSignature()
+
+func init() {
+	exponentialMovingAverageSignature.Params["n"] = semantic.Int
+
+	query.RegisterFunction(ExponentialMovingAverageKind, createExponentialMovingAverageOpSpec, exponentialMovingAverageSignature)
+	query.RegisterOpSpec(ExponentialMovingAverageKind, newExponentialMovingAverageOp)
+	plan.RegisterProcedureSpec(ExponentialMovingAverageKind, newExponentialMovingAverageProcedure, ExponentialMovingAverageKind)
+	execute.RegisterTransformation(ExponentialMovingAverageKind, createExponentialMovingAverageTransformation)
+}
+
+func createExponentialMovingAverageOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, errors.New("exponentialMovingAverage n must be positive")
+	}
+	return &ExponentialMovingAverageOpSpec{
+		N: n,
+	}, nil
+}
+
+func newExponentialMovingAverageOp() query.OperationSpec {
+	return new(ExponentialMovingAverageOpSpec)
+}
+
+func (s *ExponentialMovingAverageOpSpec) Kind() query.OperationKind {
+	return ExponentialMovingAverageKind
+}
+
+type ExponentialMovingAverageProcedureSpec struct {
+	N int64 `json:"n"`
+}
+
+func newExponentialMovingAverageProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ExponentialMovingAverageOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &ExponentialMovingAverageProcedureSpec{
+		N: spec.N,
+	}, nil
+}
+
+func (s *ExponentialMovingAverageProcedureSpec) Kind() plan.ProcedureKind {
+	return ExponentialMovingAverageKind
+}
+func (s *ExponentialMovingAverageProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ExponentialMovingAverageProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createExponentialMovingAverageTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ExponentialMovingAverageProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewExponentialMovingAverageTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type exponentialMovingAverageTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+
+	n int
+}
+
+func NewExponentialMovingAverageTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *ExponentialMovingAverageProcedureSpec) *exponentialMovingAverageTransformation {
+	return &exponentialMovingAverageTransformation{
+		d:     d,
+		cache: cache,
+		n:     int(spec.N),
+	}
+}
+
+func (t *exponentialMovingAverageTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+// Process replaces the numeric value columns with their exponential moving average with the smoothing factor 2/(n+1).
+// The first average of a column is the mean of its first n values and the first n-1 rows of a block are dropped.
+// Null values do not change the average.
+func (t *exponentialMovingAverageTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	builder, new := t.cache.BlockBuilder(b)
+	cols := b.Cols()
+	if new {
+		addAverageCols(b, builder)
+	}
+
+	averages := make([]*exponentialMovingAverage, len(cols))
+	for j, c := range cols {
+		if isNumericValueCol(c) {
+			averages[j] = newExponentialMovingAverage(t.n)
+		}
+	}
+
+	row := 0
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i := range ts {
+			for j, avg := range averages {
+				if avg == nil || rr.IsNull(i, j) {
+					continue
+				}
+				switch cols[j].Type {
+				case execute.TInt:
+					avg.add(float64(rr.AtInt(i, j)))
+				case execute.TUInt:
+					avg.add(float64(rr.AtUInt(i, j)))
+				case execute.TFloat:
+					avg.add(rr.AtFloat(i, j))
+				}
+			}
+			row++
+			if row < t.n {
+				continue
+			}
+			for j := range cols {
+				if avg := averages[j]; avg != nil {
+					if v, ok := avg.value(); ok {
+						builder.AppendFloat(j, v)
+					} else {
+						builder.AppendNil(j)
+					}
+					continue
+				}
+				appendValue(builder, rr, i, j)
+			}
+		}
+	})
+	return nil
+}
+
+func (t *exponentialMovingAverageTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *exponentialMovingAverageTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *exponentialMovingAverageTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+type exponentialMovingAverage struct {
+	n     int
+	alpha float64
+
+	count int
+	sum   float64
+	ema   float64
+}
+
+func newExponentialMovingAverage(n int) *exponentialMovingAverage {
+	return &exponentialMovingAverage{
+		n:     n,
+		alpha: 2 / float64(n+1),
+	}
+}
+
+func (a *exponentialMovingAverage) add(v float64) {
+	if a.count < a.n {
+		a.count++
+		a.sum += v
+		a.ema = a.sum / float64(a.count)
+		return
+	}
+	a.ema = a.alpha*v + (1-a.alpha)*a.ema
+}
+
+// value returns the average once the column had n values.
+func (a *exponentialMovingAverage) value() (float64, bool) {
+	return a.ema, a.count == a.n
+}
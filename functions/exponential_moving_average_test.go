@@ -0,0 +1,168 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestExponentialMovingAverage_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "exponential moving average",
+			Raw:  `from(db:"mydb") |> exponentialMovingAverage(n: 3)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "exponentialMovingAverage1",
+						Spec: &functions.ExponentialMovingAverageOpSpec{
+							N: 3,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "exponentialMovingAverage1"},
+				},
+			},
+		},
+		{
+			Name:    "zero n",
+			Raw:     `from(db:"mydb") |> exponentialMovingAverage(n: 0)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestExponentialMovingAverageOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"exponentialMovingAverage","kind":"exponentialMovingAverage","spec":{"n":3}}`)
+	op := &query.Operation{
+		ID: "exponentialMovingAverage",
+		Spec: &functions.ExponentialMovingAverageOpSpec{
+			N: 3,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestExponentialMovingAverage_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+		s := functions.NewExponentialMovingAverageTransformation(
+			d,
+			c,
+			&functions.ExponentialMovingAverageProcedureSpec{N: 2},
+		)
+		return s
+	})
+}
+
+func TestExponentialMovingAverage_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.ExponentialMovingAverageProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "float",
+			spec: &functions.ExponentialMovingAverageProcedureSpec{N: 3},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  6,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+					{execute.Time(2), 2.0},
+					{execute.Time(3), 3.0},
+					{execute.Time(4), 5.0},
+					{execute.Time(5), 1.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  6,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), 2.0},
+					{execute.Time(4), 3.5},
+					{execute.Time(5), 2.25},
+				},
+			}},
+		},
+		{
+			name: "uint with nulls",
+			spec: &functions.ExponentialMovingAverageProcedureSpec{N: 3},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  6,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TUInt, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), uint64(1)},
+					{execute.Time(2), nil},
+					{execute.Time(3), uint64(2)},
+					{execute.Time(4), uint64(3)},
+					{execute.Time(5), uint64(7)},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  6,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), nil},
+					{execute.Time(4), 2.0},
+					{execute.Time(5), 4.5},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewExponentialMovingAverageTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
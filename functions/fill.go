@@ -0,0 +1,413 @@
+package functions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/ifql/compiler"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const FillKind = "fill"
+
+// FillOpSpec replaces the null values of a column.
+// The null values are replaced either with Value or with the previous non null value of the column.
+// When Every is set, rows are first added to fill the gaps in the time grid of the rows.
+type FillOpSpec struct {
+	Column      string         `json:"column"`
+	Value       interface{}    `json:"value"`
+	UsePrevious bool           `json:"use_previous"`
+	Every       query.Duration `json:"every"`
+}
+
+var fillSignature = query.DefaultFunctionSignature()
+
+func init() {
+	fillSignature.Params["column"] = semantic.String
+	fillSignature.Params["value"] = semantic.Invalid
+	fillSignature.Params["usePrevious"] = semantic.Bool
+	fillSignature.Params["every"] = semantic.Duration
+
+	query.RegisterFunction(FillKind, createFillOpSpec, fillSignature)
+	query.RegisterOpSpec(FillKind, newFillOp)
+	plan.RegisterProcedureSpec(FillKind, newFillProcedure, FillKind)
+	plan.RegisterRewriteRule(WindowGridRewriteRule{Kind: FillKind})
+	execute.RegisterTransformation(FillKind, createFillTransformation)
+}
+
+func createFillOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &FillOpSpec{
+		Column: execute.DefaultValueColLabel,
+	}
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	}
+
+	if usePrevious, ok, err := args.GetBool("usePrevious"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.UsePrevious = usePrevious
+	}
+
+	if every, ok, err := args.GetDuration("every"); err != nil {
+		return nil, err
+	} else if ok {
+		if every <= 0 {
+			return nil, errors.New("fill every must be positive")
+		}
+		spec.Every = every
+	}
+
+	if v, ok := args.Get("value"); ok {
+		if spec.UsePrevious {
+			return nil, errors.New("fill accepts only one of value and usePrevious")
+		}
+		switch k := v.Type().Kind(); k {
+		case semantic.Bool, semantic.Int, semantic.UInt, semantic.Float, semantic.String:
+			spec.Value = v.Value()
+		default:
+			return nil, fmt.Errorf("cannot fill with a value of kind %v", k)
+		}
+	} else if !spec.UsePrevious {
+		return nil, errors.New("fill requires either value or usePrevious")
+	}
+
+	return spec, nil
+}
+
+func newFillOp() query.OperationSpec {
+	return new(FillOpSpec)
+}
+
+func (s *FillOpSpec) Kind() query.OperationKind {
+	return FillKind
+}
+
+// fillValueJSON is the JSON encoding of a fill value, which records the type of the value.
+type fillValueJSON struct {
+	Column      string          `json:"column"`
+	Type        string          `json:"type,omitempty"`
+	Value       json.RawMessage `json:"value,omitempty"`
+	UsePrevious bool            `json:"use_previous"`
+	Every       query.Duration  `json:"every"`
+}
+
+func (s *FillOpSpec) MarshalJSON() ([]byte, error) {
+	raw := fillValueJSON{
+		Column:      s.Column,
+		UsePrevious: s.UsePrevious,
+		Every:       s.Every,
+	}
+	if s.Value != nil {
+		switch s.Value.(type) {
+		case bool:
+			raw.Type = "bool"
+		case int64:
+			raw.Type = "int"
+		case uint64:
+			raw.Type = "uint"
+		case float64:
+			raw.Type = "float"
+		case string:
+			raw.Type = "string"
+		default:
+			return nil, fmt.Errorf("cannot fill with a value of type %T", s.Value)
+		}
+		v, err := json.Marshal(s.Value)
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = v
+	}
+	return json.Marshal(raw)
+}
+
+func (s *FillOpSpec) UnmarshalJSON(data []byte) error {
+	var raw fillValueJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Column = raw.Column
+	s.UsePrevious = raw.UsePrevious
+	s.Every = raw.Every
+	s.Value = nil
+	if raw.Type == "" {
+		return nil
+	}
+	var err error
+	switch raw.Type {
+	case "bool":
+		var v bool
+		err = json.Unmarshal(raw.Value, &v)
+		s.Value = v
+	case "int":
+		var v int64
+		err = json.Unmarshal(raw.Value, &v)
+		s.Value = v
+	case "uint":
+		var v uint64
+		err = json.Unmarshal(raw.Value, &v)
+		s.Value = v
+	case "float":
+		var v float64
+		err = json.Unmarshal(raw.Value, &v)
+		s.Value = v
+	case "string":
+		var v string
+		err = json.Unmarshal(raw.Value, &v)
+		s.Value = v
+	default:
+		return fmt.Errorf("unknown fill value type %q", raw.Type)
+	}
+	return err
+}
+
+type FillProcedureSpec struct {
+	Column      string
+	Value       interface{}
+	UsePrevious bool
+	Every       query.Duration
+}
+
+func newFillProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FillOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &FillProcedureSpec{
+		Column:      spec.Column,
+		Value:       spec.Value,
+		UsePrevious: spec.UsePrevious,
+		Every:       spec.Every,
+	}, nil
+}
+
+func (s *FillProcedureSpec) Kind() plan.ProcedureKind {
+	return FillKind
+}
+func (s *FillProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(FillProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+// WindowGridRewriteRule sets the time grid of a procedure that fills gaps,
+// to the every duration of the closest window of its data, unless it is already set.
+type WindowGridRewriteRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (r WindowGridRewriteRule) Root() plan.ProcedureKind {
+	return r.Kind
+}
+
+func (r WindowGridRewriteRule) Rewrite(pr *plan.Procedure, planner plan.PlanRewriter) error {
+	var every *query.Duration
+	switch s := pr.Spec.(type) {
+	case *FillProcedureSpec:
+		every = &s.Every
+	case *InterpolateProcedureSpec:
+		every = &s.Every
+	default:
+		return fmt.Errorf("invalid spec type %T", pr.Spec)
+	}
+	if *every == 0 {
+		*every = findTimeAlignment(pr).window.Every
+	}
+	return nil
+}
+
+func createFillTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*FillProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewFillTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type fillTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+
+	column      string
+	value       interface{}
+	usePrevious bool
+	every       execute.Duration
+
+	colMap []int
+}
+
+func NewFillTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *FillProcedureSpec) *fillTransformation {
+	return &fillTransformation{
+		d:           d,
+		cache:       cache,
+		column:      spec.Column,
+		value:       spec.Value,
+		usePrevious: spec.UsePrevious,
+		every:       execute.Duration(spec.Every),
+	}
+}
+
+func (t *fillTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+// Process appends the rows of b with the null values of the column filled.
+// When every is set, rows are added at the times of the grid of every duration that are missing between consecutive rows.
+func (t *fillTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	builder, new := t.cache.BlockBuilder(b)
+	if new {
+		execute.AddBlockCols(b, builder)
+	}
+
+	ncols := builder.NCols()
+	if cap(t.colMap) < ncols {
+		t.colMap = make([]int, ncols)
+		for j := range t.colMap {
+			t.colMap[j] = j
+		}
+	} else {
+		t.colMap = t.colMap[:ncols]
+	}
+
+	cols := builder.Cols()
+	fillIdx := execute.ColIdx(t.column, cols)
+	if fillIdx < 0 {
+		execute.AppendBlock(b, builder, t.colMap)
+		return nil
+	}
+	typ := cols[fillIdx].Type
+
+	// The previous value does not carry over from other blocks, leading null values remain null.
+	var fill compiler.Value
+	if !t.usePrevious {
+		v, err := fillValue(t.value, typ)
+		if err != nil {
+			return err
+		}
+		fill = v
+	}
+
+	timeIdx := execute.TimeIdx(cols)
+	var (
+		prev    execute.Time
+		hasPrev bool
+	)
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i, tm := range ts {
+			if t.every > 0 && hasPrev {
+				for gap := prev.Add(t.every); gap < tm; gap = gap.Add(t.every) {
+					appendGapRow(builder, timeIdx, gap)
+					if fill != nil {
+						setValue(builder, builder.NRows()-1, fillIdx, typ, fill)
+					}
+				}
+			}
+			prev, hasPrev = tm, true
+
+			execute.AppendRow(i, rr, builder, t.colMap)
+			if !rr.IsNull(i, fillIdx) {
+				if t.usePrevious {
+					fill = execute.ValueForRow(i, fillIdx, rr)
+				}
+				continue
+			}
+			if fill == nil {
+				continue
+			}
+			setValue(builder, builder.NRows()-1, fillIdx, typ, fill)
+		}
+	})
+	return nil
+}
+
+// appendGapRow appends a row at time tm, with null values in all columns except the time and common columns.
+func appendGapRow(builder execute.BlockBuilder, timeIdx int, tm execute.Time) {
+	for j, c := range builder.Cols() {
+		switch {
+		case j == timeIdx:
+			builder.AppendTime(j, tm)
+		case c.Common:
+			// Common columns hold a single value for all rows.
+		default:
+			builder.AppendNil(j)
+		}
+	}
+}
+
+// setValue sets the value of row i and column j, of type typ, to v.
+func setValue(builder execute.BlockBuilder, i, j int, typ execute.DataType, v compiler.Value) {
+	switch typ {
+	case execute.TBool:
+		builder.SetBool(i, j, v.Bool())
+	case execute.TInt:
+		builder.SetInt(i, j, v.Int())
+	case execute.TUInt:
+		builder.SetUInt(i, j, v.UInt())
+	case execute.TFloat:
+		builder.SetFloat(i, j, v.Float())
+	case execute.TString:
+		builder.SetString(i, j, v.Str())
+	case execute.TTime:
+		builder.SetTime(i, j, execute.Time(v.Time()))
+	default:
+		execute.PanicUnknownType(typ)
+	}
+}
+
+// fillValue converts v to a value of a column of type typ.
+// Integer values may fill float columns.
+func fillValue(v interface{}, typ execute.DataType) (compiler.Value, error) {
+	switch v := v.(type) {
+	case bool:
+		if typ == execute.TBool {
+			return compiler.NewBool(v), nil
+		}
+	case int64:
+		switch typ {
+		case execute.TInt:
+			return compiler.NewInt(v), nil
+		case execute.TFloat:
+			return compiler.NewFloat(float64(v)), nil
+		}
+	case uint64:
+		switch typ {
+		case execute.TUInt:
+			return compiler.NewUInt(v), nil
+		case execute.TFloat:
+			return compiler.NewFloat(float64(v)), nil
+		}
+	case float64:
+		if typ == execute.TFloat {
+			return compiler.NewFloat(v), nil
+		}
+	case string:
+		if typ == execute.TString {
+			return compiler.NewString(v), nil
+		}
+	}
+	return nil, fmt.Errorf("cannot fill column of type %v with value %v", typ, v)
+}
+
+func (t *fillTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *fillTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *fillTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
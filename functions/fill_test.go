@@ -0,0 +1,436 @@
+package functions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestFill_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "fill with value",
+			Raw:  `from(db:"mydb") |> fill(value: 0.0)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "fill1",
+						Spec: &functions.FillOpSpec{
+							Column: "_value",
+							Value:  0.0,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "fill1"},
+				},
+			},
+		},
+		{
+			Name: "fill column with previous",
+			Raw:  `from(db:"mydb") |> fill(column: "host", usePrevious: true)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "fill1",
+						Spec: &functions.FillOpSpec{
+							Column:      "host",
+							UsePrevious: true,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "fill1"},
+				},
+			},
+		},
+		{
+			Name: "fill with every",
+			Raw:  `from(db:"mydb") |> fill(value: 0, every: 1m)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "fill1",
+						Spec: &functions.FillOpSpec{
+							Column: "_value",
+							Value:  int64(0),
+							Every:  query.Duration(time.Minute),
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "fill1"},
+				},
+			},
+		},
+		{
+			Name:    "value and previous",
+			Raw:     `from(db:"mydb") |> fill(value: 0.0, usePrevious: true)`,
+			WantErr: true,
+		},
+		{
+			Name:    "no value",
+			Raw:     `from(db:"mydb") |> fill()`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestFillOperation_Marshaling(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+		op   *query.Operation
+	}{
+		{
+			name: "value",
+			data: []byte(`{"id":"fill","kind":"fill","spec":{"column":"_value","type":"int","value":1,"use_previous":false}}`),
+			op: &query.Operation{
+				ID: "fill",
+				Spec: &functions.FillOpSpec{
+					Column: "_value",
+					Value:  int64(1),
+				},
+			},
+		},
+		{
+			name: "previous",
+			data: []byte(`{"id":"fill","kind":"fill","spec":{"column":"_value","use_previous":true}}`),
+			op: &query.Operation{
+				ID: "fill",
+				Spec: &functions.FillOpSpec{
+					Column:      "_value",
+					UsePrevious: true,
+				},
+			},
+		},
+		{
+			name: "every",
+			data: []byte(`{"id":"fill","kind":"fill","spec":{"column":"_value","use_previous":true,"every":"1m"}}`),
+			op: &query.Operation{
+				ID: "fill",
+				Spec: &functions.FillOpSpec{
+					Column:      "_value",
+					UsePrevious: true,
+					Every:       query.Duration(time.Minute),
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			querytest.OperationMarshalingTestHelper(t, tc.data, tc.op)
+		})
+	}
+}
+
+func TestFill_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.FillProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "value",
+			spec: &functions.FillProcedureSpec{
+				Column: "_value",
+				Value:  0.0,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), nil},
+					{execute.Time(3), 1.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), 0.0},
+					{execute.Time(3), 1.0},
+				},
+			}},
+		},
+		{
+			name: "integer value in float column",
+			spec: &functions.FillProcedureSpec{
+				Column: "_value",
+				Value:  int64(-1),
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil},
+					{execute.Time(2), 1.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), -1.0},
+					{execute.Time(2), 1.0},
+				},
+			}},
+		},
+		{
+			name: "use previous",
+			spec: &functions.FillProcedureSpec{
+				Column:      "host",
+				UsePrevious: true,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  6,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, nil},
+					{execute.Time(2), nil, "a"},
+					{execute.Time(3), 3.0, nil},
+					{execute.Time(4), 4.0, "b"},
+					{execute.Time(5), 5.0, nil},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  6,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, nil},
+					{execute.Time(2), nil, "a"},
+					{execute.Time(3), 3.0, "a"},
+					{execute.Time(4), 4.0, "b"},
+					{execute.Time(5), 5.0, "b"},
+				},
+			}},
+		},
+		{
+			name: "every",
+			spec: &functions.FillProcedureSpec{
+				Column: "_value",
+				Value:  0.0,
+				Every:  query.Duration(2),
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 2.0, "a", "x"},
+					{execute.Time(4), nil, "a", "x"},
+					{execute.Time(9), 1.0, "a", "y"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 2.0, "a", "x"},
+					{execute.Time(4), 0.0, "a", "x"},
+					{execute.Time(6), 0.0, "a", nil},
+					{execute.Time(8), 0.0, "a", nil},
+					{execute.Time(9), 1.0, "a", "y"},
+				},
+			}},
+		},
+		{
+			name: "every with previous",
+			spec: &functions.FillProcedureSpec{
+				Column:      "_value",
+				UsePrevious: true,
+				Every:       query.Duration(1),
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  5,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(3)},
+					{execute.Time(4), int64(5)},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  5,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(3)},
+					{execute.Time(2), int64(3)},
+					{execute.Time(3), int64(3)},
+					{execute.Time(4), int64(5)},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewFillTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
+
+func TestWindowGridRewriteRule(t *testing.T) {
+	testCases := []struct {
+		name string
+		q    string
+		want query.Duration
+	}{
+		{
+			name: "window",
+			q:    `from(db:"mydb") |> range(start:-1h) |> window(every:5m) |> count() |> fill(value:0)`,
+			want: query.Duration(5 * time.Minute),
+		},
+		{
+			name: "explicit every",
+			q:    `from(db:"mydb") |> range(start:-1h) |> window(every:5m) |> count() |> fill(value:0, every:1m)`,
+			want: query.Duration(time.Minute),
+		},
+		{
+			name: "no window",
+			q:    `from(db:"mydb") |> range(start:-1h) |> fill(value:0)`,
+			want: 0,
+		},
+		{
+			name: "interpolate",
+			q:    `from(db:"mydb") |> range(start:-1h) |> window(every:10m) |> mean() |> interpolate()`,
+			want: query.Duration(10 * time.Minute),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := query.Compile(context.Background(), tc.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lp, err := plan.NewLogicalPlanner().Plan(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pp, err := plan.NewPlanner().Plan(lp, nil, time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []query.Duration
+			pp.Do(func(pr *plan.Procedure) {
+				switch s := pr.Spec.(type) {
+				case *functions.FillProcedureSpec:
+					got = append(got, s.Every)
+				case *functions.InterpolateProcedureSpec:
+					got = append(got, s.Every)
+				}
+			})
+			if len(got) != 1 || got[0] != tc.want {
+				t.Errorf("unexpected every: want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -89,6 +89,10 @@ func (s *FilterProcedureSpec) PushDownRules() []plan.PushDownRule {
 				if _, ok := s.Fn.Body.(semantic.Expression); !ok {
 					return false
 				}
+				// Only push down filters that storage can evaluate
+				if _, err := execute.ToStoragePredicate(s.Fn); err != nil {
+					return false
+				}
 				fs := spec.(*FromProcedureSpec)
 				if fs.Filter != nil {
 					if _, ok := fs.Filter.Body.(semantic.Expression); !ok {
@@ -247,6 +251,10 @@ func (t *filterTransformation) Process(id execute.DatasetID, b execute.Block) er
 				if c.Common {
 					continue
 				}
+				if rr.IsNull(i, j) {
+					builder.AppendNil(j)
+					continue
+				}
 				switch c.Type {
 				case execute.TBool:
 					builder.AppendBool(j, rr.AtBool(i, j))
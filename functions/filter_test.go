@@ -566,6 +566,53 @@ func TestFilter_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: `_value>5 with null`,
+			spec: &functions.FilterProcedureSpec{
+				Fn: &semantic.FunctionExpression{
+					Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+					Body: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left: &semantic.MemberExpression{
+							Object:   &semantic.IdentifierExpression{Name: "r"},
+							Property: "_value",
+						},
+						Right: &semantic.FloatLiteral{Value: 5},
+					},
+				},
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil, "a"},
+					{execute.Time(2), 6.0, nil},
+					{execute.Time(3), 7.0, "b"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 6.0, nil},
+					{execute.Time(3), 7.0, "b"},
+				},
+			}},
+		},
 		{
 			name: "_value>5 multiple blocks",
 			spec: &functions.FilterProcedureSpec{
@@ -720,6 +767,69 @@ func TestFilter_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "t1 =~ /^a/ and t2 !~ /x$/",
+			spec: &functions.FilterProcedureSpec{
+				Fn: &semantic.FunctionExpression{
+					Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+					Body: &semantic.LogicalExpression{
+						Operator: ast.AndOperator,
+						Left: &semantic.BinaryExpression{
+							Operator: ast.RegexpMatchOperator,
+							Left: &semantic.MemberExpression{
+								Object:   &semantic.IdentifierExpression{Name: "r"},
+								Property: "t1",
+							},
+							Right: &semantic.RegexpLiteral{
+								Value: regexp.MustCompile("^a"),
+							},
+						},
+						Right: &semantic.BinaryExpression{
+							Operator: ast.NotRegexpMatchOperator,
+							Left: &semantic.MemberExpression{
+								Object:   &semantic.IdentifierExpression{Name: "r"},
+								Property: "t2",
+							},
+							Right: &semantic.RegexpLiteral{
+								Value: regexp.MustCompile("x$"),
+							},
+						},
+					},
+				},
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: false},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind, Common: false},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "ab", "x"},
+					{execute.Time(2), 6.0, "ba", "y"},
+					{execute.Time(3), 8.0, "ab", "y"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: false},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind, Common: false},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), 8.0, "ab", "y"},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -777,6 +887,48 @@ func TestFilter_PushDown(t *testing.T) {
 	plantest.PhysicalPlan_PushDown_TestHelper(t, spec, root, false, want)
 }
 
+func TestFilter_PushDown_Match(t *testing.T) {
+	spec := &functions.FilterProcedureSpec{
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+			Body: &semantic.ConditionalExpression{
+				Test: &semantic.BinaryExpression{
+					Operator: ast.EqualOperator,
+					Left: &semantic.MemberExpression{
+						Object:   &semantic.IdentifierExpression{Name: "r"},
+						Property: "host",
+					},
+					Right: &semantic.StringLiteral{Value: "a"},
+				},
+				Consequent: &semantic.BinaryExpression{
+					Operator: ast.GreaterThanOperator,
+					Left: &semantic.MemberExpression{
+						Object:   &semantic.IdentifierExpression{Name: "r"},
+						Property: "_value",
+					},
+					Right: &semantic.FloatLiteral{Value: 90},
+				},
+				Alternate: &semantic.BinaryExpression{
+					Operator: ast.GreaterThanOperator,
+					Left: &semantic.MemberExpression{
+						Object:   &semantic.IdentifierExpression{Name: "r"},
+						Property: "_value",
+					},
+					Right: &semantic.FloatLiteral{Value: 80},
+				},
+			},
+		},
+	}
+	// Storage cannot evaluate conditional expressions
+	rules := spec.PushDownRules()
+	if rules[0].Root != functions.FromKind {
+		t.Fatalf("unexpected root of first push down rule %v", rules[0].Root)
+	}
+	if rules[0].Match(new(functions.FromProcedureSpec)) {
+		t.Error("unexpected push down of conditional expression into storage")
+	}
+}
+
 func TestFilter_PushDown_MergeExpressions(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -58,6 +58,8 @@ func (s *FromOpSpec) Kind() query.OperationKind {
 type FromProcedureSpec struct {
 	Database string
 	Hosts    []string
+	// Empty indicates that no shard holds data within the bounds, the read produces no blocks without reading storage.
+	Empty bool
 
 	BoundsSet bool
 	Bounds    plan.BoundsSpec
@@ -105,6 +107,10 @@ func (s *FromProcedureSpec) Kind() plan.ProcedureKind {
 func (s *FromProcedureSpec) TimeBounds() plan.BoundsSpec {
 	return s.Bounds
 }
+func (s *FromProcedureSpec) SetTimeBounds(bounds plan.BoundsSpec) {
+	s.BoundsSet = true
+	s.Bounds = bounds
+}
 func (s *FromProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(FromProcedureSpec)
 
@@ -114,6 +120,7 @@ func (s *FromProcedureSpec) Copy() plan.ProcedureSpec {
 		ns.Hosts = make([]string, len(s.Hosts))
 		copy(ns.Hosts, s.Hosts)
 	}
+	ns.Empty = s.Empty
 
 	ns.BoundsSet = s.BoundsSet
 	ns.Bounds = s.Bounds
@@ -133,14 +140,71 @@ func (s *FromProcedureSpec) Copy() plan.ProcedureSpec {
 	ns.WindowSet = s.WindowSet
 	ns.Window = s.Window
 
+	ns.GroupingSet = s.GroupingSet
+	ns.OrderByTime = s.OrderByTime
+	ns.MergeAll = s.MergeAll
+	ns.GroupKeys = s.GroupKeys
+	ns.GroupExcept = s.GroupExcept
+	ns.GroupKeep = s.GroupKeep
+
 	ns.AggregateSet = s.AggregateSet
 	ns.AggregateMethod = s.AggregateMethod
 
 	return ns
 }
 
+func (s *FromProcedureSpec) StorageDatabase() string {
+	return s.Database
+}
+func (s *FromProcedureSpec) StorageHosts() []string {
+	return s.Hosts
+}
+func (s *FromProcedureSpec) SetStorageHosts(hosts []string) {
+	s.Hosts = hosts
+}
+func (s *FromProcedureSpec) SetStorageEmpty() {
+	s.Hosts = nil
+	s.Empty = true
+}
+func (s *FromProcedureSpec) ShardSpec(hosts []string, bounds plan.BoundsSpec) plan.ProcedureSpec {
+	ns := s.Copy().(*FromProcedureSpec)
+	ns.Hosts = hosts
+	ns.BoundsSet = true
+	ns.Bounds = bounds
+	return ns
+}
+
+func (s *FromProcedureSpec) StorageRaw() bool {
+	return !s.LimitSet && !s.WindowSet && !s.AggregateSet
+}
+
+// MergeSpec returns a spec that merges the per shard reads.
+// Reads that limit, order or window their results, or that aggregate groups, cannot be merged.
+func (s *FromProcedureSpec) MergeSpec() plan.ProcedureSpec {
+	if s.LimitSet || s.DescendingSet || s.WindowSet {
+		return nil
+	}
+	if s.AggregateSet {
+		if s.GroupingSet {
+			return nil
+		}
+		switch s.AggregateMethod {
+		case CountKind, SumKind:
+		default:
+			return nil
+		}
+	}
+	return &ShardMergeProcedureSpec{
+		Bounds:          s.Bounds,
+		AggregateMethod: s.AggregateMethod,
+	}
+}
+
 func createFromSource(prSpec plan.ProcedureSpec, id execute.DatasetID, sr execute.StorageReader, a execute.Administration) execute.Source {
 	spec := prSpec.(*FromProcedureSpec)
+	if spec.Empty {
+		return execute.NewEmptySource(id)
+	}
 	var w execute.Window
 	if spec.WindowSet {
 		w = execute.Window{
@@ -149,7 +213,7 @@ func createFromSource(prSpec plan.ProcedureSpec, id execute.DatasetID, sr execut
 			Round:  execute.Duration(spec.Window.Round),
 			Start:  a.ResolveTime(spec.Window.Start),
 		}
-	} else {
+	} else if a.Continuous() == nil {
 		duration := execute.Duration(a.ResolveTime(spec.Bounds.Stop)) - execute.Duration(a.ResolveTime(spec.Bounds.Start))
 		w = execute.Window{
 			Every:  duration,
@@ -162,24 +226,36 @@ func createFromSource(prSpec plan.ProcedureSpec, id execute.DatasetID, sr execut
 		Start: a.ResolveTime(spec.Bounds.Start),
 		Stop:  a.ResolveTime(spec.Bounds.Stop),
 	}
+	readSpec := execute.ReadSpec{
+		Database:        spec.Database,
+		Hosts:           spec.Hosts,
+		Predicate:       spec.Filter,
+		PointsLimit:     spec.PointsLimit,
+		SeriesLimit:     spec.SeriesLimit,
+		SeriesOffset:    spec.SeriesOffset,
+		Descending:      spec.Descending,
+		OrderByTime:     spec.OrderByTime,
+		MergeAll:        spec.MergeAll,
+		GroupKeys:       spec.GroupKeys,
+		GroupExcept:     spec.GroupExcept,
+		GroupKeep:       spec.GroupKeep,
+		AggregateMethod: spec.AggregateMethod,
+	}
+	if c := a.Continuous(); c != nil {
+		return execute.NewContinuousStorageSource(
+			id,
+			sr,
+			readSpec,
+			bounds,
+			w,
+			currentTime,
+			execute.Duration(c.Interval),
+		)
+	}
 	return execute.NewStorageSource(
 		id,
 		sr,
-		execute.ReadSpec{
-			Database:        spec.Database,
-			Hosts:           spec.Hosts,
-			Predicate:       spec.Filter,
-			PointsLimit:     spec.PointsLimit,
-			SeriesLimit:     spec.SeriesLimit,
-			SeriesOffset:    spec.SeriesOffset,
-			Descending:      spec.Descending,
-			OrderByTime:     spec.OrderByTime,
-			MergeAll:        spec.MergeAll,
-			GroupKeys:       spec.GroupKeys,
-			GroupExcept:     spec.GroupExcept,
-			GroupKeep:       spec.GroupKeep,
-			AggregateMethod: spec.AggregateMethod,
-		},
+		readSpec,
 		bounds,
 		w,
 		currentTime,
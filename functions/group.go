@@ -240,6 +240,9 @@ func (t *groupTransformation) RetractBlock(id execute.DatasetID, meta execute.Bl
 }
 
 func (t *groupTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	if err := execute.SpillIfNeeded(t.cache); err != nil {
+		return err
+	}
 	isFanIn := false
 	var tags execute.Tags
 	if t.ignoring {
@@ -0,0 +1,241 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const HistogramKind = "histogram"
+
+// DefaultUpperBoundColLabel is the default label of the column holding the upper bounds of histogram buckets.
+const DefaultUpperBoundColLabel = "le"
+
+// HistogramOpSpec counts the values of a column into cumulative buckets.
+// The buckets are bounded above by Bins, a final bucket with an infinite upper bound counts all values.
+type HistogramOpSpec struct {
+	Column           string    `json:"column"`
+	UpperBoundColumn string    `json:"upper_bound_column"`
+	CountColumn      string    `json:"count_column"`
+	Bins             []float64 `json:"bins"`
+}
+
+var histogramSignature = query.DefaultFunctionSignature()
+
+func init() {
+	histogramSignature.Params["column"] = semantic.String
+	histogramSignature.Params["upperBoundColumn"] = semantic.String
+	histogramSignature.Params["countColumn"] = semantic.String
+	histogramSignature.Params["bins"] = semantic.NewArrayType(semantic.Float)
+
+	query.RegisterFunction(HistogramKind, createHistogramOpSpec, histogramSignature)
+	query.RegisterOpSpec(HistogramKind, newHistogramOp)
+	plan.RegisterProcedureSpec(HistogramKind, newHistogramProcedure, HistogramKind)
+	execute.RegisterTransformation(HistogramKind, createHistogramTransformation)
+}
+
+func createHistogramOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &HistogramOpSpec{
+		Column:           execute.DefaultValueColLabel,
+		UpperBoundColumn: DefaultUpperBoundColLabel,
+		CountColumn:      execute.DefaultValueColLabel,
+	}
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	}
+	if col, ok, err := args.GetString("upperBoundColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.UpperBoundColumn = col
+	}
+	if col, ok, err := args.GetString("countColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.CountColumn = col
+	}
+	if spec.UpperBoundColumn == spec.CountColumn {
+		return nil, errors.New("histogram upperBoundColumn and countColumn must differ")
+	}
+
+	bins, err := args.GetRequiredArray("bins", semantic.Float)
+	if err != nil {
+		return nil, err
+	}
+	spec.Bins = make([]float64, len(bins.Elements))
+	for i, b := range bins.Elements {
+		spec.Bins[i] = b.Value().(float64)
+	}
+	if !sort.Float64sAreSorted(spec.Bins) {
+		return nil, errors.New("histogram bins must be sorted in increasing order")
+	}
+
+	return spec, nil
+}
+
+func newHistogramOp() query.OperationSpec {
+	return new(HistogramOpSpec)
+}
+
+func (s *HistogramOpSpec) Kind() query.OperationKind {
+	return HistogramKind
+}
+
+type HistogramProcedureSpec struct {
+	Column           string
+	UpperBoundColumn string
+	CountColumn      string
+	Bins             []float64
+}
+
+func newHistogramProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*HistogramOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &HistogramProcedureSpec{
+		Column:           spec.Column,
+		UpperBoundColumn: spec.UpperBoundColumn,
+		CountColumn:      spec.CountColumn,
+		Bins:             spec.Bins,
+	}, nil
+}
+
+func (s *HistogramProcedureSpec) Kind() plan.ProcedureKind {
+	return HistogramKind
+}
+func (s *HistogramProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(HistogramProcedureSpec)
+	*ns = *s
+	ns.Bins = make([]float64, len(s.Bins))
+	copy(ns.Bins, s.Bins)
+	return ns
+}
+
+func createHistogramTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*HistogramProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	t, d := execute.NewAggregateTransformationAndDataset(id, mode, a.Bounds(), NewHistogramAgg(s), a.Allocator())
+	return t, d, nil
+}
+
+// HistogramAgg counts the values of a column into cumulative buckets.
+// Each input block adds a row for each bucket to the output block, at the stop time of the input block.
+type HistogramAgg struct {
+	column           string
+	upperBoundColumn string
+	countColumn      string
+	bins             []float64
+
+	counts []float64
+}
+
+// NewHistogramAgg creates the aggregate of the spec.
+// A final bucket with an infinite upper bound is added to the bins, unless they end with one.
+func NewHistogramAgg(spec *HistogramProcedureSpec) *HistogramAgg {
+	bins := spec.Bins
+	if len(bins) == 0 || !math.IsInf(bins[len(bins)-1], 1) {
+		bins = append(bins[:len(bins):len(bins)], math.Inf(1))
+	}
+	return &HistogramAgg{
+		column:           spec.Column,
+		upperBoundColumn: spec.UpperBoundColumn,
+		countColumn:      spec.CountColumn,
+		bins:             bins,
+	}
+}
+
+func (a *HistogramAgg) Column() string {
+	return a.column
+}
+
+func (a *HistogramAgg) Cols() []execute.ColMeta {
+	return []execute.ColMeta{
+		{Label: a.upperBoundColumn, Type: execute.TFloat, Kind: execute.ValueColKind},
+		{Label: a.countColumn, Type: execute.TFloat, Kind: execute.ValueColKind},
+	}
+}
+
+func (a *HistogramAgg) newAgg() *HistogramAgg {
+	return &HistogramAgg{
+		column:           a.column,
+		upperBoundColumn: a.upperBoundColumn,
+		countColumn:      a.countColumn,
+		bins:             a.bins,
+		counts:           make([]float64, len(a.bins)),
+	}
+}
+
+func (a *HistogramAgg) NewBoolAgg() execute.DoBoolAgg {
+	return nil
+}
+
+func (a *HistogramAgg) NewIntAgg() execute.DoIntAgg {
+	return a.newAgg()
+}
+
+func (a *HistogramAgg) NewUIntAgg() execute.DoUIntAgg {
+	return a.newAgg()
+}
+
+func (a *HistogramAgg) NewFloatAgg() execute.DoFloatAgg {
+	return a.newAgg()
+}
+
+func (a *HistogramAgg) NewStringAgg() execute.DoStringAgg {
+	return nil
+}
+
+func (a *HistogramAgg) DoInt(vs []int64) {
+	for _, v := range vs {
+		a.count(float64(v))
+	}
+}
+
+func (a *HistogramAgg) DoUInt(vs []uint64) {
+	for _, v := range vs {
+		a.count(float64(v))
+	}
+}
+
+func (a *HistogramAgg) DoFloat(vs []float64) {
+	for _, v := range vs {
+		a.count(v)
+	}
+}
+
+// count increments the count of the first bucket whose upper bound is not less than v.
+func (a *HistogramAgg) count(v float64) {
+	if math.IsNaN(v) {
+		return
+	}
+	a.counts[sort.SearchFloat64s(a.bins, v)]++
+}
+
+func (a *HistogramAgg) Type() execute.DataType {
+	return execute.TFloat
+}
+
+// AppendRows appends the upper bound and the cumulative count of each bucket.
+func (a *HistogramAgg) AppendRows(builder execute.BlockBuilder, cols []int) int {
+	var count float64
+	for i, bound := range a.bins {
+		count += a.counts[i]
+		builder.AppendFloat(cols[0], bound)
+		builder.AppendFloat(cols[1], count)
+	}
+	return len(a.bins)
+}
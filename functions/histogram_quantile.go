@@ -0,0 +1,307 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const HistogramQuantileKind = "histogramQuantile"
+
+// HistogramQuantileOpSpec computes a quantile from cumulative histogram buckets.
+// Each row of a block is a bucket, the rows with the same time form a histogram.
+// The upper bounds of the buckets are either float values or strings such as the "le" tag of Prometheus histograms.
+type HistogramQuantileOpSpec struct {
+	Quantile         float64 `json:"quantile"`
+	CountColumn      string  `json:"count_column"`
+	UpperBoundColumn string  `json:"upper_bound_column"`
+	ValueColumn      string  `json:"value_column"`
+	MinValue         float64 `json:"min_value"`
+}
+
+var histogramQuantileSignature = query.DefaultFunctionSignature()
+
+func init() {
+	histogramQuantileSignature.Params["quantile"] = semantic.Float
+	histogramQuantileSignature.Params["countColumn"] = semantic.String
+	histogramQuantileSignature.Params["upperBoundColumn"] = semantic.String
+	histogramQuantileSignature.Params["valueColumn"] = semantic.String
+	histogramQuantileSignature.Params["minValue"] = semantic.Float
+
+	query.RegisterFunction(HistogramQuantileKind, createHistogramQuantileOpSpec, histogramQuantileSignature)
+	query.RegisterOpSpec(HistogramQuantileKind, newHistogramQuantileOp)
+	plan.RegisterProcedureSpec(HistogramQuantileKind, newHistogramQuantileProcedure, HistogramQuantileKind)
+	execute.RegisterTransformation(HistogramQuantileKind, createHistogramQuantileTransformation)
+}
+
+func createHistogramQuantileOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &HistogramQuantileOpSpec{
+		CountColumn:      execute.DefaultValueColLabel,
+		UpperBoundColumn: DefaultUpperBoundColLabel,
+		ValueColumn:      execute.DefaultValueColLabel,
+	}
+	q, err := args.GetRequiredFloat("quantile")
+	if err != nil {
+		return nil, err
+	}
+	if q < 0 || q > 1 {
+		return nil, errors.New("quantile must be between 0 and 1")
+	}
+	spec.Quantile = q
+
+	if col, ok, err := args.GetString("countColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.CountColumn = col
+	}
+	if col, ok, err := args.GetString("upperBoundColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.UpperBoundColumn = col
+	}
+	if col, ok, err := args.GetString("valueColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.ValueColumn = col
+	}
+	if min, ok, err := args.GetFloat("minValue"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.MinValue = min
+	}
+
+	return spec, nil
+}
+
+func newHistogramQuantileOp() query.OperationSpec {
+	return new(HistogramQuantileOpSpec)
+}
+
+func (s *HistogramQuantileOpSpec) Kind() query.OperationKind {
+	return HistogramQuantileKind
+}
+
+type HistogramQuantileProcedureSpec struct {
+	Quantile         float64
+	CountColumn      string
+	UpperBoundColumn string
+	ValueColumn      string
+	MinValue         float64
+}
+
+func newHistogramQuantileProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*HistogramQuantileOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &HistogramQuantileProcedureSpec{
+		Quantile:         spec.Quantile,
+		CountColumn:      spec.CountColumn,
+		UpperBoundColumn: spec.UpperBoundColumn,
+		ValueColumn:      spec.ValueColumn,
+		MinValue:         spec.MinValue,
+	}, nil
+}
+
+func (s *HistogramQuantileProcedureSpec) Kind() plan.ProcedureKind {
+	return HistogramQuantileKind
+}
+func (s *HistogramQuantileProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(HistogramQuantileProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createHistogramQuantileTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*HistogramQuantileProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewHistogramQuantileTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type histogramQuantileTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+
+	spec HistogramQuantileProcedureSpec
+}
+
+func NewHistogramQuantileTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *HistogramQuantileProcedureSpec) *histogramQuantileTransformation {
+	return &histogramQuantileTransformation{
+		d:     d,
+		cache: cache,
+		spec:  *spec,
+	}
+}
+
+func (t *histogramQuantileTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+type bucket struct {
+	upperBound float64
+	count      float64
+}
+
+// histogramRows are the buckets of a histogram and the time of their rows.
+type histogramRows struct {
+	time    execute.Time
+	buckets []bucket
+}
+
+func (t *histogramQuantileTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	cols := b.Cols()
+	countIdx := execute.ColIdx(t.spec.CountColumn, cols)
+	if countIdx < 0 {
+		return fmt.Errorf("histogramQuantile count column %q does not exist", t.spec.CountColumn)
+	}
+	boundIdx := execute.ColIdx(t.spec.UpperBoundColumn, cols)
+	if boundIdx < 0 {
+		return fmt.Errorf("histogramQuantile upper bound column %q does not exist", t.spec.UpperBoundColumn)
+	}
+	switch typ := cols[countIdx].Type; typ {
+	case execute.TFloat, execute.TInt, execute.TUInt:
+	default:
+		return fmt.Errorf("histogramQuantile count column %q must be numeric, got %v", t.spec.CountColumn, typ)
+	}
+	switch typ := cols[boundIdx].Type; typ {
+	case execute.TFloat, execute.TString:
+	default:
+		return fmt.Errorf("histogramQuantile upper bound column %q must be a float or a string, got %v", t.spec.UpperBoundColumn, typ)
+	}
+
+	builder, new := t.cache.BlockBuilder(b)
+	if new {
+		builder.AddCol(execute.TimeCol)
+		execute.AddTags(b.Tags(), builder)
+		builder.AddCol(execute.ColMeta{
+			Label: t.spec.ValueColumn,
+			Type:  execute.TFloat,
+			Kind:  execute.ValueColKind,
+		})
+	}
+
+	// Group the buckets into histograms by time, keeping the order of the times.
+	var histograms []*histogramRows
+	byTime := make(map[execute.Time]*histogramRows)
+	var err error
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		if err != nil {
+			return
+		}
+		for i, tm := range ts {
+			if rr.IsNull(i, countIdx) || rr.IsNull(i, boundIdx) {
+				continue
+			}
+			var bkt bucket
+			bkt.upperBound, err = upperBound(rr, i, boundIdx, cols[boundIdx].Type)
+			if err != nil {
+				return
+			}
+			switch cols[countIdx].Type {
+			case execute.TFloat:
+				bkt.count = rr.AtFloat(i, countIdx)
+			case execute.TInt:
+				bkt.count = float64(rr.AtInt(i, countIdx))
+			case execute.TUInt:
+				bkt.count = float64(rr.AtUInt(i, countIdx))
+			}
+			h, ok := byTime[tm]
+			if !ok {
+				h = &histogramRows{time: tm}
+				byTime[tm] = h
+				histograms = append(histograms, h)
+			}
+			h.buckets = append(h.buckets, bkt)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	outCols := builder.Cols()
+	timeIdx := execute.TimeIdx(outCols)
+	valueIdx := execute.ColIdx(t.spec.ValueColumn, outCols)
+	for _, h := range histograms {
+		builder.AppendTime(timeIdx, h.time)
+		builder.AppendFloat(valueIdx, bucketQuantile(t.spec.Quantile, t.spec.MinValue, h.buckets))
+	}
+	return nil
+}
+
+func upperBound(rr execute.RowReader, i, j int, typ execute.DataType) (float64, error) {
+	if typ == execute.TFloat {
+		return rr.AtFloat(i, j), nil
+	}
+	s := rr.AtString(i, j)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid histogram upper bound %q", s)
+	}
+	return f, nil
+}
+
+// bucketQuantile computes the quantile q of a histogram by linear interpolation within the bucket the quantile falls into.
+// The lower bound of the first bucket is minValue, unless its upper bound is lower.
+// The histogram must contain a bucket with an infinite upper bound, otherwise the quantile is NaN.
+// If the quantile falls into the infinite bucket the upper bound of the largest finite bucket is returned.
+func bucketQuantile(q, minValue float64, buckets []bucket) float64 {
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].upperBound < buckets[j].upperBound
+	})
+	n := len(buckets)
+	if n < 2 || !math.IsInf(buckets[n-1].upperBound, 1) {
+		return math.NaN()
+	}
+	total := buckets[n-1].count
+	if total == 0 {
+		return math.NaN()
+	}
+
+	rank := q * total
+	idx := sort.Search(n-1, func(i int) bool {
+		return buckets[i].count >= rank
+	})
+	if idx == n-1 {
+		return buckets[n-2].upperBound
+	}
+	if idx == 0 && buckets[0].upperBound <= minValue {
+		return buckets[0].upperBound
+	}
+
+	lowerBound, lowerCount := minValue, 0.0
+	if idx > 0 {
+		lowerBound = buckets[idx-1].upperBound
+		lowerCount = buckets[idx-1].count
+	}
+	count := buckets[idx].count - lowerCount
+	if count == 0 {
+		return lowerBound
+	}
+	return lowerBound + (buckets[idx].upperBound-lowerBound)*(rank-lowerCount)/count
+}
+
+func (t *histogramQuantileTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *histogramQuantileTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *histogramQuantileTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
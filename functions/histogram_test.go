@@ -0,0 +1,452 @@
+package functions_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestHistogram_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "histogram",
+			Raw:  `from(db:"mydb") |> histogram(bins:[0.5, 1.0, 2.0])`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "histogram1",
+						Spec: &functions.HistogramOpSpec{
+							Column:           "_value",
+							UpperBoundColumn: "le",
+							CountColumn:      "_value",
+							Bins:             []float64{0.5, 1.0, 2.0},
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "histogram1"},
+				},
+			},
+		},
+		{
+			Name: "histogram with columns",
+			Raw:  `from(db:"mydb") |> histogram(column:"latency", upperBoundColumn:"bound", countColumn:"n", bins:[1.0])`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "histogram1",
+						Spec: &functions.HistogramOpSpec{
+							Column:           "latency",
+							UpperBoundColumn: "bound",
+							CountColumn:      "n",
+							Bins:             []float64{1.0},
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "histogram1"},
+				},
+			},
+		},
+		{
+			Name:    "unsorted bins",
+			Raw:     `from(db:"mydb") |> histogram(bins:[2.0, 1.0])`,
+			WantErr: true,
+		},
+		{
+			Name:    "missing bins",
+			Raw:     `from(db:"mydb") |> histogram()`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestHistogramOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"histogram","kind":"histogram","spec":{"column":"_value","upper_bound_column":"le","count_column":"_value","bins":[0.5,1]}}`)
+	op := &query.Operation{
+		ID: "histogram",
+		Spec: &functions.HistogramOpSpec{
+			Column:           "_value",
+			UpperBoundColumn: "le",
+			CountColumn:      "_value",
+			Bins:             []float64{0.5, 1},
+		},
+	}
+
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestHistogram_Process(t *testing.T) {
+	testCases := []struct {
+		name   string
+		spec   *functions.HistogramProcedureSpec
+		bounds execute.Bounds
+		data   []execute.Block
+		want   []*executetest.Block
+	}{
+		{
+			name: "cumulative counts",
+			spec: &functions.HistogramProcedureSpec{
+				Column:           "_value",
+				UpperBoundColumn: "le",
+				CountColumn:      "_value",
+				Bins:             []float64{1, 2, 3},
+			},
+			bounds: execute.Bounds{
+				Start: 0,
+				Stop:  10,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 0.5, "a"},
+					{execute.Time(2), 1.0, "a"},
+					{execute.Time(3), 1.5, "a"},
+					{execute.Time(4), nil, "a"},
+					{execute.Time(5), 2.5, "a"},
+					{execute.Time(6), 4.0, "a"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "le", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), "a", 1.0, 2.0},
+					{execute.Time(10), "a", 2.0, 3.0},
+					{execute.Time(10), "a", 3.0, 4.0},
+					{execute.Time(10), "a", math.Inf(1), 5.0},
+				},
+			}},
+		},
+		{
+			name: "integer values",
+			spec: &functions.HistogramProcedureSpec{
+				Column:           "_value",
+				UpperBoundColumn: "le",
+				CountColumn:      "count",
+				Bins:             []float64{10},
+			},
+			bounds: execute.Bounds{
+				Start: 0,
+				Stop:  10,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(5)},
+					{execute.Time(2), int64(10)},
+					{execute.Time(3), int64(15)},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "le", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "count", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), 10.0, 2.0},
+					{execute.Time(10), math.Inf(1), 3.0},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return execute.NewAggregateTransformation(d, c, execute.DiscardingMode, tc.bounds, functions.NewHistogramAgg(tc.spec))
+				},
+			)
+		})
+	}
+}
+
+func TestHistogram_Retract(t *testing.T) {
+	bounds := execute.Bounds{Start: 0, Stop: 200}
+	window := func(start, stop execute.Time, values ...float64) *executetest.Block {
+		b := &executetest.Block{
+			Bnds: execute.Bounds{Start: start, Stop: stop},
+			ColMeta: []execute.ColMeta{
+				{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+				{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+			},
+		}
+		for i, v := range values {
+			b.Data = append(b.Data, []interface{}{start + execute.Time(i), v})
+		}
+		return b
+	}
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	h := execute.NewAggregateTransformation(d, c, execute.AccumulatingRetractingMode, bounds, functions.NewHistogramAgg(&functions.HistogramProcedureSpec{
+		Column:           "_value",
+		UpperBoundColumn: "le",
+		CountColumn:      "_value",
+		Bins:             []float64{1},
+	}))
+
+	// The buckets of the retracted window are removed, and those of its correction are added.
+	parentID := executetest.RandomDatasetID()
+	if err := h.Process(parentID, window(0, 100, 0.5, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Process(parentID, window(100, 200, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RetractBlock(parentID, window(0, 100)); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Process(parentID, window(0, 100, 0.5)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*executetest.Block{{
+		Bnds: bounds,
+		ColMeta: []execute.ColMeta{
+			{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+			{Label: "le", Type: execute.TFloat, Kind: execute.ValueColKind},
+			{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+		},
+		Data: [][]interface{}{
+			{execute.Time(200), 1.0, 0.0},
+			{execute.Time(200), math.Inf(1), 1.0},
+			{execute.Time(100), 1.0, 1.0},
+			{execute.Time(100), math.Inf(1), 1.0},
+		},
+	}}
+	got := executetest.BlocksFromCache(c)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(want, got))
+	}
+	if len(d.Retractions) != 1 {
+		t.Errorf("unexpected number of retractions: got %d want 1", len(d.Retractions))
+	}
+}
+
+func TestHistogramQuantile_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "histogramQuantile",
+			Raw:  `from(db:"mydb") |> histogramQuantile(quantile:0.9)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "histogramQuantile1",
+						Spec: &functions.HistogramQuantileOpSpec{
+							Quantile:         0.9,
+							CountColumn:      "_value",
+							UpperBoundColumn: "le",
+							ValueColumn:      "_value",
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "histogramQuantile1"},
+				},
+			},
+		},
+		{
+			Name:    "quantile out of range",
+			Raw:     `from(db:"mydb") |> histogramQuantile(quantile:1.5)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestHistogramQuantileOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"histogramQuantile","kind":"histogramQuantile","spec":{"quantile":0.9,"count_column":"_value","upper_bound_column":"le","value_column":"_value","min_value":0}}`)
+	op := &query.Operation{
+		ID: "histogramQuantile",
+		Spec: &functions.HistogramQuantileOpSpec{
+			Quantile:         0.9,
+			CountColumn:      "_value",
+			UpperBoundColumn: "le",
+			ValueColumn:      "_value",
+		},
+	}
+
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestHistogramQuantile_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.HistogramQuantileProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "float bounds",
+			spec: &functions.HistogramQuantileProcedureSpec{
+				Quantile:         0.5,
+				CountColumn:      "_value",
+				UpperBoundColumn: "le",
+				ValueColumn:      "_value",
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  30,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "le", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), 1.0, 2.0},
+					{execute.Time(10), 2.0, 6.0},
+					{execute.Time(10), 3.0, 8.0},
+					{execute.Time(10), math.Inf(1), 10.0},
+					{execute.Time(20), math.Inf(1), 4.0},
+					{execute.Time(20), 1.0, 4.0},
+					{execute.Time(20), 2.0, 4.0},
+					{execute.Time(30), 1.0, 1.0},
+					{execute.Time(30), math.Inf(1), 4.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  30,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					// rank 5 falls into (1, 2] which holds 4 of the 10 counts
+					{execute.Time(10), 1.75},
+					// rank 2 falls into [0, 1]
+					{execute.Time(20), 0.5},
+					// rank 2 falls into the infinite bucket
+					{execute.Time(30), 1.0},
+				},
+			}},
+		},
+		{
+			name: "prometheus tags",
+			spec: &functions.HistogramQuantileProcedureSpec{
+				Quantile:         0.9,
+				CountColumn:      "_value",
+				UpperBoundColumn: "le",
+				ValueColumn:      "latency",
+				MinValue:         0.5,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  20,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "_metric", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "le", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), int64(0), "http_duration_seconds_bucket", "1"},
+					{execute.Time(10), int64(10), "http_duration_seconds_bucket", "+Inf"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  20,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_metric", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "latency", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), "http_duration_seconds_bucket", 1.0},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewHistogramQuantileTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const HoltWintersKind = "holtWinters"
+
+// HoltWintersOpSpec forecasts the values of a column with the Holt-Winters method,
+// using additive trend and seasonality.
+type HoltWintersOpSpec struct {
+	N           int64  `json:"n"`
+	Seasonality int64  `json:"seasonality"`
+	Column      string `json:"column"`
+	WithFit     bool   `json:"with_fit"`
+}
+
+var holtWintersSignature = query.DefaultFunctionSignature()
+
+func init() {
+	holtWintersSignature.Params["n"] = semantic.Int
+	holtWintersSignature.Params["seasonality"] = semantic.Int
+	holtWintersSignature.Params["column"] = semantic.String
+	holtWintersSignature.Params["withFit"] = semantic.Bool
+
+	query.RegisterFunction(HoltWintersKind, createHoltWintersOpSpec, holtWintersSignature)
+	query.RegisterOpSpec(HoltWintersKind, newHoltWintersOp)
+	plan.RegisterProcedureSpec(HoltWintersKind, newHoltWintersProcedure, HoltWintersKind)
+	execute.RegisterTransformation(HoltWintersKind, createHoltWintersTransformation)
+}
+
+func createHoltWintersOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &HoltWintersOpSpec{
+		Column: execute.DefaultValueColLabel,
+	}
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, errors.New("holtWinters n must be positive")
+	}
+	spec.N = n
+
+	if s, ok, err := args.GetInt("seasonality"); err != nil {
+		return nil, err
+	} else if ok {
+		if s < 0 {
+			return nil, errors.New("holtWinters seasonality must not be negative")
+		}
+		spec.Seasonality = s
+	}
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	}
+	if withFit, ok, err := args.GetBool("withFit"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.WithFit = withFit
+	}
+	return spec, nil
+}
+
+func newHoltWintersOp() query.OperationSpec {
+	return new(HoltWintersOpSpec)
+}
+
+func (s *HoltWintersOpSpec) Kind() query.OperationKind {
+	return HoltWintersKind
+}
+
+type HoltWintersProcedureSpec struct {
+	N           int64
+	Seasonality int64
+	Column      string
+	WithFit     bool
+}
+
+func newHoltWintersProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*HoltWintersOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &HoltWintersProcedureSpec{
+		N:           spec.N,
+		Seasonality: spec.Seasonality,
+		Column:      spec.Column,
+		WithFit:     spec.WithFit,
+	}, nil
+}
+
+func (s *HoltWintersProcedureSpec) Kind() plan.ProcedureKind {
+	return HoltWintersKind
+}
+func (s *HoltWintersProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(HoltWintersProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createHoltWintersTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*HoltWintersProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewHoltWintersTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type holtWintersTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+
+	n           int
+	seasonality int
+	column      string
+	withFit     bool
+}
+
+func NewHoltWintersTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *HoltWintersProcedureSpec) *holtWintersTransformation {
+	return &holtWintersTransformation{
+		d:           d,
+		cache:       cache,
+		n:           int(spec.N),
+		seasonality: int(spec.Seasonality),
+		column:      spec.Column,
+		withFit:     spec.WithFit,
+	}
+}
+
+func (t *holtWintersTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+// Process forecasts n values of the column after the last row of the block.
+// The rows are expected to be evenly spaced in time, the forecast uses the interval between the last two rows.
+// Null values are ignored, a block with too few values for the seasonality has no forecast.
+func (t *holtWintersTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	cols := b.Cols()
+	valueIdx := execute.ColIdx(t.column, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("holtWinters column %q does not exist", t.column)
+	}
+	if !isNumeric(cols[valueIdx].Type) {
+		return fmt.Errorf("holtWinters column %q must be numeric, got %v", t.column, cols[valueIdx].Type)
+	}
+
+	builder, new := t.cache.BlockBuilder(b)
+	if new {
+		builder.AddCol(execute.TimeCol)
+		execute.AddTags(b.Tags(), builder)
+		builder.AddCol(execute.ColMeta{
+			Label: t.column,
+			Type:  execute.TFloat,
+			Kind:  execute.ValueColKind,
+		})
+	}
+
+	series := &timedSeries{}
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i, tm := range ts {
+			if rr.IsNull(i, valueIdx) {
+				continue
+			}
+			switch cols[valueIdx].Type {
+			case execute.TInt:
+				series.add(tm, float64(rr.AtInt(i, valueIdx)))
+			case execute.TUInt:
+				series.add(tm, float64(rr.AtUInt(i, valueIdx)))
+			case execute.TFloat:
+				series.add(tm, rr.AtFloat(i, valueIdx))
+			}
+		}
+	})
+	sort.Stable(series)
+
+	model := newHoltWintersModel(series.values, t.seasonality)
+	if model == nil {
+		return nil
+	}
+	fitted, forecast := model.forecast(t.n)
+
+	outCols := builder.Cols()
+	timeIdx := execute.TimeIdx(outCols)
+	valueIdx = execute.ColIdx(t.column, outCols)
+	if t.withFit {
+		times := series.times[len(series.times)-len(fitted):]
+		for i, v := range fitted {
+			builder.AppendTime(timeIdx, times[i])
+			builder.AppendFloat(valueIdx, v)
+		}
+	}
+	last := series.times[len(series.times)-1]
+	interval := execute.Duration(last - series.times[len(series.times)-2])
+	for i, v := range forecast {
+		builder.AppendTime(timeIdx, last.Add(execute.Duration(i+1)*interval))
+		builder.AppendFloat(valueIdx, v)
+	}
+	return nil
+}
+
+func (t *holtWintersTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *holtWintersTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *holtWintersTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// holtWintersModel smooths a series with additive trend and seasonality.
+// The initial level, trend and seasonal components are estimated from the first two seasons,
+// the smoothing parameters are chosen to minimize the squared errors of the one step predictions.
+type holtWintersModel struct {
+	values []float64
+	m      int
+
+	// start is the index of the first predicted value.
+	start    int
+	level    float64
+	trend    float64
+	seasonal []float64
+}
+
+// newHoltWintersModel returns nil if there are too few values,
+// at least two values or two seasons of values are needed.
+func newHoltWintersModel(values []float64, seasonality int) *holtWintersModel {
+	hw := &holtWintersModel{
+		values: values,
+		m:      seasonality,
+	}
+	if hw.m <= 1 {
+		if len(values) < 2 {
+			return nil
+		}
+		hw.m = 0
+		hw.start = 1
+		hw.level = values[0]
+		hw.trend = values[1] - values[0]
+		return hw
+	}
+	if len(values) < 2*hw.m {
+		return nil
+	}
+
+	m := float64(hw.m)
+	var first, second float64
+	for i := 0; i < hw.m; i++ {
+		first += values[i]
+		second += values[i+hw.m]
+	}
+	first /= m
+	second /= m
+
+	// The mean of the first season is the level at its center.
+	center := (m - 1) / 2
+	hw.trend = (second - first) / m
+	hw.seasonal = make([]float64, hw.m)
+	for i := range hw.seasonal {
+		hw.seasonal[i] = values[i] - (first + (float64(i)-center)*hw.trend)
+	}
+	hw.start = hw.m
+	hw.level = first + (m-1-center)*hw.trend
+	return hw
+}
+
+// smooth returns the sum of the squared errors of the one step predictions.
+// The predictions are appended to fitted if it is not nil, the final state is returned.
+func (hw *holtWintersModel) smooth(alpha, beta, gamma float64, fitted *[]float64) (sse, level, trend float64, seasonal []float64) {
+	level, trend = hw.level, hw.trend
+	seasonal = make([]float64, len(hw.seasonal))
+	copy(seasonal, hw.seasonal)
+	for t := hw.start; t < len(hw.values); t++ {
+		var s float64
+		if hw.m > 0 {
+			s = seasonal[t%hw.m]
+		}
+		y := hw.values[t]
+		prediction := level + trend + s
+		if fitted != nil {
+			*fitted = append(*fitted, prediction)
+		}
+		sse += (y - prediction) * (y - prediction)
+
+		prevLevel := level
+		level = alpha*(y-s) + (1-alpha)*(level+trend)
+		trend = beta*(level-prevLevel) + (1-beta)*trend
+		if hw.m > 0 {
+			seasonal[t%hw.m] = gamma*(y-level) + (1-gamma)*s
+		}
+	}
+	return
+}
+
+// forecast fits the smoothing parameters and returns the one step predictions of the values
+// and the next n forecasted values.
+func (hw *holtWintersModel) forecast(n int) (fitted, forecast []float64) {
+	dims := 2
+	if hw.m > 0 {
+		dims = 3
+	}
+	params := nelderMead(func(p []float64) float64 {
+		for _, v := range p {
+			if v < 0 || v > 1 {
+				return math.Inf(1)
+			}
+		}
+		var gamma float64
+		if hw.m > 0 {
+			gamma = p[2]
+		}
+		sse, _, _, _ := hw.smooth(p[0], p[1], gamma, nil)
+		return sse
+	}, dims)
+
+	var gamma float64
+	if hw.m > 0 {
+		gamma = params[2]
+	}
+	_, level, trend, seasonal := hw.smooth(params[0], params[1], gamma, &fitted)
+
+	last := len(hw.values) - 1
+	forecast = make([]float64, n)
+	for h := 1; h <= n; h++ {
+		forecast[h-1] = level + float64(h)*trend
+		if hw.m > 0 {
+			forecast[h-1] += seasonal[(last+h)%hw.m]
+		}
+	}
+	return fitted, forecast
+}
+
+const (
+	nelderMeadMaxIterations = 1000
+	nelderMeadTolerance     = 1e-10
+)
+
+// nelderMead minimizes f over the unit cube of the given dimensions with the Nelder-Mead simplex method,
+// starting from a simplex around the center of the cube.
+func nelderMead(f func([]float64) float64, dims int) []float64 {
+	points := make([][]float64, dims+1)
+	values := make([]float64, dims+1)
+	for i := range points {
+		points[i] = make([]float64, dims)
+		for j := range points[i] {
+			points[i][j] = 0.3
+		}
+		if i > 0 {
+			points[i][i-1] = 0.7
+		}
+		values[i] = f(points[i])
+	}
+
+	// point returns the point on the line from the centroid through the worst point, scaled by c.
+	point := func(centroid, worst []float64, c float64) []float64 {
+		p := make([]float64, dims)
+		for j := range p {
+			p[j] = centroid[j] + c*(worst[j]-centroid[j])
+		}
+		return p
+	}
+
+	centroid := make([]float64, dims)
+	for iter := 0; iter < nelderMeadMaxIterations; iter++ {
+		sort.Sort(simplex{points: points, values: values})
+		best, worst := 0, dims
+		if math.Abs(values[worst]-values[best]) <= nelderMeadTolerance*(math.Abs(values[best])+nelderMeadTolerance) {
+			break
+		}
+
+		for j := range centroid {
+			centroid[j] = 0
+			for i := 0; i < dims; i++ {
+				centroid[j] += points[i][j] / float64(dims)
+			}
+		}
+
+		reflected := point(centroid, points[worst], -1)
+		fr := f(reflected)
+		switch {
+		case fr < values[best]:
+			expanded := point(centroid, points[worst], -2)
+			if fe := f(expanded); fe < fr {
+				points[worst], values[worst] = expanded, fe
+			} else {
+				points[worst], values[worst] = reflected, fr
+			}
+		case fr < values[worst-1]:
+			points[worst], values[worst] = reflected, fr
+		default:
+			contracted := point(centroid, points[worst], 0.5)
+			if fc := f(contracted); fc < values[worst] {
+				points[worst], values[worst] = contracted, fc
+				continue
+			}
+			// Shrink the simplex towards the best point.
+			for i := 1; i <= dims; i++ {
+				for j := range points[i] {
+					points[i][j] = points[best][j] + 0.5*(points[i][j]-points[best][j])
+				}
+				values[i] = f(points[i])
+			}
+		}
+	}
+	sort.Sort(simplex{points: points, values: values})
+	return points[0]
+}
+
+// simplex sorts the points of a simplex by their values.
+type simplex struct {
+	points [][]float64
+	values []float64
+}
+
+func (s simplex) Len() int           { return len(s.points) }
+func (s simplex) Less(i, j int) bool { return s.values[i] < s.values[j] }
+func (s simplex) Swap(i, j int) {
+	s.points[i], s.points[j] = s.points[j], s.points[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
@@ -0,0 +1,268 @@
+package functions_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestHoltWinters_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(db:"mydb") |> holtWinters(n: 10)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "holtWinters1",
+						Spec: &functions.HoltWintersOpSpec{
+							N:      10,
+							Column: "_value",
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "holtWinters1"},
+				},
+			},
+		},
+		{
+			Name: "seasonality with fit",
+			Raw:  `from(db:"mydb") |> holtWinters(n: 10, seasonality: 4, column: "load", withFit: true)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "holtWinters1",
+						Spec: &functions.HoltWintersOpSpec{
+							N:           10,
+							Seasonality: 4,
+							Column:      "load",
+							WithFit:     true,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "holtWinters1"},
+				},
+			},
+		},
+		{
+			Name:    "negative seasonality",
+			Raw:     `from(db:"mydb") |> holtWinters(n: 10, seasonality: -1)`,
+			WantErr: true,
+		},
+		{
+			Name:    "no n",
+			Raw:     `from(db:"mydb") |> holtWinters(seasonality: 4)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestHoltWintersOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"holtWinters","kind":"holtWinters","spec":{"n":10,"seasonality":4,"column":"_value","with_fit":true}}`)
+	op := &query.Operation{
+		ID: "holtWinters",
+		Spec: &functions.HoltWintersOpSpec{
+			N:           10,
+			Seasonality: 4,
+			Column:      "_value",
+			WithFit:     true,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestHoltWinters_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+		s := functions.NewHoltWintersTransformation(
+			d,
+			c,
+			&functions.HoltWintersProcedureSpec{
+				N:      1,
+				Column: "_value",
+			},
+		)
+		return s
+	})
+}
+
+func TestHoltWinters_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.HoltWintersProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "trend",
+			spec: &functions.HoltWintersProcedureSpec{
+				N:      2,
+				Column: "_value",
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  50,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), int64(1), "a"},
+					{execute.Time(10), int64(3), "a"},
+					{execute.Time(20), nil, "a"},
+					{execute.Time(30), int64(5), "a"},
+					{execute.Time(40), int64(7), "a"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  50,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(50), "a", 9.0},
+					{execute.Time(60), "a", 11.0},
+				},
+			}},
+		},
+		{
+			name: "seasonality with fit",
+			spec: &functions.HoltWintersProcedureSpec{
+				N:           3,
+				Seasonality: 2,
+				Column:      "_value",
+				WithFit:     true,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  6,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 10.0},
+					{execute.Time(1), 14.0},
+					{execute.Time(2), 12.0},
+					{execute.Time(3), 16.0},
+					{execute.Time(4), 14.0},
+					{execute.Time(5), 18.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  6,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 12.0},
+					{execute.Time(3), 16.0},
+					{execute.Time(4), 14.0},
+					{execute.Time(5), 18.0},
+					{execute.Time(6), 16.0},
+					{execute.Time(7), 20.0},
+					{execute.Time(8), 18.0},
+				},
+			}},
+		},
+		{
+			name: "too few values",
+			spec: &functions.HoltWintersProcedureSpec{
+				N:           3,
+				Seasonality: 2,
+				Column:      "_value",
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 10.0},
+					{execute.Time(1), 14.0},
+					{execute.Time(2), 12.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+
+			tx := functions.NewHoltWintersTransformation(d, c, tc.spec)
+			parentID := executetest.RandomDatasetID()
+			for _, b := range tc.data {
+				if err := tx.Process(parentID, b); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := executetest.BlocksFromCache(c)
+			sort.Sort(executetest.SortedBlocks(got))
+			sort.Sort(executetest.SortedBlocks(tc.want))
+
+			// The forecasts depend on the fitted smoothing parameters, which are only approximate.
+			if !cmp.Equal(tc.want, got, cmpopts.EquateApprox(0, 1e-6)) {
+				t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
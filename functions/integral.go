@@ -149,6 +149,9 @@ func (t *integralTransformation) Process(id execute.DatasetID, b execute.Block)
 				continue
 			}
 			for i, t := range ts {
+				if rr.IsNull(i, j) {
+					continue
+				}
 				in.updateFloat(t, rr.AtFloat(i, j))
 			}
 		}
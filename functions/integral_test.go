@@ -195,6 +195,44 @@ func TestIntegral_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "nulls",
+			spec: &functions.IntegralProcedureSpec{
+				Unit: 1,
+			},
+			bounds: execute.Bounds{
+				Start: 1,
+				Stop:  4,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), nil},
+					{execute.Time(3), 4.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(4), 6.0},
+				},
+			}},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -0,0 +1,263 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const InterpolateKind = "interpolate"
+
+const LinearInterpolation = "linear"
+
+// InterpolateOpSpec replaces the null values of a numeric column with values interpolated from the non null values around them.
+// When Every is set, rows are first added to fill the gaps in the time grid of the rows.
+type InterpolateOpSpec struct {
+	Column string         `json:"column"`
+	Method string         `json:"method"`
+	Every  query.Duration `json:"every"`
+}
+
+var interpolateSignature = query.DefaultFunctionSignature()
+
+func init() {
+	interpolateSignature.Params["column"] = semantic.String
+	interpolateSignature.Params["method"] = semantic.String
+	interpolateSignature.Params["every"] = semantic.Duration
+
+	query.RegisterFunction(InterpolateKind, createInterpolateOpSpec, interpolateSignature)
+	query.RegisterOpSpec(InterpolateKind, newInterpolateOp)
+	plan.RegisterProcedureSpec(InterpolateKind, newInterpolateProcedure, InterpolateKind)
+	plan.RegisterRewriteRule(WindowGridRewriteRule{Kind: InterpolateKind})
+	execute.RegisterTransformation(InterpolateKind, createInterpolateTransformation)
+}
+
+func createInterpolateOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &InterpolateOpSpec{
+		Column: execute.DefaultValueColLabel,
+		Method: LinearInterpolation,
+	}
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	}
+	if method, ok, err := args.GetString("method"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Method = method
+	}
+	if spec.Method != LinearInterpolation {
+		return nil, fmt.Errorf("unknown interpolation method %q", spec.Method)
+	}
+	if every, ok, err := args.GetDuration("every"); err != nil {
+		return nil, err
+	} else if ok {
+		if every <= 0 {
+			return nil, errors.New("interpolate every must be positive")
+		}
+		spec.Every = every
+	}
+	return spec, nil
+}
+
+func newInterpolateOp() query.OperationSpec {
+	return new(InterpolateOpSpec)
+}
+
+func (s *InterpolateOpSpec) Kind() query.OperationKind {
+	return InterpolateKind
+}
+
+type InterpolateProcedureSpec struct {
+	Column string
+	Method string
+	Every  query.Duration
+}
+
+func newInterpolateProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*InterpolateOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &InterpolateProcedureSpec{
+		Column: spec.Column,
+		Method: spec.Method,
+		Every:  spec.Every,
+	}, nil
+}
+
+func (s *InterpolateProcedureSpec) Kind() plan.ProcedureKind {
+	return InterpolateKind
+}
+func (s *InterpolateProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(InterpolateProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createInterpolateTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*InterpolateProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewInterpolateTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type interpolateTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+
+	column string
+	every  execute.Duration
+}
+
+func NewInterpolateTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *InterpolateProcedureSpec) *interpolateTransformation {
+	return &interpolateTransformation{
+		d:      d,
+		cache:  cache,
+		column: spec.Column,
+		every:  execute.Duration(spec.Every),
+	}
+}
+
+func (t *interpolateTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+// interpolatedPoint is a row of the output of the interpolation.
+type interpolatedPoint struct {
+	time  execute.Time
+	value float64
+	valid bool
+	// gap reports whether the point fills a gap in the grid, instead of being a row of the input.
+	gap bool
+}
+
+// Process appends the rows of b with the null values of the column linearly interpolated in time.
+// The column becomes a float column, null values before the first or after the last non null value remain null.
+// When every is set, rows are added at the times of the grid of every duration that are missing between consecutive rows.
+func (t *interpolateTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	cols := b.Cols()
+	colIdx := execute.ColIdx(t.column, cols)
+	if colIdx >= 0 && !isNumeric(cols[colIdx].Type) {
+		return fmt.Errorf("cannot interpolate column %q of type %v", t.column, cols[colIdx].Type)
+	}
+
+	builder, new := t.cache.BlockBuilder(b)
+	if new {
+		for j, c := range cols {
+			if j == colIdx {
+				c.Type = execute.TFloat
+			}
+			builder.AddCol(c)
+			if c.IsTag() && c.Common {
+				builder.SetCommonString(j, b.Tags()[c.Label])
+			}
+		}
+	}
+	if colIdx < 0 {
+		b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+			for i := range ts {
+				for j := range cols {
+					appendValue(builder, rr, i, j)
+				}
+			}
+		})
+		return nil
+	}
+
+	var points []interpolatedPoint
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i, tm := range ts {
+			if t.every > 0 && len(points) > 0 {
+				for gap := points[len(points)-1].time.Add(t.every); gap < tm; gap = gap.Add(t.every) {
+					points = append(points, interpolatedPoint{time: gap, gap: true})
+				}
+			}
+			p := interpolatedPoint{time: tm}
+			if !rr.IsNull(i, colIdx) {
+				p.valid = true
+				switch cols[colIdx].Type {
+				case execute.TInt:
+					p.value = float64(rr.AtInt(i, colIdx))
+				case execute.TUInt:
+					p.value = float64(rr.AtUInt(i, colIdx))
+				case execute.TFloat:
+					p.value = rr.AtFloat(i, colIdx)
+				}
+			}
+			points = append(points, p)
+		}
+	})
+	interpolateLinear(points)
+
+	timeIdx := execute.TimeIdx(cols)
+	k := 0
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i := range ts {
+			for ; points[k].gap; k++ {
+				appendGapRow(builder, timeIdx, points[k].time)
+				if points[k].valid {
+					builder.SetFloat(builder.NRows()-1, colIdx, points[k].value)
+				}
+			}
+			for j := range cols {
+				if j != colIdx {
+					appendValue(builder, rr, i, j)
+				}
+			}
+			if points[k].valid {
+				builder.AppendFloat(colIdx, points[k].value)
+			} else {
+				builder.AppendNil(colIdx)
+			}
+			k++
+		}
+	})
+	return nil
+}
+
+// interpolateLinear sets the values of the invalid points between two valid points,
+// from the line through these valid points.
+func interpolateLinear(points []interpolatedPoint) {
+	last := -1
+	for k, p := range points {
+		if !p.valid {
+			continue
+		}
+		if last >= 0 && k-last > 1 {
+			p0 := points[last]
+			var slope float64
+			if p.time > p0.time {
+				slope = (p.value - p0.value) / float64(p.time-p0.time)
+			}
+			for m := last + 1; m < k; m++ {
+				points[m].value = p0.value + slope*float64(points[m].time-p0.time)
+				points[m].valid = true
+			}
+		}
+		last = k
+	}
+}
+
+func (t *interpolateTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *interpolateTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *interpolateTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
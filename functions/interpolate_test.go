@@ -0,0 +1,247 @@
+package functions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestInterpolate_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(db:"mydb") |> interpolate()`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "interpolate1",
+						Spec: &functions.InterpolateOpSpec{
+							Column: "_value",
+							Method: "linear",
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "interpolate1"},
+				},
+			},
+		},
+		{
+			Name: "column and every",
+			Raw:  `from(db:"mydb") |> interpolate(column: "x", method: "linear", every: 1m)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "interpolate1",
+						Spec: &functions.InterpolateOpSpec{
+							Column: "x",
+							Method: "linear",
+							Every:  query.Duration(time.Minute),
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "interpolate1"},
+				},
+			},
+		},
+		{
+			Name:    "unknown method",
+			Raw:     `from(db:"mydb") |> interpolate(method: "cubic")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestInterpolateOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"interpolate","kind":"interpolate","spec":{"column":"_value","method":"linear","every":"1m"}}`)
+	op := &query.Operation{
+		ID: "interpolate",
+		Spec: &functions.InterpolateOpSpec{
+			Column: "_value",
+			Method: "linear",
+			Every:  query.Duration(time.Minute),
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestInterpolate_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+		s := functions.NewInterpolateTransformation(
+			d,
+			c,
+			&functions.InterpolateProcedureSpec{
+				Column: "_value",
+				Method: "linear",
+			},
+		)
+		return s
+	})
+}
+
+func TestInterpolate_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.InterpolateProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "nulls",
+			spec: &functions.InterpolateProcedureSpec{
+				Column: "_value",
+				Method: "linear",
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil},
+					{execute.Time(2), 2.0},
+					{execute.Time(3), nil},
+					{execute.Time(6), 6.0},
+					{execute.Time(8), nil},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil},
+					{execute.Time(2), 2.0},
+					{execute.Time(3), 3.0},
+					{execute.Time(6), 6.0},
+					{execute.Time(8), nil},
+				},
+			}},
+		},
+		{
+			name: "gaps",
+			spec: &functions.InterpolateProcedureSpec{
+				Column: "_value",
+				Method: "linear",
+				Every:  query.Duration(2),
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), int64(2), "a", "x"},
+					{execute.Time(8), int64(5), "a", "y"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 2.0, "a", "x"},
+					{execute.Time(4), 3.0, "a", nil},
+					{execute.Time(6), 4.0, "a", nil},
+					{execute.Time(8), 5.0, "a", "y"},
+				},
+			}},
+		},
+		{
+			name: "missing column",
+			spec: &functions.InterpolateProcedureSpec{
+				Column: "x",
+				Method: "linear",
+				Every:  query.Duration(1),
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+					{execute.Time(5), nil},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  10,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+					{execute.Time(5), nil},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewInterpolateTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
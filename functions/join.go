@@ -1,7 +1,9 @@
 package functions
 
 import (
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"math"
 	"sort"
@@ -18,6 +20,20 @@ import (
 
 const JoinKind = "join"
 const MergeJoinKind = "merge-join"
+const HashJoinKind = "hash-join"
+
+// Join methods determine which rows are kept when a row of one table has no matching row in the other table.
+// The left table is the table whose name sorts first.
+const (
+	// InnerJoin only keeps rows that match in both tables.
+	InnerJoin = "inner"
+	// LeftJoin keeps all rows of the left table.
+	LeftJoin = "left"
+	// RightJoin keeps all rows of the right table.
+	RightJoin = "right"
+	// FullJoin keeps all rows of both tables.
+	FullJoin = "full"
+)
 
 type JoinOpSpec struct {
 	// On is a list of tags on which to join.
@@ -30,6 +46,8 @@ type JoinOpSpec struct {
 	// TODO(nathanielc): Change this to a map of parent operation IDs to names.
 	// Then make it possible for the transformation to map operation IDs to parent IDs.
 	TableNames map[query.OperationID]string `json:"table_names"`
+	// Method is the join method, an empty method is an inner join.
+	Method string `json:"method,omitempty"`
 }
 
 var joinSignature = semantic.FunctionSignature{
@@ -37,6 +55,7 @@ var joinSignature = semantic.FunctionSignature{
 		"tables": semantic.Object,
 		"fn":     semantic.Function,
 		"on":     semantic.NewArrayType(semantic.String),
+		"method": semantic.String,
 	},
 	ReturnType:   query.TableObjectType,
 	PipeArgument: "tables",
@@ -45,9 +64,11 @@ var joinSignature = semantic.FunctionSignature{
 func init() {
 	query.RegisterFunction(JoinKind, createJoinOpSpec, semantic.FunctionSignature{})
 	query.RegisterOpSpec(JoinKind, newJoinOp)
-	//TODO(nathanielc): Allow for other types of join implementations
 	plan.RegisterProcedureSpec(MergeJoinKind, newMergeJoinProcedure, JoinKind)
+	plan.RegisterProcedureSpec(HashJoinKind, newHashJoinProcedure)
+	plan.RegisterRewriteRule(HashJoinRewriteRule{})
 	execute.RegisterTransformation(MergeJoinKind, createMergeJoinTransformation)
+	execute.RegisterTransformation(HashJoinKind, createHashJoinTransformation)
 }
 
 func createJoinOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
@@ -70,6 +91,17 @@ func createJoinOpSpec(args query.Arguments, a *query.Administration) (query.Oper
 		spec.On = array.AsStrings()
 	}
 
+	if method, ok, err := args.GetString("method"); err != nil {
+		return nil, err
+	} else if ok {
+		switch method {
+		case InnerJoin, LeftJoin, RightJoin, FullJoin:
+			spec.Method = method
+		default:
+			return nil, fmt.Errorf("unknown join method %q", method)
+		}
+	}
+
 	if m, ok, err := args.GetObject("tables"); err != nil {
 		return nil, err
 	} else if ok {
@@ -98,6 +130,7 @@ type MergeJoinProcedureSpec struct {
 	On         []string                     `json:"keys"`
 	Fn         *semantic.FunctionExpression `json:"f"`
 	TableNames map[plan.ProcedureID]string  `json:"table_names"`
+	Method     string                       `json:"method,omitempty"`
 }
 
 func newMergeJoinProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -116,6 +149,7 @@ func newMergeJoinProcedure(qs query.OperationSpec, pa plan.Administration) (plan
 		On:         spec.On,
 		Fn:         spec.Fn,
 		TableNames: tableNames,
+		Method:     spec.Method,
 	}
 	sort.Strings(p.On)
 	return p, nil
@@ -131,6 +165,7 @@ func (s *MergeJoinProcedureSpec) Copy() plan.ProcedureSpec {
 	copy(ns.On, s.On)
 
 	ns.Fn = s.Fn.Copy().(*semantic.FunctionExpression)
+	ns.Method = s.Method
 
 	return ns
 }
@@ -142,11 +177,127 @@ func (s *MergeJoinProcedureSpec) ParentChanged(old, new plan.ProcedureID) {
 	}
 }
 
+// HashJoinProcedureSpec joins tables whose blocks do not share the same bounds.
+// Rows are matched on time and the join keys regardless of the block they belong to.
+type HashJoinProcedureSpec struct {
+	MergeJoinProcedureSpec
+}
+
+func newHashJoinProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, err := newMergeJoinProcedure(qs, pa)
+	if err != nil {
+		return nil, err
+	}
+	return &HashJoinProcedureSpec{MergeJoinProcedureSpec: *spec.(*MergeJoinProcedureSpec)}, nil
+}
+
+func (s *HashJoinProcedureSpec) Kind() plan.ProcedureKind {
+	return HashJoinKind
+}
+func (s *HashJoinProcedureSpec) Copy() plan.ProcedureSpec {
+	return &HashJoinProcedureSpec{
+		MergeJoinProcedureSpec: *s.MergeJoinProcedureSpec.Copy().(*MergeJoinProcedureSpec),
+	}
+}
+
+// HashJoinRewriteRule replaces a merge join with a hash join,
+// when its parents are not read with the same bounds, window and shift,
+// since their blocks would then never line up.
+type HashJoinRewriteRule struct {
+}
+
+func (r HashJoinRewriteRule) Root() plan.ProcedureKind {
+	return MergeJoinKind
+}
+
+func (r HashJoinRewriteRule) Rewrite(pr *plan.Procedure, planner plan.PlanRewriter) error {
+	var alignments []timeAlignment
+	pr.DoParents(func(parent *plan.Procedure) {
+		alignments = append(alignments, findTimeAlignment(parent))
+	})
+	for _, a := range alignments {
+		if a != alignments[0] {
+			spec := pr.Spec.(*MergeJoinProcedureSpec)
+			pr.Spec = &HashJoinProcedureSpec{MergeJoinProcedureSpec: *spec}
+			return nil
+		}
+	}
+	return nil
+}
+
+// timeAlignment describes the time alignment of the blocks of a procedure.
+type timeAlignment struct {
+	bounds plan.BoundsSpec
+	window plan.WindowSpec
+	shift  query.Duration
+}
+
+// findTimeAlignment walks up the single parent ancestors of the procedure
+// to find the closest bounds and window, and the total shift applied to its data.
+func findTimeAlignment(pr *plan.Procedure) timeAlignment {
+	var (
+		a                    timeAlignment
+		boundsSet, windowSet bool
+	)
+	for pr != nil {
+		switch s := pr.Spec.(type) {
+		case *FromProcedureSpec:
+			if !boundsSet && s.BoundsSet {
+				a.bounds = s.Bounds
+				boundsSet = true
+			}
+			if !windowSet && s.WindowSet {
+				a.window = s.Window
+				windowSet = true
+			}
+		case *ShiftProcedureSpec:
+			a.shift += s.Shift
+		case plan.WindowedProcedureSpec:
+			if !windowSet {
+				a.window = s.TimeWindow()
+				windowSet = true
+			}
+		case plan.BoundedProcedureSpec:
+			if !boundsSet {
+				a.bounds = s.TimeBounds()
+				boundsSet = true
+			}
+		}
+		if len(pr.Parents) != 1 {
+			break
+		}
+		var parent *plan.Procedure
+		pr.DoParents(func(p *plan.Procedure) {
+			parent = p
+		})
+		pr = parent
+	}
+	return a
+}
+
 func createMergeJoinTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*MergeJoinProcedureSpec)
 	if !ok {
 		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
 	}
+	return createJoinTransformation(id, mode, s, a, NewMergeJoinCache)
+}
+
+func createHashJoinTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*HashJoinProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	return createJoinTransformation(id, mode, &s.MergeJoinProcedureSpec, a, NewHashJoinCache)
+}
+
+func createJoinTransformation(
+	id execute.DatasetID,
+	mode execute.AccumulationMode,
+	s *MergeJoinProcedureSpec,
+	a execute.Administration,
+	newCache func(*joinFunc, *execute.Allocator, string, string, string) *mergeJoinCache,
+) (execute.Transformation, execute.Dataset, error) {
 	parents := a.Parents()
 	if len(parents) != 2 {
 		//TODO(nathanielc): Support n-way joins
@@ -158,6 +309,10 @@ func createMergeJoinTransformation(id execute.DatasetID, mode execute.Accumulati
 		id := a.ConvertID(pid)
 		tableNames[id] = name
 	}
+	// The order of the tables is not defined by the query, the left table is the one whose name sorts first.
+	if tableNames[parents[0]] > tableNames[parents[1]] {
+		parents = []execute.DatasetID{parents[1], parents[0]}
+	}
 	leftName := tableNames[parents[0]]
 	rightName := tableNames[parents[1]]
 
@@ -165,7 +320,7 @@ func createMergeJoinTransformation(id execute.DatasetID, mode execute.Accumulati
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "invalid expression")
 	}
-	cache := NewMergeJoinCache(joinFn, a.Allocator(), leftName, rightName)
+	cache := newCache(joinFn, a.Allocator(), leftName, rightName, s.Method)
 	d := execute.NewDataset(id, mode, cache)
 	t := NewMergeJoinTransformation(d, cache, s, parents, tableNames)
 	return t, d, nil
@@ -218,28 +373,40 @@ func (t *mergeJoinTransformation) RetractBlock(id execute.DatasetID, meta execut
 		tags:   meta.Tags().IntersectingSubset(t.keys),
 		bounds: meta.Bounds(),
 	}
-	return t.d.RetractBlock(execute.ToBlockKey(bm))
+	return t.d.RetractBlock(t.cache.Key(bm))
 }
 
 func (t *mergeJoinTransformation) Process(id execute.DatasetID, b execute.Block) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if s, ok := t.cache.(execute.BlockBuilderSpiller); ok {
+		if err := s.SpillIfNeeded(); err != nil {
+			return err
+		}
+	}
+
 	bm := blockMetadata{
 		tags:   b.Tags().IntersectingSubset(t.keys),
 		bounds: b.Bounds(),
 	}
 	tables := t.cache.Tables(bm)
 
-	var table execute.BlockBuilder
+	var (
+		table     execute.BlockBuilder
+		tableName string
+	)
 	switch id {
 	case t.leftID:
 		table = tables.left
+		tableName = t.leftName
 	case t.rightID:
 		table = tables.right
+		tableName = t.rightName
 	}
 
 	colMap := t.addNewCols(b, table)
+	t.cache.AddCols(tableName, table.Cols())
 
 	times := b.Times()
 	times.DoTime(func(ts []execute.Time, rr execute.RowReader) {
@@ -343,6 +510,10 @@ func (t *mergeJoinTransformation) Finish(id execute.DatasetID, err error) {
 
 type MergeJoinCache interface {
 	Tables(execute.BlockMetadata) *joinTables
+	// Key returns the key of the joined block that the rows of a block belong to.
+	Key(execute.BlockMetadata) execute.BlockKey
+	// AddCols records the columns seen on one of the tables.
+	AddCols(table string, cols []execute.ColMeta)
 }
 
 type mergeJoinCache struct {
@@ -350,22 +521,38 @@ type mergeJoinCache struct {
 	alloc *execute.Allocator
 
 	leftName, rightName string
+	method              string
+
+	// hash indicates that blocks are joined regardless of their bounds, using a hash join.
+	hash bool
+	// cols are the columns seen on each table,
+	// so that a table without any rows for a block can be joined.
+	cols map[string][]execute.ColMeta
 
 	triggerSpec query.TriggerSpec
 
 	joinFn *joinFunc
 }
 
-func NewMergeJoinCache(joinFn *joinFunc, a *execute.Allocator, leftName, rightName string) *mergeJoinCache {
+func NewMergeJoinCache(joinFn *joinFunc, a *execute.Allocator, leftName, rightName, method string) *mergeJoinCache {
 	return &mergeJoinCache{
 		data:      make(map[execute.BlockKey]*joinTables),
 		joinFn:    joinFn,
 		alloc:     a,
 		leftName:  leftName,
 		rightName: rightName,
+		method:    method,
+		cols:      make(map[string][]execute.ColMeta, 2),
 	}
 }
 
+// NewHashJoinCache creates a cache that joins the rows of all blocks with the same join keys, whatever their bounds.
+func NewHashJoinCache(joinFn *joinFunc, a *execute.Allocator, leftName, rightName, method string) *mergeJoinCache {
+	c := NewMergeJoinCache(joinFn, a, leftName, rightName, method)
+	c.hash = true
+	return c
+}
+
 func (c *mergeJoinCache) BlockMetadata(key execute.BlockKey) execute.BlockMetadata {
 	return c.data[key]
 }
@@ -402,8 +589,43 @@ func (c *mergeJoinCache) SetTriggerSpec(spec query.TriggerSpec) {
 	c.triggerSpec = spec
 }
 
+// SpillIfNeeded partitions the tables of the cache to disk, if the memory used by the query nears its quota.
+func (c *mergeJoinCache) SpillIfNeeded() error {
+	if !c.alloc.NearLimit() {
+		return nil
+	}
+	return c.Spill()
+}
+
+// Spill partitions the tables of the cache to disk.
+func (c *mergeJoinCache) Spill() error {
+	for _, tables := range c.data {
+		if err := tables.spill(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mergeJoinCache) Key(bm execute.BlockMetadata) execute.BlockKey {
+	if c.hash {
+		return execute.ToBlockKey(blockMetadata{tags: bm.Tags()})
+	}
+	return execute.ToBlockKey(bm)
+}
+
+func (c *mergeJoinCache) AddCols(table string, cols []execute.ColMeta) {
+	known := c.cols[table]
+	for _, col := range cols {
+		if execute.ColIdx(col.Label, known) < 0 {
+			known = append(known, col)
+		}
+	}
+	c.cols[table] = known
+}
+
 func (c *mergeJoinCache) Tables(bm execute.BlockMetadata) *joinTables {
-	key := execute.ToBlockKey(bm)
+	key := c.Key(bm)
 	tables := c.data[key]
 	if tables == nil {
 		tables = &joinTables{
@@ -414,12 +636,24 @@ func (c *mergeJoinCache) Tables(bm execute.BlockMetadata) *joinTables {
 			right:     execute.NewColListBlockBuilder(c.alloc),
 			leftName:  c.leftName,
 			rightName: c.rightName,
+			method:    c.method,
+			hash:      c.hash,
+			cols:      c.cols,
 			trigger:   execute.NewTriggerFromSpec(c.triggerSpec),
 			joinFn:    c.joinFn,
 		}
 		tables.left.AddCol(execute.TimeCol)
 		tables.right.AddCol(execute.TimeCol)
 		c.data[key] = tables
+	} else if c.hash {
+		// The rows of blocks with different bounds are joined together, extend the bounds to cover them all.
+		b := bm.Bounds()
+		if b.Start < tables.bounds.Start {
+			tables.bounds.Start = b.Start
+		}
+		if b.Stop > tables.bounds.Stop {
+			tables.bounds.Stop = b.Stop
+		}
 	}
 	return tables
 }
@@ -432,10 +666,18 @@ type joinTables struct {
 
 	left, right         *execute.ColListBlockBuilder
 	leftName, rightName string
+	method              string
+	hash                bool
+	// cols are the columns seen on each table across all blocks.
+	cols map[string][]execute.ColMeta
 
 	trigger execute.Trigger
 
 	joinFn *joinFunc
+
+	// leftRuns and rightRuns hold the rows of the tables that were spilled to disk, by partition.
+	leftRuns, rightRuns [][]*execute.SpillRun
+	spilledRows         int
 }
 
 func (t *joinTables) Bounds() execute.Bounds {
@@ -445,19 +687,196 @@ func (t *joinTables) Tags() execute.Tags {
 	return t.tags
 }
 func (t *joinTables) Size() int {
-	return t.left.NRows() + t.right.NRows()
+	return t.left.NRows() + t.right.NRows() + t.spilledRows
 }
 
 func (t *joinTables) ClearData() {
 	t.left = execute.NewColListBlockBuilder(t.alloc)
 	t.right = execute.NewColListBlockBuilder(t.alloc)
+	t.leftRuns = nil
+	t.rightRuns = nil
+	t.spilledRows = 0
+}
+
+// joinPartitions is the number of partitions the tables of a join are split into when they are spilled to disk.
+const joinPartitions = 16
+
+// spillAlloc allocates the small batches of rows written to disk, which are not accounted to the query.
+var spillAlloc = &execute.Allocator{Limit: math.MaxInt64}
+
+// spill writes the rows of both tables to disk, partitioned by the hash of their join key,
+// so that the partitions can be joined one at a time.
+func (t *joinTables) spill() error {
+	n := t.left.NRows() + t.right.NRows()
+	var err error
+	if t.leftRuns, err = t.spillTable(t.left, t.leftRuns); err != nil {
+		return err
+	}
+	if t.rightRuns, err = t.spillTable(t.right, t.rightRuns); err != nil {
+		return err
+	}
+	t.spilledRows += n
+	return nil
+}
+
+func (t *joinTables) spillTable(table *execute.ColListBlockBuilder, runs [][]*execute.SpillRun) ([][]*execute.SpillRun, error) {
+	if runs == nil {
+		runs = make([][]*execute.SpillRun, joinPartitions)
+	}
+	raw := table.RawBlock()
+	if raw.NRows() == 0 {
+		return runs, nil
+	}
+	colMap := make([]int, len(raw.Cols()))
+	for j := range colMap {
+		colMap[j] = j
+	}
+	var (
+		writers  [joinPartitions]*execute.SpillWriter
+		builders [joinPartitions]*execute.ColListBlockBuilder
+	)
+	flush := func(p int) error {
+		if writers[p] == nil {
+			w, err := execute.NewSpillWriter(t.alloc.SpillDir)
+			if err != nil {
+				return err
+			}
+			writers[p] = w
+		}
+		if err := writers[p].Write(builders[p].RawBlock()); err != nil {
+			return err
+		}
+		builders[p].ClearData()
+		return nil
+	}
+	for i := 0; i < raw.NRows(); i++ {
+		p := rowKey(i, raw).partition()
+		if builders[p] == nil {
+			builders[p] = execute.NewColListBlockBuilder(spillAlloc)
+			builders[p].SetBounds(t.bounds)
+			execute.AddBlockCols(raw, builders[p])
+		}
+		execute.AppendRow(i, raw, builders[p], colMap)
+		if builders[p].NRows() == 1024 {
+			if err := flush(p); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for p, b := range builders {
+		if b == nil {
+			continue
+		}
+		if b.NRows() > 0 {
+			if err := flush(p); err != nil {
+				return nil, err
+			}
+		}
+		run, err := writers[p].Run()
+		if err != nil {
+			return nil, err
+		}
+		runs[p] = append(runs[p], run)
+	}
+	table.ClearData()
+	return runs, nil
+}
+
+// loadPartition reads the rows of a partition of a table back from disk.
+func (t *joinTables) loadPartition(runs []*execute.SpillRun, cols []execute.ColMeta) (*execute.ColListBlockBuilder, error) {
+	builder := execute.NewColListBlockBuilder(t.alloc)
+	builder.SetBounds(t.bounds)
+	colMap := make([]int, len(cols))
+	for j, c := range cols {
+		builder.AddCol(c)
+		colMap[j] = j
+	}
+	for _, run := range runs {
+		if err := run.Do(t, cols, func(batch *execute.ColListBlock) error {
+			execute.AppendBlock(batch, builder, colMap)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return builder, nil
+}
+
+// keepLeft reports whether rows of the left table without a match are kept.
+func (t *joinTables) keepLeft() bool {
+	return t.method == LeftJoin || t.method == FullJoin
+}
+
+// keepRight reports whether rows of the right table without a match are kept.
+func (t *joinTables) keepRight() bool {
+	return t.method == RightJoin || t.method == FullJoin
+}
+
+// addKnownCols adds the columns seen on the table in other blocks to a table without any rows.
+func (t *joinTables) addKnownCols(name string, table *execute.ColListBlockBuilder, runs [][]*execute.SpillRun) {
+	if table.NRows() > 0 || runs != nil {
+		return
+	}
+	for _, c := range t.cols[name] {
+		if execute.ColIdx(c.Label, table.Cols()) < 0 {
+			table.AddCol(c)
+		}
+	}
 }
 
-// Join performs a sort-merge join
+// Join performs a sort-merge join, or a hash join if the tables are not aligned.
+// Tables that were spilled to disk are joined one partition at a time.
 func (t *joinTables) Join() (execute.Block, error) {
+	t.addKnownCols(t.leftName, t.left, t.leftRuns)
+	t.addKnownCols(t.rightName, t.right, t.rightRuns)
+	if t.leftRuns == nil && t.rightRuns == nil {
+		builder, err := t.join(t.left, t.right, nil)
+		if err != nil {
+			return nil, err
+		}
+		return builder.Block()
+	}
+
+	if err := t.spill(); err != nil {
+		return nil, err
+	}
+	var builder *execute.ColListBlockBuilder
+	for p := 0; p < joinPartitions; p++ {
+		noLeft, noRight := len(t.leftRuns[p]) == 0, len(t.rightRuns[p]) == 0
+		if (noLeft && noRight) || (noLeft && !t.keepRight()) || (noRight && !t.keepLeft()) {
+			continue
+		}
+		left, err := t.loadPartition(t.leftRuns[p], t.left.Cols())
+		if err != nil {
+			return nil, err
+		}
+		right, err := t.loadPartition(t.rightRuns[p], t.right.Cols())
+		if err != nil {
+			return nil, err
+		}
+		builder, err = t.join(left, right, builder)
+		left.ClearData()
+		right.ClearData()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if builder == nil {
+		// No partition has rows on both sides, the empty tables still determine the columns of the result.
+		var err error
+		if builder, err = t.join(t.left, t.right, nil); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Block()
+}
+
+// join appends the result of joining the left and right tables to the builder.
+// A new builder is created when it is nil.
+func (t *joinTables) join(leftTable, rightTable *execute.ColListBlockBuilder, builder *execute.ColListBlockBuilder) (*execute.ColListBlockBuilder, error) {
 	// First prepare the join function
-	left := t.left.RawBlock()
-	right := t.right.RawBlock()
+	left := leftTable.RawBlock()
+	right := rightTable.RawBlock()
 	err := t.joinFn.Prepare(map[string]*execute.ColListBlock{
 		t.leftName:  left,
 		t.rightName: right,
@@ -465,7 +884,172 @@ func (t *joinTables) Join() (execute.Block, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to prepare join function")
 	}
-	// Create a builder to the result of the join
+	if builder == nil {
+		builder = t.newBuilder(leftTable.Cols(), rightTable.Cols())
+	}
+
+	if t.hash {
+		err = t.hashJoin(left, right, builder)
+	} else {
+		err = t.mergeJoin(leftTable, rightTable, builder)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return builder, nil
+}
+
+// mergeJoin joins the tables by sorting them on time and tags and merging their rows.
+func (t *joinTables) mergeJoin(leftTable, rightTable *execute.ColListBlockBuilder, builder *execute.ColListBlockBuilder) error {
+	left := leftTable.RawBlock()
+	right := rightTable.RawBlock()
+
+	// Determine sort order for the joining tables
+	tags := joinTags(left.Cols(), right.Cols())
+	sortOrder := append([]string{execute.TimeColLabel}, tags...)
+	leftTable.Sort(sortOrder, false)
+	rightTable.Sort(sortOrder, false)
+
+	var (
+		leftSet, rightSet subset
+		leftKey, rightKey joinKey
+	)
+
+	leftSet, leftKey = t.advance(leftSet.Stop, left)
+	rightSet, rightKey = t.advance(rightSet.Stop, right)
+	for (!leftSet.Empty() && !rightSet.Empty()) ||
+		(!leftSet.Empty() && t.keepLeft()) ||
+		(!rightSet.Empty() && t.keepRight()) {
+		switch {
+		case !leftSet.Empty() && !rightSet.Empty() && leftKey.Equal(rightKey):
+			for l := leftSet.Start; l < leftSet.Stop; l++ {
+				for r := rightSet.Start; r < rightSet.Stop; r++ {
+					if err := t.appendRow(builder, leftKey, l, r); err != nil {
+						return err
+					}
+				}
+			}
+			leftSet, leftKey = t.advance(leftSet.Stop, left)
+			rightSet, rightKey = t.advance(rightSet.Stop, right)
+		case rightSet.Empty() || (!leftSet.Empty() && leftKey.Less(rightKey, tags)):
+			if t.keepLeft() {
+				for l := leftSet.Start; l < leftSet.Stop; l++ {
+					if err := t.appendRow(builder, leftKey, l, -1); err != nil {
+						return err
+					}
+				}
+			}
+			leftSet, leftKey = t.advance(leftSet.Stop, left)
+		default:
+			if t.keepRight() {
+				for r := rightSet.Start; r < rightSet.Stop; r++ {
+					if err := t.appendRow(builder, rightKey, -1, r); err != nil {
+						return err
+					}
+				}
+			}
+			rightSet, rightKey = t.advance(rightSet.Stop, right)
+		}
+	}
+	return nil
+}
+
+// hashJoin joins the tables by building a hash table of the rows of the right table
+// and probing it with the rows of the left table.
+func (t *joinTables) hashJoin(left, right *execute.ColListBlock, builder *execute.ColListBlockBuilder) error {
+	index := make(map[string][]int)
+	for r := 0; r < right.NRows(); r++ {
+		k := rowKey(r, right).hashKey()
+		index[k] = append(index[k], r)
+	}
+	var matched []bool
+	if t.keepRight() {
+		matched = make([]bool, right.NRows())
+	}
+	for l := 0; l < left.NRows(); l++ {
+		key := rowKey(l, left)
+		rows := index[key.hashKey()]
+		if len(rows) == 0 && t.keepLeft() {
+			if err := t.appendRow(builder, key, l, -1); err != nil {
+				return err
+			}
+		}
+		for _, r := range rows {
+			if err := t.appendRow(builder, key, l, r); err != nil {
+				return err
+			}
+			if matched != nil {
+				matched[r] = true
+			}
+		}
+	}
+	for r, ok := range matched {
+		if !ok {
+			if err := t.appendRow(builder, rowKey(r, right), -1, r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendRow evaluates the join function for a row of each table and appends the result to the builder.
+// A row index of -1 indicates that the table has no row matching the key.
+func (t *joinTables) appendRow(builder *execute.ColListBlockBuilder, key joinKey, l, r int) error {
+	m, err := t.joinFn.Eval(map[string]int{
+		t.leftName:  l,
+		t.rightName: r,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to evaluate join function")
+	}
+	for j, c := range builder.Cols() {
+		switch c.Kind {
+		case execute.TimeColKind:
+			builder.AppendTime(j, key.Time)
+		case execute.TagColKind:
+			if c.Common {
+				continue
+			}
+
+			builder.AppendString(j, key.Tags[c.Label])
+		case execute.ValueColKind:
+			v := m.Get(c.Label)
+			execute.AppendValue(builder, j, v)
+		default:
+			log.Printf("unexpected column %v", c)
+		}
+	}
+	return nil
+}
+
+// joinTags returns the sorted labels of the tag columns of either table.
+func joinTags(left, right []execute.ColMeta) []string {
+	var tags []string
+	for _, cols := range [][]execute.ColMeta{left, right} {
+		for _, c := range cols {
+			if !c.IsTag() {
+				continue
+			}
+			found := false
+			for _, t := range tags {
+				if t == c.Label {
+					found = true
+					break
+				}
+			}
+			if !found {
+				tags = append(tags, c.Label)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// newBuilder creates a builder for the result of the join of tables with the columns.
+// The join function must have been prepared.
+func (t *joinTables) newBuilder(left, right []execute.ColMeta) *execute.ColListBlockBuilder {
 	builder := execute.NewColListBlockBuilder(t.alloc)
 	builder.SetBounds(t.bounds)
 	builder.AddCol(execute.TimeCol)
@@ -489,76 +1073,14 @@ func (t *joinTables) Join() (execute.Block, error) {
 	execute.AddTags(t.tags, builder)
 
 	// Add non common tags
-	cols := t.left.Cols()
-	for _, c := range cols {
-		if c.IsTag() && !c.Common {
-			builder.AddCol(c)
-		}
-	}
-
-	// Now that all columns have been added, keep a reference.
-	bCols := builder.Cols()
-
-	// Determine sort order for the joining tables
-	sortOrder := make([]string, len(cols))
-	for i, c := range cols {
-		sortOrder[i] = c.Label
-	}
-	t.left.Sort(sortOrder, false)
-	t.right.Sort(sortOrder, false)
-
-	var (
-		leftSet, rightSet subset
-		leftKey, rightKey joinKey
-	)
-
-	rows := map[string]int{
-		t.leftName:  -1,
-		t.rightName: -1,
-	}
-
-	leftSet, leftKey = t.advance(leftSet.Stop, left)
-	rightSet, rightKey = t.advance(rightSet.Stop, right)
-	for !leftSet.Empty() && !rightSet.Empty() {
-		if leftKey.Equal(rightKey) {
-			// Inner join
-			for l := leftSet.Start; l < leftSet.Stop; l++ {
-				for r := rightSet.Start; r < rightSet.Stop; r++ {
-					// Evaluate expression and add to block
-					rows[t.leftName] = l
-					rows[t.rightName] = r
-					m, err := t.joinFn.Eval(rows)
-					if err != nil {
-						return nil, errors.Wrap(err, "failed to evaluate join function")
-					}
-					for j, c := range bCols {
-						switch c.Kind {
-						case execute.TimeColKind:
-							builder.AppendTime(j, leftKey.Time)
-						case execute.TagColKind:
-							if c.Common {
-								continue
-							}
-
-							builder.AppendString(j, leftKey.Tags[c.Label])
-						case execute.ValueColKind:
-							v := m.Get(c.Label)
-							execute.AppendValue(builder, j, v)
-						default:
-							log.Printf("unexpected column %v", c)
-						}
-					}
-				}
+	for _, cols := range [][]execute.ColMeta{left, right} {
+		for _, c := range cols {
+			if c.IsTag() && !c.Common && execute.ColIdx(c.Label, builder.Cols()) < 0 {
+				builder.AddCol(c)
 			}
-			leftSet, leftKey = t.advance(leftSet.Stop, left)
-			rightSet, rightKey = t.advance(rightSet.Stop, right)
-		} else if leftKey.Less(rightKey) {
-			leftSet, leftKey = t.advance(leftSet.Stop, left)
-		} else {
-			rightSet, rightKey = t.advance(rightSet.Stop, right)
 		}
 	}
-	return builder.Block()
+	return builder
 }
 
 func (t *joinTables) advance(offset int, table *execute.ColListBlock) (subset, joinKey) {
@@ -618,6 +1140,32 @@ type joinKey struct {
 	Tags map[string]string
 }
 
+// hashKey encodes the key so that equal keys have the same encoding.
+func (k joinKey) hashKey() string {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(k.Time))
+	tags := make([]string, 0, len(k.Tags))
+	for t := range k.Tags {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	b := buf[:]
+	for _, t := range tags {
+		b = append(b, t...)
+		b = append(b, 0)
+		b = append(b, k.Tags[t]...)
+		b = append(b, 0)
+	}
+	return string(b)
+}
+
+// partition returns the partition of the key when the tables of a join are spilled to disk.
+func (k joinKey) partition() int {
+	h := fnv.New32a()
+	h.Write([]byte(k.hashKey()))
+	return int(h.Sum32() % joinPartitions)
+}
+
 func (k joinKey) Equal(o joinKey) bool {
 	if k.Time == o.Time {
 		for t := range k.Tags {
@@ -629,9 +1177,11 @@ func (k joinKey) Equal(o joinKey) bool {
 	}
 	return false
 }
-func (k joinKey) Less(o joinKey) bool {
+
+// Less reports whether the key sorts before the other key, comparing tags in the given order.
+func (k joinKey) Less(o joinKey, tags []string) bool {
 	if k.Time == o.Time {
-		for t := range k.Tags {
+		for _, t := range tags {
 			if k.Tags[t] != o.Tags[t] {
 				return k.Tags[t] < o.Tags[t]
 			}
@@ -684,7 +1234,7 @@ func (f *joinFunc) Prepare(tables map[string]*execute.ColListBlock) error {
 	// Prepare types and recordcols
 	for tbl, b := range tables {
 		cols := b.Cols()
-		f.record.Set(tbl, compiler.NewObject())
+		obj := compiler.NewObject()
 		tblPropertyTypes := make(map[string]semantic.Type, len(f.references[tbl]))
 		for _, r := range f.references[tbl] {
 			found := false
@@ -692,6 +1242,7 @@ func (f *joinFunc) Prepare(tables map[string]*execute.ColListBlock) error {
 				if r == c.Label {
 					f.recordCols[tableCol{table: tbl, col: c.Label}] = j
 					tblPropertyTypes[r] = execute.ConvertToKind(c.Type)
+					obj.SetPropertyType(r, tblPropertyTypes[r])
 					found = true
 					break
 				}
@@ -700,6 +1251,7 @@ func (f *joinFunc) Prepare(tables map[string]*execute.ColListBlock) error {
 				return fmt.Errorf("function references unknown column %q of table %q", r, tbl)
 			}
 		}
+		f.record.Set(tbl, obj)
 		propertyTypes[tbl] = semantic.NewObjectType(tblPropertyTypes)
 	}
 	// Compile fn for given types
@@ -749,8 +1301,13 @@ func (f *joinFunc) Eval(rows map[string]int) (*compiler.Object, error) {
 	return v.Object(), nil
 }
 
+// readValue reads the value of a row of the table.
+// A row of -1 reads a null value, which stands in for the missing row of an outer join.
 func readValue(i, j int, table *execute.ColListBlock) compiler.Value {
 	cols := table.Cols()
+	if i < 0 || table.IsNull(i, j) {
+		return compiler.NewNil()
+	}
 	switch t := cols[j].Type; t {
 	case execute.TBool:
 		return compiler.NewBool(table.AtBool(i, j))
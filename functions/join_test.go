@@ -1,6 +1,9 @@
 package functions_test
 
 import (
+	"io/ioutil"
+	"math"
+	"os"
 	"sort"
 	"testing"
 	"time"
@@ -200,6 +203,87 @@ join(tables:{a:a,b:b}, on:["host"], fn: (t) => t.a["_value"] + t.b["_value"])`,
 				},
 			},
 		},
+		{
+			Name: "join with method",
+			Raw: `
+a = from(db:"dbA") |> range(start:-1h)
+b = from(db:"dbB") |> range(start:-1h)
+join(tables:{a:a,b:b}, on:["host"], method:"full", fn: (t) => t.a["_value"])`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "dbA",
+						},
+					},
+					{
+						ID: "range1",
+						Spec: &functions.RangeOpSpec{
+							Start: query.Time{
+								Relative:   -1 * time.Hour,
+								IsRelative: true,
+							},
+							Stop: query.Time{
+								IsRelative: true,
+							},
+						},
+					},
+					{
+						ID: "from2",
+						Spec: &functions.FromOpSpec{
+							Database: "dbB",
+						},
+					},
+					{
+						ID: "range3",
+						Spec: &functions.RangeOpSpec{
+							Start: query.Time{
+								Relative:   -1 * time.Hour,
+								IsRelative: true,
+							},
+							Stop: query.Time{
+								IsRelative: true,
+							},
+						},
+					},
+					{
+						ID: "join4",
+						Spec: &functions.JoinOpSpec{
+							On:         []string{"host"},
+							TableNames: map[query.OperationID]string{"range1": "a", "range3": "b"},
+							Method:     functions.FullJoin,
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "t"}}},
+								Body: &semantic.MemberExpression{
+									Object: &semantic.MemberExpression{
+										Object: &semantic.IdentifierExpression{
+											Name: "t",
+										},
+										Property: "a",
+									},
+									Property: "_value",
+								},
+							},
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "range1"},
+					{Parent: "from2", Child: "range3"},
+					{Parent: "range1", Child: "join4"},
+					{Parent: "range3", Child: "join4"},
+				},
+			},
+		},
+		{
+			Name: "join with unknown method",
+			Raw: `
+a = from(db:"dbA") |> range(start:-1h)
+b = from(db:"dbB") |> range(start:-1h)
+join(tables:{a:a,b:b}, on:["host"], method:"cross", fn: (t) => t.a["_value"])`,
+			WantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -915,6 +999,187 @@ func TestMergeJoin_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "left outer with gaps",
+			spec: &functions.MergeJoinProcedureSpec{
+				Fn:         passThroughFunc,
+				TableNames: tableNames,
+				Method:     functions.LeftJoin,
+			},
+			data0: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0},
+						{execute.Time(2), 2.0},
+						{execute.Time(3), 3.0},
+					},
+				},
+			},
+			data1: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), 20.0},
+						{execute.Time(3), 30.0},
+						{execute.Time(4), 40.0},
+					},
+				},
+			},
+			want: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "a", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "b", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, nil},
+						{execute.Time(2), 2.0, 20.0},
+						{execute.Time(3), 3.0, 30.0},
+					},
+				},
+			},
+		},
+		{
+			name: "right outer with gaps",
+			spec: &functions.MergeJoinProcedureSpec{
+				Fn:         passThroughFunc,
+				TableNames: tableNames,
+				Method:     functions.RightJoin,
+			},
+			data0: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0},
+						{execute.Time(2), 2.0},
+						{execute.Time(3), 3.0},
+					},
+				},
+			},
+			data1: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), 20.0},
+						{execute.Time(3), 30.0},
+						{execute.Time(4), 40.0},
+					},
+				},
+			},
+			want: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "a", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "b", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), 2.0, 20.0},
+						{execute.Time(3), 3.0, 30.0},
+						{execute.Time(4), nil, 40.0},
+					},
+				},
+			},
+		},
+		{
+			name: "full outer with gaps",
+			spec: &functions.MergeJoinProcedureSpec{
+				Fn:         passThroughFunc,
+				TableNames: tableNames,
+				Method:     functions.FullJoin,
+			},
+			data0: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0},
+						{execute.Time(2), 2.0},
+						{execute.Time(3), 3.0},
+					},
+				},
+			},
+			data1: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), 20.0},
+						{execute.Time(3), 30.0},
+						{execute.Time(4), 40.0},
+					},
+				},
+			},
+			want: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 0,
+						Stop:  10,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "a", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "b", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, nil},
+						{execute.Time(2), 2.0, 20.0},
+						{execute.Time(3), 3.0, 30.0},
+						{execute.Time(4), nil, 40.0},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -934,7 +1199,7 @@ func TestMergeJoin_Process(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			c := functions.NewMergeJoinCache(joinExpr, executetest.UnlimitedAllocator, tableNames[parents[0]], tableNames[parents[1]])
+			c := functions.NewMergeJoinCache(joinExpr, executetest.UnlimitedAllocator, tableNames[parents[0]], tableNames[parents[1]], tc.spec.Method)
 			c.SetTriggerSpec(execute.DefaultTriggerSpec)
 			jt := functions.NewMergeJoinTransformation(d, c, tc.spec, parents, tableNames)
 
@@ -966,3 +1231,204 @@ func TestMergeJoin_Process(t *testing.T) {
 		})
 	}
 }
+
+// TestHashJoin_Process checks that rows of blocks with different bounds are joined.
+func TestHashJoin_Process(t *testing.T) {
+	parents := []execute.DatasetID{executetest.RandomDatasetID(), executetest.RandomDatasetID()}
+	tableNames := map[execute.DatasetID]string{
+		parents[0]: "a",
+		parents[1]: "b",
+	}
+	spec := &functions.HashJoinProcedureSpec{
+		MergeJoinProcedureSpec: functions.MergeJoinProcedureSpec{
+			On:     []string{"t1"},
+			Method: functions.FullJoin,
+			Fn: &semantic.FunctionExpression{
+				Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "t"}}},
+				Body: &semantic.BinaryExpression{
+					Operator: ast.AdditionOperator,
+					Left: &semantic.MemberExpression{
+						Object:   &semantic.MemberExpression{Object: &semantic.IdentifierExpression{Name: "t"}, Property: "a"},
+						Property: "_value",
+					},
+					Right: &semantic.MemberExpression{
+						Object:   &semantic.MemberExpression{Object: &semantic.IdentifierExpression{Name: "t"}, Property: "b"},
+						Property: "_value",
+					},
+				},
+			},
+		},
+	}
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+		{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+	}
+	data0 := []*executetest.Block{
+		{
+			Bnds:    execute.Bounds{Start: 0, Stop: 10},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(1), 1.0, "a"},
+				{execute.Time(5), 5.0, "a"},
+			},
+		},
+		{
+			Bnds:    execute.Bounds{Start: 10, Stop: 20},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(11), 11.0, "a"},
+				{execute.Time(15), 15.0, "a"},
+			},
+		},
+	}
+	data1 := []*executetest.Block{
+		{
+			Bnds:    execute.Bounds{Start: 5, Stop: 15},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(5), 50.0, "a"},
+				{execute.Time(11), 110.0, "a"},
+				{execute.Time(12), 120.0, "a"},
+			},
+		},
+	}
+	want := []*executetest.Block{
+		{
+			Bnds: execute.Bounds{Start: 0, Stop: 20},
+			ColMeta: []execute.ColMeta{
+				{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+				{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), nil, "a"},
+				{execute.Time(5), 55.0, "a"},
+				{execute.Time(11), 121.0, "a"},
+				{execute.Time(12), nil, "a"},
+				{execute.Time(15), nil, "a"},
+			},
+		},
+	}
+
+	joinFn, err := functions.NewRowJoinFunction(spec.Fn, parents, tableNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := functions.NewHashJoinCache(joinFn, executetest.UnlimitedAllocator, "a", "b", spec.Method)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	jt := functions.NewMergeJoinTransformation(executetest.NewDataset(executetest.RandomDatasetID()), c, &spec.MergeJoinProcedureSpec, parents, tableNames)
+	for _, b := range data0 {
+		if err := jt.Process(parents[0], b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, b := range data1 {
+		if err := jt.Process(parents[1], b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := executetest.BlocksFromCache(c)
+	for _, b := range got {
+		sort.Slice(b.Data, func(i, j int) bool {
+			return b.Data[i][0].(execute.Time) < b.Data[j][0].(execute.Time)
+		})
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+// TestMergeJoin_Spill checks that joining tables partitioned to disk produces the same rows as joining them in memory.
+func TestMergeJoin_Spill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "join_spill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	parents := []execute.DatasetID{executetest.RandomDatasetID(), executetest.RandomDatasetID()}
+	tableNames := map[execute.DatasetID]string{
+		parents[0]: "a",
+		parents[1]: "b",
+	}
+	spec := &functions.MergeJoinProcedureSpec{
+		On: []string{"t1"},
+		Fn: &semantic.FunctionExpression{
+			Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "t"}}},
+			Body: &semantic.BinaryExpression{
+				Operator: ast.AdditionOperator,
+				Left: &semantic.MemberExpression{
+					Object:   &semantic.MemberExpression{Object: &semantic.IdentifierExpression{Name: "t"}, Property: "a"},
+					Property: "_value",
+				},
+				Right: &semantic.MemberExpression{
+					Object:   &semantic.MemberExpression{Object: &semantic.IdentifierExpression{Name: "t"}, Property: "b"},
+					Property: "_value",
+				},
+			},
+		},
+	}
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+		{Label: "t1", Type: execute.TString, Kind: execute.TagColKind},
+	}
+	// Each parent produces several blocks, so that the tables are spilled more than once.
+	var data [2][]execute.Block
+	for p := range data {
+		for i := 0; i < 3; i++ {
+			b := &executetest.Block{Bnds: execute.Bounds{Start: 0, Stop: 100}, ColMeta: cols}
+			for j := 0; j < 10; j++ {
+				ts := execute.Time(i*10 + j)
+				b.Data = append(b.Data,
+					[]interface{}{ts, float64(p*100 + i*10 + j), "x"},
+					[]interface{}{ts, float64(p*100 + i*10 + j + 1000), "y"},
+				)
+			}
+			data[p] = append(data[p], b)
+		}
+	}
+
+	join := func(spill bool) []*executetest.Block {
+		joinFn, err := functions.NewRowJoinFunction(spec.Fn, parents, tableNames)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := functions.NewMergeJoinCache(joinFn, &execute.Allocator{Limit: math.MaxInt64, SpillDir: dir}, "a", "b", functions.InnerJoin)
+		c.SetTriggerSpec(execute.DefaultTriggerSpec)
+		jt := functions.NewMergeJoinTransformation(executetest.NewDataset(executetest.RandomDatasetID()), c, spec, parents, tableNames)
+		for i := range data[0] {
+			for p := range data {
+				if err := jt.Process(parents[p], data[p][i]); err != nil {
+					t.Fatal(err)
+				}
+				if spill {
+					if err := c.Spill(); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+		}
+		blocks := executetest.BlocksFromCache(c)
+		for _, b := range blocks {
+			sort.Slice(b.Data, func(i, j int) bool {
+				if b.Data[i][0] != b.Data[j][0] {
+					return b.Data[i][0].(execute.Time) < b.Data[j][0].(execute.Time)
+				}
+				return b.Data[i][2].(string) < b.Data[j][2].(string)
+			})
+		}
+		return blocks
+	}
+
+	want := join(false)
+	got := join(true)
+	if len(want) != 1 || len(want[0].Data) != 60 {
+		t.Fatalf("unexpected join result %v", want)
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
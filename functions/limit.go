@@ -169,6 +169,10 @@ func (t *limitTransformation) Process(id execute.DatasetID, b execute.Block) err
 				continue
 			}
 			for i := range ts[:l] {
+				if rr.IsNull(i, t.colMap[j]) {
+					builder.AppendNil(j)
+					continue
+				}
 				switch c.Type {
 				case execute.TBool:
 					builder.AppendBool(j, rr.AtBool(i, t.colMap[j]))
@@ -84,6 +84,45 @@ func TestMap_NewQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "map with builtin call",
+			Raw:  `from(db:"mydb") |> map(fn: (r) => abs(x: r._value))`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "map1",
+						Spec: &functions.MapOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+								Body: &semantic.CallExpression{
+									Callee: &semantic.IdentifierExpression{Name: "abs"},
+									Arguments: &semantic.ObjectExpression{
+										Properties: []*semantic.Property{{
+											Key: &semantic.Identifier{Name: "x"},
+											Value: &semantic.MemberExpression{
+												Object: &semantic.IdentifierExpression{
+													Name: "r",
+												},
+												Property: "_value",
+											},
+										}},
+									},
+								},
+							},
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "map1"},
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -196,6 +235,219 @@ func TestMap_Process(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: `_value+5 with null`,
+			spec: &functions.MapProcedureSpec{
+				Fn: &semantic.FunctionExpression{
+					Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+					Body: &semantic.BinaryExpression{
+						Operator: ast.AdditionOperator,
+						Left: &semantic.MemberExpression{
+							Object: &semantic.IdentifierExpression{
+								Name: "r",
+							},
+							Property: "_value",
+						},
+						Right: &semantic.FloatLiteral{
+							Value: 5,
+						},
+					},
+				},
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil},
+					{execute.Time(2), 6.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil},
+					{execute.Time(2), 11.0},
+				},
+			}},
+		},
+		{
+			name: `_value > 5.0 ? _value : 0`,
+			spec: &functions.MapProcedureSpec{
+				Fn: &semantic.FunctionExpression{
+					Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+					Body: &semantic.ConditionalExpression{
+						Test: &semantic.BinaryExpression{
+							Operator: ast.GreaterThanOperator,
+							Left: &semantic.MemberExpression{
+								Object: &semantic.IdentifierExpression{
+									Name: "r",
+								},
+								Property: "_value",
+							},
+							Right: &semantic.FloatLiteral{
+								Value: 5,
+							},
+						},
+						Consequent: &semantic.MemberExpression{
+							Object: &semantic.IdentifierExpression{
+								Name: "r",
+							},
+							Property: "_value",
+						},
+						Alternate: &semantic.IntegerLiteral{
+							Value: 0,
+						},
+					},
+				},
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+					{execute.Time(2), 6.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 0.0},
+					{execute.Time(2), 6.0},
+				},
+			}},
+		},
+		{
+			name: `pow(x: _value, y: 2)`,
+			spec: &functions.MapProcedureSpec{
+				Fn: &semantic.FunctionExpression{
+					Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+					Body: &semantic.CallExpression{
+						Callee: &semantic.IdentifierExpression{Name: "pow"},
+						Arguments: &semantic.ObjectExpression{
+							Properties: []*semantic.Property{
+								{
+									Key: &semantic.Identifier{Name: "x"},
+									Value: &semantic.MemberExpression{
+										Object: &semantic.IdentifierExpression{
+											Name: "r",
+										},
+										Property: "_value",
+									},
+								},
+								{
+									Key:   &semantic.Identifier{Name: "y"},
+									Value: &semantic.IntegerLiteral{Value: 2},
+								},
+							},
+						},
+					},
+				},
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 3.0},
+					{execute.Time(2), -6.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 9.0},
+					{execute.Time(2), 36.0},
+				},
+			}},
+		},
+		{
+			name: `toFloat(v: _value)`,
+			spec: &functions.MapProcedureSpec{
+				Fn: &semantic.FunctionExpression{
+					Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+					Body: &semantic.CallExpression{
+						Callee: &semantic.IdentifierExpression{Name: "toFloat"},
+						Arguments: &semantic.ObjectExpression{
+							Properties: []*semantic.Property{{
+								Key: &semantic.Identifier{Name: "v"},
+								Value: &semantic.MemberExpression{
+									Object:   &semantic.IdentifierExpression{Name: "r"},
+									Property: "_value",
+								},
+							}},
+						},
+					},
+				},
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TString, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "1.5"},
+					{execute.Time(2), "n/a"},
+					{execute.Time(3), "-2"},
+				},
+			}},
+			// The row that fails to convert is reported and skipped
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.5},
+					{execute.Time(3), -2.0},
+				},
+			}},
+		},
 		{
 			name: `_value*_value`,
 			spec: &functions.MapProcedureSpec{
@@ -51,6 +51,13 @@ func (s *MeanProcedureSpec) Copy() plan.ProcedureSpec {
 	return new(MeanProcedureSpec)
 }
 
+func (s *MeanProcedureSpec) PartialSpec() plan.ProcedureSpec {
+	return &PartialProcedureSpec{AggregateKind: MeanKind}
+}
+func (s *MeanProcedureSpec) CombineSpec() plan.ProcedureSpec {
+	return &CombineProcedureSpec{AggregateKind: MeanKind}
+}
+
 type MeanAgg struct {
 	count float64
 	sum   float64
@@ -61,27 +68,20 @@ func createMeanTransformation(id execute.DatasetID, mode execute.AccumulationMod
 	return t, d, nil
 }
 
-func (a *MeanAgg) reset() {
-	a.count = 0
-	a.sum = 0
-}
 func (a *MeanAgg) NewBoolAgg() execute.DoBoolAgg {
 	return nil
 }
 
 func (a *MeanAgg) NewIntAgg() execute.DoIntAgg {
-	a.reset()
-	return a
+	return new(MeanAgg)
 }
 
 func (a *MeanAgg) NewUIntAgg() execute.DoUIntAgg {
-	a.reset()
-	return a
+	return new(MeanAgg)
 }
 
 func (a *MeanAgg) NewFloatAgg() execute.DoFloatAgg {
-	a.reset()
-	return a
+	return new(MeanAgg)
 }
 
 func (a *MeanAgg) NewStringAgg() execute.DoStringAgg {
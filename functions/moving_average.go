@@ -0,0 +1,265 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const MovingAverageKind = "movingAverage"
+
+type MovingAverageOpSpec struct {
+	N int64 `json:"n"`
+}
+
+var movingAverageSignature = query.DefaultFunctionSignature()
+
+func init() {
+	movingAverageSignature.Params["n"] = semantic.Int
+
+	query.RegisterFunction(MovingAverageKind, createMovingAverageOpSpec, movingAverageSignature)
+	query.RegisterOpSpec(MovingAverageKind, newMovingAverageOp)
+	plan.RegisterProcedureSpec(MovingAverageKind, newMovingAverageProcedure, MovingAverageKind)
+	execute.RegisterTransformation(MovingAverageKind, createMovingAverageTransformation)
+}
+
+func createMovingAverageOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, errors.New("movingAverage n must be positive")
+	}
+	return &MovingAverageOpSpec{
+		N: n,
+	}, nil
+}
+
+func newMovingAverageOp() query.OperationSpec {
+	return new(MovingAverageOpSpec)
+}
+
+func (s *MovingAverageOpSpec) Kind() query.OperationKind {
+	return MovingAverageKind
+}
+
+type MovingAverageProcedureSpec struct {
+	N int64 `json:"n"`
+}
+
+func newMovingAverageProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*MovingAverageOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &MovingAverageProcedureSpec{
+		N: spec.N,
+	}, nil
+}
+
+func (s *MovingAverageProcedureSpec) Kind() plan.ProcedureKind {
+	return MovingAverageKind
+}
+func (s *MovingAverageProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(MovingAverageProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createMovingAverageTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MovingAverageProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewMovingAverageTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type movingAverageTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+
+	n int
+}
+
+func NewMovingAverageTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *MovingAverageProcedureSpec) *movingAverageTransformation {
+	return &movingAverageTransformation{
+		d:     d,
+		cache: cache,
+		n:     int(spec.N),
+	}
+}
+
+func (t *movingAverageTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+// Process replaces the numeric value columns with the average of their last n values.
+// The first n-1 rows of a block are dropped, null values are not part of the average.
+func (t *movingAverageTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	builder, new := t.cache.BlockBuilder(b)
+	cols := b.Cols()
+	if new {
+		addAverageCols(b, builder)
+	}
+
+	averages := make([]*movingAverage, len(cols))
+	for j, c := range cols {
+		if isNumericValueCol(c) {
+			averages[j] = newMovingAverage(t.n)
+		}
+	}
+
+	row := 0
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i := range ts {
+			for j, avg := range averages {
+				if avg == nil {
+					continue
+				}
+				if rr.IsNull(i, j) {
+					avg.addNull()
+					continue
+				}
+				switch cols[j].Type {
+				case execute.TInt:
+					avg.add(float64(rr.AtInt(i, j)))
+				case execute.TUInt:
+					avg.add(float64(rr.AtUInt(i, j)))
+				case execute.TFloat:
+					avg.add(rr.AtFloat(i, j))
+				}
+			}
+			row++
+			if row < t.n {
+				continue
+			}
+			for j := range cols {
+				if avg := averages[j]; avg != nil {
+					if v, ok := avg.value(); ok {
+						builder.AppendFloat(j, v)
+					} else {
+						builder.AppendNil(j)
+					}
+					continue
+				}
+				appendValue(builder, rr, i, j)
+			}
+		}
+	})
+	return nil
+}
+
+func (t *movingAverageTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *movingAverageTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *movingAverageTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// movingAverage is the average of the last n values of a column.
+type movingAverage struct {
+	values []float64
+	nulls  []bool
+	next   int
+	size   int
+
+	sum   float64
+	count int
+}
+
+func newMovingAverage(n int) *movingAverage {
+	return &movingAverage{
+		values: make([]float64, n),
+		nulls:  make([]bool, n),
+	}
+}
+
+func (a *movingAverage) add(v float64) {
+	a.evict()
+	a.values[a.next] = v
+	a.nulls[a.next] = false
+	a.sum += v
+	a.count++
+	a.next = (a.next + 1) % len(a.values)
+}
+
+func (a *movingAverage) addNull() {
+	a.evict()
+	a.nulls[a.next] = true
+	a.next = (a.next + 1) % len(a.values)
+}
+
+// evict removes the oldest value once the window is full.
+func (a *movingAverage) evict() {
+	if a.size < len(a.values) {
+		a.size++
+		return
+	}
+	if !a.nulls[a.next] {
+		a.sum -= a.values[a.next]
+		a.count--
+	}
+}
+
+func (a *movingAverage) value() (float64, bool) {
+	if a.count == 0 {
+		return 0, false
+	}
+	return a.sum / float64(a.count), true
+}
+
+// isNumericValueCol reports whether c is a value column of integers, unsigned integers or floats.
+func isNumericValueCol(c execute.ColMeta) bool {
+	return c.IsValue() && isNumeric(c.Type)
+}
+
+// addAverageCols adds the columns of b to the builder, numeric value columns become float columns.
+func addAverageCols(b execute.Block, builder execute.BlockBuilder) {
+	for j, c := range b.Cols() {
+		if isNumericValueCol(c) {
+			c.Type = execute.TFloat
+		}
+		builder.AddCol(c)
+		if c.IsTag() && c.Common {
+			builder.SetCommonString(j, b.Tags()[c.Label])
+		}
+	}
+}
+
+// appendValue appends the value of row i and column j to the same column of the builder.
+func appendValue(builder execute.BlockBuilder, rr execute.RowReader, i, j int) {
+	if rr.IsNull(i, j) {
+		builder.AppendNil(j)
+		return
+	}
+	switch rr.Cols()[j].Type {
+	case execute.TBool:
+		builder.AppendBool(j, rr.AtBool(i, j))
+	case execute.TInt:
+		builder.AppendInt(j, rr.AtInt(i, j))
+	case execute.TUInt:
+		builder.AppendUInt(j, rr.AtUInt(i, j))
+	case execute.TFloat:
+		builder.AppendFloat(j, rr.AtFloat(i, j))
+	case execute.TString:
+		builder.AppendString(j, rr.AtString(i, j))
+	case execute.TTime:
+		builder.AppendTime(j, rr.AtTime(i, j))
+	}
+}
@@ -0,0 +1,199 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestMovingAverage_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "moving average",
+			Raw:  `from(db:"mydb") |> movingAverage(n: 3)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "movingAverage1",
+						Spec: &functions.MovingAverageOpSpec{
+							N: 3,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "movingAverage1"},
+				},
+			},
+		},
+		{
+			Name:    "zero n",
+			Raw:     `from(db:"mydb") |> movingAverage(n: 0)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestMovingAverageOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"movingAverage","kind":"movingAverage","spec":{"n":3}}`)
+	op := &query.Operation{
+		ID: "movingAverage",
+		Spec: &functions.MovingAverageOpSpec{
+			N: 3,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestMovingAverage_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+		s := functions.NewMovingAverageTransformation(
+			d,
+			c,
+			&functions.MovingAverageProcedureSpec{N: 2},
+		)
+		return s
+	})
+}
+
+func TestMovingAverage_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.MovingAverageProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "float",
+			spec: &functions.MovingAverageProcedureSpec{N: 2},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  5,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), 4.0},
+					{execute.Time(3), 1.0},
+					{execute.Time(4), 6.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  5,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 3.0},
+					{execute.Time(3), 2.5},
+					{execute.Time(4), 3.5},
+				},
+			}},
+		},
+		{
+			name: "int with nulls",
+			spec: &functions.MovingAverageProcedureSpec{N: 3},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  7,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(3), "a"},
+					{execute.Time(2), nil, "b"},
+					{execute.Time(3), int64(6), "c"},
+					{execute.Time(4), nil, "d"},
+					{execute.Time(5), nil, "e"},
+					{execute.Time(6), nil, "f"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  7,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), 4.5, "c"},
+					{execute.Time(4), 6.0, "d"},
+					{execute.Time(5), 6.0, "e"},
+					{execute.Time(6), nil, "f"},
+				},
+			}},
+		},
+		{
+			name: "fewer rows than n",
+			spec: &functions.MovingAverageProcedureSpec{N: 3},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), 4.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewMovingAverageTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,662 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+)
+
+const (
+	// PartialKind is the kind of the procedure that computes the partial state of an aggregate.
+	PartialKind = "partial"
+	// CombineKind is the kind of the procedure that combines partial states into the final aggregate.
+	CombineKind = "combine"
+)
+
+func init() {
+	execute.RegisterTransformation(PartialKind, createPartialTransformation)
+	execute.RegisterTransformation(CombineKind, createCombineTransformation)
+}
+
+// newPartialAggregate returns the state of the aggregate of the given kind.
+func newPartialAggregate(kind plan.ProcedureKind) (partialAggregate, error) {
+	switch kind {
+	case CountKind:
+		return new(countPartial), nil
+	case MeanKind:
+		return new(meanPartial), nil
+	case StddevKind:
+		return new(momentsPartial), nil
+	case SkewKind:
+		return &momentsPartial{skew: true}, nil
+	case SpreadKind:
+		return new(spreadPartial), nil
+	default:
+		return nil, fmt.Errorf("aggregate %q cannot be computed in two phases", kind)
+	}
+}
+
+// partialAggregate is the state of an aggregate that is computed in two phases.
+// Partial states are computed over disjoint subsets of the values of a block,
+// and the final aggregate is computed once the partial states have been merged.
+// The state is exchanged between the phases as the value columns of a block.
+type partialAggregate interface {
+	// stateLabels returns the labels of the columns that hold the state.
+	stateLabels() []string
+	// stateCols returns the columns that hold the state of the aggregate of values of the given type.
+	stateCols(typ execute.DataType) ([]execute.ColMeta, error)
+	// do adds the values to the state.
+	do(values execute.ValueIterator, typ execute.DataType)
+	// appendState appends the state to the columns of the builder returned by stateCols.
+	appendState(b execute.BlockBuilder, cols []int)
+	// merge merges the state held in the i-th row of the columns returned by stateLabels.
+	merge(rr execute.RowReader, i int, cols []int)
+	// valueType reports the type of the final aggregate.
+	valueType() execute.DataType
+	// appendValue appends the final aggregate to the builder.
+	appendValue(b execute.BlockBuilder, j int)
+}
+
+// PartialProcedureSpec computes the partial state of an aggregate of the _value column of each block.
+type PartialProcedureSpec struct {
+	AggregateKind plan.ProcedureKind
+}
+
+func (s *PartialProcedureSpec) Kind() plan.ProcedureKind {
+	return PartialKind
+}
+func (s *PartialProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(PartialProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+// CombineProcedureSpec merges the partial states of an aggregate and computes the final aggregate.
+type CombineProcedureSpec struct {
+	AggregateKind plan.ProcedureKind
+}
+
+func (s *CombineProcedureSpec) Kind() plan.ProcedureKind {
+	return CombineKind
+}
+func (s *CombineProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(CombineProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createPartialTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*PartialProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	if _, err := newPartialAggregate(s.AggregateKind); err != nil {
+		return nil, nil, err
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewPartialTransformation(d, cache, a.Bounds(), s.AggregateKind)
+	return t, d, nil
+}
+
+type partialTransformation struct {
+	d      execute.Dataset
+	cache  execute.BlockBuilderCache
+	bounds execute.Bounds
+	kind   plan.ProcedureKind
+}
+
+func NewPartialTransformation(d execute.Dataset, cache execute.BlockBuilderCache, bounds execute.Bounds, kind plan.ProcedureKind) *partialTransformation {
+	return &partialTransformation{
+		d:      d,
+		cache:  cache,
+		bounds: bounds,
+		kind:   kind,
+	}
+}
+
+func (t *partialTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(blockMetadata{
+		tags:   meta.Tags(),
+		bounds: t.bounds,
+	}))
+}
+
+// Process appends a row holding the partial state of the block.
+func (t *partialTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	cols := b.Cols()
+	valueIdx := execute.ValueIdx(cols)
+	if valueIdx < 0 {
+		return execute.NoDefaultValueColumn
+	}
+	for j, c := range cols {
+		if c.Kind == execute.ValueColKind && j != valueIdx {
+			return fmt.Errorf("cannot compute the partial aggregate of value column %q", c.Label)
+		}
+	}
+	typ := cols[valueIdx].Type
+
+	agg, err := newPartialAggregate(t.kind)
+	if err != nil {
+		return err
+	}
+	stateCols, err := agg.stateCols(typ)
+	if err != nil {
+		return err
+	}
+	agg.do(b.Col(valueIdx), typ)
+
+	builder, new := t.cache.BlockBuilder(blockMetadata{
+		tags:   b.Tags(),
+		bounds: t.bounds,
+	})
+	if new {
+		builder.AddCol(execute.TimeCol)
+		for _, c := range stateCols {
+			builder.AddCol(c)
+		}
+		execute.AddTags(b.Tags(), builder)
+	}
+	timeIdx := execute.TimeIdx(builder.Cols())
+	builder.AppendTime(timeIdx, t.bounds.Stop)
+	agg.appendState(builder, stateIdxs(agg, builder.Cols()))
+	return nil
+}
+
+func (t *partialTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *partialTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *partialTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+func createCombineTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*CombineProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	if _, err := newPartialAggregate(s.AggregateKind); err != nil {
+		return nil, nil, err
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewCombineTransformation(d, cache, a.Bounds(), s.AggregateKind, a.Parents())
+	return t, d, nil
+}
+
+type combineTransformation struct {
+	mu sync.Mutex
+
+	d      execute.Dataset
+	cache  execute.BlockBuilderCache
+	bounds execute.Bounds
+	kind   plan.ProcedureKind
+
+	// aggs holds the merged state of each block.
+	aggs map[execute.BlockKey]partialAggregate
+
+	parentState map[execute.DatasetID]*mergeJoinParentState
+}
+
+func NewCombineTransformation(d execute.Dataset, cache execute.BlockBuilderCache, bounds execute.Bounds, kind plan.ProcedureKind, parents []execute.DatasetID) *combineTransformation {
+	t := &combineTransformation{
+		d:           d,
+		cache:       cache,
+		bounds:      bounds,
+		kind:        kind,
+		aggs:        make(map[execute.BlockKey]partialAggregate),
+		parentState: make(map[execute.DatasetID]*mergeJoinParentState, len(parents)),
+	}
+	for _, id := range parents {
+		t.parentState[id] = new(mergeJoinParentState)
+	}
+	return t
+}
+
+func (t *combineTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := execute.ToBlockKey(blockMetadata{
+		tags:   meta.Tags(),
+		bounds: t.bounds,
+	})
+	delete(t.aggs, key)
+	return t.d.RetractBlock(key)
+}
+
+// Process merges the partial states of the block and replaces the row of the output block.
+func (t *combineTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	meta := blockMetadata{
+		tags:   b.Tags(),
+		bounds: t.bounds,
+	}
+	key := execute.ToBlockKey(meta)
+	agg, ok := t.aggs[key]
+	if !ok {
+		var err error
+		agg, err = newPartialAggregate(t.kind)
+		if err != nil {
+			return err
+		}
+		t.aggs[key] = agg
+	}
+
+	idxs := stateIdxs(agg, b.Cols())
+	for j, idx := range idxs {
+		if idx < 0 {
+			return fmt.Errorf("missing partial state column %q", agg.stateLabels()[j])
+		}
+	}
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i := range ts {
+			agg.merge(rr, i, idxs)
+		}
+	})
+
+	builder, new := t.cache.BlockBuilder(meta)
+	if new {
+		builder.AddCol(execute.TimeCol)
+		builder.AddCol(execute.ColMeta{
+			Label: execute.DefaultValueColLabel,
+			Type:  agg.valueType(),
+			Kind:  execute.ValueColKind,
+		})
+		execute.AddTags(b.Tags(), builder)
+	}
+	cols := builder.Cols()
+	builder.ClearData()
+	builder.AppendTime(execute.TimeIdx(cols), t.bounds.Stop)
+	agg.appendValue(builder, execute.ValueIdx(cols))
+	return nil
+}
+
+func (t *combineTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.parentState[id].mark = mark
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.mark < min {
+			min = state.mark
+		}
+	}
+
+	return t.d.UpdateWatermark(min)
+}
+
+func (t *combineTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.parentState[id].processing = pt
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.processing < min {
+			min = state.processing
+		}
+	}
+
+	return t.d.UpdateProcessingTime(min)
+}
+
+func (t *combineTransformation) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		t.d.Finish(err)
+		return
+	}
+
+	t.parentState[id].finished = true
+	finished := true
+	for _, state := range t.parentState {
+		finished = finished && state.finished
+	}
+
+	if finished {
+		t.d.Finish(nil)
+	}
+}
+
+// stateIdxs returns the indexes of the state columns of the aggregate, -1 for missing columns.
+func stateIdxs(agg partialAggregate, cols []execute.ColMeta) []int {
+	labels := agg.stateLabels()
+	idxs := make([]int, len(labels))
+	for i, l := range labels {
+		idxs[i] = execute.ColIdx(l, cols)
+	}
+	return idxs
+}
+
+// stateCols returns value columns with the given labels and types.
+func stateCols(labels []string, types ...execute.DataType) []execute.ColMeta {
+	cols := make([]execute.ColMeta, len(labels))
+	for i, l := range labels {
+		cols[i] = execute.ColMeta{
+			Label: l,
+			Type:  types[i],
+			Kind:  execute.ValueColKind,
+		}
+	}
+	return cols
+}
+
+// doFloats calls f with the values converted to floats.
+func doFloats(values execute.ValueIterator, typ execute.DataType, f func(float64)) {
+	switch typ {
+	case execute.TInt:
+		values.DoInt(func(vs []int64, _ execute.RowReader) {
+			for _, v := range vs {
+				f(float64(v))
+			}
+		})
+	case execute.TUInt:
+		values.DoUInt(func(vs []uint64, _ execute.RowReader) {
+			for _, v := range vs {
+				f(float64(v))
+			}
+		})
+	case execute.TFloat:
+		values.DoFloat(func(vs []float64, _ execute.RowReader) {
+			for _, v := range vs {
+				f(v)
+			}
+		})
+	}
+}
+
+func isNumeric(typ execute.DataType) bool {
+	switch typ {
+	case execute.TInt, execute.TUInt, execute.TFloat:
+		return true
+	default:
+		return false
+	}
+}
+
+type countPartial struct {
+	n int64
+}
+
+func (a *countPartial) stateLabels() []string {
+	return []string{"count"}
+}
+func (a *countPartial) stateCols(typ execute.DataType) ([]execute.ColMeta, error) {
+	return stateCols(a.stateLabels(), execute.TInt), nil
+}
+func (a *countPartial) do(values execute.ValueIterator, typ execute.DataType) {
+	switch typ {
+	case execute.TBool:
+		values.DoBool(func(vs []bool, _ execute.RowReader) {
+			a.n += int64(len(vs))
+		})
+	case execute.TInt:
+		values.DoInt(func(vs []int64, _ execute.RowReader) {
+			a.n += int64(len(vs))
+		})
+	case execute.TUInt:
+		values.DoUInt(func(vs []uint64, _ execute.RowReader) {
+			a.n += int64(len(vs))
+		})
+	case execute.TFloat:
+		values.DoFloat(func(vs []float64, _ execute.RowReader) {
+			a.n += int64(len(vs))
+		})
+	case execute.TString:
+		values.DoString(func(vs []string, _ execute.RowReader) {
+			a.n += int64(len(vs))
+		})
+	}
+}
+func (a *countPartial) appendState(b execute.BlockBuilder, cols []int) {
+	b.AppendInt(cols[0], a.n)
+}
+func (a *countPartial) merge(rr execute.RowReader, i int, cols []int) {
+	a.n += rr.AtInt(i, cols[0])
+}
+func (a *countPartial) valueType() execute.DataType {
+	return execute.TInt
+}
+func (a *countPartial) appendValue(b execute.BlockBuilder, j int) {
+	b.AppendInt(j, a.n)
+}
+
+type meanPartial struct {
+	count int64
+	sum   float64
+}
+
+func (a *meanPartial) stateLabels() []string {
+	return []string{"count", "sum"}
+}
+func (a *meanPartial) stateCols(typ execute.DataType) ([]execute.ColMeta, error) {
+	if !isNumeric(typ) {
+		return nil, fmt.Errorf("cannot compute the mean of values of type %v", typ)
+	}
+	return stateCols(a.stateLabels(), execute.TInt, execute.TFloat), nil
+}
+func (a *meanPartial) do(values execute.ValueIterator, typ execute.DataType) {
+	doFloats(values, typ, func(v float64) {
+		a.count++
+		a.sum += v
+	})
+}
+func (a *meanPartial) appendState(b execute.BlockBuilder, cols []int) {
+	b.AppendInt(cols[0], a.count)
+	b.AppendFloat(cols[1], a.sum)
+}
+func (a *meanPartial) merge(rr execute.RowReader, i int, cols []int) {
+	a.count += rr.AtInt(i, cols[0])
+	a.sum += rr.AtFloat(i, cols[1])
+}
+func (a *meanPartial) valueType() execute.DataType {
+	return execute.TFloat
+}
+func (a *meanPartial) appendValue(b execute.BlockBuilder, j int) {
+	if a.count < 1 {
+		b.AppendFloat(j, math.NaN())
+		return
+	}
+	b.AppendFloat(j, a.sum/float64(a.count))
+}
+
+// momentsPartial holds the count, mean, and the sums of the squared and cubed differences from the mean,
+// from which the standard deviation and skew are computed.
+// The states are merged using the pairwise update formulas of Chan et al.
+type momentsPartial struct {
+	skew bool
+
+	n, m1, m2, m3 float64
+}
+
+func (a *momentsPartial) stateLabels() []string {
+	if a.skew {
+		return []string{"n", "m1", "m2", "m3"}
+	}
+	return []string{"n", "m1", "m2"}
+}
+func (a *momentsPartial) stateCols(typ execute.DataType) ([]execute.ColMeta, error) {
+	if !isNumeric(typ) {
+		return nil, fmt.Errorf("cannot compute the moments of values of type %v", typ)
+	}
+	return stateCols(a.stateLabels(), execute.TFloat, execute.TFloat, execute.TFloat, execute.TFloat), nil
+}
+func (a *momentsPartial) do(values execute.ValueIterator, typ execute.DataType) {
+	doFloats(values, typ, func(v float64) {
+		n0 := a.n
+		a.n++
+		delta := v - a.m1
+		deltaN := delta / a.n
+		t := delta * deltaN * n0
+		a.m3 += t*deltaN*(a.n-2) - 3*deltaN*a.m2
+		a.m2 += t
+		a.m1 += deltaN
+	})
+}
+func (a *momentsPartial) appendState(b execute.BlockBuilder, cols []int) {
+	b.AppendFloat(cols[0], a.n)
+	b.AppendFloat(cols[1], a.m1)
+	b.AppendFloat(cols[2], a.m2)
+	if a.skew {
+		b.AppendFloat(cols[3], a.m3)
+	}
+}
+func (a *momentsPartial) merge(rr execute.RowReader, i int, cols []int) {
+	nb := rr.AtFloat(i, cols[0])
+	if nb == 0 {
+		return
+	}
+	m1b := rr.AtFloat(i, cols[1])
+	m2b := rr.AtFloat(i, cols[2])
+	var m3b float64
+	if a.skew {
+		m3b = rr.AtFloat(i, cols[3])
+	}
+	na := a.n
+	n := na + nb
+	delta := m1b - a.m1
+	a.m3 += m3b + delta*delta*delta*na*nb*(na-nb)/(n*n) + 3*delta*(na*m2b-nb*a.m2)/n
+	a.m2 += m2b + delta*delta*na*nb/n
+	a.m1 += delta * nb / n
+	a.n = n
+}
+func (a *momentsPartial) valueType() execute.DataType {
+	return execute.TFloat
+}
+func (a *momentsPartial) appendValue(b execute.BlockBuilder, j int) {
+	if a.n < 2 {
+		b.AppendFloat(j, math.NaN())
+		return
+	}
+	if a.skew {
+		b.AppendFloat(j, math.Sqrt(a.n)*a.m3/math.Pow(a.m2, 1.5))
+		return
+	}
+	b.AppendFloat(j, math.Sqrt(a.m2/(a.n-1)))
+}
+
+type spreadPartial struct {
+	typ execute.DataType
+	set bool
+
+	minInt, maxInt     int64
+	minUInt, maxUInt   uint64
+	minFloat, maxFloat float64
+}
+
+func (a *spreadPartial) stateLabels() []string {
+	return []string{"min", "max"}
+}
+func (a *spreadPartial) stateCols(typ execute.DataType) ([]execute.ColMeta, error) {
+	if !isNumeric(typ) {
+		return nil, fmt.Errorf("cannot compute the spread of values of type %v", typ)
+	}
+	a.typ = typ
+	return stateCols(a.stateLabels(), typ, typ), nil
+}
+func (a *spreadPartial) do(values execute.ValueIterator, typ execute.DataType) {
+	switch typ {
+	case execute.TInt:
+		values.DoInt(func(vs []int64, _ execute.RowReader) {
+			for _, v := range vs {
+				a.addInt(v, v)
+			}
+		})
+	case execute.TUInt:
+		values.DoUInt(func(vs []uint64, _ execute.RowReader) {
+			for _, v := range vs {
+				a.addUInt(v, v)
+			}
+		})
+	case execute.TFloat:
+		values.DoFloat(func(vs []float64, _ execute.RowReader) {
+			for _, v := range vs {
+				a.addFloat(v, v)
+			}
+		})
+	}
+}
+func (a *spreadPartial) addInt(min, max int64) {
+	if !a.set || min < a.minInt {
+		a.minInt = min
+	}
+	if !a.set || max > a.maxInt {
+		a.maxInt = max
+	}
+	a.set = true
+}
+func (a *spreadPartial) addUInt(min, max uint64) {
+	if !a.set || min < a.minUInt {
+		a.minUInt = min
+	}
+	if !a.set || max > a.maxUInt {
+		a.maxUInt = max
+	}
+	a.set = true
+}
+func (a *spreadPartial) addFloat(min, max float64) {
+	if !a.set || min < a.minFloat {
+		a.minFloat = min
+	}
+	if !a.set || max > a.maxFloat {
+		a.maxFloat = max
+	}
+	a.set = true
+}
+func (a *spreadPartial) appendState(b execute.BlockBuilder, cols []int) {
+	// The bounds of a part without values are unknown, they are null so that merging skips them.
+	if !a.set {
+		b.AppendNil(cols[0])
+		b.AppendNil(cols[1])
+		return
+	}
+	switch a.typ {
+	case execute.TInt:
+		b.AppendInt(cols[0], a.minInt)
+		b.AppendInt(cols[1], a.maxInt)
+	case execute.TUInt:
+		b.AppendUInt(cols[0], a.minUInt)
+		b.AppendUInt(cols[1], a.maxUInt)
+	case execute.TFloat:
+		b.AppendFloat(cols[0], a.minFloat)
+		b.AppendFloat(cols[1], a.maxFloat)
+	}
+}
+func (a *spreadPartial) merge(rr execute.RowReader, i int, cols []int) {
+	a.typ = rr.Cols()[cols[0]].Type
+	if rr.IsNull(i, cols[0]) || rr.IsNull(i, cols[1]) {
+		return
+	}
+	switch a.typ {
+	case execute.TInt:
+		a.addInt(rr.AtInt(i, cols[0]), rr.AtInt(i, cols[1]))
+	case execute.TUInt:
+		a.addUInt(rr.AtUInt(i, cols[0]), rr.AtUInt(i, cols[1]))
+	case execute.TFloat:
+		a.addFloat(rr.AtFloat(i, cols[0]), rr.AtFloat(i, cols[1]))
+	}
+}
+func (a *spreadPartial) valueType() execute.DataType {
+	return a.typ
+}
+func (a *spreadPartial) appendValue(b execute.BlockBuilder, j int) {
+	switch a.typ {
+	case execute.TInt:
+		b.AppendInt(j, a.maxInt-a.minInt)
+	case execute.TUInt:
+		b.AppendUInt(j, a.maxUInt-a.minUInt)
+	case execute.TFloat:
+		b.AppendFloat(j, a.maxFloat-a.minFloat)
+	}
+}
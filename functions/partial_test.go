@@ -0,0 +1,165 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/plan"
+)
+
+func TestPartial_Process(t *testing.T) {
+	executetest.ProcessTestHelper(
+		t,
+		[]execute.Block{&executetest.Block{
+			Bnds: execute.Bounds{Start: 0, Stop: 5},
+			ColMeta: []execute.ColMeta{
+				{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+				{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+			},
+			Data: [][]interface{}{
+				{execute.Time(1), 1.0, "a"},
+				{execute.Time(2), 4.0, "a"},
+			},
+		}},
+		[]*executetest.Block{{
+			Bnds: execute.Bounds{Start: 0, Stop: 10},
+			ColMeta: []execute.ColMeta{
+				{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+				{Label: "count", Type: execute.TInt, Kind: execute.ValueColKind},
+				{Label: "sum", Type: execute.TFloat, Kind: execute.ValueColKind},
+				{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+			},
+			Data: [][]interface{}{
+				{execute.Time(10), int64(2), 5.0, "a"},
+			},
+		}},
+		func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+			return functions.NewPartialTransformation(d, c, execute.Bounds{Start: 0, Stop: 10}, functions.MeanKind)
+		},
+	)
+}
+
+// TestCombine_Process checks that combining the partial aggregates of two parts of the data
+// produces the same result as aggregating all of the data.
+func TestCombine_Process(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 10, 12, 15, 15}
+	testCases := []struct {
+		kind plan.ProcedureKind
+		agg  execute.Aggregate
+	}{
+		{kind: functions.CountKind, agg: new(functions.CountAgg)},
+		{kind: functions.MeanKind, agg: new(functions.MeanAgg)},
+		{kind: functions.StddevKind, agg: new(functions.StddevAgg)},
+		{kind: functions.SkewKind, agg: new(functions.SkewAgg)},
+		{kind: functions.SpreadKind, agg: new(functions.SpreadAgg)},
+	}
+	bounds := execute.Bounds{Start: 0, Stop: 10}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(string(tc.kind), func(t *testing.T) {
+			vf := tc.agg.NewFloatAgg()
+			vf.DoFloat(data)
+			var want interface{}
+			switch vf.Type() {
+			case execute.TInt:
+				want = vf.(execute.IntValueFunc).ValueInt()
+			case execute.TFloat:
+				want = vf.(execute.FloatValueFunc).ValueFloat()
+			}
+
+			parents := []execute.DatasetID{executetest.RandomDatasetID(), executetest.RandomDatasetID()}
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			ct := functions.NewCombineTransformation(d, c, bounds, tc.kind, parents)
+
+			for i, part := range [][]float64{data[:3], data[3:]} {
+				pc := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+				pc.SetTriggerSpec(execute.DefaultTriggerSpec)
+				pt := functions.NewPartialTransformation(executetest.NewDataset(executetest.RandomDatasetID()), pc, bounds, tc.kind)
+
+				b := &executetest.Block{
+					Bnds: bounds,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+				}
+				for j, v := range part {
+					b.Data = append(b.Data, []interface{}{execute.Time(j), v})
+				}
+				if err := pt.Process(parents[i], b); err != nil {
+					t.Fatal(err)
+				}
+				for _, pb := range executetest.BlocksFromCache(pc) {
+					if err := ct.Process(parents[i], pb); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			got := executetest.BlocksFromCache(c)
+			if len(got) != 1 || len(got[0].Data) != 1 {
+				t.Fatalf("expected a single row, got %v", got)
+			}
+			if !cmp.Equal(want, got[0].Data[0][1], cmpopts.EquateApprox(0, 1e-9)) {
+				t.Errorf("unexpected value -want/+got\n%s", cmp.Diff(want, got[0].Data[0][1]))
+			}
+		})
+	}
+}
+
+// TestCombine_Process_EmptyPart checks that the partial spread of a part without values does not affect the spread.
+func TestCombine_Process_EmptyPart(t *testing.T) {
+	bounds := execute.Bounds{Start: 0, Stop: 10}
+	parents := []execute.DatasetID{executetest.RandomDatasetID(), executetest.RandomDatasetID()}
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	ct := functions.NewCombineTransformation(d, c, bounds, functions.SpreadKind, parents)
+
+	for i, part := range [][]float64{nil, {5, 7, 10}} {
+		pc := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+		pc.SetTriggerSpec(execute.DefaultTriggerSpec)
+		pt := functions.NewPartialTransformation(executetest.NewDataset(executetest.RandomDatasetID()), pc, bounds, functions.SpreadKind)
+
+		b := &executetest.Block{
+			Bnds: bounds,
+			ColMeta: []execute.ColMeta{
+				{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+				{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+			},
+		}
+		for j, v := range part {
+			b.Data = append(b.Data, []interface{}{execute.Time(j), v})
+		}
+		if err := pt.Process(parents[i], b); err != nil {
+			t.Fatal(err)
+		}
+		for _, pb := range executetest.BlocksFromCache(pc) {
+			if err := ct.Process(parents[i], pb); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	want := []*executetest.Block{{
+		Bnds: bounds,
+		ColMeta: []execute.ColMeta{
+			{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+			{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+		},
+		Data: [][]interface{}{
+			{execute.Time(10), 5.0},
+		},
+	}}
+	got := executetest.BlocksFromCache(c)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
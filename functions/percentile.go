@@ -3,7 +3,6 @@ package functions
 import (
 	"fmt"
 	"math"
-	"sort"
 
 	"github.com/influxdata/ifql/query"
 	"github.com/influxdata/ifql/query/execute"
@@ -39,7 +38,7 @@ func init() {
 var percentileBuiltin = `
 // median returns the 50th percentile.
 // By default an approximate percentile is computed, this can be disabled by passing exact:true.
-// Using the exact method requires that the entire data set can fit in memory.
+// Using the exact method requires that the entire data set fits in memory, unless a spill directory is configured.
 median = (exact=false, compression=0.0, table=<-) => percentile(table:table, p:0.5, exact:exact, compression:compression)
 `
 
@@ -153,9 +152,6 @@ func createPercentileTransformation(id execute.DatasetID, mode execute.Accumulat
 	return t, d, nil
 }
 
-func (a *PercentileAgg) reset() {
-	a.digest = tdigest.NewWithCompression(a.Compression)
-}
 func (a *PercentileAgg) NewBoolAgg() execute.DoBoolAgg {
 	return nil
 }
@@ -169,8 +165,11 @@ func (a *PercentileAgg) NewUIntAgg() execute.DoUIntAgg {
 }
 
 func (a *PercentileAgg) NewFloatAgg() execute.DoFloatAgg {
-	a.reset()
-	return a
+	return &PercentileAgg{
+		Quantile:    a.Quantile,
+		Compression: a.Compression,
+		digest:      tdigest.NewWithCompression(a.Compression),
+	}
 }
 
 func (a *PercentileAgg) NewStringAgg() execute.DoStringAgg {
@@ -190,10 +189,13 @@ func (a *PercentileAgg) ValueFloat() float64 {
 	return a.digest.Quantile(a.Quantile)
 }
 
+// ExactPercentileAgg computes the exact percentile of the values.
+// The values are spilled to disk as sorted runs when the query nears its memory quota, see execute.SortedFloats.
 type ExactPercentileAgg struct {
 	Quantile float64
 
-	data []float64
+	alloc *execute.Allocator
+	data  *execute.SortedFloats
 }
 
 func createExactPercentileTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
@@ -203,14 +205,12 @@ func createExactPercentileTransformation(id execute.DatasetID, mode execute.Accu
 	}
 	agg := &ExactPercentileAgg{
 		Quantile: ps.Percentile,
+		alloc:    a.Allocator(),
 	}
 	t, d := execute.NewAggregateTransformationAndDataset(id, mode, a.Bounds(), agg, a.Allocator())
 	return t, d, nil
 }
 
-func (a *ExactPercentileAgg) reset() {
-	a.data = a.data[0:0]
-}
 func (a *ExactPercentileAgg) NewBoolAgg() execute.DoBoolAgg {
 	return nil
 }
@@ -224,16 +224,23 @@ func (a *ExactPercentileAgg) NewUIntAgg() execute.DoUIntAgg {
 }
 
 func (a *ExactPercentileAgg) NewFloatAgg() execute.DoFloatAgg {
-	a.reset()
-	return a
+	return &ExactPercentileAgg{
+		Quantile: a.Quantile,
+		alloc:    a.alloc,
+		data:     execute.NewSortedFloats(a.alloc),
+	}
 }
 
 func (a *ExactPercentileAgg) NewStringAgg() execute.DoStringAgg {
 	return nil
 }
 
+// DoFloat adds the values. Errors spilling the values cannot be reported through the aggregate interface,
+// so they panic, which the executor reports as an error of the query.
 func (a *ExactPercentileAgg) DoFloat(vs []float64) {
-	a.data = append(a.data, vs...)
+	if err := a.data.Append(vs); err != nil {
+		panic(err)
+	}
 }
 
 func (a *ExactPercentileAgg) Type() execute.DataType {
@@ -241,20 +248,42 @@ func (a *ExactPercentileAgg) Type() execute.DataType {
 }
 
 func (a *ExactPercentileAgg) ValueFloat() float64 {
-	sort.Float64s(a.data)
-
-	x := a.Quantile * float64(len(a.data)-1)
+	n := a.data.Len()
+	if n == 0 {
+		return math.NaN()
+	}
+	x := a.Quantile * float64(n-1)
 	x0 := math.Floor(x)
 	x1 := math.Ceil(x)
 
+	// Read the sorted values up to the ones being interpolated.
+	var y0, y1 float64
+	i := 0
+	if err := a.data.Do(func(v float64) bool {
+		if i == int(x0) {
+			y0 = v
+		}
+		if i == int(x1) {
+			y1 = v
+			return false
+		}
+		i++
+		return true
+	}); err != nil {
+		panic(err)
+	}
+
 	if x0 == x1 {
-		return a.data[int(x0)]
+		return y0
 	}
 
 	// Linear interpolate
-	y0 := a.data[int(x0)]
-	y1 := a.data[int(x1)]
 	y := y0*(x1-x) + y1*(x-x0)
 
 	return y
 }
+
+// Release releases the values held in memory and on disk.
+func (a *ExactPercentileAgg) Release() {
+	a.data.Release()
+}
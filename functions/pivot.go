@@ -0,0 +1,398 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const PivotKind = "pivot"
+
+// PivotOpSpec turns the rows of blocks into columns.
+// The rows with the same values of the RowKey columns become a single row,
+// with a column for each distinct combination of values of the ColKey columns, holding the value of ValueCol.
+type PivotOpSpec struct {
+	RowKey   []string `json:"row_key"`
+	ColKey   []string `json:"col_key"`
+	ValueCol string   `json:"value_col"`
+}
+
+var pivotSignature = query.DefaultFunctionSignature()
+
+func init() {
+	pivotSignature.Params["rowKey"] = semantic.NewArrayType(semantic.String)
+	pivotSignature.Params["colKey"] = semantic.NewArrayType(semantic.String)
+	pivotSignature.Params["valueCol"] = semantic.String
+
+	query.RegisterFunction(PivotKind, createPivotOpSpec, pivotSignature)
+	query.RegisterOpSpec(PivotKind, newPivotOp)
+	plan.RegisterProcedureSpec(PivotKind, newPivotProcedure, PivotKind)
+	plan.RegisterRewriteRule(PivotGroupRewriteRule{})
+	execute.RegisterTransformation(PivotKind, createPivotTransformation)
+}
+
+func createPivotOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := &PivotOpSpec{
+		RowKey:   []string{execute.TimeColLabel},
+		ColKey:   []string{"_field"},
+		ValueCol: execute.DefaultValueColLabel,
+	}
+	if array, ok, err := args.GetArray("rowKey", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		spec.RowKey = array.AsStrings()
+	}
+	if array, ok, err := args.GetArray("colKey", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		spec.ColKey = array.AsStrings()
+	}
+	if col, ok, err := args.GetString("valueCol"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.ValueCol = col
+	}
+
+	if len(spec.RowKey) == 0 || len(spec.ColKey) == 0 {
+		return nil, errors.New("pivot rowKey and colKey must not be empty")
+	}
+	seen := make(map[string]bool, len(spec.RowKey)+len(spec.ColKey))
+	for _, k := range append(append([]string{spec.ValueCol}, spec.RowKey...), spec.ColKey...) {
+		if seen[k] {
+			return nil, fmt.Errorf("pivot column %q may only be used once in rowKey, colKey and valueCol", k)
+		}
+		seen[k] = true
+	}
+	return spec, nil
+}
+
+func newPivotOp() query.OperationSpec {
+	return new(PivotOpSpec)
+}
+
+func (s *PivotOpSpec) Kind() query.OperationKind {
+	return PivotKind
+}
+
+type PivotProcedureSpec struct {
+	RowKey   []string
+	ColKey   []string
+	ValueCol string
+}
+
+func newPivotProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*PivotOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &PivotProcedureSpec{
+		RowKey:   spec.RowKey,
+		ColKey:   spec.ColKey,
+		ValueCol: spec.ValueCol,
+	}, nil
+}
+
+func (s *PivotProcedureSpec) Kind() plan.ProcedureKind {
+	return PivotKind
+}
+func (s *PivotProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(PivotProcedureSpec)
+	ns.RowKey = make([]string, len(s.RowKey))
+	copy(ns.RowKey, s.RowKey)
+	ns.ColKey = make([]string, len(s.ColKey))
+	copy(ns.ColKey, s.ColKey)
+	ns.ValueCol = s.ValueCol
+	return ns
+}
+
+// PivotGroupRewriteRule groups the series read from storage by all tags except the column key,
+// when a pivot directly follows the read.
+// Storage then produces a block per output block of the pivot,
+// instead of a block per series that the pivot merges.
+type PivotGroupRewriteRule struct {
+}
+
+func (r PivotGroupRewriteRule) Root() plan.ProcedureKind {
+	return FromKind
+}
+
+func (r PivotGroupRewriteRule) Rewrite(pr *plan.Procedure, planner plan.PlanRewriter) error {
+	fromSpec := pr.Spec.(*FromProcedureSpec)
+	if fromSpec.GroupingSet || fromSpec.AggregateSet {
+		return nil
+	}
+
+	// Find a pivot following the read through procedures that do not depend on the grouping,
+	// each procedure on the path must be the only child of its parent.
+	child := pr
+	for {
+		if len(child.Children) != 1 {
+			return nil
+		}
+		child = child.Child(0)
+		switch child.Spec.Kind() {
+		case RangeKind, FilterKind:
+			continue
+		case PivotKind:
+		default:
+			return nil
+		}
+		break
+	}
+	pivotSpec := child.Spec.(*PivotProcedureSpec)
+
+	fromSpec.GroupingSet = true
+	fromSpec.GroupExcept = pivotSpec.ColKey
+	fromSpec.GroupKeep = pivotSpec.ColKey
+	return nil
+}
+
+func createPivotTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*PivotProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewPivotTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type pivotTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+	spec  PivotProcedureSpec
+
+	// keys are the labels of the row and column key columns.
+	keys   map[string]bool
+	groups map[execute.BlockKey]*pivotGroup
+}
+
+// pivotGroup indexes the rows and value columns of an output block.
+type pivotGroup struct {
+	rows map[string]int
+	cols map[string]int
+}
+
+func NewPivotTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *PivotProcedureSpec) *pivotTransformation {
+	keys := make(map[string]bool, len(spec.RowKey)+len(spec.ColKey))
+	for _, k := range spec.RowKey {
+		keys[k] = true
+	}
+	for _, k := range spec.ColKey {
+		keys[k] = true
+	}
+	return &pivotTransformation{
+		d:      d,
+		cache:  cache,
+		spec:   *spec,
+		keys:   keys,
+		groups: make(map[execute.BlockKey]*pivotGroup),
+	}
+}
+
+func (t *pivotTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	key := execute.ToBlockKey(meta)
+	delete(t.groups, key)
+	return t.d.RetractBlock(key)
+}
+
+// Process adds the rows of b to the output block of its group, the group of a block are its common tags except the column and row keys.
+// The output block has the row key columns, the group tags, and a column for each value of the column key in the order they are found.
+// The labels of the value columns are the values of the column key joined by an underscore.
+func (t *pivotTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	cols := b.Cols()
+	rowIdxs := make([]int, len(t.spec.RowKey))
+	for i, label := range t.spec.RowKey {
+		rowIdxs[i] = execute.ColIdx(label, cols)
+		if rowIdxs[i] < 0 {
+			return fmt.Errorf("pivot row key column %q does not exist", label)
+		}
+	}
+	colIdxs := make([]int, len(t.spec.ColKey))
+	for i, label := range t.spec.ColKey {
+		colIdxs[i] = execute.ColIdx(label, cols)
+		if colIdxs[i] < 0 {
+			return fmt.Errorf("pivot column key column %q does not exist", label)
+		}
+		if typ := cols[colIdxs[i]].Type; typ != execute.TString {
+			return fmt.Errorf("pivot column key column %q must be a string, got %v", label, typ)
+		}
+	}
+	valueIdx := execute.ColIdx(t.spec.ValueCol, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("pivot value column %q does not exist", t.spec.ValueCol)
+	}
+	valueType := cols[valueIdx].Type
+
+	tags := make(execute.Tags, len(b.Tags()))
+	for k, v := range b.Tags() {
+		if !t.keys[k] {
+			tags[k] = v
+		}
+	}
+	meta := blockMetadata{
+		bounds: b.Bounds(),
+		tags:   tags,
+	}
+	builder, new := t.cache.BlockBuilder(meta)
+	key := execute.ToBlockKey(meta)
+	g := t.groups[key]
+	if new || g == nil {
+		for _, idx := range rowIdxs {
+			c := cols[idx]
+			c.Common = false
+			builder.AddCol(c)
+		}
+		execute.AddTags(tags, builder)
+		g = &pivotGroup{
+			rows: make(map[string]int),
+			cols: make(map[string]int),
+		}
+		t.groups[key] = g
+	}
+	if builder.NRows() == 0 && len(g.rows) > 0 {
+		// The rows of the builder have been cleared since they were indexed.
+		g.rows = make(map[string]int)
+	}
+
+	var err error
+	values := make([]string, len(colIdxs))
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i := range ts {
+			if err != nil {
+				return
+			}
+			if rr.IsNull(i, valueIdx) {
+				continue
+			}
+
+			for k, j := range colIdxs {
+				values[k] = rr.AtString(i, j)
+			}
+			label := strings.Join(values, "_")
+			j, ok := g.cols[label]
+			if !ok {
+				if execute.ColIdx(label, builder.Cols()) >= 0 {
+					err = fmt.Errorf("pivot column %q already exists", label)
+					return
+				}
+				j = builder.AddCol(execute.ColMeta{
+					Label: label,
+					Type:  valueType,
+					Kind:  execute.ValueColKind,
+				})
+				for n := builder.NRows(); n > 0; n-- {
+					builder.AppendNil(j)
+				}
+				g.cols[label] = j
+			} else if typ := builder.Cols()[j].Type; typ != valueType {
+				err = fmt.Errorf("pivot column %q has values of type %v and %v", label, typ, valueType)
+				return
+			}
+
+			rowKey := pivotRowKey(rr, i, rowIdxs)
+			r, ok := g.rows[rowKey]
+			if !ok {
+				r = t.appendRow(builder, rr, i, rowIdxs)
+				g.rows[rowKey] = r
+			}
+			switch valueType {
+			case execute.TBool:
+				builder.SetBool(r, j, rr.AtBool(i, valueIdx))
+			case execute.TInt:
+				builder.SetInt(r, j, rr.AtInt(i, valueIdx))
+			case execute.TUInt:
+				builder.SetUInt(r, j, rr.AtUInt(i, valueIdx))
+			case execute.TFloat:
+				builder.SetFloat(r, j, rr.AtFloat(i, valueIdx))
+			case execute.TString:
+				builder.SetString(r, j, rr.AtString(i, valueIdx))
+			case execute.TTime:
+				builder.SetTime(r, j, rr.AtTime(i, valueIdx))
+			}
+		}
+	})
+	return err
+}
+
+// appendRow appends a row with the row key of row i, the group tags and null values, and returns its index.
+func (t *pivotTransformation) appendRow(builder execute.BlockBuilder, rr execute.RowReader, i int, rowIdxs []int) int {
+	for j, c := range builder.Cols() {
+		switch {
+		case j < len(rowIdxs):
+			if rr.IsNull(i, rowIdxs[j]) {
+				builder.AppendNil(j)
+				continue
+			}
+			switch c.Type {
+			case execute.TBool:
+				builder.AppendBool(j, rr.AtBool(i, rowIdxs[j]))
+			case execute.TInt:
+				builder.AppendInt(j, rr.AtInt(i, rowIdxs[j]))
+			case execute.TUInt:
+				builder.AppendUInt(j, rr.AtUInt(i, rowIdxs[j]))
+			case execute.TFloat:
+				builder.AppendFloat(j, rr.AtFloat(i, rowIdxs[j]))
+			case execute.TString:
+				builder.AppendString(j, rr.AtString(i, rowIdxs[j]))
+			case execute.TTime:
+				builder.AppendTime(j, rr.AtTime(i, rowIdxs[j]))
+			}
+		case c.Common:
+			// Common columns hold a single value for all rows.
+		default:
+			builder.AppendNil(j)
+		}
+	}
+	return builder.NRows() - 1
+}
+
+// pivotRowKey encodes the values of the row key columns of row i.
+func pivotRowKey(rr execute.RowReader, i int, rowIdxs []int) string {
+	var buf []byte
+	for _, j := range rowIdxs {
+		if rr.IsNull(i, j) {
+			buf = append(buf, 'n')
+			continue
+		}
+		buf = append(buf, 'v')
+		switch rr.Cols()[j].Type {
+		case execute.TBool:
+			buf = strconv.AppendBool(buf, rr.AtBool(i, j))
+		case execute.TInt:
+			buf = strconv.AppendInt(buf, rr.AtInt(i, j), 10)
+		case execute.TUInt:
+			buf = strconv.AppendUint(buf, rr.AtUInt(i, j), 10)
+		case execute.TFloat:
+			buf = strconv.AppendFloat(buf, rr.AtFloat(i, j), 'g', -1, 64)
+		case execute.TString:
+			buf = strconv.AppendQuote(buf, rr.AtString(i, j))
+		case execute.TTime:
+			buf = strconv.AppendInt(buf, int64(rr.AtTime(i, j)), 10)
+		}
+		buf = append(buf, ',')
+	}
+	return string(buf)
+}
+
+func (t *pivotTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *pivotTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *pivotTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
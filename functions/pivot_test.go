@@ -0,0 +1,398 @@
+package functions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestPivot_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(db:"mydb") |> pivot()`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "pivot1",
+						Spec: &functions.PivotOpSpec{
+							RowKey:   []string{"_time"},
+							ColKey:   []string{"_field"},
+							ValueCol: "_value",
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "pivot1"},
+				},
+			},
+		},
+		{
+			Name: "keys",
+			Raw:  `from(db:"mydb") |> pivot(rowKey:["_time", "host"], colKey:["_measurement", "_field"], valueCol:"v")`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "pivot1",
+						Spec: &functions.PivotOpSpec{
+							RowKey:   []string{"_time", "host"},
+							ColKey:   []string{"_measurement", "_field"},
+							ValueCol: "v",
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "pivot1"},
+				},
+			},
+		},
+		{
+			Name:    "empty column key",
+			Raw:     `from(db:"mydb") |> pivot(colKey:[])`,
+			WantErr: true,
+		},
+		{
+			Name:    "value column in row key",
+			Raw:     `from(db:"mydb") |> pivot(rowKey:["_time", "_value"])`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestPivotOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"pivot","kind":"pivot","spec":{"row_key":["_time"],"col_key":["_field"],"value_col":"_value"}}`)
+	op := &query.Operation{
+		ID: "pivot",
+		Spec: &functions.PivotOpSpec{
+			RowKey:   []string{"_time"},
+			ColKey:   []string{"_field"},
+			ValueCol: "_value",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestPivot_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+		s := functions.NewPivotTransformation(
+			d,
+			c,
+			&functions.PivotProcedureSpec{
+				RowKey:   []string{"_time"},
+				ColKey:   []string{"_field"},
+				ValueCol: "_value",
+			},
+		)
+		return s
+	})
+}
+
+func TestPivot_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.PivotProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "fields of a series",
+			spec: &functions.PivotProcedureSpec{
+				RowKey:   []string{"_time"},
+				ColKey:   []string{"_field"},
+				ValueCol: "_value",
+			},
+			data: []execute.Block{
+				&executetest.Block{
+					Bnds: execute.Bounds{
+						Start: 1,
+						Stop:  4,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "_field", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0, "usage_user", "a"},
+						{execute.Time(2), 1.0, "usage_user", "a"},
+						{execute.Time(3), 3.0, "usage_user", "a"},
+					},
+				},
+				&executetest.Block{
+					Bnds: execute.Bounds{
+						Start: 1,
+						Stop:  4,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "_field", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 97.0, "usage_idle", "a"},
+						{execute.Time(3), 96.0, "usage_idle", "a"},
+					},
+				},
+			},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  4,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "usage_user", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "usage_idle", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 2.0, 97.0},
+					{execute.Time(2), "a", 1.0, nil},
+					{execute.Time(3), "a", 3.0, 96.0},
+				},
+			}},
+		},
+		{
+			name: "groups and types",
+			spec: &functions.PivotProcedureSpec{
+				RowKey:   []string{"_time"},
+				ColKey:   []string{"_field"},
+				ValueCol: "_value",
+			},
+			data: []execute.Block{
+				&executetest.Block{
+					Bnds: execute.Bounds{
+						Start: 1,
+						Stop:  3,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+						{Label: "_field", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), int64(5), "count", "a"},
+						{execute.Time(2), nil, "count", "a"},
+					},
+				},
+				&executetest.Block{
+					Bnds: execute.Bounds{
+						Start: 1,
+						Stop:  3,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TString, Kind: execute.ValueColKind},
+						{Label: "_field", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), "ok", "status", "a"},
+					},
+				},
+				&executetest.Block{
+					Bnds: execute.Bounds{
+						Start: 1,
+						Stop:  3,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+						{Label: "_field", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), int64(7), "count", "b"},
+					},
+				},
+			},
+			want: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{
+						Start: 1,
+						Stop:  3,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "count", Type: execute.TInt, Kind: execute.ValueColKind},
+						{Label: "status", Type: execute.TString, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "a", int64(5), nil},
+						{execute.Time(2), "a", nil, "ok"},
+					},
+				},
+				{
+					Bnds: execute.Bounds{
+						Start: 1,
+						Stop:  3,
+					},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "count", Type: execute.TInt, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "b", int64(7)},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple keys",
+			spec: &functions.PivotProcedureSpec{
+				RowKey:   []string{"_time", "cpu"},
+				ColKey:   []string{"_measurement", "_field"},
+				ValueCol: "_value",
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "_field", Type: execute.TString, Kind: execute.TagColKind},
+					{Label: "_measurement", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "cpu", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "user", "cpu", "cpu0"},
+					{execute.Time(1), 2.0, "user", "cpu", "cpu1"},
+					{execute.Time(1), 3.0, "idle", "cpu", "cpu0"},
+					{execute.Time(2), 4.0, "idle", "cpu", "cpu1"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 1,
+					Stop:  3,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "cpu", Type: execute.TString, Kind: execute.TagColKind},
+					{Label: "cpu_user", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "cpu_idle", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "cpu0", 1.0, 3.0},
+					{execute.Time(1), "cpu1", 2.0, nil},
+					{execute.Time(2), "cpu1", nil, 4.0},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewPivotTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
+
+func TestPivotGroupRewriteRule(t *testing.T) {
+	testCases := []struct {
+		name string
+		q    string
+		want *functions.FromProcedureSpec
+	}{
+		{
+			name: "pivot after read",
+			q:    `from(db:"mydb") |> range(start:-1h) |> filter(fn: (r) => r._measurement == "cpu") |> pivot()`,
+			want: &functions.FromProcedureSpec{
+				GroupingSet: true,
+				GroupExcept: []string{"_field"},
+				GroupKeep:   []string{"_field"},
+			},
+		},
+		{
+			name: "grouped read",
+			q:    `from(db:"mydb") |> range(start:-1h) |> group(by:["host"]) |> pivot()`,
+			want: &functions.FromProcedureSpec{
+				GroupingSet: true,
+				GroupKeys:   []string{"host"},
+			},
+		},
+		{
+			name: "pivot after transformation",
+			q:    `from(db:"mydb") |> range(start:-1h) |> cumulativeSum() |> pivot()`,
+			want: &functions.FromProcedureSpec{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := query.Compile(context.Background(), tc.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lp, err := plan.NewLogicalPlanner().Plan(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pp, err := plan.NewPlanner().Plan(lp, nil, time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got *functions.FromProcedureSpec
+			pp.Do(func(pr *plan.Procedure) {
+				if s, ok := pr.Spec.(*functions.FromProcedureSpec); ok {
+					got = s
+				}
+			})
+			if got == nil {
+				t.Fatal("missing from procedure")
+			}
+			// Only compare the grouping of the read.
+			g := &functions.FromProcedureSpec{
+				GroupingSet: got.GroupingSet,
+				MergeAll:    got.MergeAll,
+				GroupKeys:   got.GroupKeys,
+				GroupExcept: got.GroupExcept,
+				GroupKeep:   got.GroupKeep,
+			}
+			if !cmp.Equal(tc.want, g) {
+				t.Errorf("unexpected from spec -want/+got:\n%s", cmp.Diff(tc.want, g))
+			}
+		})
+	}
+}
@@ -112,3 +112,6 @@ func (s *RangeProcedureSpec) PushDown(root *plan.Procedure, dup func() *plan.Pro
 func (s *RangeProcedureSpec) TimeBounds() plan.BoundsSpec {
 	return s.Bounds
 }
+func (s *RangeProcedureSpec) SetTimeBounds(bounds plan.BoundsSpec) {
+	s.Bounds = bounds
+}
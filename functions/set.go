@@ -171,6 +171,10 @@ func (t *setTransformation) Process(id execute.DatasetID, b execute.Block) error
 				continue
 			}
 			for i := range ts {
+				if j != setIdx && rr.IsNull(i, j) {
+					builder.AppendNil(j)
+					continue
+				}
 				switch c.Type {
 				case execute.TBool:
 					builder.AppendBool(j, rr.AtBool(i, j))
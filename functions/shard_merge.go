@@ -0,0 +1,267 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+)
+
+// ShardMergeKind is the kind of the procedure that merges the results of a read that the planner split by shard.
+const ShardMergeKind = "shard-merge"
+
+func init() {
+	execute.RegisterTransformation(ShardMergeKind, createShardMergeTransformation)
+}
+
+// ShardMergeProcedureSpec merges the results of reads of separate shards into the result of a single read.
+type ShardMergeProcedureSpec struct {
+	// Bounds are the bounds of the read before it was split.
+	Bounds plan.BoundsSpec
+	// AggregateMethod is the aggregate that was pushed down into the reads.
+	// Partial counts and sums are merged by adding them.
+	AggregateMethod string
+}
+
+func (s *ShardMergeProcedureSpec) Kind() plan.ProcedureKind {
+	return ShardMergeKind
+}
+func (s *ShardMergeProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ShardMergeProcedureSpec)
+	*ns = *s
+	return ns
+}
+func (s *ShardMergeProcedureSpec) TimeBounds() plan.BoundsSpec {
+	return s.Bounds
+}
+
+func createShardMergeTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ShardMergeProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	stop := s.Bounds.Stop
+	if stop.IsZero() {
+		stop = query.Now
+	}
+	bounds := execute.Bounds{
+		Start: a.ResolveTime(s.Bounds.Start),
+		Stop:  a.ResolveTime(stop),
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewShardMergeTransformation(d, cache, bounds, s.AggregateMethod != "", a.Parents())
+	return t, d, nil
+}
+
+type shardMergeTransformation struct {
+	mu sync.Mutex
+
+	d      execute.Dataset
+	cache  execute.BlockBuilderCache
+	bounds execute.Bounds
+
+	// aggregate indicates the blocks contain partial aggregates.
+	aggregate bool
+	// partials holds the running totals of the partial aggregates of each block.
+	partials map[execute.BlockKey][]interface{}
+	// unsorted holds the builders with rows appended since they were last sorted.
+	// The shards are read concurrently, so the rows are sorted by time before the dataset may trigger.
+	unsorted map[execute.BlockKey]execute.BlockBuilder
+
+	parentState map[execute.DatasetID]*mergeJoinParentState
+}
+
+func NewShardMergeTransformation(d execute.Dataset, cache execute.BlockBuilderCache, bounds execute.Bounds, aggregate bool, parents []execute.DatasetID) *shardMergeTransformation {
+	t := &shardMergeTransformation{
+		d:           d,
+		cache:       cache,
+		bounds:      bounds,
+		aggregate:   aggregate,
+		partials:    make(map[execute.BlockKey][]interface{}),
+		unsorted:    make(map[execute.BlockKey]execute.BlockBuilder),
+		parentState: make(map[execute.DatasetID]*mergeJoinParentState, len(parents)),
+	}
+	for _, id := range parents {
+		t.parentState[id] = new(mergeJoinParentState)
+	}
+	return t
+}
+
+func (t *shardMergeTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := execute.ToBlockKey(blockMetadata{
+		tags:   meta.Tags(),
+		bounds: t.bounds,
+	})
+	delete(t.partials, key)
+	delete(t.unsorted, key)
+	return t.d.RetractBlock(key)
+}
+
+func (t *shardMergeTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	meta := blockMetadata{
+		tags:   b.Tags(),
+		bounds: t.bounds,
+	}
+	key := execute.ToBlockKey(meta)
+	builder, new := t.cache.BlockBuilder(meta)
+	if new {
+		execute.AddBlockCols(b, builder)
+	}
+	if t.aggregate {
+		return t.mergePartials(key, b, builder)
+	}
+
+	colMap := execute.AddNewCols(b, builder)
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i := range ts {
+			execute.AppendRow(i, rr, builder, colMap)
+		}
+	})
+	t.unsorted[key] = builder
+	return nil
+}
+
+// sortBlocks sorts the rows of the builders appended to since they were last sorted by time.
+func (t *shardMergeTransformation) sortBlocks() {
+	for key, builder := range t.unsorted {
+		builder.Sort([]string{execute.TimeColLabel}, false)
+		delete(t.unsorted, key)
+	}
+}
+
+// mergePartials adds the partial aggregates of the block to the single row of the builder.
+func (t *shardMergeTransformation) mergePartials(key execute.BlockKey, b execute.Block, builder execute.BlockBuilder) error {
+	cols := builder.Cols()
+	partials, ok := t.partials[key]
+	if !ok {
+		partials = make([]interface{}, len(cols))
+		for j, c := range cols {
+			switch c.Kind {
+			case execute.TimeColKind:
+				builder.AppendTime(j, t.bounds.Stop)
+			case execute.TagColKind:
+				if !c.Common {
+					return fmt.Errorf("cannot merge partial aggregates of grouped column %q", c.Label)
+				}
+			case execute.ValueColKind:
+				switch c.Type {
+				case execute.TInt:
+					partials[j] = int64(0)
+					builder.AppendInt(j, 0)
+				case execute.TUInt:
+					partials[j] = uint64(0)
+					builder.AppendUInt(j, 0)
+				case execute.TFloat:
+					partials[j] = float64(0)
+					builder.AppendFloat(j, 0)
+				default:
+					return fmt.Errorf("cannot merge partial aggregates of type %v", c.Type)
+				}
+			}
+		}
+		t.partials[key] = partials
+	}
+
+	for j, c := range cols {
+		if c.Kind != execute.ValueColKind {
+			continue
+		}
+		bj := execute.ColIdx(c.Label, b.Cols())
+		if bj < 0 {
+			continue
+		}
+		values := b.Col(bj)
+		switch c.Type {
+		case execute.TInt:
+			sum := partials[j].(int64)
+			values.DoInt(func(vs []int64, _ execute.RowReader) {
+				for _, v := range vs {
+					sum += v
+				}
+			})
+			partials[j] = sum
+			builder.SetInt(0, j, sum)
+		case execute.TUInt:
+			sum := partials[j].(uint64)
+			values.DoUInt(func(vs []uint64, _ execute.RowReader) {
+				for _, v := range vs {
+					sum += v
+				}
+			})
+			partials[j] = sum
+			builder.SetUInt(0, j, sum)
+		case execute.TFloat:
+			sum := partials[j].(float64)
+			values.DoFloat(func(vs []float64, _ execute.RowReader) {
+				for _, v := range vs {
+					sum += v
+				}
+			})
+			partials[j] = sum
+			builder.SetFloat(0, j, sum)
+		}
+	}
+	return nil
+}
+
+func (t *shardMergeTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.parentState[id].mark = mark
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.mark < min {
+			min = state.mark
+		}
+	}
+
+	t.sortBlocks()
+	return t.d.UpdateWatermark(min)
+}
+
+func (t *shardMergeTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.parentState[id].processing = pt
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.processing < min {
+			min = state.processing
+		}
+	}
+
+	t.sortBlocks()
+	return t.d.UpdateProcessingTime(min)
+}
+
+func (t *shardMergeTransformation) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		t.d.Finish(err)
+		return
+	}
+
+	t.parentState[id].finished = true
+	finished := true
+	for _, state := range t.parentState {
+		finished = finished && state.finished
+	}
+
+	if finished {
+		t.sortBlocks()
+		t.d.Finish(nil)
+	}
+}
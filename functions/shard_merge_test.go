@@ -0,0 +1,165 @@
+package functions_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/plan/plantest"
+)
+
+func TestShardMerge_Process(t *testing.T) {
+	parentID0 := execute.DatasetID(plantest.RandomProcedureID())
+	parentID1 := execute.DatasetID(plantest.RandomProcedureID())
+	bounds := execute.Bounds{
+		Start: 0,
+		Stop:  10,
+	}
+	testCases := []struct {
+		name      string
+		aggregate bool
+		data0     []*executetest.Block
+		data1     []*executetest.Block
+		want      []*executetest.Block
+	}{
+		{
+			name: "raw",
+			data0: []*executetest.Block{{
+				Bnds: execute.Bounds{Start: 0, Stop: 5},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a"},
+					{execute.Time(3), 3.0, "a"},
+				},
+			}},
+			data1: []*executetest.Block{
+				{
+					Bnds: execute.Bounds{Start: 5, Stop: 10},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(5), 5.0, "a"},
+					},
+				},
+				{
+					Bnds: execute.Bounds{Start: 5, Stop: 10},
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(7), 7.0, "b"},
+					},
+				},
+			},
+			want: []*executetest.Block{
+				{
+					Bnds: bounds,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a"},
+						{execute.Time(3), 3.0, "a"},
+						{execute.Time(5), 5.0, "a"},
+					},
+				},
+				{
+					Bnds: bounds,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(7), 7.0, "b"},
+					},
+				},
+			},
+		},
+		{
+			name:      "partial counts",
+			aggregate: true,
+			data0: []*executetest.Block{{
+				Bnds: execute.Bounds{Start: 0, Stop: 5},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(5), int64(4), "a"},
+				},
+			}},
+			data1: []*executetest.Block{{
+				Bnds: execute.Bounds{Start: 5, Stop: 10},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), int64(3), "a"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: bounds,
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), int64(7), "a"},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parents := []execute.DatasetID{parentID0, parentID1}
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			st := functions.NewShardMergeTransformation(d, c, bounds, tc.aggregate, parents)
+
+			for _, b := range tc.data1 {
+				if err := st.Process(parentID1, b); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, b := range tc.data0 {
+				if err := st.Process(parentID0, b); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// The rows are sorted once every shard has finished.
+			st.Finish(parentID1, nil)
+			st.Finish(parentID0, nil)
+
+			got := executetest.BlocksFromCache(c)
+
+			sort.Sort(executetest.SortedBlocks(got))
+			sort.Sort(executetest.SortedBlocks(tc.want))
+
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
@@ -128,6 +128,10 @@ func (t *shiftTransformation) Process(id execute.DatasetID, b execute.Block) err
 				continue
 			}
 			for i := range ts {
+				if rr.IsNull(i, j) {
+					builder.AppendNil(j)
+					continue
+				}
 				switch c.Type {
 				case execute.TBool:
 					builder.AppendBool(j, rr.AtBool(i, j))
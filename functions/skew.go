@@ -51,6 +51,13 @@ func (s *SkewProcedureSpec) Copy() plan.ProcedureSpec {
 	return new(SkewProcedureSpec)
 }
 
+func (s *SkewProcedureSpec) PartialSpec() plan.ProcedureSpec {
+	return &PartialProcedureSpec{AggregateKind: SkewKind}
+}
+func (s *SkewProcedureSpec) CombineSpec() plan.ProcedureSpec {
+	return &CombineProcedureSpec{AggregateKind: SkewKind}
+}
+
 type SkewAgg struct {
 	n, m1, m2, m3 float64
 }
@@ -60,29 +67,20 @@ func createSkewTransformation(id execute.DatasetID, mode execute.AccumulationMod
 	return t, d, nil
 }
 
-func (a *SkewAgg) reset() {
-	a.n = 0
-	a.m1 = 0
-	a.m2 = 0
-	a.m3 = 0
-}
 func (a *SkewAgg) NewBoolAgg() execute.DoBoolAgg {
 	return nil
 }
 
 func (a *SkewAgg) NewIntAgg() execute.DoIntAgg {
-	a.reset()
-	return a
+	return new(SkewAgg)
 }
 
 func (a *SkewAgg) NewUIntAgg() execute.DoUIntAgg {
-	a.reset()
-	return a
+	return new(SkewAgg)
 }
 
 func (a *SkewAgg) NewFloatAgg() execute.DoFloatAgg {
-	a.reset()
-	return a
+	return new(SkewAgg)
 }
 
 func (a *SkewAgg) NewStringAgg() execute.DoStringAgg {
@@ -96,6 +96,8 @@ func createSortTransformation(id execute.DatasetID, mode execute.AccumulationMod
 		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
 	}
 	cache := execute.NewBlockBuilderCache(a.Allocator())
+	// Data spilled to disk is sorted, so that it can be merged.
+	cache.SetSortOrder(s.Cols, s.Desc)
 	d := execute.NewDataset(id, mode, cache)
 	t := NewSortTransformation(d, cache, s)
 	return t, d, nil
@@ -125,6 +127,9 @@ func (t *sortTransformation) RetractBlock(id execute.DatasetID, meta execute.Blo
 }
 
 func (t *sortTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	if err := execute.SpillIfNeeded(t.cache); err != nil {
+		return err
+	}
 	builder, new := t.cache.BlockBuilder(b)
 	if new {
 		execute.AddBlockCols(b, builder)
@@ -60,6 +60,13 @@ func (s *SpreadProcedureSpec) Copy() plan.ProcedureSpec {
 	return new(SpreadProcedureSpec)
 }
 
+func (s *SpreadProcedureSpec) PartialSpec() plan.ProcedureSpec {
+	return &PartialProcedureSpec{AggregateKind: SpreadKind}
+}
+func (s *SpreadProcedureSpec) CombineSpec() plan.ProcedureSpec {
+	return &CombineProcedureSpec{AggregateKind: SpreadKind}
+}
+
 func createSpreadTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	t, d := execute.NewAggregateTransformationAndDataset(id, mode, a.Bounds(), new(SpreadAgg), a.Allocator())
 	return t, d, nil
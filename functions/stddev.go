@@ -51,6 +51,13 @@ func (s *StddevProcedureSpec) Copy() plan.ProcedureSpec {
 	return new(StddevProcedureSpec)
 }
 
+func (s *StddevProcedureSpec) PartialSpec() plan.ProcedureSpec {
+	return &PartialProcedureSpec{AggregateKind: StddevKind}
+}
+func (s *StddevProcedureSpec) CombineSpec() plan.ProcedureSpec {
+	return &CombineProcedureSpec{AggregateKind: StddevKind}
+}
+
 type StddevAgg struct {
 	n, m2, mean float64
 }
@@ -60,28 +67,20 @@ func createStddevTransformation(id execute.DatasetID, mode execute.AccumulationM
 	return t, d, nil
 }
 
-func (a *StddevAgg) reset() {
-	a.n = 0
-	a.mean = 0
-	a.m2 = 0
-}
 func (a *StddevAgg) NewBoolAgg() execute.DoBoolAgg {
 	return nil
 }
 
 func (a *StddevAgg) NewIntAgg() execute.DoIntAgg {
-	a.reset()
-	return a
+	return new(StddevAgg)
 }
 
 func (a *StddevAgg) NewUIntAgg() execute.DoUIntAgg {
-	a.reset()
-	return a
+	return new(StddevAgg)
 }
 
 func (a *StddevAgg) NewFloatAgg() execute.DoFloatAgg {
-	a.reset()
-	return a
+	return new(StddevAgg)
 }
 
 func (a *StddevAgg) NewStringAgg() execute.DoStringAgg {
@@ -0,0 +1,271 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const TimedMovingAverageKind = "timedMovingAverage"
+
+type TimedMovingAverageOpSpec struct {
+	Every  query.Duration `json:"every"`
+	Period query.Duration `json:"period"`
+}
+
+var timedMovingAverageSignature = query.DefaultFunctionSignature()
+
+func init() {
+	timedMovingAverageSignature.Params["every"] = semantic.Duration
+	timedMovingAverageSignature.Params["period"] = semantic.Duration
+
+	query.RegisterFunction(TimedMovingAverageKind, createTimedMovingAverageOpSpec, timedMovingAverageSignature)
+	query.RegisterOpSpec(TimedMovingAverageKind, newTimedMovingAverageOp)
+	plan.RegisterProcedureSpec(TimedMovingAverageKind, newTimedMovingAverageProcedure, TimedMovingAverageKind)
+	execute.RegisterTransformation(TimedMovingAverageKind, createTimedMovingAverageTransformation)
+}
+
+func createTimedMovingAverageOpSpec(args query.Arguments, a *query.Administration) (query.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(TimedMovingAverageOpSpec)
+	every, err := args.GetRequiredDuration("every")
+	if err != nil {
+		return nil, err
+	}
+	spec.Every = every
+
+	if period, ok, err := args.GetDuration("period"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Period = period
+	} else {
+		spec.Period = spec.Every
+	}
+
+	if spec.Every <= 0 || spec.Period <= 0 {
+		return nil, errors.New("timedMovingAverage every and period must be positive")
+	}
+	return spec, nil
+}
+
+func newTimedMovingAverageOp() query.OperationSpec {
+	return new(TimedMovingAverageOpSpec)
+}
+
+func (s *TimedMovingAverageOpSpec) Kind() query.OperationKind {
+	return TimedMovingAverageKind
+}
+
+type TimedMovingAverageProcedureSpec struct {
+	Every  query.Duration `json:"every"`
+	Period query.Duration `json:"period"`
+}
+
+func newTimedMovingAverageProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TimedMovingAverageOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &TimedMovingAverageProcedureSpec{
+		Every:  spec.Every,
+		Period: spec.Period,
+	}, nil
+}
+
+func (s *TimedMovingAverageProcedureSpec) Kind() plan.ProcedureKind {
+	return TimedMovingAverageKind
+}
+func (s *TimedMovingAverageProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(TimedMovingAverageProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createTimedMovingAverageTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*TimedMovingAverageProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewBlockBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewTimedMovingAverageTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type timedMovingAverageTransformation struct {
+	d     execute.Dataset
+	cache execute.BlockBuilderCache
+
+	every  execute.Duration
+	period execute.Duration
+}
+
+func NewTimedMovingAverageTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *TimedMovingAverageProcedureSpec) *timedMovingAverageTransformation {
+	return &timedMovingAverageTransformation{
+		d:      d,
+		cache:  cache,
+		every:  execute.Duration(spec.Every),
+		period: execute.Duration(spec.Period),
+	}
+}
+
+func (t *timedMovingAverageTransformation) RetractBlock(id execute.DatasetID, meta execute.BlockMetadata) error {
+	return t.d.RetractBlock(execute.ToBlockKey(meta))
+}
+
+// timedSeries are the non null values of a column ordered by time.
+type timedSeries struct {
+	times  []execute.Time
+	values []float64
+	// sums are the cumulative sums of the values, sums[i] is the sum of the first i values.
+	sums []float64
+}
+
+func (s *timedSeries) Len() int           { return len(s.times) }
+func (s *timedSeries) Less(i, j int) bool { return s.times[i] < s.times[j] }
+func (s *timedSeries) Swap(i, j int) {
+	s.times[i], s.times[j] = s.times[j], s.times[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+func (s *timedSeries) add(t execute.Time, v float64) {
+	s.times = append(s.times, t)
+	s.values = append(s.values, v)
+}
+
+// sort orders the values by time and computes their cumulative sums.
+func (s *timedSeries) sort() {
+	sort.Stable(s)
+	s.sums = make([]float64, len(s.values)+1)
+	for i, v := range s.values {
+		s.sums[i+1] = s.sums[i] + v
+	}
+}
+
+// mean returns the mean of the values with times in [start, stop).
+func (s *timedSeries) mean(start, stop execute.Time) (float64, bool) {
+	lo := sort.Search(len(s.times), func(i int) bool { return s.times[i] >= start })
+	hi := sort.Search(len(s.times), func(i int) bool { return s.times[i] >= stop })
+	if lo == hi {
+		return 0, false
+	}
+	return (s.sums[hi] - s.sums[lo]) / float64(hi-lo), true
+}
+
+// Process averages the numeric value columns over windows of length period, that stop every duration.
+// The windows stop at multiples of every and the output has a row for every window containing values,
+// at the stop time of the window limited to the stop of the block bounds.
+// Only the time, common tag and numeric value columns are part of the output.
+func (t *timedMovingAverageTransformation) Process(id execute.DatasetID, b execute.Block) error {
+	builder, new := t.cache.BlockBuilder(b)
+	cols := b.Cols()
+	if new {
+		for _, c := range cols {
+			switch {
+			case c.IsTime():
+				builder.AddCol(c)
+			case c.IsTag() && c.Common:
+				builder.AddCol(c)
+				builder.SetCommonString(builder.NCols()-1, b.Tags()[c.Label])
+			case isNumericValueCol(c):
+				c.Type = execute.TFloat
+				builder.AddCol(c)
+			}
+		}
+	}
+
+	series := make([]*timedSeries, len(cols))
+	for j, c := range cols {
+		if isNumericValueCol(c) {
+			series[j] = &timedSeries{}
+		}
+	}
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		for i, tm := range ts {
+			for j, s := range series {
+				if s == nil || rr.IsNull(i, j) {
+					continue
+				}
+				var v float64
+				switch cols[j].Type {
+				case execute.TInt:
+					v = float64(rr.AtInt(i, j))
+				case execute.TUInt:
+					v = float64(rr.AtUInt(i, j))
+				case execute.TFloat:
+					v = rr.AtFloat(i, j)
+				}
+				s.add(tm, v)
+			}
+		}
+	})
+	for _, s := range series {
+		if s == nil {
+			continue
+		}
+		s.sort()
+	}
+
+	outCols := builder.Cols()
+	timeIdx := execute.TimeIdx(outCols)
+	valueIdxs := make([]int, len(cols))
+	for j, s := range series {
+		if s != nil {
+			valueIdxs[j] = execute.ColIdx(cols[j].Label, outCols)
+		}
+	}
+
+	bounds := b.Bounds()
+	means := make([]float64, len(cols))
+	found := make([]bool, len(cols))
+	for stop := bounds.Start.Truncate(t.every).Add(t.every); stop.Add(-t.every) < bounds.Stop; stop = stop.Add(t.every) {
+		start := stop.Add(-t.period)
+		hasValues := false
+		for j, s := range series {
+			if s == nil {
+				continue
+			}
+			means[j], found[j] = s.mean(start, stop)
+			hasValues = hasValues || found[j]
+		}
+		if !hasValues {
+			continue
+		}
+
+		tm := stop
+		if tm > bounds.Stop {
+			tm = bounds.Stop
+		}
+		builder.AppendTime(timeIdx, tm)
+		for j, s := range series {
+			if s == nil {
+				continue
+			}
+			if found[j] {
+				builder.AppendFloat(valueIdxs[j], means[j])
+			} else {
+				builder.AppendNil(valueIdxs[j])
+			}
+		}
+	}
+	return nil
+}
+
+func (t *timedMovingAverageTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *timedMovingAverageTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *timedMovingAverageTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,207 @@
+package functions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/querytest"
+)
+
+func TestTimedMovingAverage_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "default period",
+			Raw:  `from(db:"mydb") |> timedMovingAverage(every: 1m)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "timedMovingAverage1",
+						Spec: &functions.TimedMovingAverageOpSpec{
+							Every:  query.Duration(time.Minute),
+							Period: query.Duration(time.Minute),
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "timedMovingAverage1"},
+				},
+			},
+		},
+		{
+			Name: "period",
+			Raw:  `from(db:"mydb") |> timedMovingAverage(every: 1m, period: 5m)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "timedMovingAverage1",
+						Spec: &functions.TimedMovingAverageOpSpec{
+							Every:  query.Duration(time.Minute),
+							Period: query.Duration(5 * time.Minute),
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "timedMovingAverage1"},
+				},
+			},
+		},
+		{
+			Name:    "no every",
+			Raw:     `from(db:"mydb") |> timedMovingAverage(period: 5m)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestTimedMovingAverageOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"timedMovingAverage","kind":"timedMovingAverage","spec":{"every":"1m","period":"5m"}}`)
+	op := &query.Operation{
+		ID: "timedMovingAverage",
+		Spec: &functions.TimedMovingAverageOpSpec{
+			Every:  query.Duration(time.Minute),
+			Period: query.Duration(5 * time.Minute),
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestTimedMovingAverage_PassThrough(t *testing.T) {
+	executetest.TransformationPassThroughTestHelper(t, func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+		s := functions.NewTimedMovingAverageTransformation(
+			d,
+			c,
+			&functions.TimedMovingAverageProcedureSpec{
+				Every:  query.Duration(time.Minute),
+				Period: query.Duration(time.Minute),
+			},
+		)
+		return s
+	})
+}
+
+func TestTimedMovingAverage_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec *functions.TimedMovingAverageProcedureSpec
+		data []execute.Block
+		want []*executetest.Block
+	}{
+		{
+			name: "overlapping windows",
+			spec: &functions.TimedMovingAverageProcedureSpec{
+				Every:  2,
+				Period: 4,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  7,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					{Label: "t2", Type: execute.TString, Kind: execute.TagColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0, "a", "x"},
+					{execute.Time(1), 3.0, "a", "y"},
+					{execute.Time(2), 5.0, "a", "x"},
+					{execute.Time(3), 7.0, "a", "y"},
+					{execute.Time(5), 9.0, "a", "x"},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  7,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 2.0, "a"},
+					{execute.Time(4), 4.0, "a"},
+					{execute.Time(6), 7.0, "a"},
+					{execute.Time(7), 9.0, "a"},
+				},
+			}},
+		},
+		{
+			name: "empty windows and nulls",
+			spec: &functions.TimedMovingAverageProcedureSpec{
+				Every:  2,
+				Period: 2,
+			},
+			data: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  8,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "x", Type: execute.TInt, Kind: execute.ValueColKind},
+					{Label: "y", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(2), 1.0},
+					{execute.Time(0), int64(4), nil},
+					{execute.Time(7), nil, 3.0},
+				},
+			}},
+			want: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  8,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "x", Type: execute.TFloat, Kind: execute.ValueColKind},
+					{Label: "y", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 3.0, 1.0},
+					{execute.Time(8), nil, 3.0},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				func(d execute.Dataset, c execute.BlockBuilderCache) execute.Transformation {
+					return functions.NewTimedMovingAverageTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package functions
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/influxdata/ifql/query"
@@ -18,6 +19,8 @@ type WindowOpSpec struct {
 	Start      query.Time        `json:"start"`
 	Round      query.Duration    `json:"round"`
 	Triggering query.TriggerSpec `json:"triggering"`
+	// CreateEmpty creates a block for every window in the time bounds of the query, even when it contains no data.
+	CreateEmpty bool `json:"create_empty"`
 }
 
 var windowSignature = query.DefaultFunctionSignature()
@@ -27,6 +30,8 @@ func init() {
 	windowSignature.Params["period"] = semantic.Duration
 	windowSignature.Params["round"] = semantic.Duration
 	windowSignature.Params["start"] = semantic.Time
+	windowSignature.Params["triggering"] = query.TriggerObjectType
+	windowSignature.Params["createEmpty"] = semantic.Bool
 
 	query.RegisterFunction(WindowKind, createWindowOpSpec, windowSignature)
 	query.RegisterOpSpec(WindowKind, newWindowOp)
@@ -52,7 +57,7 @@ func createWindowOpSpec(args query.Arguments, a *query.Administration) (query.Op
 		return nil, err
 	}
 	if periodSet {
-		spec.Every = period
+		spec.Period = period
 	}
 	if round, ok, err := args.GetDuration("round"); err != nil {
 		return nil, err
@@ -64,6 +69,16 @@ func createWindowOpSpec(args query.Arguments, a *query.Administration) (query.Op
 	} else if ok {
 		spec.Start = start
 	}
+	if triggering, ok, err := args.GetTrigger("triggering"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Triggering = triggering
+	}
+	if createEmpty, ok, err := args.GetBool("createEmpty"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.CreateEmpty = createEmpty
+	}
 
 	if !everySet && !periodSet {
 		return nil, errors.New(`window function requires at least one of "every" or "period" to be set`)
@@ -86,9 +101,44 @@ func (s *WindowOpSpec) Kind() query.OperationKind {
 	return WindowKind
 }
 
+func (s WindowOpSpec) MarshalJSON() ([]byte, error) {
+	type Alias WindowOpSpec
+	triggering, err := query.MarshalTriggerSpec(s.Triggering)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Alias
+		Triggering json.RawMessage `json:"triggering"`
+	}{
+		Alias:      Alias(s),
+		Triggering: triggering,
+	})
+}
+
+func (s *WindowOpSpec) UnmarshalJSON(data []byte) error {
+	type Alias WindowOpSpec
+	raw := struct {
+		*Alias
+		Triggering json.RawMessage `json:"triggering"`
+	}{
+		Alias: (*Alias)(s),
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	triggering, err := query.UnmarshalTriggerSpec(raw.Triggering)
+	if err != nil {
+		return err
+	}
+	s.Triggering = triggering
+	return nil
+}
+
 type WindowProcedureSpec struct {
-	Window     plan.WindowSpec
-	Triggering query.TriggerSpec
+	Window      plan.WindowSpec
+	Triggering  query.TriggerSpec
+	CreateEmpty bool
 }
 
 func newWindowProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -103,11 +153,15 @@ func newWindowProcedure(qs query.OperationSpec, pa plan.Administration) (plan.Pr
 			Round:  s.Round,
 			Start:  s.Start,
 		},
-		Triggering: s.Triggering,
+		Triggering:  s.Triggering,
+		CreateEmpty: s.CreateEmpty,
 	}
 	if p.Triggering == nil {
 		p.Triggering = query.DefaultTrigger
 	}
+	if err := query.ValidateTriggerSpec(p.Triggering); err != nil {
+		return nil, err
+	}
 	return p, nil
 }
 
@@ -118,9 +172,14 @@ func (s *WindowProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(WindowProcedureSpec)
 	ns.Window = s.Window
 	ns.Triggering = s.Triggering
+	ns.CreateEmpty = s.CreateEmpty
 	return ns
 }
 
+func (s *WindowProcedureSpec) TimeWindow() plan.WindowSpec {
+	return s.Window
+}
+
 func (s *WindowProcedureSpec) TriggerSpec() query.TriggerSpec {
 	return s.Triggering
 }
@@ -137,7 +196,7 @@ func createWindowTransformation(id execute.DatasetID, mode execute.AccumulationM
 		Period: execute.Duration(s.Window.Period),
 		Round:  execute.Duration(s.Window.Round),
 		Start:  a.ResolveTime(s.Window.Start),
-	})
+	}, s.CreateEmpty)
 	return t, d, nil
 }
 
@@ -148,6 +207,20 @@ type fixedWindowTransformation struct {
 	bounds execute.Bounds
 
 	offset execute.Duration
+
+	createEmpty bool
+	// series holds the tag sets that have been seen, by tag key, when creating empty windows.
+	series map[execute.TagsKey]*windowSeries
+	// latest is the latest time of the processed data.
+	latest execute.Time
+}
+
+// windowSeries records the empty windows created for a tag set.
+type windowSeries struct {
+	tags     execute.Tags
+	valueCol execute.ColMeta
+	// nextStop is the stop time of the next window to create.
+	nextStop execute.Time
 }
 
 func NewFixedWindowTransformation(
@@ -155,14 +228,17 @@ func NewFixedWindowTransformation(
 	cache execute.BlockBuilderCache,
 	bounds execute.Bounds,
 	w execute.Window,
+	createEmpty bool,
 ) execute.Transformation {
 	offset := execute.Duration(w.Start - w.Start.Truncate(w.Every))
 	return &fixedWindowTransformation{
-		d:      d,
-		cache:  cache,
-		w:      w,
-		bounds: bounds,
-		offset: offset,
+		d:           d,
+		cache:       cache,
+		w:           w,
+		bounds:      bounds,
+		offset:      offset,
+		createEmpty: createEmpty,
+		series:      make(map[execute.TagsKey]*windowSeries),
 	}
 }
 
@@ -186,17 +262,12 @@ func (t *fixedWindowTransformation) Process(id execute.DatasetID, b execute.Bloc
 	times := b.Times()
 	times.DoTime(func(ts []execute.Time, rr execute.RowReader) {
 		for i, time := range ts {
+			if t.createEmpty {
+				t.addSeries(b.Tags(), valueCol, time)
+			}
 			bounds := t.getWindowBounds(time)
 			for _, bnds := range bounds {
-				builder, new := t.cache.BlockBuilder(blockMetadata{
-					tags:   b.Tags(),
-					bounds: bnds,
-				})
-				if new {
-					builder.AddCol(execute.TimeCol)
-					builder.AddCol(valueCol)
-					execute.AddTags(b.Tags(), builder)
-				}
+				builder := t.windowBuilder(b.Tags(), valueCol, bnds)
 				colMap := execute.AddNewCols(b, builder)
 
 				execute.AppendRow(i, rr, builder, colMap)
@@ -206,6 +277,74 @@ func (t *fixedWindowTransformation) Process(id execute.DatasetID, b execute.Bloc
 	return nil
 }
 
+// windowBuilder returns the builder of the window with the bounds for the tag set.
+func (t *fixedWindowTransformation) windowBuilder(tags execute.Tags, valueCol execute.ColMeta, bnds execute.Bounds) execute.BlockBuilder {
+	builder, new := t.cache.BlockBuilder(blockMetadata{
+		tags:   tags,
+		bounds: bnds,
+	})
+	if new {
+		builder.AddCol(execute.TimeCol)
+		builder.AddCol(valueCol)
+		execute.AddTags(tags, builder)
+	}
+	return builder
+}
+
+// addSeries records the tag set of data at time now for the creation of empty windows.
+// The empty windows of a tag set start at the start bound, or at its first data when the start is unbounded.
+func (t *fixedWindowTransformation) addSeries(tags execute.Tags, valueCol execute.ColMeta, now execute.Time) {
+	if now > t.latest {
+		t.latest = now
+	}
+	key := tags.Key()
+	if _, ok := t.series[key]; ok {
+		return
+	}
+	start := t.bounds.Start
+	if start == execute.MinTime {
+		start = now
+	}
+	stop := start.Truncate(t.w.Every) + execute.Time(t.offset)
+	for stop <= start {
+		stop += execute.Time(t.w.Every)
+	}
+	t.series[key] = &windowSeries{
+		tags:     tags,
+		valueCol: valueCol,
+		nextStop: stop,
+	}
+}
+
+// createEmptyWindows creates the windows of all seen tag sets that start before the limit.
+// Windows are never created past the stop bound, or past the latest data when the stop is unbounded.
+func (t *fixedWindowTransformation) createEmptyWindows(limit execute.Time) {
+	if limit > t.bounds.Stop {
+		limit = t.bounds.Stop
+	}
+	if limit == execute.MaxTime {
+		limit = t.latest + 1
+	}
+	for _, s := range t.series {
+		for ; s.nextStop-execute.Time(t.w.Period) < limit; s.nextStop += execute.Time(t.w.Every) {
+			bnds := execute.Bounds{
+				Start: s.nextStop - execute.Time(t.w.Period),
+				Stop:  s.nextStop,
+			}
+			if bnds.Stop > t.bounds.Stop {
+				bnds.Stop = t.bounds.Stop
+			}
+			if bnds.Start < t.bounds.Start {
+				bnds.Start = t.bounds.Start
+			}
+			if bnds.Start >= bnds.Stop {
+				break
+			}
+			t.windowBuilder(s.tags, s.valueCol, bnds)
+		}
+	}
+}
+
 func (t *fixedWindowTransformation) getWindowBounds(now execute.Time) []execute.Bounds {
 	stop := now.Truncate(t.w.Every) + execute.Time(t.offset)
 	if now >= stop {
@@ -244,11 +383,17 @@ func (t *fixedWindowTransformation) getWindowBounds(now execute.Time) []execute.
 }
 
 func (t *fixedWindowTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	if t.createEmpty {
+		t.createEmptyWindows(mark)
+	}
 	return t.d.UpdateWatermark(mark)
 }
 func (t *fixedWindowTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
 	return t.d.UpdateProcessingTime(pt)
 }
 func (t *fixedWindowTransformation) Finish(id execute.DatasetID, err error) {
+	if err == nil && t.createEmpty {
+		t.createEmptyWindows(t.bounds.Stop)
+	}
 	t.d.Finish(err)
 }
@@ -44,6 +44,126 @@ func TestWindow_NewQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "from with window every and period",
+			Raw:  `from(db:"mydb") |> window(every:1h, period:2h)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "window1",
+						Spec: &functions.WindowOpSpec{
+							Every:  query.Duration(time.Hour),
+							Period: query.Duration(2 * time.Hour),
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "window1"},
+				},
+			},
+		},
+		{
+			Name: "from with window period",
+			Raw:  `from(db:"mydb") |> window(period:2h)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "window1",
+						Spec: &functions.WindowOpSpec{
+							Every:  query.Duration(2 * time.Hour),
+							Period: query.Duration(2 * time.Hour),
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "window1"},
+				},
+			},
+		},
+		{
+			Name: "from with window triggering",
+			Raw:  `from(db:"mydb") |> window(every:1h, triggering:orFinally(main:repeated(trigger:afterAtLeastCount(n:10)), finally:afterWatermark(allowedLateness:5m)))`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "window1",
+						Spec: &functions.WindowOpSpec{
+							Every:  query.Duration(time.Hour),
+							Period: query.Duration(time.Hour),
+							Triggering: query.OrFinallyTriggerSpec{
+								Main: query.RepeatedTriggerSpec{
+									Trigger: query.AfterAtLeastCountTriggerSpec{Count: 10},
+								},
+								Finally: query.AfterWatermarkTriggerSpec{
+									AllowedLateness: query.Duration(5 * time.Minute),
+								},
+							},
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "window1"},
+				},
+			},
+		},
+		{
+			Name: "from with window create empty",
+			Raw:  `from(db:"mydb") |> window(every:1h, createEmpty:true)`,
+			Want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID: "from0",
+						Spec: &functions.FromOpSpec{
+							Database: "mydb",
+						},
+					},
+					{
+						ID: "window1",
+						Spec: &functions.WindowOpSpec{
+							Every:       query.Duration(time.Hour),
+							Period:      query.Duration(time.Hour),
+							CreateEmpty: true,
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "window1"},
+				},
+			},
+		},
+		{
+			Name:    "window invalid trigger count",
+			Raw:     `from(db:"mydb") |> window(every:1h, triggering:afterAtLeastCount(n:0))`,
+			WantErr: true,
+		},
+		{
+			Name:    "window triggering not a trigger",
+			Raw:     `from(db:"mydb") |> window(every:1h, triggering:5m)`,
+			WantErr: true,
+		},
+		{
+			Name:    "repeated trigger missing trigger",
+			Raw:     `from(db:"mydb") |> window(every:1h, triggering:repeated())`,
+			WantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -55,8 +175,7 @@ func TestWindow_NewQuery(t *testing.T) {
 }
 
 func TestWindowOperation_Marshaling(t *testing.T) {
-	//TODO: Test marshalling of triggerspec
-	data := []byte(`{"id":"window","kind":"window","spec":{"every":"1m","period":"1h","start":"-4h","round":"1s"}}`)
+	data := []byte(`{"id":"window","kind":"window","spec":{"every":"1m","period":"1h","start":"-4h","round":"1s","triggering":{"kind":"orFinally","spec":{"main":{"kind":"repeated","spec":{"trigger":{"kind":"afterProcessingTime","spec":{"duration":"10s"}}}},"finally":{"kind":"afterWatermark","spec":{"allowedLateness":"1m"}}}}}}`)
 	op := &query.Operation{
 		ID: "window",
 		Spec: &functions.WindowOpSpec{
@@ -67,6 +186,16 @@ func TestWindowOperation_Marshaling(t *testing.T) {
 				IsRelative: true,
 			},
 			Round: query.Duration(time.Second),
+			Triggering: query.OrFinallyTriggerSpec{
+				Main: query.RepeatedTriggerSpec{
+					Trigger: query.AfterProcessingTimeTriggerSpec{
+						Duration: query.Duration(10 * time.Second),
+					},
+				},
+				Finally: query.AfterWatermarkTriggerSpec{
+					AllowedLateness: query.Duration(time.Minute),
+				},
+			},
 		},
 	}
 
@@ -83,6 +212,7 @@ func TestFixedWindow_PassThrough(t *testing.T) {
 				Every:  execute.Duration(time.Minute),
 				Period: execute.Duration(time.Minute),
 			},
+			false,
 		)
 		return fw
 	})
@@ -593,6 +723,7 @@ func TestFixedWindow_Process(t *testing.T) {
 					Period: tc.period,
 					Start:  start,
 				},
+				false,
 			)
 
 			block0 := &executetest.Block{
@@ -643,3 +774,98 @@ func TestFixedWindow_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestFixedWindow_Process_CreateEmpty(t *testing.T) {
+	start := execute.Time(time.Date(2017, 10, 10, 10, 0, 0, 0, time.UTC).UnixNano())
+	stop := start + execute.Time(4*time.Minute)
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+		{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+	}
+	window := func(i int, host string, data ...[]interface{}) *executetest.Block {
+		b := &executetest.Block{
+			Bnds: execute.Bounds{
+				Start: start + execute.Time(time.Duration(i)*time.Minute),
+				Stop:  start + execute.Time(time.Duration(i+1)*time.Minute),
+			},
+			ColMeta: cols,
+			Data:    data,
+		}
+		if len(data) == 0 {
+			b.Tgs = execute.Tags{"host": host}
+		}
+		return b
+	}
+
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+
+	fw := functions.NewFixedWindowTransformation(
+		d,
+		c,
+		execute.Bounds{
+			Start: start,
+			Stop:  stop,
+		},
+		execute.Window{
+			Every:  execute.Duration(time.Minute),
+			Period: execute.Duration(time.Minute),
+			Start:  start,
+		},
+		true,
+	)
+
+	parentID := executetest.RandomDatasetID()
+	blocks := []*executetest.Block{
+		{
+			Bnds: execute.Bounds{
+				Start: start,
+				Stop:  stop,
+			},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{start + execute.Time(10*time.Second), 1.0, "a"},
+				{start + execute.Time(130*time.Second), 2.0, "a"},
+			},
+		},
+		{
+			Bnds: execute.Bounds{
+				Start: start,
+				Stop:  stop,
+			},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{start + execute.Time(70*time.Second), 3.0, "b"},
+			},
+		},
+	}
+	for _, b := range blocks {
+		if err := fw.Process(parentID, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fw.UpdateWatermark(parentID, stop); err != nil {
+		t.Fatal(err)
+	}
+
+	got := executetest.BlocksFromCache(c)
+	want := []*executetest.Block{
+		window(0, "a", []interface{}{start + execute.Time(10*time.Second), 1.0, "a"}),
+		window(1, "a"),
+		window(2, "a", []interface{}{start + execute.Time(130*time.Second), 2.0, "a"}),
+		window(3, "a"),
+		window(0, "b"),
+		window(1, "b", []interface{}{start + execute.Time(70*time.Second), 3.0, "b"}),
+		window(2, "b"),
+		window(3, "b"),
+	}
+
+	sort.Sort(executetest.SortedBlocks(got))
+	sort.Sort(executetest.SortedBlocks(want))
+
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
@@ -11,8 +11,14 @@ import (
 )
 
 func Eval(program *semantic.Program, scope *Scope, d Domain) error {
+	return EvalWithImporter(program, scope, d, nil)
+}
+
+// EvalWithImporter evaluates the program using the importer to resolve its import declarations.
+func EvalWithImporter(program *semantic.Program, scope *Scope, d Domain, importer Importer) error {
 	itrp := interpreter{
-		d: d,
+		d:        d,
+		importer: importer,
 	}
 	return itrp.eval(program, scope)
 }
@@ -20,8 +26,14 @@ func Eval(program *semantic.Program, scope *Scope, d Domain) error {
 // Domain represents any specific domain being used during evaluation.
 type Domain interface{}
 
+// Importer resolves the value of an imported package from its path.
+type Importer interface {
+	Import(path string) (Value, error)
+}
+
 type interpreter struct {
-	d Domain
+	d        Domain
+	importer Importer
 }
 
 func (itrp interpreter) eval(program *semantic.Program, scope *Scope) error {
@@ -66,6 +78,15 @@ func (itrp interpreter) doStatement(stmt semantic.Statement, scope *Scope) error
 			return err
 		}
 		scope.SetReturn(v)
+	case *semantic.ImportDeclaration:
+		if itrp.importer == nil {
+			return fmt.Errorf("cannot import %q, imports are not supported", s.Path)
+		}
+		v, err := itrp.importer.Import(s.Path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to import %q", s.Path)
+		}
+		scope.Set(s.Name.Name, v)
 	default:
 		return fmt.Errorf("unsupported statement type %T", stmt)
 	}
@@ -189,6 +210,32 @@ func (itrp interpreter) doExpression(expr semantic.Expression, scope *Scope) (Va
 		default:
 			return nil, fmt.Errorf("invalid logical operator %v", e.Operator)
 		}
+	case *semantic.ConditionalExpression:
+		t, err := itrp.doExpression(e.Test, scope)
+		if err != nil {
+			return nil, err
+		}
+		if t.Type() != semantic.Bool {
+			return nil, fmt.Errorf("test of conditional expression is not a boolean value, got %v", t.Type())
+		}
+		branch := e.Alternate
+		if t.Value().(bool) {
+			branch = e.Consequent
+		}
+		v, err := itrp.doExpression(branch, scope)
+		if err != nil {
+			return nil, err
+		}
+		// Unify numeric branches to the type of the expression
+		if e.Type() == semantic.Float {
+			switch v.Type() {
+			case semantic.Int:
+				return NewFloatValue(float64(v.Value().(int64))), nil
+			case semantic.UInt:
+				return NewFloatValue(float64(v.Value().(uint64))), nil
+			}
+		}
+		return v, nil
 	case *semantic.FunctionExpression:
 		return value{
 			t: semantic.Function,
@@ -373,6 +420,15 @@ func (s *Scope) Set(name string, value Value) {
 	s.values[name] = value
 }
 
+// Values returns the values set in this scope, excluding any values of its parent scopes.
+func (s *Scope) Values() map[string]Value {
+	values := make(map[string]Value, len(s.values))
+	for k, v := range s.values {
+		values[k] = v
+	}
+	return values
+}
+
 // SetReturn sets the return value of this scope.
 func (s *Scope) SetReturn(value Value) {
 	s.returnValue = value
@@ -891,7 +947,7 @@ func (a *arguments) GetArray(name string, t semantic.Kind) (Array, bool, error)
 	}
 	arr := v.Value().(Array)
 	if arr.Type().ElementType() != t {
-		return Array{}, true, fmt.Errorf("keyword argument %q should be of an array of type %v, but got an array of type %v", name, t, arr.Type)
+		return Array{}, true, fmt.Errorf("keyword argument %q should be of an array of type %v, but got an array of type %v", name, t, arr.Type())
 	}
 	return v.Value().(Array), ok, nil
 }
@@ -902,7 +958,7 @@ func (a *arguments) GetRequiredArray(name string, t semantic.Kind) (Array, error
 	}
 	arr := v.Value().(Array)
 	if arr.Type().ElementType() != t {
-		return Array{}, fmt.Errorf("keyword argument %q should be of an array of type %v, but got an array of type %v", name, t, arr.Type)
+		return Array{}, fmt.Errorf("keyword argument %q should be of an array of type %v, but got an array of type %v", name, t, arr.Type())
 	}
 	return arr, nil
 }
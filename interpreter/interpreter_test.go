@@ -61,6 +61,30 @@ func init() {
 	)
 }
 
+// testImporter provides the packages available to test programs.
+var testImporter = importer{
+	"math/answers": interpreter.Object{
+		Properties: map[string]interpreter.Value{
+			"fortyTwo": function{
+				name: "fortyTwo",
+				call: func(args interpreter.Arguments, d interpreter.Domain) (interpreter.Value, error) {
+					return interpreter.NewFloatValue(42.0), nil
+				},
+			},
+		},
+	},
+}
+
+type importer map[string]interpreter.Value
+
+func (imp importer) Import(path string) (interpreter.Value, error) {
+	v, ok := imp[path]
+	if !ok {
+		return nil, fmt.Errorf("unknown package %q", path)
+	}
+	return v, nil
+}
+
 // TestEval tests whether a program can run to completion or not
 func TestEval(t *testing.T) {
 	testCases := []struct {
@@ -103,6 +127,34 @@ func TestEval(t *testing.T) {
             answer = (not (fortyTwo() == six * nine)) or fail()
 			`,
 		},
+		{
+			name: "conditional expressions",
+			query: `
+            classify = (v) => v > 90.0 ? "crit" : v > 80.0 ? "warn" : "ok"
+            classify(v:95.0) == "crit" or fail()
+            classify(v:85.0) == "warn" or fail()
+            classify(v:5.0) == "ok" or fail()
+			`,
+		},
+		{
+			name: "conditional expression only evaluates one branch",
+			query: `
+            answer = fortyTwo() == 42.0 ? six() : fail()
+			`,
+		},
+		{
+			name: "conditional expression unifies numeric branches",
+			query: `
+            (false ? 1.5 : 2) == 2.0 or fail()
+			`,
+		},
+		{
+			name: "conditional expression test is not a boolean",
+			query: `
+            answer = six() ? 1 : 2
+			`,
+			wantErr: true,
+		},
 		{
 			name: "arrow function",
 			query: `
@@ -176,6 +228,20 @@ func TestEval(t *testing.T) {
 			six() |> plusOne() == 7.0 or fail()
 			`,
 		},
+		{
+			name: "import package",
+			query: `
+			import "math/answers"
+			answers.fortyTwo() == 42.0 or fail()
+			`,
+		},
+		{
+			name: "import unknown package",
+			query: `
+			import "math/questions"
+			`,
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -190,7 +256,7 @@ func TestEval(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			err = interpreter.Eval(graph, testScope.Nest(), nil)
+			err = interpreter.EvalWithImporter(graph, testScope.Nest(), nil, testImporter)
 			if !tc.wantErr && err != nil {
 				t.Fatal(err)
 			} else if tc.wantErr && err == nil {
@@ -28,29 +28,29 @@ var g = &grammar{
 					pos: position{line: 9, col: 5, offset: 112},
 					exprs: []interface{}{
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -58,7 +58,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -76,29 +76,29 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -106,7 +106,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -116,9 +116,9 @@ var g = &grammar{
 							},
 						},
 						&notExpr{
-							pos: position{line: 479, col: 5, offset: 8932},
+							pos: position{line: 491, col: 5, offset: 9242},
 							expr: &anyMatcher{
-								line: 479, col: 6, offset: 8933,
+								line: 485, col: 6, offset: 9058,
 							},
 						},
 					},
@@ -167,29 +167,29 @@ var g = &grammar{
 									pos: position{line: 19, col: 30, offset: 300},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -197,7 +197,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -211,29 +211,29 @@ var g = &grammar{
 											name: "SourceElement",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -241,7 +241,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -282,10 +282,14 @@ var g = &grammar{
 					},
 					&ruleRefExpr{
 						pos:  position{line: 29, col: 5, offset: 450},
+						name: "ImportStatement",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 30, col: 5, offset: 470},
 						name: "ExpressionStatement",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 30, col: 5, offset: 474},
+						pos:  position{line: 31, col: 5, offset: 494},
 						name: "BlockStatement",
 					},
 				},
@@ -293,15 +297,15 @@ var g = &grammar{
 		},
 		{
 			name: "VariableStatement",
-			pos:  position{line: 33, col: 1, offset: 491},
+			pos:  position{line: 34, col: 1, offset: 511},
 			expr: &actionExpr{
-				pos: position{line: 34, col: 5, offset: 513},
+				pos: position{line: 35, col: 5, offset: 533},
 				run: (*parser).callonVariableStatement1,
 				expr: &labeledExpr{
-					pos:   position{line: 34, col: 5, offset: 513},
+					pos:   position{line: 35, col: 5, offset: 533},
 					label: "declaration",
 					expr: &ruleRefExpr{
-						pos:  position{line: 34, col: 17, offset: 525},
+						pos:  position{line: 35, col: 17, offset: 545},
 						name: "VariableDeclaration",
 					},
 				},
@@ -309,42 +313,42 @@ var g = &grammar{
 		},
 		{
 			name: "ReturnStatement",
-			pos:  position{line: 38, col: 1, offset: 604},
+			pos:  position{line: 39, col: 1, offset: 624},
 			expr: &actionExpr{
-				pos: position{line: 39, col: 5, offset: 624},
+				pos: position{line: 40, col: 5, offset: 644},
 				run: (*parser).callonReturnStatement1,
 				expr: &seqExpr{
-					pos: position{line: 39, col: 5, offset: 624},
+					pos: position{line: 40, col: 5, offset: 644},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 39, col: 5, offset: 624},
+							pos:        position{line: 40, col: 5, offset: 644},
 							val:        "return",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -352,7 +356,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -362,10 +366,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 39, col: 17, offset: 636},
+							pos:   position{line: 40, col: 17, offset: 656},
 							label: "argument",
 							expr: &ruleRefExpr{
-								pos:  position{line: 39, col: 26, offset: 645},
+								pos:  position{line: 40, col: 26, offset: 665},
 								name: "Expr",
 							},
 						},
@@ -373,17 +377,271 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "ImportStatement",
+			pos:  position{line: 44, col: 1, offset: 728},
+			expr: &actionExpr{
+				pos: position{line: 45, col: 5, offset: 748},
+				run: (*parser).callonImportStatement1,
+				expr: &seqExpr{
+					pos: position{line: 45, col: 5, offset: 748},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 45, col: 5, offset: 748},
+							val:        "import",
+							ignoreCase: false,
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 476, col: 5, offset: 9119},
+							expr: &choiceExpr{
+								pos: position{line: 476, col: 7, offset: 9121},
+								alternatives: []interface{}{
+									&charClassMatcher{
+										pos:        position{line: 482, col: 5, offset: 9182},
+										val:        "[ \\t\\r\\n]",
+										chars:      []rune{' ', '\t', '\r', '\n'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&seqExpr{
+										pos: position{line: 479, col: 5, offset: 9156},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 479, col: 5, offset: 9156},
+												val:        "//",
+												ignoreCase: false,
+											},
+											&zeroOrMoreExpr{
+												pos: position{line: 479, col: 10, offset: 9161},
+												expr: &charClassMatcher{
+													pos:        position{line: 479, col: 10, offset: 9161},
+													val:        "[^\\r\\n]",
+													chars:      []rune{'\r', '\n'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+											&litMatcher{
+												pos:        position{line: 488, col: 5, offset: 9228},
+												val:        "\n",
+												ignoreCase: false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 45, col: 17, offset: 760},
+							label: "path",
+							expr: &choiceExpr{
+								pos: position{line: 390, col: 5, offset: 7431},
+								alternatives: []interface{}{
+									&actionExpr{
+										pos: position{line: 390, col: 5, offset: 7431},
+										run: (*parser).callonImportStatement14,
+										expr: &seqExpr{
+											pos: position{line: 390, col: 7, offset: 7433},
+											exprs: []interface{}{
+												&litMatcher{
+													pos:        position{line: 390, col: 7, offset: 7433},
+													val:        "\"",
+													ignoreCase: false,
+												},
+												&zeroOrMoreExpr{
+													pos: position{line: 390, col: 11, offset: 7437},
+													expr: &choiceExpr{
+														pos: position{line: 398, col: 5, offset: 7654},
+														alternatives: []interface{}{
+															&seqExpr{
+																pos: position{line: 398, col: 5, offset: 7654},
+																exprs: []interface{}{
+																	&notExpr{
+																		pos: position{line: 398, col: 5, offset: 7654},
+																		expr: &charClassMatcher{
+																			pos:        position{line: 398, col: 8, offset: 7657},
+																			val:        "[\"\\\\\\n]",
+																			chars:      []rune{'"', '\\', '\n'},
+																			ignoreCase: false,
+																			inverted:   false,
+																		},
+																	},
+																	&anyMatcher{
+																		line: 468, col: 5, offset: 8925,
+																	},
+																},
+															},
+															&seqExpr{
+																pos: position{line: 399, col: 5, offset: 7691},
+																exprs: []interface{}{
+																	&litMatcher{
+																		pos:        position{line: 399, col: 5, offset: 7691},
+																		val:        "\\",
+																		ignoreCase: false,
+																	},
+																	&choiceExpr{
+																		pos: position{line: 402, col: 5, offset: 7739},
+																		alternatives: []interface{}{
+																			&litMatcher{
+																				pos:        position{line: 402, col: 5, offset: 7739},
+																				val:        "\"",
+																				ignoreCase: false,
+																			},
+																			&actionExpr{
+																				pos: position{line: 403, col: 5, offset: 7747},
+																				run: (*parser).callonImportStatement27,
+																				expr: &choiceExpr{
+																					pos: position{line: 403, col: 7, offset: 7749},
+																					alternatives: []interface{}{
+																						&anyMatcher{
+																							line: 468, col: 5, offset: 8925,
+																						},
+																						&litMatcher{
+																							pos:        position{line: 488, col: 5, offset: 9228},
+																							val:        "\n",
+																							ignoreCase: false,
+																						},
+																						&notExpr{
+																							pos: position{line: 491, col: 5, offset: 9242},
+																							expr: &anyMatcher{
+																								line: 485, col: 6, offset: 9058,
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+												&litMatcher{
+													pos:        position{line: 390, col: 29, offset: 7455},
+													val:        "\"",
+													ignoreCase: false,
+												},
+											},
+										},
+									},
+									&actionExpr{
+										pos: position{line: 393, col: 5, offset: 7519},
+										run: (*parser).callonImportStatement34,
+										expr: &seqExpr{
+											pos: position{line: 393, col: 7, offset: 7521},
+											exprs: []interface{}{
+												&litMatcher{
+													pos:        position{line: 393, col: 7, offset: 7521},
+													val:        "\"",
+													ignoreCase: false,
+												},
+												&zeroOrMoreExpr{
+													pos: position{line: 393, col: 11, offset: 7525},
+													expr: &choiceExpr{
+														pos: position{line: 398, col: 5, offset: 7654},
+														alternatives: []interface{}{
+															&seqExpr{
+																pos: position{line: 398, col: 5, offset: 7654},
+																exprs: []interface{}{
+																	&notExpr{
+																		pos: position{line: 398, col: 5, offset: 7654},
+																		expr: &charClassMatcher{
+																			pos:        position{line: 398, col: 8, offset: 7657},
+																			val:        "[\"\\\\\\n]",
+																			chars:      []rune{'"', '\\', '\n'},
+																			ignoreCase: false,
+																			inverted:   false,
+																		},
+																	},
+																	&anyMatcher{
+																		line: 468, col: 5, offset: 8925,
+																	},
+																},
+															},
+															&seqExpr{
+																pos: position{line: 399, col: 5, offset: 7691},
+																exprs: []interface{}{
+																	&litMatcher{
+																		pos:        position{line: 399, col: 5, offset: 7691},
+																		val:        "\\",
+																		ignoreCase: false,
+																	},
+																	&choiceExpr{
+																		pos: position{line: 402, col: 5, offset: 7739},
+																		alternatives: []interface{}{
+																			&litMatcher{
+																				pos:        position{line: 402, col: 5, offset: 7739},
+																				val:        "\"",
+																				ignoreCase: false,
+																			},
+																			&actionExpr{
+																				pos: position{line: 403, col: 5, offset: 7747},
+																				run: (*parser).callonImportStatement47,
+																				expr: &choiceExpr{
+																					pos: position{line: 403, col: 7, offset: 7749},
+																					alternatives: []interface{}{
+																						&anyMatcher{
+																							line: 468, col: 5, offset: 8925,
+																						},
+																						&litMatcher{
+																							pos:        position{line: 488, col: 5, offset: 9228},
+																							val:        "\n",
+																							ignoreCase: false,
+																						},
+																						&notExpr{
+																							pos: position{line: 491, col: 5, offset: 9242},
+																							expr: &anyMatcher{
+																								line: 485, col: 6, offset: 9058,
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+												&choiceExpr{
+													pos: position{line: 393, col: 31, offset: 7545},
+													alternatives: []interface{}{
+														&litMatcher{
+															pos:        position{line: 488, col: 5, offset: 9228},
+															val:        "\n",
+															ignoreCase: false,
+														},
+														&notExpr{
+															pos: position{line: 491, col: 5, offset: 9242},
+															expr: &anyMatcher{
+																line: 485, col: 6, offset: 9058,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "ExpressionStatement",
-			pos:  position{line: 43, col: 1, offset: 708},
+			pos:  position{line: 49, col: 1, offset: 833},
 			expr: &actionExpr{
-				pos: position{line: 44, col: 5, offset: 732},
+				pos: position{line: 50, col: 5, offset: 857},
 				run: (*parser).callonExpressionStatement1,
 				expr: &labeledExpr{
-					pos:   position{line: 44, col: 5, offset: 732},
+					pos:   position{line: 50, col: 5, offset: 857},
 					label: "expr",
 					expr: &ruleRefExpr{
-						pos:  position{line: 44, col: 10, offset: 737},
+						pos:  position{line: 50, col: 10, offset: 862},
 						name: "Expr",
 					},
 				},
@@ -391,42 +649,42 @@ var g = &grammar{
 		},
 		{
 			name: "BlockStatement",
-			pos:  position{line: 48, col: 1, offset: 796},
+			pos:  position{line: 54, col: 1, offset: 921},
 			expr: &actionExpr{
-				pos: position{line: 49, col: 5, offset: 815},
+				pos: position{line: 55, col: 5, offset: 940},
 				run: (*parser).callonBlockStatement1,
 				expr: &seqExpr{
-					pos: position{line: 49, col: 5, offset: 815},
+					pos: position{line: 55, col: 5, offset: 940},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 49, col: 5, offset: 815},
+							pos:        position{line: 55, col: 5, offset: 940},
 							val:        "{",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -434,7 +692,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -444,37 +702,37 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 49, col: 12, offset: 822},
+							pos:   position{line: 55, col: 12, offset: 947},
 							label: "body",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 49, col: 17, offset: 827},
+								pos: position{line: 55, col: 17, offset: 952},
 								expr: &seqExpr{
-									pos: position{line: 49, col: 19, offset: 829},
+									pos: position{line: 55, col: 19, offset: 954},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -482,7 +740,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -492,33 +750,33 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 49, col: 22, offset: 832},
+											pos:  position{line: 55, col: 22, offset: 957},
 											name: "Statement",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -526,7 +784,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -540,29 +798,29 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -570,7 +828,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -580,7 +838,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 49, col: 41, offset: 851},
+							pos:        position{line: 55, col: 41, offset: 976},
 							val:        "}",
 							ignoreCase: false,
 						},
@@ -590,24 +848,24 @@ var g = &grammar{
 		},
 		{
 			name: "VariableDeclaration",
-			pos:  position{line: 53, col: 1, offset: 908},
+			pos:  position{line: 59, col: 1, offset: 1033},
 			expr: &actionExpr{
-				pos: position{line: 54, col: 5, offset: 932},
+				pos: position{line: 60, col: 5, offset: 1057},
 				run: (*parser).callonVariableDeclaration1,
 				expr: &seqExpr{
-					pos: position{line: 54, col: 5, offset: 932},
+					pos: position{line: 60, col: 5, offset: 1057},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 54, col: 5, offset: 932},
+							pos:   position{line: 60, col: 5, offset: 1057},
 							label: "id",
 							expr: &actionExpr{
-								pos: position{line: 456, col: 5, offset: 8719},
+								pos: position{line: 468, col: 5, offset: 9029},
 								run: (*parser).callonVariableDeclaration4,
 								expr: &seqExpr{
-									pos: position{line: 456, col: 5, offset: 8719},
+									pos: position{line: 468, col: 5, offset: 9029},
 									exprs: []interface{}{
 										&charClassMatcher{
-											pos:        position{line: 456, col: 5, offset: 8719},
+											pos:        position{line: 468, col: 5, offset: 9029},
 											val:        "[_\\pL]",
 											chars:      []rune{'_'},
 											classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -615,9 +873,9 @@ var g = &grammar{
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 456, col: 11, offset: 8725},
+											pos: position{line: 468, col: 11, offset: 9035},
 											expr: &charClassMatcher{
-												pos:        position{line: 456, col: 11, offset: 8725},
+												pos:        position{line: 468, col: 11, offset: 9035},
 												val:        "[_0-9\\pL]",
 												chars:      []rune{'_'},
 												ranges:     []rune{'0', '9'},
@@ -631,29 +889,29 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -661,7 +919,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -671,34 +929,34 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 54, col: 22, offset: 949},
+							pos:        position{line: 60, col: 22, offset: 1074},
 							val:        "=",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -706,7 +964,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -716,10 +974,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 54, col: 29, offset: 956},
+							pos:   position{line: 60, col: 29, offset: 1081},
 							label: "init",
 							expr: &ruleRefExpr{
-								pos:  position{line: 54, col: 34, offset: 961},
+								pos:  position{line: 60, col: 34, offset: 1086},
 								name: "Expr",
 							},
 						},
@@ -729,24 +987,24 @@ var g = &grammar{
 		},
 		{
 			name: "MemberExpressions",
-			pos:  position{line: 59, col: 1, offset: 1022},
+			pos:  position{line: 65, col: 1, offset: 1147},
 			expr: &actionExpr{
-				pos: position{line: 60, col: 5, offset: 1044},
+				pos: position{line: 66, col: 5, offset: 1169},
 				run: (*parser).callonMemberExpressions1,
 				expr: &seqExpr{
-					pos: position{line: 60, col: 5, offset: 1044},
+					pos: position{line: 66, col: 5, offset: 1169},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 60, col: 5, offset: 1044},
+							pos:   position{line: 66, col: 5, offset: 1169},
 							label: "head",
 							expr: &actionExpr{
-								pos: position{line: 456, col: 5, offset: 8719},
+								pos: position{line: 468, col: 5, offset: 9029},
 								run: (*parser).callonMemberExpressions4,
 								expr: &seqExpr{
-									pos: position{line: 456, col: 5, offset: 8719},
+									pos: position{line: 468, col: 5, offset: 9029},
 									exprs: []interface{}{
 										&charClassMatcher{
-											pos:        position{line: 456, col: 5, offset: 8719},
+											pos:        position{line: 468, col: 5, offset: 9029},
 											val:        "[_\\pL]",
 											chars:      []rune{'_'},
 											classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -754,9 +1012,9 @@ var g = &grammar{
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 456, col: 11, offset: 8725},
+											pos: position{line: 468, col: 11, offset: 9035},
 											expr: &charClassMatcher{
-												pos:        position{line: 456, col: 11, offset: 8725},
+												pos:        position{line: 468, col: 11, offset: 9035},
 												val:        "[_0-9\\pL]",
 												chars:      []rune{'_'},
 												ranges:     []rune{'0', '9'},
@@ -770,40 +1028,40 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 61, col: 5, offset: 1091},
+							pos:   position{line: 67, col: 5, offset: 1216},
 							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 61, col: 10, offset: 1096},
+								pos: position{line: 67, col: 10, offset: 1221},
 								expr: &actionExpr{
-									pos: position{line: 62, col: 10, offset: 1107},
+									pos: position{line: 68, col: 10, offset: 1232},
 									run: (*parser).callonMemberExpressions11,
 									expr: &seqExpr{
-										pos: position{line: 62, col: 10, offset: 1107},
+										pos: position{line: 68, col: 10, offset: 1232},
 										exprs: []interface{}{
 											&zeroOrMoreExpr{
-												pos: position{line: 464, col: 5, offset: 8809},
+												pos: position{line: 476, col: 5, offset: 9119},
 												expr: &choiceExpr{
-													pos: position{line: 464, col: 7, offset: 8811},
+													pos: position{line: 476, col: 7, offset: 9121},
 													alternatives: []interface{}{
 														&charClassMatcher{
-															pos:        position{line: 470, col: 5, offset: 8872},
+															pos:        position{line: 482, col: 5, offset: 9182},
 															val:        "[ \\t\\r\\n]",
 															chars:      []rune{' ', '\t', '\r', '\n'},
 															ignoreCase: false,
 															inverted:   false,
 														},
 														&seqExpr{
-															pos: position{line: 467, col: 5, offset: 8846},
+															pos: position{line: 479, col: 5, offset: 9156},
 															exprs: []interface{}{
 																&litMatcher{
-																	pos:        position{line: 467, col: 5, offset: 8846},
+																	pos:        position{line: 479, col: 5, offset: 9156},
 																	val:        "//",
 																	ignoreCase: false,
 																},
 																&zeroOrMoreExpr{
-																	pos: position{line: 467, col: 10, offset: 8851},
+																	pos: position{line: 479, col: 10, offset: 9161},
 																	expr: &charClassMatcher{
-																		pos:        position{line: 467, col: 10, offset: 8851},
+																		pos:        position{line: 479, col: 10, offset: 9161},
 																		val:        "[^\\r\\n]",
 																		chars:      []rune{'\r', '\n'},
 																		ignoreCase: false,
@@ -811,7 +1069,7 @@ var g = &grammar{
 																	},
 																},
 																&litMatcher{
-																	pos:        position{line: 476, col: 5, offset: 8918},
+																	pos:        position{line: 488, col: 5, offset: 9228},
 																	val:        "\n",
 																	ignoreCase: false,
 																},
@@ -821,10 +1079,10 @@ var g = &grammar{
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 62, col: 13, offset: 1110},
+												pos:   position{line: 68, col: 13, offset: 1235},
 												label: "property",
 												expr: &ruleRefExpr{
-													pos:  position{line: 62, col: 22, offset: 1119},
+													pos:  position{line: 68, col: 22, offset: 1244},
 													name: "MemberExpressionProperty",
 												},
 											},
@@ -839,45 +1097,45 @@ var g = &grammar{
 		},
 		{
 			name: "MemberExpressionProperty",
-			pos:  position{line: 70, col: 1, offset: 1259},
+			pos:  position{line: 76, col: 1, offset: 1384},
 			expr: &choiceExpr{
-				pos: position{line: 71, col: 5, offset: 1288},
+				pos: position{line: 77, col: 5, offset: 1413},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 71, col: 5, offset: 1288},
+						pos: position{line: 77, col: 5, offset: 1413},
 						run: (*parser).callonMemberExpressionProperty2,
 						expr: &seqExpr{
-							pos: position{line: 71, col: 5, offset: 1288},
+							pos: position{line: 77, col: 5, offset: 1413},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 71, col: 5, offset: 1288},
+									pos:        position{line: 77, col: 5, offset: 1413},
 									val:        ".",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -885,7 +1143,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -895,16 +1153,16 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 71, col: 12, offset: 1295},
+									pos:   position{line: 77, col: 12, offset: 1420},
 									label: "property",
 									expr: &actionExpr{
-										pos: position{line: 456, col: 5, offset: 8719},
+										pos: position{line: 468, col: 5, offset: 9029},
 										run: (*parser).callonMemberExpressionProperty14,
 										expr: &seqExpr{
-											pos: position{line: 456, col: 5, offset: 8719},
+											pos: position{line: 468, col: 5, offset: 9029},
 											exprs: []interface{}{
 												&charClassMatcher{
-													pos:        position{line: 456, col: 5, offset: 8719},
+													pos:        position{line: 468, col: 5, offset: 9029},
 													val:        "[_\\pL]",
 													chars:      []rune{'_'},
 													classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -912,9 +1170,9 @@ var g = &grammar{
 													inverted:   false,
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 456, col: 11, offset: 8725},
+													pos: position{line: 468, col: 11, offset: 9035},
 													expr: &charClassMatcher{
-														pos:        position{line: 456, col: 11, offset: 8725},
+														pos:        position{line: 468, col: 11, offset: 9035},
 														val:        "[_0-9\\pL]",
 														chars:      []rune{'_'},
 														ranges:     []rune{'0', '9'},
@@ -931,40 +1189,40 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 74, col: 7, offset: 1356},
+						pos: position{line: 80, col: 7, offset: 1481},
 						run: (*parser).callonMemberExpressionProperty19,
 						expr: &seqExpr{
-							pos: position{line: 74, col: 7, offset: 1356},
+							pos: position{line: 80, col: 7, offset: 1481},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 74, col: 7, offset: 1356},
+									pos:        position{line: 80, col: 7, offset: 1481},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -972,7 +1230,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -982,37 +1240,37 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 74, col: 14, offset: 1363},
+									pos:   position{line: 80, col: 14, offset: 1488},
 									label: "property",
 									expr: &ruleRefExpr{
-										pos:  position{line: 74, col: 23, offset: 1372},
+										pos:  position{line: 80, col: 23, offset: 1497},
 										name: "Primary",
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -1020,7 +1278,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -1030,34 +1288,34 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 74, col: 34, offset: 1383},
+									pos:        position{line: 80, col: 34, offset: 1508},
 									val:        "]",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -1065,7 +1323,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -1082,54 +1340,54 @@ var g = &grammar{
 		},
 		{
 			name: "CallExpression",
-			pos:  position{line: 78, col: 1, offset: 1426},
+			pos:  position{line: 84, col: 1, offset: 1551},
 			expr: &actionExpr{
-				pos: position{line: 79, col: 5, offset: 1445},
+				pos: position{line: 85, col: 5, offset: 1570},
 				run: (*parser).callonCallExpression1,
 				expr: &seqExpr{
-					pos: position{line: 79, col: 5, offset: 1445},
+					pos: position{line: 85, col: 5, offset: 1570},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 79, col: 5, offset: 1445},
+							pos:   position{line: 85, col: 5, offset: 1570},
 							label: "head",
 							expr: &actionExpr{
-								pos: position{line: 80, col: 7, offset: 1458},
+								pos: position{line: 86, col: 7, offset: 1583},
 								run: (*parser).callonCallExpression4,
 								expr: &seqExpr{
-									pos: position{line: 80, col: 7, offset: 1458},
+									pos: position{line: 86, col: 7, offset: 1583},
 									exprs: []interface{}{
 										&labeledExpr{
-											pos:   position{line: 80, col: 7, offset: 1458},
+											pos:   position{line: 86, col: 7, offset: 1583},
 											label: "callee",
 											expr: &ruleRefExpr{
-												pos:  position{line: 80, col: 14, offset: 1465},
+												pos:  position{line: 86, col: 14, offset: 1590},
 												name: "MemberExpressions",
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -1137,7 +1395,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -1147,10 +1405,10 @@ var g = &grammar{
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 80, col: 35, offset: 1486},
+											pos:   position{line: 86, col: 35, offset: 1611},
 											label: "args",
 											expr: &ruleRefExpr{
-												pos:  position{line: 80, col: 40, offset: 1491},
+												pos:  position{line: 86, col: 40, offset: 1616},
 												name: "Arguments",
 											},
 										},
@@ -1159,43 +1417,43 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 84, col: 5, offset: 1574},
+							pos:   position{line: 90, col: 5, offset: 1699},
 							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 84, col: 10, offset: 1579},
+								pos: position{line: 90, col: 10, offset: 1704},
 								expr: &choiceExpr{
-									pos: position{line: 85, col: 9, offset: 1589},
+									pos: position{line: 91, col: 9, offset: 1714},
 									alternatives: []interface{}{
 										&actionExpr{
-											pos: position{line: 85, col: 9, offset: 1589},
+											pos: position{line: 91, col: 9, offset: 1714},
 											run: (*parser).callonCallExpression21,
 											expr: &seqExpr{
-												pos: position{line: 85, col: 9, offset: 1589},
+												pos: position{line: 91, col: 9, offset: 1714},
 												exprs: []interface{}{
 													&zeroOrMoreExpr{
-														pos: position{line: 464, col: 5, offset: 8809},
+														pos: position{line: 476, col: 5, offset: 9119},
 														expr: &choiceExpr{
-															pos: position{line: 464, col: 7, offset: 8811},
+															pos: position{line: 476, col: 7, offset: 9121},
 															alternatives: []interface{}{
 																&charClassMatcher{
-																	pos:        position{line: 470, col: 5, offset: 8872},
+																	pos:        position{line: 482, col: 5, offset: 9182},
 																	val:        "[ \\t\\r\\n]",
 																	chars:      []rune{' ', '\t', '\r', '\n'},
 																	ignoreCase: false,
 																	inverted:   false,
 																},
 																&seqExpr{
-																	pos: position{line: 467, col: 5, offset: 8846},
+																	pos: position{line: 479, col: 5, offset: 9156},
 																	exprs: []interface{}{
 																		&litMatcher{
-																			pos:        position{line: 467, col: 5, offset: 8846},
+																			pos:        position{line: 479, col: 5, offset: 9156},
 																			val:        "//",
 																			ignoreCase: false,
 																		},
 																		&zeroOrMoreExpr{
-																			pos: position{line: 467, col: 10, offset: 8851},
+																			pos: position{line: 479, col: 10, offset: 9161},
 																			expr: &charClassMatcher{
-																				pos:        position{line: 467, col: 10, offset: 8851},
+																				pos:        position{line: 479, col: 10, offset: 9161},
 																				val:        "[^\\r\\n]",
 																				chars:      []rune{'\r', '\n'},
 																				ignoreCase: false,
@@ -1203,7 +1461,7 @@ var g = &grammar{
 																			},
 																		},
 																		&litMatcher{
-																			pos:        position{line: 476, col: 5, offset: 8918},
+																			pos:        position{line: 488, col: 5, offset: 9228},
 																			val:        "\n",
 																			ignoreCase: false,
 																		},
@@ -1213,10 +1471,10 @@ var g = &grammar{
 														},
 													},
 													&labeledExpr{
-														pos:   position{line: 85, col: 12, offset: 1592},
+														pos:   position{line: 91, col: 12, offset: 1717},
 														label: "args",
 														expr: &ruleRefExpr{
-															pos:  position{line: 85, col: 17, offset: 1597},
+															pos:  position{line: 91, col: 17, offset: 1722},
 															name: "Arguments",
 														},
 													},
@@ -1224,35 +1482,35 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 88, col: 10, offset: 1680},
+											pos: position{line: 94, col: 10, offset: 1805},
 											run: (*parser).callonCallExpression33,
 											expr: &seqExpr{
-												pos: position{line: 88, col: 10, offset: 1680},
+												pos: position{line: 94, col: 10, offset: 1805},
 												exprs: []interface{}{
 													&zeroOrMoreExpr{
-														pos: position{line: 464, col: 5, offset: 8809},
+														pos: position{line: 476, col: 5, offset: 9119},
 														expr: &choiceExpr{
-															pos: position{line: 464, col: 7, offset: 8811},
+															pos: position{line: 476, col: 7, offset: 9121},
 															alternatives: []interface{}{
 																&charClassMatcher{
-																	pos:        position{line: 470, col: 5, offset: 8872},
+																	pos:        position{line: 482, col: 5, offset: 9182},
 																	val:        "[ \\t\\r\\n]",
 																	chars:      []rune{' ', '\t', '\r', '\n'},
 																	ignoreCase: false,
 																	inverted:   false,
 																},
 																&seqExpr{
-																	pos: position{line: 467, col: 5, offset: 8846},
+																	pos: position{line: 479, col: 5, offset: 9156},
 																	exprs: []interface{}{
 																		&litMatcher{
-																			pos:        position{line: 467, col: 5, offset: 8846},
+																			pos:        position{line: 479, col: 5, offset: 9156},
 																			val:        "//",
 																			ignoreCase: false,
 																		},
 																		&zeroOrMoreExpr{
-																			pos: position{line: 467, col: 10, offset: 8851},
+																			pos: position{line: 479, col: 10, offset: 9161},
 																			expr: &charClassMatcher{
-																				pos:        position{line: 467, col: 10, offset: 8851},
+																				pos:        position{line: 479, col: 10, offset: 9161},
 																				val:        "[^\\r\\n]",
 																				chars:      []rune{'\r', '\n'},
 																				ignoreCase: false,
@@ -1260,7 +1518,7 @@ var g = &grammar{
 																			},
 																		},
 																		&litMatcher{
-																			pos:        position{line: 476, col: 5, offset: 8918},
+																			pos:        position{line: 488, col: 5, offset: 9228},
 																			val:        "\n",
 																			ignoreCase: false,
 																		},
@@ -1270,10 +1528,10 @@ var g = &grammar{
 														},
 													},
 													&labeledExpr{
-														pos:   position{line: 88, col: 13, offset: 1683},
+														pos:   position{line: 94, col: 13, offset: 1808},
 														label: "property",
 														expr: &ruleRefExpr{
-															pos:  position{line: 88, col: 22, offset: 1692},
+															pos:  position{line: 94, col: 22, offset: 1817},
 															name: "MemberExpressionProperty",
 														},
 													},
@@ -1290,45 +1548,45 @@ var g = &grammar{
 		},
 		{
 			name: "PipeExpression",
-			pos:  position{line: 96, col: 1, offset: 1857},
+			pos:  position{line: 102, col: 1, offset: 1982},
 			expr: &actionExpr{
-				pos: position{line: 97, col: 5, offset: 1876},
+				pos: position{line: 103, col: 5, offset: 2001},
 				run: (*parser).callonPipeExpression1,
 				expr: &seqExpr{
-					pos: position{line: 97, col: 5, offset: 1876},
+					pos: position{line: 103, col: 5, offset: 2001},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 97, col: 5, offset: 1876},
+							pos:   position{line: 103, col: 5, offset: 2001},
 							label: "head",
 							expr: &ruleRefExpr{
-								pos:  position{line: 97, col: 10, offset: 1881},
+								pos:  position{line: 103, col: 10, offset: 2006},
 								name: "PipeExpressionHead",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -1336,7 +1594,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -1346,37 +1604,37 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 97, col: 32, offset: 1903},
+							pos:   position{line: 103, col: 32, offset: 2028},
 							label: "tail",
 							expr: &oneOrMoreExpr{
-								pos: position{line: 97, col: 37, offset: 1908},
+								pos: position{line: 103, col: 37, offset: 2033},
 								expr: &seqExpr{
-									pos: position{line: 97, col: 38, offset: 1909},
+									pos: position{line: 103, col: 38, offset: 2034},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -1384,7 +1642,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -1394,33 +1652,33 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 97, col: 41, offset: 1912},
+											pos:  position{line: 103, col: 41, offset: 2037},
 											name: "PipeExpressionPipe",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -1428,7 +1686,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -1447,37 +1705,37 @@ var g = &grammar{
 		},
 		{
 			name: "PipeExpressionHead",
-			pos:  position{line: 101, col: 1, offset: 1995},
+			pos:  position{line: 107, col: 1, offset: 2120},
 			expr: &choiceExpr{
-				pos: position{line: 102, col: 5, offset: 2018},
+				pos: position{line: 108, col: 5, offset: 2143},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 102, col: 5, offset: 2018},
+						pos:  position{line: 108, col: 5, offset: 2143},
 						name: "CallExpression",
 					},
 					&actionExpr{
-						pos: position{line: 378, col: 5, offset: 7121},
+						pos: position{line: 390, col: 5, offset: 7431},
 						run: (*parser).callonPipeExpressionHead3,
 						expr: &seqExpr{
-							pos: position{line: 378, col: 7, offset: 7123},
+							pos: position{line: 390, col: 7, offset: 7433},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 378, col: 7, offset: 7123},
+									pos:        position{line: 390, col: 7, offset: 7433},
 									val:        "\"",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 378, col: 11, offset: 7127},
+									pos: position{line: 390, col: 11, offset: 7437},
 									expr: &choiceExpr{
-										pos: position{line: 386, col: 5, offset: 7344},
+										pos: position{line: 398, col: 5, offset: 7654},
 										alternatives: []interface{}{
 											&seqExpr{
-												pos: position{line: 386, col: 5, offset: 7344},
+												pos: position{line: 398, col: 5, offset: 7654},
 												exprs: []interface{}{
 													&notExpr{
-														pos: position{line: 386, col: 5, offset: 7344},
+														pos: position{line: 398, col: 5, offset: 7654},
 														expr: &charClassMatcher{
-															pos:        position{line: 386, col: 8, offset: 7347},
+															pos:        position{line: 398, col: 8, offset: 7657},
 															val:        "[\"\\\\\\n]",
 															chars:      []rune{'"', '\\', '\n'},
 															ignoreCase: false,
@@ -1485,44 +1743,44 @@ var g = &grammar{
 														},
 													},
 													&anyMatcher{
-														line: 462, col: 5, offset: 8800,
+														line: 468, col: 5, offset: 8925,
 													},
 												},
 											},
 											&seqExpr{
-												pos: position{line: 387, col: 5, offset: 7381},
+												pos: position{line: 399, col: 5, offset: 7691},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 387, col: 5, offset: 7381},
+														pos:        position{line: 399, col: 5, offset: 7691},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&choiceExpr{
-														pos: position{line: 390, col: 5, offset: 7429},
+														pos: position{line: 402, col: 5, offset: 7739},
 														alternatives: []interface{}{
 															&litMatcher{
-																pos:        position{line: 390, col: 5, offset: 7429},
+																pos:        position{line: 402, col: 5, offset: 7739},
 																val:        "\"",
 																ignoreCase: false,
 															},
 															&actionExpr{
-																pos: position{line: 391, col: 5, offset: 7437},
+																pos: position{line: 403, col: 5, offset: 7747},
 																run: (*parser).callonPipeExpressionHead16,
 																expr: &choiceExpr{
-																	pos: position{line: 391, col: 7, offset: 7439},
+																	pos: position{line: 403, col: 7, offset: 7749},
 																	alternatives: []interface{}{
 																		&anyMatcher{
-																			line: 462, col: 5, offset: 8800,
+																			line: 468, col: 5, offset: 8925,
 																		},
 																		&litMatcher{
-																			pos:        position{line: 476, col: 5, offset: 8918},
+																			pos:        position{line: 488, col: 5, offset: 9228},
 																			val:        "\n",
 																			ignoreCase: false,
 																		},
 																		&notExpr{
-																			pos: position{line: 479, col: 5, offset: 8932},
+																			pos: position{line: 491, col: 5, offset: 9242},
 																			expr: &anyMatcher{
-																				line: 479, col: 6, offset: 8933,
+																				line: 485, col: 6, offset: 9058,
 																			},
 																		},
 																	},
@@ -1536,7 +1794,7 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 378, col: 29, offset: 7145},
+									pos:        position{line: 390, col: 29, offset: 7455},
 									val:        "\"",
 									ignoreCase: false,
 								},
@@ -1544,28 +1802,28 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 381, col: 5, offset: 7209},
+						pos: position{line: 393, col: 5, offset: 7519},
 						run: (*parser).callonPipeExpressionHead23,
 						expr: &seqExpr{
-							pos: position{line: 381, col: 7, offset: 7211},
+							pos: position{line: 393, col: 7, offset: 7521},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 381, col: 7, offset: 7211},
+									pos:        position{line: 393, col: 7, offset: 7521},
 									val:        "\"",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 381, col: 11, offset: 7215},
+									pos: position{line: 393, col: 11, offset: 7525},
 									expr: &choiceExpr{
-										pos: position{line: 386, col: 5, offset: 7344},
+										pos: position{line: 398, col: 5, offset: 7654},
 										alternatives: []interface{}{
 											&seqExpr{
-												pos: position{line: 386, col: 5, offset: 7344},
+												pos: position{line: 398, col: 5, offset: 7654},
 												exprs: []interface{}{
 													&notExpr{
-														pos: position{line: 386, col: 5, offset: 7344},
+														pos: position{line: 398, col: 5, offset: 7654},
 														expr: &charClassMatcher{
-															pos:        position{line: 386, col: 8, offset: 7347},
+															pos:        position{line: 398, col: 8, offset: 7657},
 															val:        "[\"\\\\\\n]",
 															chars:      []rune{'"', '\\', '\n'},
 															ignoreCase: false,
@@ -1573,44 +1831,44 @@ var g = &grammar{
 														},
 													},
 													&anyMatcher{
-														line: 462, col: 5, offset: 8800,
+														line: 468, col: 5, offset: 8925,
 													},
 												},
 											},
 											&seqExpr{
-												pos: position{line: 387, col: 5, offset: 7381},
+												pos: position{line: 399, col: 5, offset: 7691},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 387, col: 5, offset: 7381},
+														pos:        position{line: 399, col: 5, offset: 7691},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&choiceExpr{
-														pos: position{line: 390, col: 5, offset: 7429},
+														pos: position{line: 402, col: 5, offset: 7739},
 														alternatives: []interface{}{
 															&litMatcher{
-																pos:        position{line: 390, col: 5, offset: 7429},
+																pos:        position{line: 402, col: 5, offset: 7739},
 																val:        "\"",
 																ignoreCase: false,
 															},
 															&actionExpr{
-																pos: position{line: 391, col: 5, offset: 7437},
+																pos: position{line: 403, col: 5, offset: 7747},
 																run: (*parser).callonPipeExpressionHead36,
 																expr: &choiceExpr{
-																	pos: position{line: 391, col: 7, offset: 7439},
+																	pos: position{line: 403, col: 7, offset: 7749},
 																	alternatives: []interface{}{
 																		&anyMatcher{
-																			line: 462, col: 5, offset: 8800,
+																			line: 468, col: 5, offset: 8925,
 																		},
 																		&litMatcher{
-																			pos:        position{line: 476, col: 5, offset: 8918},
+																			pos:        position{line: 488, col: 5, offset: 9228},
 																			val:        "\n",
 																			ignoreCase: false,
 																		},
 																		&notExpr{
-																			pos: position{line: 479, col: 5, offset: 8932},
+																			pos: position{line: 491, col: 5, offset: 9242},
 																			expr: &anyMatcher{
-																				line: 479, col: 6, offset: 8933,
+																				line: 485, col: 6, offset: 9058,
 																			},
 																		},
 																	},
@@ -1624,17 +1882,17 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 381, col: 31, offset: 7235},
+									pos: position{line: 393, col: 31, offset: 7545},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 476, col: 5, offset: 8918},
+											pos:        position{line: 488, col: 5, offset: 9228},
 											val:        "\n",
 											ignoreCase: false,
 										},
 										&notExpr{
-											pos: position{line: 479, col: 5, offset: 8932},
+											pos: position{line: 491, col: 5, offset: 9242},
 											expr: &anyMatcher{
-												line: 479, col: 6, offset: 8933,
+												line: 485, col: 6, offset: 9058,
 											},
 										},
 									},
@@ -1643,35 +1901,35 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 396, col: 5, offset: 7549},
+						pos: position{line: 408, col: 5, offset: 7859},
 						run: (*parser).callonPipeExpressionHead46,
 						expr: &seqExpr{
-							pos: position{line: 396, col: 5, offset: 7549},
+							pos: position{line: 408, col: 5, offset: 7859},
 							exprs: []interface{}{
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -1679,7 +1937,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -1689,34 +1947,34 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 396, col: 8, offset: 7552},
+									pos:        position{line: 408, col: 8, offset: 7862},
 									val:        "true",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -1724,7 +1982,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -1737,35 +1995,35 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 399, col: 5, offset: 7623},
+						pos: position{line: 411, col: 5, offset: 7933},
 						run: (*parser).callonPipeExpressionHead65,
 						expr: &seqExpr{
-							pos: position{line: 399, col: 5, offset: 7623},
+							pos: position{line: 411, col: 5, offset: 7933},
 							exprs: []interface{}{
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -1773,7 +2031,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -1783,34 +2041,34 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 399, col: 8, offset: 7626},
+									pos:        position{line: 411, col: 8, offset: 7936},
 									val:        "false",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -1818,7 +2076,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -1831,40 +2089,40 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 428, col: 5, offset: 8052},
+						pos: position{line: 440, col: 5, offset: 8362},
 						run: (*parser).callonPipeExpressionHead84,
 						expr: &seqExpr{
-							pos: position{line: 428, col: 5, offset: 8052},
+							pos: position{line: 440, col: 5, offset: 8362},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 428, col: 5, offset: 8052},
+									pos:        position{line: 440, col: 5, offset: 8362},
 									val:        "/",
 									ignoreCase: false,
 								},
 								&labeledExpr{
-									pos:   position{line: 428, col: 9, offset: 8056},
+									pos:   position{line: 440, col: 9, offset: 8366},
 									label: "pattern",
 									expr: &actionExpr{
-										pos: position{line: 433, col: 5, offset: 8155},
+										pos: position{line: 445, col: 5, offset: 8465},
 										run: (*parser).callonPipeExpressionHead88,
 										expr: &labeledExpr{
-											pos:   position{line: 433, col: 5, offset: 8155},
+											pos:   position{line: 445, col: 5, offset: 8465},
 											label: "chars",
 											expr: &oneOrMoreExpr{
-												pos: position{line: 433, col: 11, offset: 8161},
+												pos: position{line: 445, col: 11, offset: 8471},
 												expr: &choiceExpr{
-													pos: position{line: 438, col: 5, offset: 8267},
+													pos: position{line: 450, col: 5, offset: 8577},
 													alternatives: []interface{}{
 														&actionExpr{
-															pos: position{line: 438, col: 5, offset: 8267},
+															pos: position{line: 450, col: 5, offset: 8577},
 															run: (*parser).callonPipeExpressionHead92,
 															expr: &seqExpr{
-																pos: position{line: 438, col: 5, offset: 8267},
+																pos: position{line: 450, col: 5, offset: 8577},
 																exprs: []interface{}{
 																	&notExpr{
-																		pos: position{line: 438, col: 5, offset: 8267},
+																		pos: position{line: 450, col: 5, offset: 8577},
 																		expr: &charClassMatcher{
-																			pos:        position{line: 438, col: 6, offset: 8268},
+																			pos:        position{line: 450, col: 6, offset: 8578},
 																			val:        "[\\\\/]",
 																			chars:      []rune{'\\', '/'},
 																			ignoreCase: false,
@@ -1872,18 +2130,18 @@ var g = &grammar{
 																		},
 																	},
 																	&labeledExpr{
-																		pos:   position{line: 438, col: 12, offset: 8274},
+																		pos:   position{line: 450, col: 12, offset: 8584},
 																		label: "re",
 																		expr: &actionExpr{
-																			pos: position{line: 450, col: 5, offset: 8528},
+																			pos: position{line: 462, col: 5, offset: 8838},
 																			run: (*parser).callonPipeExpressionHead97,
 																			expr: &seqExpr{
-																				pos: position{line: 450, col: 5, offset: 8528},
+																				pos: position{line: 462, col: 5, offset: 8838},
 																				exprs: []interface{}{
 																					&notExpr{
-																						pos: position{line: 450, col: 5, offset: 8528},
+																						pos: position{line: 462, col: 5, offset: 8838},
 																						expr: &charClassMatcher{
-																							pos:        position{line: 473, col: 5, offset: 8902},
+																							pos:        position{line: 485, col: 5, offset: 9212},
 																							val:        "[\\n\\r]",
 																							chars:      []rune{'\n', '\r'},
 																							ignoreCase: false,
@@ -1891,7 +2149,7 @@ var g = &grammar{
 																						},
 																					},
 																					&anyMatcher{
-																						line: 462, col: 5, offset: 8800,
+																						line: 468, col: 5, offset: 8925,
 																					},
 																				},
 																			},
@@ -1901,32 +2159,32 @@ var g = &grammar{
 															},
 														},
 														&actionExpr{
-															pos: position{line: 444, col: 5, offset: 8416},
+															pos: position{line: 456, col: 5, offset: 8726},
 															run: (*parser).callonPipeExpressionHead102,
 															expr: &litMatcher{
-																pos:        position{line: 444, col: 5, offset: 8416},
+																pos:        position{line: 456, col: 5, offset: 8726},
 																val:        "\\/",
 																ignoreCase: false,
 															},
 														},
 														&seqExpr{
-															pos: position{line: 447, col: 5, offset: 8456},
+															pos: position{line: 459, col: 5, offset: 8766},
 															exprs: []interface{}{
 																&litMatcher{
-																	pos:        position{line: 447, col: 5, offset: 8456},
+																	pos:        position{line: 459, col: 5, offset: 8766},
 																	val:        "\\",
 																	ignoreCase: false,
 																},
 																&actionExpr{
-																	pos: position{line: 450, col: 5, offset: 8528},
+																	pos: position{line: 462, col: 5, offset: 8838},
 																	run: (*parser).callonPipeExpressionHead106,
 																	expr: &seqExpr{
-																		pos: position{line: 450, col: 5, offset: 8528},
+																		pos: position{line: 462, col: 5, offset: 8838},
 																		exprs: []interface{}{
 																			&notExpr{
-																				pos: position{line: 450, col: 5, offset: 8528},
+																				pos: position{line: 462, col: 5, offset: 8838},
 																				expr: &charClassMatcher{
-																					pos:        position{line: 473, col: 5, offset: 8902},
+																					pos:        position{line: 485, col: 5, offset: 9212},
 																					val:        "[\\n\\r]",
 																					chars:      []rune{'\n', '\r'},
 																					ignoreCase: false,
@@ -1934,7 +2192,7 @@ var g = &grammar{
 																				},
 																			},
 																			&anyMatcher{
-																				line: 462, col: 5, offset: 8800,
+																				line: 468, col: 5, offset: 8925,
 																			},
 																		},
 																	},
@@ -1948,7 +2206,7 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 428, col: 39, offset: 8086},
+									pos:        position{line: 440, col: 39, offset: 8396},
 									val:        "/",
 									ignoreCase: false,
 								},
@@ -1956,44 +2214,44 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 423, col: 5, offset: 7960},
+						pos: position{line: 435, col: 5, offset: 8270},
 						run: (*parser).callonPipeExpressionHead112,
 						expr: &litMatcher{
-							pos:        position{line: 423, col: 5, offset: 7960},
+							pos:        position{line: 435, col: 5, offset: 8270},
 							val:        "<-",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 373, col: 5, offset: 7034},
+						pos: position{line: 385, col: 5, offset: 7344},
 						run: (*parser).callonPipeExpressionHead114,
 						expr: &oneOrMoreExpr{
-							pos: position{line: 373, col: 5, offset: 7034},
+							pos: position{line: 385, col: 5, offset: 7344},
 							expr: &seqExpr{
-								pos: position{line: 370, col: 5, offset: 6991},
+								pos: position{line: 382, col: 5, offset: 7301},
 								exprs: []interface{}{
 									&choiceExpr{
-										pos: position{line: 409, col: 6, offset: 7796},
+										pos: position{line: 421, col: 6, offset: 8106},
 										alternatives: []interface{}{
 											&litMatcher{
-												pos:        position{line: 409, col: 6, offset: 7796},
+												pos:        position{line: 421, col: 6, offset: 8106},
 												val:        "0",
 												ignoreCase: false,
 											},
 											&seqExpr{
-												pos: position{line: 409, col: 12, offset: 7802},
+												pos: position{line: 421, col: 12, offset: 8112},
 												exprs: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 417, col: 5, offset: 7920},
+														pos:        position{line: 429, col: 5, offset: 8230},
 														val:        "[1-9]",
 														ranges:     []rune{'1', '9'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 409, col: 25, offset: 7815},
+														pos: position{line: 421, col: 25, offset: 8125},
 														expr: &charClassMatcher{
-															pos:        position{line: 420, col: 5, offset: 7937},
+															pos:        position{line: 432, col: 5, offset: 8247},
 															val:        "[0-9]",
 															ranges:     []rune{'0', '9'},
 															ignoreCase: false,
@@ -2005,35 +2263,35 @@ var g = &grammar{
 										},
 									},
 									&choiceExpr{
-										pos: position{line: 361, col: 9, offset: 6841},
+										pos: position{line: 373, col: 9, offset: 7151},
 										alternatives: []interface{}{
 											&litMatcher{
-												pos:        position{line: 342, col: 5, offset: 6674},
+												pos:        position{line: 354, col: 5, offset: 6984},
 												val:        "ns",
 												ignoreCase: false,
 											},
 											&litMatcher{
-												pos:        position{line: 345, col: 6, offset: 6702},
+												pos:        position{line: 357, col: 6, offset: 7012},
 												val:        "us",
 												ignoreCase: false,
 											},
 											&litMatcher{
-												pos:        position{line: 345, col: 13, offset: 6709},
+												pos:        position{line: 357, col: 13, offset: 7019},
 												val:        "µs",
 												ignoreCase: false,
 											},
 											&litMatcher{
-												pos:        position{line: 345, col: 20, offset: 6717},
+												pos:        position{line: 357, col: 20, offset: 7027},
 												val:        "μs",
 												ignoreCase: false,
 											},
 											&litMatcher{
-												pos:        position{line: 348, col: 5, offset: 6746},
+												pos:        position{line: 360, col: 5, offset: 7056},
 												val:        "ms",
 												ignoreCase: false,
 											},
 											&charClassMatcher{
-												pos:        position{line: 351, col: 5, offset: 6768},
+												pos:        position{line: 363, col: 5, offset: 7078},
 												val:        "[smh]",
 												chars:      []rune{'s', 'm', 'h'},
 												ignoreCase: false,
@@ -2046,148 +2304,148 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 337, col: 5, offset: 6586},
+						pos: position{line: 349, col: 5, offset: 6896},
 						run: (*parser).callonPipeExpressionHead130,
 						expr: &seqExpr{
-							pos: position{line: 337, col: 5, offset: 6586},
+							pos: position{line: 349, col: 5, offset: 6896},
 							exprs: []interface{}{
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 331, col: 18, offset: 6501},
+									pos:        position{line: 343, col: 18, offset: 6811},
 									val:        "-",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 331, col: 32, offset: 6515},
+									pos:        position{line: 343, col: 32, offset: 6825},
 									val:        "-",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 337, col: 14, offset: 6595},
+									pos:        position{line: 349, col: 14, offset: 6905},
 									val:        "T",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 328, col: 14, offset: 6431},
+									pos:        position{line: 340, col: 14, offset: 6741},
 									val:        ":",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 328, col: 29, offset: 6446},
+									pos:        position{line: 340, col: 29, offset: 6756},
 									val:        ":",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 328, col: 44, offset: 6461},
+									pos: position{line: 340, col: 44, offset: 6771},
 									expr: &seqExpr{
-										pos: position{line: 319, col: 5, offset: 6301},
+										pos: position{line: 331, col: 5, offset: 6611},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 319, col: 5, offset: 6301},
+												pos:        position{line: 331, col: 5, offset: 6611},
 												val:        ".",
 												ignoreCase: false,
 											},
 											&oneOrMoreExpr{
-												pos: position{line: 319, col: 9, offset: 6305},
+												pos: position{line: 331, col: 9, offset: 6615},
 												expr: &charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
@@ -2198,51 +2456,51 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 325, col: 6, offset: 6384},
+									pos: position{line: 337, col: 6, offset: 6694},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 325, col: 6, offset: 6384},
+											pos:        position{line: 337, col: 6, offset: 6694},
 											val:        "Z",
 											ignoreCase: false,
 										},
 										&seqExpr{
-											pos: position{line: 322, col: 5, offset: 6331},
+											pos: position{line: 334, col: 5, offset: 6641},
 											exprs: []interface{}{
 												&charClassMatcher{
-													pos:        position{line: 322, col: 6, offset: 6332},
+													pos:        position{line: 334, col: 6, offset: 6642},
 													val:        "[+-]",
 													chars:      []rune{'+', '-'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&litMatcher{
-													pos:        position{line: 322, col: 26, offset: 6352},
+													pos:        position{line: 334, col: 26, offset: 6662},
 													val:        ":",
 													ignoreCase: false,
 												},
 												&charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
@@ -2256,33 +2514,33 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 404, col: 5, offset: 7714},
+						pos: position{line: 416, col: 5, offset: 8024},
 						run: (*parser).callonPipeExpressionHead165,
 						expr: &seqExpr{
-							pos: position{line: 404, col: 5, offset: 7714},
+							pos: position{line: 416, col: 5, offset: 8024},
 							exprs: []interface{}{
 								&choiceExpr{
-									pos: position{line: 409, col: 6, offset: 7796},
+									pos: position{line: 421, col: 6, offset: 8106},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 409, col: 6, offset: 7796},
+											pos:        position{line: 421, col: 6, offset: 8106},
 											val:        "0",
 											ignoreCase: false,
 										},
 										&seqExpr{
-											pos: position{line: 409, col: 12, offset: 7802},
+											pos: position{line: 421, col: 12, offset: 8112},
 											exprs: []interface{}{
 												&charClassMatcher{
-													pos:        position{line: 417, col: 5, offset: 7920},
+													pos:        position{line: 429, col: 5, offset: 8230},
 													val:        "[1-9]",
 													ranges:     []rune{'1', '9'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 409, col: 25, offset: 7815},
+													pos: position{line: 421, col: 25, offset: 8125},
 													expr: &charClassMatcher{
-														pos:        position{line: 420, col: 5, offset: 7937},
+														pos:        position{line: 432, col: 5, offset: 8247},
 														val:        "[0-9]",
 														ranges:     []rune{'0', '9'},
 														ignoreCase: false,
@@ -2294,14 +2552,14 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 404, col: 13, offset: 7722},
+									pos:        position{line: 416, col: 13, offset: 8032},
 									val:        ".",
 									ignoreCase: false,
 								},
 								&oneOrMoreExpr{
-									pos: position{line: 404, col: 17, offset: 7726},
+									pos: position{line: 416, col: 17, offset: 8036},
 									expr: &charClassMatcher{
-										pos:        position{line: 420, col: 5, offset: 7937},
+										pos:        position{line: 432, col: 5, offset: 8247},
 										val:        "[0-9]",
 										ranges:     []rune{'0', '9'},
 										ignoreCase: false,
@@ -2312,30 +2570,30 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 412, col: 5, offset: 7843},
+						pos: position{line: 424, col: 5, offset: 8153},
 						run: (*parser).callonPipeExpressionHead176,
 						expr: &choiceExpr{
-							pos: position{line: 409, col: 6, offset: 7796},
+							pos: position{line: 421, col: 6, offset: 8106},
 							alternatives: []interface{}{
 								&litMatcher{
-									pos:        position{line: 409, col: 6, offset: 7796},
+									pos:        position{line: 421, col: 6, offset: 8106},
 									val:        "0",
 									ignoreCase: false,
 								},
 								&seqExpr{
-									pos: position{line: 409, col: 12, offset: 7802},
+									pos: position{line: 421, col: 12, offset: 8112},
 									exprs: []interface{}{
 										&charClassMatcher{
-											pos:        position{line: 417, col: 5, offset: 7920},
+											pos:        position{line: 429, col: 5, offset: 8230},
 											val:        "[1-9]",
 											ranges:     []rune{'1', '9'},
 											ignoreCase: false,
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 409, col: 25, offset: 7815},
+											pos: position{line: 421, col: 25, offset: 8125},
 											expr: &charClassMatcher{
-												pos:        position{line: 420, col: 5, offset: 7937},
+												pos:        position{line: 432, col: 5, offset: 8247},
 												val:        "[0-9]",
 												ranges:     []rune{'0', '9'},
 												ignoreCase: false,
@@ -2348,21 +2606,21 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 104, col: 5, offset: 2171},
+						pos:  position{line: 110, col: 5, offset: 2296},
 						name: "Array",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 105, col: 5, offset: 2181},
+						pos:  position{line: 111, col: 5, offset: 2306},
 						name: "MemberExpressions",
 					},
 					&actionExpr{
-						pos: position{line: 456, col: 5, offset: 8719},
+						pos: position{line: 468, col: 5, offset: 9029},
 						run: (*parser).callonPipeExpressionHead185,
 						expr: &seqExpr{
-							pos: position{line: 456, col: 5, offset: 8719},
+							pos: position{line: 468, col: 5, offset: 9029},
 							exprs: []interface{}{
 								&charClassMatcher{
-									pos:        position{line: 456, col: 5, offset: 8719},
+									pos:        position{line: 468, col: 5, offset: 9029},
 									val:        "[_\\pL]",
 									chars:      []rune{'_'},
 									classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -2370,9 +2628,9 @@ var g = &grammar{
 									inverted:   false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 456, col: 11, offset: 8725},
+									pos: position{line: 468, col: 11, offset: 9035},
 									expr: &charClassMatcher{
-										pos:        position{line: 456, col: 11, offset: 8725},
+										pos:        position{line: 468, col: 11, offset: 9035},
 										val:        "[_0-9\\pL]",
 										chars:      []rune{'_'},
 										ranges:     []rune{'0', '9'},
@@ -2385,15 +2643,15 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 107, col: 5, offset: 2218},
+						pos:  position{line: 113, col: 5, offset: 2343},
 						name: "ObjectExpression",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 108, col: 5, offset: 2239},
+						pos:  position{line: 114, col: 5, offset: 2364},
 						name: "ArrowFunctionExpression",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 109, col: 5, offset: 2267},
+						pos:  position{line: 115, col: 5, offset: 2392},
 						name: "Parens",
 					},
 				},
@@ -2401,42 +2659,42 @@ var g = &grammar{
 		},
 		{
 			name: "PipeExpressionPipe",
-			pos:  position{line: 111, col: 1, offset: 2275},
+			pos:  position{line: 117, col: 1, offset: 2400},
 			expr: &actionExpr{
-				pos: position{line: 112, col: 5, offset: 2298},
+				pos: position{line: 118, col: 5, offset: 2423},
 				run: (*parser).callonPipeExpressionPipe1,
 				expr: &seqExpr{
-					pos: position{line: 112, col: 5, offset: 2298},
+					pos: position{line: 118, col: 5, offset: 2423},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 112, col: 5, offset: 2298},
+							pos:        position{line: 118, col: 5, offset: 2423},
 							val:        "|>",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2444,7 +2702,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2454,10 +2712,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 112, col: 13, offset: 2306},
+							pos:   position{line: 118, col: 13, offset: 2431},
 							label: "call",
 							expr: &ruleRefExpr{
-								pos:  position{line: 112, col: 18, offset: 2311},
+								pos:  position{line: 118, col: 18, offset: 2436},
 								name: "CallExpression",
 							},
 						},
@@ -2467,42 +2725,42 @@ var g = &grammar{
 		},
 		{
 			name: "Arguments",
-			pos:  position{line: 116, col: 1, offset: 2388},
+			pos:  position{line: 122, col: 1, offset: 2513},
 			expr: &actionExpr{
-				pos: position{line: 117, col: 5, offset: 2402},
+				pos: position{line: 123, col: 5, offset: 2527},
 				run: (*parser).callonArguments1,
 				expr: &seqExpr{
-					pos: position{line: 117, col: 5, offset: 2402},
+					pos: position{line: 123, col: 5, offset: 2527},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 117, col: 5, offset: 2402},
+							pos:        position{line: 123, col: 5, offset: 2527},
 							val:        "(",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2510,7 +2768,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2520,40 +2778,40 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 117, col: 12, offset: 2409},
+							pos:   position{line: 123, col: 12, offset: 2534},
 							label: "args",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 117, col: 17, offset: 2414},
+								pos: position{line: 123, col: 17, offset: 2539},
 								expr: &ruleRefExpr{
-									pos:  position{line: 117, col: 18, offset: 2415},
+									pos:  position{line: 123, col: 18, offset: 2540},
 									name: "ObjectProperties",
 								},
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2561,7 +2819,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2571,7 +2829,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 117, col: 40, offset: 2437},
+							pos:        position{line: 123, col: 40, offset: 2562},
 							val:        ")",
 							ignoreCase: false,
 						},
@@ -2581,42 +2839,42 @@ var g = &grammar{
 		},
 		{
 			name: "ArrowFunctionExpression",
-			pos:  position{line: 121, col: 1, offset: 2473},
+			pos:  position{line: 127, col: 1, offset: 2598},
 			expr: &actionExpr{
-				pos: position{line: 122, col: 5, offset: 2501},
+				pos: position{line: 128, col: 5, offset: 2626},
 				run: (*parser).callonArrowFunctionExpression1,
 				expr: &seqExpr{
-					pos: position{line: 122, col: 5, offset: 2501},
+					pos: position{line: 128, col: 5, offset: 2626},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 122, col: 5, offset: 2501},
+							pos:        position{line: 128, col: 5, offset: 2626},
 							val:        "(",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2624,7 +2882,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2634,40 +2892,40 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 122, col: 12, offset: 2508},
+							pos:   position{line: 128, col: 12, offset: 2633},
 							label: "params",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 122, col: 19, offset: 2515},
+								pos: position{line: 128, col: 19, offset: 2640},
 								expr: &ruleRefExpr{
-									pos:  position{line: 122, col: 19, offset: 2515},
+									pos:  position{line: 128, col: 19, offset: 2640},
 									name: "ArrowFunctionParams",
 								},
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2675,7 +2933,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2685,34 +2943,34 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 122, col: 43, offset: 2539},
+							pos:        position{line: 128, col: 43, offset: 2664},
 							val:        ")",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2720,7 +2978,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2730,34 +2988,34 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 122, col: 50, offset: 2546},
+							pos:        position{line: 128, col: 50, offset: 2671},
 							val:        "=>",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2765,7 +3023,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2775,37 +3033,37 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 122, col: 58, offset: 2554},
+							pos:   position{line: 128, col: 58, offset: 2679},
 							label: "body",
 							expr: &ruleRefExpr{
-								pos:  position{line: 122, col: 63, offset: 2559},
+								pos:  position{line: 128, col: 63, offset: 2684},
 								name: "ArrowFunctionBody",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2813,7 +3071,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2828,45 +3086,45 @@ var g = &grammar{
 		},
 		{
 			name: "ArrowFunctionParams",
-			pos:  position{line: 126, col: 1, offset: 2646},
+			pos:  position{line: 132, col: 1, offset: 2771},
 			expr: &actionExpr{
-				pos: position{line: 127, col: 5, offset: 2670},
+				pos: position{line: 133, col: 5, offset: 2795},
 				run: (*parser).callonArrowFunctionParams1,
 				expr: &seqExpr{
-					pos: position{line: 127, col: 5, offset: 2670},
+					pos: position{line: 133, col: 5, offset: 2795},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 127, col: 5, offset: 2670},
+							pos:   position{line: 133, col: 5, offset: 2795},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 127, col: 11, offset: 2676},
+								pos:  position{line: 133, col: 11, offset: 2801},
 								name: "ArrowFunctionParam",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2874,7 +3132,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2884,20 +3142,20 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 127, col: 33, offset: 2698},
+							pos:   position{line: 133, col: 33, offset: 2823},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 127, col: 38, offset: 2703},
+								pos: position{line: 133, col: 38, offset: 2828},
 								expr: &ruleRefExpr{
-									pos:  position{line: 127, col: 38, offset: 2703},
+									pos:  position{line: 133, col: 38, offset: 2828},
 									name: "ArrowFunctionParamsRest",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 127, col: 63, offset: 2728},
+							pos: position{line: 133, col: 63, offset: 2853},
 							expr: &litMatcher{
-								pos:        position{line: 127, col: 63, offset: 2728},
+								pos:        position{line: 133, col: 63, offset: 2853},
 								val:        ",",
 								ignoreCase: false,
 							},
@@ -2908,42 +3166,42 @@ var g = &grammar{
 		},
 		{
 			name: "ArrowFunctionParamsRest",
-			pos:  position{line: 131, col: 1, offset: 2813},
+			pos:  position{line: 137, col: 1, offset: 2938},
 			expr: &actionExpr{
-				pos: position{line: 132, col: 5, offset: 2841},
+				pos: position{line: 138, col: 5, offset: 2966},
 				run: (*parser).callonArrowFunctionParamsRest1,
 				expr: &seqExpr{
-					pos: position{line: 132, col: 5, offset: 2841},
+					pos: position{line: 138, col: 5, offset: 2966},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 132, col: 5, offset: 2841},
+							pos:        position{line: 138, col: 5, offset: 2966},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2951,7 +3209,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -2961,37 +3219,37 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 132, col: 13, offset: 2849},
+							pos:   position{line: 138, col: 13, offset: 2974},
 							label: "arg",
 							expr: &ruleRefExpr{
-								pos:  position{line: 132, col: 17, offset: 2853},
+								pos:  position{line: 138, col: 17, offset: 2978},
 								name: "ArrowFunctionParam",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -2999,7 +3257,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -3014,27 +3272,27 @@ var g = &grammar{
 		},
 		{
 			name: "ArrowFunctionParam",
-			pos:  position{line: 136, col: 1, offset: 2906},
+			pos:  position{line: 142, col: 1, offset: 3031},
 			expr: &choiceExpr{
-				pos: position{line: 137, col: 5, offset: 2929},
+				pos: position{line: 143, col: 5, offset: 3054},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 137, col: 5, offset: 2929},
+						pos: position{line: 143, col: 5, offset: 3054},
 						run: (*parser).callonArrowFunctionParam2,
 						expr: &seqExpr{
-							pos: position{line: 137, col: 5, offset: 2929},
+							pos: position{line: 143, col: 5, offset: 3054},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 137, col: 5, offset: 2929},
+									pos:   position{line: 143, col: 5, offset: 3054},
 									label: "key",
 									expr: &actionExpr{
-										pos: position{line: 456, col: 5, offset: 8719},
+										pos: position{line: 468, col: 5, offset: 9029},
 										run: (*parser).callonArrowFunctionParam5,
 										expr: &seqExpr{
-											pos: position{line: 456, col: 5, offset: 8719},
+											pos: position{line: 468, col: 5, offset: 9029},
 											exprs: []interface{}{
 												&charClassMatcher{
-													pos:        position{line: 456, col: 5, offset: 8719},
+													pos:        position{line: 468, col: 5, offset: 9029},
 													val:        "[_\\pL]",
 													chars:      []rune{'_'},
 													classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -3042,9 +3300,9 @@ var g = &grammar{
 													inverted:   false,
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 456, col: 11, offset: 8725},
+													pos: position{line: 468, col: 11, offset: 9035},
 													expr: &charClassMatcher{
-														pos:        position{line: 456, col: 11, offset: 8725},
+														pos:        position{line: 468, col: 11, offset: 9035},
 														val:        "[_0-9\\pL]",
 														chars:      []rune{'_'},
 														ranges:     []rune{'0', '9'},
@@ -3058,29 +3316,29 @@ var g = &grammar{
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -3088,7 +3346,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -3098,34 +3356,34 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 137, col: 23, offset: 2947},
+									pos:        position{line: 143, col: 23, offset: 3072},
 									val:        "=",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -3133,7 +3391,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -3143,37 +3401,37 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 137, col: 30, offset: 2954},
+									pos:   position{line: 143, col: 30, offset: 3079},
 									label: "value",
 									expr: &ruleRefExpr{
-										pos:  position{line: 137, col: 36, offset: 2960},
+										pos:  position{line: 143, col: 36, offset: 3085},
 										name: "Primary",
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -3181,7 +3439,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -3194,22 +3452,22 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 140, col: 5, offset: 3033},
+						pos: position{line: 146, col: 5, offset: 3158},
 						run: (*parser).callonArrowFunctionParam37,
 						expr: &seqExpr{
-							pos: position{line: 140, col: 5, offset: 3033},
+							pos: position{line: 146, col: 5, offset: 3158},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 140, col: 5, offset: 3033},
+									pos:   position{line: 146, col: 5, offset: 3158},
 									label: "key",
 									expr: &actionExpr{
-										pos: position{line: 456, col: 5, offset: 8719},
+										pos: position{line: 468, col: 5, offset: 9029},
 										run: (*parser).callonArrowFunctionParam40,
 										expr: &seqExpr{
-											pos: position{line: 456, col: 5, offset: 8719},
+											pos: position{line: 468, col: 5, offset: 9029},
 											exprs: []interface{}{
 												&charClassMatcher{
-													pos:        position{line: 456, col: 5, offset: 8719},
+													pos:        position{line: 468, col: 5, offset: 9029},
 													val:        "[_\\pL]",
 													chars:      []rune{'_'},
 													classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -3217,9 +3475,9 @@ var g = &grammar{
 													inverted:   false,
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 456, col: 11, offset: 8725},
+													pos: position{line: 468, col: 11, offset: 9035},
 													expr: &charClassMatcher{
-														pos:        position{line: 456, col: 11, offset: 8725},
+														pos:        position{line: 468, col: 11, offset: 9035},
 														val:        "[_0-9\\pL]",
 														chars:      []rune{'_'},
 														ranges:     []rune{'0', '9'},
@@ -3233,29 +3491,29 @@ var g = &grammar{
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -3263,7 +3521,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -3280,30 +3538,30 @@ var g = &grammar{
 		},
 		{
 			name: "ArrowFunctionBody",
-			pos:  position{line: 145, col: 1, offset: 3109},
+			pos:  position{line: 151, col: 1, offset: 3234},
 			expr: &choiceExpr{
-				pos: position{line: 146, col: 5, offset: 3131},
+				pos: position{line: 152, col: 5, offset: 3256},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 146, col: 5, offset: 3131},
+						pos: position{line: 152, col: 5, offset: 3256},
 						run: (*parser).callonArrowFunctionBody2,
 						expr: &labeledExpr{
-							pos:   position{line: 146, col: 5, offset: 3131},
+							pos:   position{line: 152, col: 5, offset: 3256},
 							label: "body",
 							expr: &ruleRefExpr{
-								pos:  position{line: 146, col: 10, offset: 3136},
+								pos:  position{line: 152, col: 10, offset: 3261},
 								name: "Expr",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 149, col: 5, offset: 3176},
+						pos: position{line: 155, col: 5, offset: 3301},
 						run: (*parser).callonArrowFunctionBody5,
 						expr: &labeledExpr{
-							pos:   position{line: 149, col: 5, offset: 3176},
+							pos:   position{line: 155, col: 5, offset: 3301},
 							label: "body",
 							expr: &ruleRefExpr{
-								pos:  position{line: 149, col: 10, offset: 3181},
+								pos:  position{line: 155, col: 10, offset: 3306},
 								name: "BlockStatement",
 							},
 						},
@@ -3313,42 +3571,42 @@ var g = &grammar{
 		},
 		{
 			name: "ObjectExpression",
-			pos:  position{line: 153, col: 1, offset: 3224},
+			pos:  position{line: 159, col: 1, offset: 3349},
 			expr: &actionExpr{
-				pos: position{line: 154, col: 5, offset: 3245},
+				pos: position{line: 160, col: 5, offset: 3370},
 				run: (*parser).callonObjectExpression1,
 				expr: &seqExpr{
-					pos: position{line: 154, col: 5, offset: 3245},
+					pos: position{line: 160, col: 5, offset: 3370},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 154, col: 5, offset: 3245},
+							pos:        position{line: 160, col: 5, offset: 3370},
 							val:        "{",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -3356,7 +3614,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -3366,40 +3624,40 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 154, col: 12, offset: 3252},
+							pos:   position{line: 160, col: 12, offset: 3377},
 							label: "object",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 154, col: 19, offset: 3259},
+								pos: position{line: 160, col: 19, offset: 3384},
 								expr: &ruleRefExpr{
-									pos:  position{line: 154, col: 20, offset: 3260},
+									pos:  position{line: 160, col: 20, offset: 3385},
 									name: "ObjectProperties",
 								},
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -3407,7 +3665,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -3417,7 +3675,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 154, col: 42, offset: 3282},
+							pos:        position{line: 160, col: 42, offset: 3407},
 							val:        "}",
 							ignoreCase: false,
 						},
@@ -3427,45 +3685,45 @@ var g = &grammar{
 		},
 		{
 			name: "ObjectProperties",
-			pos:  position{line: 158, col: 1, offset: 3316},
+			pos:  position{line: 164, col: 1, offset: 3441},
 			expr: &actionExpr{
-				pos: position{line: 159, col: 5, offset: 3337},
+				pos: position{line: 165, col: 5, offset: 3462},
 				run: (*parser).callonObjectProperties1,
 				expr: &seqExpr{
-					pos: position{line: 159, col: 5, offset: 3337},
+					pos: position{line: 165, col: 5, offset: 3462},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 159, col: 5, offset: 3337},
+							pos:   position{line: 165, col: 5, offset: 3462},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 159, col: 11, offset: 3343},
+								pos:  position{line: 165, col: 11, offset: 3468},
 								name: "Property",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -3473,7 +3731,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -3483,40 +3741,40 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 23, offset: 3355},
+							pos:   position{line: 165, col: 23, offset: 3480},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 159, col: 28, offset: 3360},
+								pos: position{line: 165, col: 28, offset: 3485},
 								expr: &ruleRefExpr{
-									pos:  position{line: 159, col: 28, offset: 3360},
+									pos:  position{line: 165, col: 28, offset: 3485},
 									name: "PropertiesRest",
 								},
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -3524,7 +3782,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -3534,9 +3792,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 159, col: 47, offset: 3379},
+							pos: position{line: 165, col: 47, offset: 3504},
 							expr: &litMatcher{
-								pos:        position{line: 159, col: 47, offset: 3379},
+								pos:        position{line: 165, col: 47, offset: 3504},
 								val:        ",",
 								ignoreCase: false,
 							},
@@ -3547,42 +3805,42 @@ var g = &grammar{
 		},
 		{
 			name: "PropertiesRest",
-			pos:  position{line: 163, col: 1, offset: 3445},
+			pos:  position{line: 169, col: 1, offset: 3570},
 			expr: &actionExpr{
-				pos: position{line: 164, col: 5, offset: 3464},
+				pos: position{line: 170, col: 5, offset: 3589},
 				run: (*parser).callonPropertiesRest1,
 				expr: &seqExpr{
-					pos: position{line: 164, col: 5, offset: 3464},
+					pos: position{line: 170, col: 5, offset: 3589},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 164, col: 5, offset: 3464},
+							pos:        position{line: 170, col: 5, offset: 3589},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -3590,7 +3848,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -3600,10 +3858,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 164, col: 13, offset: 3472},
+							pos:   position{line: 170, col: 13, offset: 3597},
 							label: "arg",
 							expr: &ruleRefExpr{
-								pos:  position{line: 164, col: 17, offset: 3476},
+								pos:  position{line: 170, col: 17, offset: 3601},
 								name: "Property",
 							},
 						},
@@ -3613,24 +3871,24 @@ var g = &grammar{
 		},
 		{
 			name: "Property",
-			pos:  position{line: 168, col: 1, offset: 3516},
+			pos:  position{line: 174, col: 1, offset: 3641},
 			expr: &actionExpr{
-				pos: position{line: 169, col: 5, offset: 3529},
+				pos: position{line: 175, col: 5, offset: 3654},
 				run: (*parser).callonProperty1,
 				expr: &seqExpr{
-					pos: position{line: 169, col: 5, offset: 3529},
+					pos: position{line: 175, col: 5, offset: 3654},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 169, col: 5, offset: 3529},
+							pos:   position{line: 175, col: 5, offset: 3654},
 							label: "key",
 							expr: &actionExpr{
-								pos: position{line: 456, col: 5, offset: 8719},
+								pos: position{line: 468, col: 5, offset: 9029},
 								run: (*parser).callonProperty4,
 								expr: &seqExpr{
-									pos: position{line: 456, col: 5, offset: 8719},
+									pos: position{line: 468, col: 5, offset: 9029},
 									exprs: []interface{}{
 										&charClassMatcher{
-											pos:        position{line: 456, col: 5, offset: 8719},
+											pos:        position{line: 468, col: 5, offset: 9029},
 											val:        "[_\\pL]",
 											chars:      []rune{'_'},
 											classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -3638,9 +3896,9 @@ var g = &grammar{
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 456, col: 11, offset: 8725},
+											pos: position{line: 468, col: 11, offset: 9035},
 											expr: &charClassMatcher{
-												pos:        position{line: 456, col: 11, offset: 8725},
+												pos:        position{line: 468, col: 11, offset: 9035},
 												val:        "[_0-9\\pL]",
 												chars:      []rune{'_'},
 												ranges:     []rune{'0', '9'},
@@ -3654,29 +3912,29 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -3684,7 +3942,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -3694,34 +3952,34 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 169, col: 24, offset: 3548},
+							pos:        position{line: 175, col: 24, offset: 3673},
 							val:        ":",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -3729,7 +3987,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -3739,10 +3997,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 169, col: 31, offset: 3555},
+							pos:   position{line: 175, col: 31, offset: 3680},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 169, col: 37, offset: 3561},
+								pos:  position{line: 175, col: 37, offset: 3686},
 								name: "Expr",
 							},
 						},
@@ -3752,61 +4010,272 @@ var g = &grammar{
 		},
 		{
 			name: "Expr",
-			pos:  position{line: 180, col: 1, offset: 3811},
+			pos:  position{line: 187, col: 1, offset: 3943},
 			expr: &ruleRefExpr{
-				pos:  position{line: 181, col: 5, offset: 3820},
-				name: "LogicalExpression",
+				pos:  position{line: 188, col: 5, offset: 3952},
+				name: "ConditionalExpression",
+			},
+		},
+		{
+			name: "ConditionalExpression",
+			pos:  position{line: 190, col: 1, offset: 3975},
+			expr: &actionExpr{
+				pos: position{line: 191, col: 5, offset: 4001},
+				run: (*parser).callonConditionalExpression1,
+				expr: &seqExpr{
+					pos: position{line: 191, col: 5, offset: 4001},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 191, col: 5, offset: 4001},
+							label: "test",
+							expr: &ruleRefExpr{
+								pos:  position{line: 191, col: 10, offset: 4006},
+								name: "LogicalExpression",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 191, col: 28, offset: 4024},
+							label: "tail",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 191, col: 33, offset: 4029},
+								expr: &seqExpr{
+									pos: position{line: 191, col: 35, offset: 4031},
+									exprs: []interface{}{
+										&zeroOrMoreExpr{
+											pos: position{line: 476, col: 5, offset: 9119},
+											expr: &choiceExpr{
+												pos: position{line: 476, col: 7, offset: 9121},
+												alternatives: []interface{}{
+													&charClassMatcher{
+														pos:        position{line: 482, col: 5, offset: 9182},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+													&seqExpr{
+														pos: position{line: 479, col: 5, offset: 9156},
+														exprs: []interface{}{
+															&litMatcher{
+																pos:        position{line: 479, col: 5, offset: 9156},
+																val:        "//",
+																ignoreCase: false,
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 479, col: 10, offset: 9161},
+																expr: &charClassMatcher{
+																	pos:        position{line: 479, col: 10, offset: 9161},
+																	val:        "[^\\r\\n]",
+																	chars:      []rune{'\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+															&litMatcher{
+																pos:        position{line: 488, col: 5, offset: 9228},
+																val:        "\n",
+																ignoreCase: false,
+															},
+														},
+													},
+												},
+											},
+										},
+										&litMatcher{
+											pos:        position{line: 191, col: 38, offset: 4034},
+											val:        "?",
+											ignoreCase: false,
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 476, col: 5, offset: 9119},
+											expr: &choiceExpr{
+												pos: position{line: 476, col: 7, offset: 9121},
+												alternatives: []interface{}{
+													&charClassMatcher{
+														pos:        position{line: 482, col: 5, offset: 9182},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+													&seqExpr{
+														pos: position{line: 479, col: 5, offset: 9156},
+														exprs: []interface{}{
+															&litMatcher{
+																pos:        position{line: 479, col: 5, offset: 9156},
+																val:        "//",
+																ignoreCase: false,
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 479, col: 10, offset: 9161},
+																expr: &charClassMatcher{
+																	pos:        position{line: 479, col: 10, offset: 9161},
+																	val:        "[^\\r\\n]",
+																	chars:      []rune{'\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+															&litMatcher{
+																pos:        position{line: 488, col: 5, offset: 9228},
+																val:        "\n",
+																ignoreCase: false,
+															},
+														},
+													},
+												},
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 191, col: 45, offset: 4041},
+											name: "Expr",
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 476, col: 5, offset: 9119},
+											expr: &choiceExpr{
+												pos: position{line: 476, col: 7, offset: 9121},
+												alternatives: []interface{}{
+													&charClassMatcher{
+														pos:        position{line: 482, col: 5, offset: 9182},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+													&seqExpr{
+														pos: position{line: 479, col: 5, offset: 9156},
+														exprs: []interface{}{
+															&litMatcher{
+																pos:        position{line: 479, col: 5, offset: 9156},
+																val:        "//",
+																ignoreCase: false,
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 479, col: 10, offset: 9161},
+																expr: &charClassMatcher{
+																	pos:        position{line: 479, col: 10, offset: 9161},
+																	val:        "[^\\r\\n]",
+																	chars:      []rune{'\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+															&litMatcher{
+																pos:        position{line: 488, col: 5, offset: 9228},
+																val:        "\n",
+																ignoreCase: false,
+															},
+														},
+													},
+												},
+											},
+										},
+										&litMatcher{
+											pos:        position{line: 191, col: 53, offset: 4049},
+											val:        ":",
+											ignoreCase: false,
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 476, col: 5, offset: 9119},
+											expr: &choiceExpr{
+												pos: position{line: 476, col: 7, offset: 9121},
+												alternatives: []interface{}{
+													&charClassMatcher{
+														pos:        position{line: 482, col: 5, offset: 9182},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+													&seqExpr{
+														pos: position{line: 479, col: 5, offset: 9156},
+														exprs: []interface{}{
+															&litMatcher{
+																pos:        position{line: 479, col: 5, offset: 9156},
+																val:        "//",
+																ignoreCase: false,
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 479, col: 10, offset: 9161},
+																expr: &charClassMatcher{
+																	pos:        position{line: 479, col: 10, offset: 9161},
+																	val:        "[^\\r\\n]",
+																	chars:      []rune{'\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+															&litMatcher{
+																pos:        position{line: 488, col: 5, offset: 9228},
+																val:        "\n",
+																ignoreCase: false,
+															},
+														},
+													},
+												},
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 191, col: 60, offset: 4056},
+											name: "Expr",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 		{
 			name: "LogicalExpression",
-			pos:  position{line: 188, col: 1, offset: 3921},
+			pos:  position{line: 200, col: 1, offset: 4217},
 			expr: &actionExpr{
-				pos: position{line: 189, col: 5, offset: 3943},
+				pos: position{line: 201, col: 5, offset: 4239},
 				run: (*parser).callonLogicalExpression1,
 				expr: &seqExpr{
-					pos: position{line: 189, col: 5, offset: 3943},
+					pos: position{line: 201, col: 5, offset: 4239},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 189, col: 5, offset: 3943},
+							pos:   position{line: 201, col: 5, offset: 4239},
 							label: "head",
 							expr: &ruleRefExpr{
-								pos:  position{line: 189, col: 10, offset: 3948},
+								pos:  position{line: 201, col: 10, offset: 4244},
 								name: "Equality",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 189, col: 19, offset: 3957},
+							pos:   position{line: 201, col: 19, offset: 4253},
 							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 189, col: 24, offset: 3962},
+								pos: position{line: 201, col: 24, offset: 4258},
 								expr: &seqExpr{
-									pos: position{line: 189, col: 26, offset: 3964},
+									pos: position{line: 201, col: 26, offset: 4260},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -3814,7 +4283,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -3824,18 +4293,18 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 184, col: 5, offset: 3860},
+											pos: position{line: 196, col: 5, offset: 4156},
 											run: (*parser).callonLogicalExpression16,
 											expr: &choiceExpr{
-												pos: position{line: 184, col: 6, offset: 3861},
+												pos: position{line: 196, col: 6, offset: 4157},
 												alternatives: []interface{}{
 													&litMatcher{
-														pos:        position{line: 184, col: 6, offset: 3861},
+														pos:        position{line: 196, col: 6, offset: 4157},
 														val:        "or",
 														ignoreCase: true,
 													},
 													&litMatcher{
-														pos:        position{line: 184, col: 14, offset: 3869},
+														pos:        position{line: 196, col: 14, offset: 4165},
 														val:        "and",
 														ignoreCase: true,
 													},
@@ -3843,29 +4312,29 @@ var g = &grammar{
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -3873,7 +4342,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -3883,7 +4352,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 189, col: 51, offset: 3989},
+											pos:  position{line: 201, col: 51, offset: 4285},
 											name: "Equality",
 										},
 									},
@@ -3896,53 +4365,53 @@ var g = &grammar{
 		},
 		{
 			name: "Equality",
-			pos:  position{line: 198, col: 1, offset: 4144},
+			pos:  position{line: 210, col: 1, offset: 4454},
 			expr: &actionExpr{
-				pos: position{line: 199, col: 5, offset: 4157},
+				pos: position{line: 211, col: 5, offset: 4467},
 				run: (*parser).callonEquality1,
 				expr: &seqExpr{
-					pos: position{line: 199, col: 5, offset: 4157},
+					pos: position{line: 211, col: 5, offset: 4467},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 199, col: 5, offset: 4157},
+							pos:   position{line: 211, col: 5, offset: 4467},
 							label: "head",
 							expr: &ruleRefExpr{
-								pos:  position{line: 199, col: 10, offset: 4162},
+								pos:  position{line: 211, col: 10, offset: 4472},
 								name: "Relational",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 199, col: 21, offset: 4173},
+							pos:   position{line: 211, col: 21, offset: 4483},
 							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 199, col: 26, offset: 4178},
+								pos: position{line: 211, col: 26, offset: 4488},
 								expr: &seqExpr{
-									pos: position{line: 199, col: 28, offset: 4180},
+									pos: position{line: 211, col: 28, offset: 4490},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -3950,7 +4419,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -3960,48 +4429,58 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 194, col: 5, offset: 4090},
+											pos: position{line: 206, col: 5, offset: 4386},
 											run: (*parser).callonEquality16,
 											expr: &choiceExpr{
-												pos: position{line: 194, col: 6, offset: 4091},
+												pos: position{line: 206, col: 6, offset: 4387},
 												alternatives: []interface{}{
 													&litMatcher{
-														pos:        position{line: 194, col: 6, offset: 4091},
+														pos:        position{line: 206, col: 6, offset: 4387},
 														val:        "==",
 														ignoreCase: false,
 													},
 													&litMatcher{
-														pos:        position{line: 194, col: 13, offset: 4098},
+														pos:        position{line: 206, col: 13, offset: 4394},
 														val:        "!=",
 														ignoreCase: false,
 													},
+													&litMatcher{
+														pos:        position{line: 206, col: 20, offset: 4401},
+														val:        "=~",
+														ignoreCase: false,
+													},
+													&litMatcher{
+														pos:        position{line: 206, col: 27, offset: 4408},
+														val:        "!~",
+														ignoreCase: false,
+													},
 												},
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -4009,7 +4488,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -4019,7 +4498,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 199, col: 52, offset: 4204},
+											pos:  position{line: 211, col: 52, offset: 4514},
 											name: "Relational",
 										},
 									},
@@ -4032,53 +4511,53 @@ var g = &grammar{
 		},
 		{
 			name: "Relational",
-			pos:  position{line: 216, col: 1, offset: 4477},
+			pos:  position{line: 228, col: 1, offset: 4787},
 			expr: &actionExpr{
-				pos: position{line: 217, col: 5, offset: 4492},
+				pos: position{line: 229, col: 5, offset: 4802},
 				run: (*parser).callonRelational1,
 				expr: &seqExpr{
-					pos: position{line: 217, col: 5, offset: 4492},
+					pos: position{line: 229, col: 5, offset: 4802},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 217, col: 5, offset: 4492},
+							pos:   position{line: 229, col: 5, offset: 4802},
 							label: "head",
 							expr: &ruleRefExpr{
-								pos:  position{line: 217, col: 10, offset: 4497},
+								pos:  position{line: 229, col: 10, offset: 4807},
 								name: "Additive",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 217, col: 19, offset: 4506},
+							pos:   position{line: 229, col: 19, offset: 4816},
 							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 217, col: 24, offset: 4511},
+								pos: position{line: 229, col: 24, offset: 4821},
 								expr: &seqExpr{
-									pos: position{line: 217, col: 26, offset: 4513},
+									pos: position{line: 229, col: 26, offset: 4823},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -4086,7 +4565,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -4096,48 +4575,48 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 204, col: 5, offset: 4308},
+											pos: position{line: 216, col: 5, offset: 4618},
 											run: (*parser).callonRelational16,
 											expr: &choiceExpr{
-												pos: position{line: 204, col: 9, offset: 4312},
+												pos: position{line: 216, col: 9, offset: 4622},
 												alternatives: []interface{}{
 													&litMatcher{
-														pos:        position{line: 204, col: 9, offset: 4312},
+														pos:        position{line: 216, col: 9, offset: 4622},
 														val:        "<=",
 														ignoreCase: false,
 													},
 													&litMatcher{
-														pos:        position{line: 205, col: 9, offset: 4325},
+														pos:        position{line: 217, col: 9, offset: 4635},
 														val:        "<",
 														ignoreCase: false,
 													},
 													&litMatcher{
-														pos:        position{line: 206, col: 9, offset: 4337},
+														pos:        position{line: 218, col: 9, offset: 4647},
 														val:        ">=",
 														ignoreCase: false,
 													},
 													&litMatcher{
-														pos:        position{line: 207, col: 9, offset: 4350},
+														pos:        position{line: 219, col: 9, offset: 4660},
 														val:        ">",
 														ignoreCase: false,
 													},
 													&litMatcher{
-														pos:        position{line: 208, col: 9, offset: 4362},
+														pos:        position{line: 220, col: 9, offset: 4672},
 														val:        "startswith",
 														ignoreCase: true,
 													},
 													&litMatcher{
-														pos:        position{line: 209, col: 9, offset: 4384},
+														pos:        position{line: 221, col: 9, offset: 4694},
 														val:        "in",
 														ignoreCase: true,
 													},
 													&litMatcher{
-														pos:        position{line: 210, col: 9, offset: 4398},
+														pos:        position{line: 222, col: 9, offset: 4708},
 														val:        "not empty",
 														ignoreCase: true,
 													},
 													&litMatcher{
-														pos:        position{line: 211, col: 9, offset: 4419},
+														pos:        position{line: 223, col: 9, offset: 4729},
 														val:        "empty",
 														ignoreCase: true,
 													},
@@ -4145,29 +4624,29 @@ var g = &grammar{
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -4175,7 +4654,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -4185,7 +4664,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 217, col: 52, offset: 4539},
+											pos:  position{line: 229, col: 52, offset: 4849},
 											name: "Additive",
 										},
 									},
@@ -4198,53 +4677,53 @@ var g = &grammar{
 		},
 		{
 			name: "Additive",
-			pos:  position{line: 226, col: 1, offset: 4693},
+			pos:  position{line: 238, col: 1, offset: 5003},
 			expr: &actionExpr{
-				pos: position{line: 227, col: 5, offset: 4706},
+				pos: position{line: 239, col: 5, offset: 5016},
 				run: (*parser).callonAdditive1,
 				expr: &seqExpr{
-					pos: position{line: 227, col: 5, offset: 4706},
+					pos: position{line: 239, col: 5, offset: 5016},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 227, col: 5, offset: 4706},
+							pos:   position{line: 239, col: 5, offset: 5016},
 							label: "head",
 							expr: &ruleRefExpr{
-								pos:  position{line: 227, col: 10, offset: 4711},
+								pos:  position{line: 239, col: 10, offset: 5021},
 								name: "Multiplicative",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 227, col: 25, offset: 4726},
+							pos:   position{line: 239, col: 25, offset: 5036},
 							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 227, col: 30, offset: 4731},
+								pos: position{line: 239, col: 30, offset: 5041},
 								expr: &seqExpr{
-									pos: position{line: 227, col: 32, offset: 4733},
+									pos: position{line: 239, col: 32, offset: 5043},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -4252,7 +4731,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -4262,10 +4741,10 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 222, col: 5, offset: 4638},
+											pos: position{line: 234, col: 5, offset: 4948},
 											run: (*parser).callonAdditive16,
 											expr: &charClassMatcher{
-												pos:        position{line: 222, col: 6, offset: 4639},
+												pos:        position{line: 234, col: 6, offset: 4949},
 												val:        "[+-]",
 												chars:      []rune{'+', '-'},
 												ignoreCase: false,
@@ -4273,29 +4752,29 @@ var g = &grammar{
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -4303,7 +4782,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -4313,7 +4792,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 227, col: 55, offset: 4756},
+											pos:  position{line: 239, col: 55, offset: 5066},
 											name: "Multiplicative",
 										},
 									},
@@ -4326,53 +4805,53 @@ var g = &grammar{
 		},
 		{
 			name: "Multiplicative",
-			pos:  position{line: 236, col: 1, offset: 4918},
+			pos:  position{line: 248, col: 1, offset: 5228},
 			expr: &actionExpr{
-				pos: position{line: 237, col: 5, offset: 4937},
+				pos: position{line: 249, col: 5, offset: 5247},
 				run: (*parser).callonMultiplicative1,
 				expr: &seqExpr{
-					pos: position{line: 237, col: 5, offset: 4937},
+					pos: position{line: 249, col: 5, offset: 5247},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 237, col: 5, offset: 4937},
+							pos:   position{line: 249, col: 5, offset: 5247},
 							label: "head",
 							expr: &ruleRefExpr{
-								pos:  position{line: 237, col: 10, offset: 4942},
+								pos:  position{line: 249, col: 10, offset: 5252},
 								name: "UnaryExpression",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 237, col: 26, offset: 4958},
+							pos:   position{line: 249, col: 26, offset: 5268},
 							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 237, col: 31, offset: 4963},
+								pos: position{line: 249, col: 31, offset: 5273},
 								expr: &seqExpr{
-									pos: position{line: 237, col: 33, offset: 4965},
+									pos: position{line: 249, col: 33, offset: 5275},
 									exprs: []interface{}{
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -4380,7 +4859,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -4390,10 +4869,10 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 232, col: 5, offset: 4867},
+											pos: position{line: 244, col: 5, offset: 5177},
 											run: (*parser).callonMultiplicative16,
 											expr: &charClassMatcher{
-												pos:        position{line: 232, col: 6, offset: 4868},
+												pos:        position{line: 244, col: 6, offset: 5178},
 												val:        "[*/]",
 												chars:      []rune{'*', '/'},
 												ignoreCase: false,
@@ -4401,29 +4880,29 @@ var g = &grammar{
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 464, col: 5, offset: 8809},
+											pos: position{line: 476, col: 5, offset: 9119},
 											expr: &choiceExpr{
-												pos: position{line: 464, col: 7, offset: 8811},
+												pos: position{line: 476, col: 7, offset: 9121},
 												alternatives: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 470, col: 5, offset: 8872},
+														pos:        position{line: 482, col: 5, offset: 9182},
 														val:        "[ \\t\\r\\n]",
 														chars:      []rune{' ', '\t', '\r', '\n'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&seqExpr{
-														pos: position{line: 467, col: 5, offset: 8846},
+														pos: position{line: 479, col: 5, offset: 9156},
 														exprs: []interface{}{
 															&litMatcher{
-																pos:        position{line: 467, col: 5, offset: 8846},
+																pos:        position{line: 479, col: 5, offset: 9156},
 																val:        "//",
 																ignoreCase: false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 467, col: 10, offset: 8851},
+																pos: position{line: 479, col: 10, offset: 9161},
 																expr: &charClassMatcher{
-																	pos:        position{line: 467, col: 10, offset: 8851},
+																	pos:        position{line: 479, col: 10, offset: 9161},
 																	val:        "[^\\r\\n]",
 																	chars:      []rune{'\r', '\n'},
 																	ignoreCase: false,
@@ -4431,7 +4910,7 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 476, col: 5, offset: 8918},
+																pos:        position{line: 488, col: 5, offset: 9228},
 																val:        "\n",
 																ignoreCase: false,
 															},
@@ -4441,7 +4920,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 237, col: 62, offset: 4994},
+											pos:  position{line: 249, col: 62, offset: 5304},
 											name: "UnaryExpression",
 										},
 									},
@@ -4454,40 +4933,40 @@ var g = &grammar{
 		},
 		{
 			name: "UnaryExpression",
-			pos:  position{line: 246, col: 1, offset: 5150},
+			pos:  position{line: 258, col: 1, offset: 5460},
 			expr: &choiceExpr{
-				pos: position{line: 247, col: 5, offset: 5170},
+				pos: position{line: 259, col: 5, offset: 5480},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 247, col: 5, offset: 5170},
+						pos: position{line: 259, col: 5, offset: 5480},
 						run: (*parser).callonUnaryExpression2,
 						expr: &seqExpr{
-							pos: position{line: 247, col: 5, offset: 5170},
+							pos: position{line: 259, col: 5, offset: 5480},
 							exprs: []interface{}{
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -4495,7 +4974,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -4505,21 +4984,21 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 247, col: 8, offset: 5173},
+									pos:   position{line: 259, col: 8, offset: 5483},
 									label: "op",
 									expr: &actionExpr{
-										pos: position{line: 242, col: 5, offset: 5097},
+										pos: position{line: 254, col: 5, offset: 5407},
 										run: (*parser).callonUnaryExpression13,
 										expr: &choiceExpr{
-											pos: position{line: 242, col: 6, offset: 5098},
+											pos: position{line: 254, col: 6, offset: 5408},
 											alternatives: []interface{}{
 												&litMatcher{
-													pos:        position{line: 242, col: 6, offset: 5098},
+													pos:        position{line: 254, col: 6, offset: 5408},
 													val:        "-",
 													ignoreCase: false,
 												},
 												&litMatcher{
-													pos:        position{line: 242, col: 12, offset: 5104},
+													pos:        position{line: 254, col: 12, offset: 5414},
 													val:        "not",
 													ignoreCase: false,
 												},
@@ -4528,29 +5007,29 @@ var g = &grammar{
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -4558,7 +5037,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -4568,37 +5047,37 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 247, col: 28, offset: 5193},
+									pos:   position{line: 259, col: 28, offset: 5503},
 									label: "argument",
 									expr: &ruleRefExpr{
-										pos:  position{line: 247, col: 37, offset: 5202},
+										pos:  position{line: 259, col: 37, offset: 5512},
 										name: "Primary",
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -4606,7 +5085,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -4619,7 +5098,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 250, col: 5, offset: 5283},
+						pos:  position{line: 262, col: 5, offset: 5593},
 						name: "Primary",
 					},
 				},
@@ -4627,41 +5106,41 @@ var g = &grammar{
 		},
 		{
 			name: "Primary",
-			pos:  position{line: 252, col: 1, offset: 5292},
+			pos:  position{line: 264, col: 1, offset: 5602},
 			expr: &choiceExpr{
-				pos: position{line: 253, col: 5, offset: 5304},
+				pos: position{line: 265, col: 5, offset: 5614},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 253, col: 5, offset: 5304},
+						pos:  position{line: 265, col: 5, offset: 5614},
 						name: "PipeExpression",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 254, col: 5, offset: 5323},
+						pos:  position{line: 266, col: 5, offset: 5633},
 						name: "Array",
 					},
 					&actionExpr{
-						pos: position{line: 378, col: 5, offset: 7121},
+						pos: position{line: 390, col: 5, offset: 7431},
 						run: (*parser).callonPrimary4,
 						expr: &seqExpr{
-							pos: position{line: 378, col: 7, offset: 7123},
+							pos: position{line: 390, col: 7, offset: 7433},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 378, col: 7, offset: 7123},
+									pos:        position{line: 390, col: 7, offset: 7433},
 									val:        "\"",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 378, col: 11, offset: 7127},
+									pos: position{line: 390, col: 11, offset: 7437},
 									expr: &choiceExpr{
-										pos: position{line: 386, col: 5, offset: 7344},
+										pos: position{line: 398, col: 5, offset: 7654},
 										alternatives: []interface{}{
 											&seqExpr{
-												pos: position{line: 386, col: 5, offset: 7344},
+												pos: position{line: 398, col: 5, offset: 7654},
 												exprs: []interface{}{
 													&notExpr{
-														pos: position{line: 386, col: 5, offset: 7344},
+														pos: position{line: 398, col: 5, offset: 7654},
 														expr: &charClassMatcher{
-															pos:        position{line: 386, col: 8, offset: 7347},
+															pos:        position{line: 398, col: 8, offset: 7657},
 															val:        "[\"\\\\\\n]",
 															chars:      []rune{'"', '\\', '\n'},
 															ignoreCase: false,
@@ -4669,44 +5148,44 @@ var g = &grammar{
 														},
 													},
 													&anyMatcher{
-														line: 462, col: 5, offset: 8800,
+														line: 468, col: 5, offset: 8925,
 													},
 												},
 											},
 											&seqExpr{
-												pos: position{line: 387, col: 5, offset: 7381},
+												pos: position{line: 399, col: 5, offset: 7691},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 387, col: 5, offset: 7381},
+														pos:        position{line: 399, col: 5, offset: 7691},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&choiceExpr{
-														pos: position{line: 390, col: 5, offset: 7429},
+														pos: position{line: 402, col: 5, offset: 7739},
 														alternatives: []interface{}{
 															&litMatcher{
-																pos:        position{line: 390, col: 5, offset: 7429},
+																pos:        position{line: 402, col: 5, offset: 7739},
 																val:        "\"",
 																ignoreCase: false,
 															},
 															&actionExpr{
-																pos: position{line: 391, col: 5, offset: 7437},
+																pos: position{line: 403, col: 5, offset: 7747},
 																run: (*parser).callonPrimary17,
 																expr: &choiceExpr{
-																	pos: position{line: 391, col: 7, offset: 7439},
+																	pos: position{line: 403, col: 7, offset: 7749},
 																	alternatives: []interface{}{
 																		&anyMatcher{
-																			line: 462, col: 5, offset: 8800,
+																			line: 468, col: 5, offset: 8925,
 																		},
 																		&litMatcher{
-																			pos:        position{line: 476, col: 5, offset: 8918},
+																			pos:        position{line: 488, col: 5, offset: 9228},
 																			val:        "\n",
 																			ignoreCase: false,
 																		},
 																		&notExpr{
-																			pos: position{line: 479, col: 5, offset: 8932},
+																			pos: position{line: 491, col: 5, offset: 9242},
 																			expr: &anyMatcher{
-																				line: 479, col: 6, offset: 8933,
+																				line: 485, col: 6, offset: 9058,
 																			},
 																		},
 																	},
@@ -4720,7 +5199,7 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 378, col: 29, offset: 7145},
+									pos:        position{line: 390, col: 29, offset: 7455},
 									val:        "\"",
 									ignoreCase: false,
 								},
@@ -4728,28 +5207,28 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 381, col: 5, offset: 7209},
+						pos: position{line: 393, col: 5, offset: 7519},
 						run: (*parser).callonPrimary24,
 						expr: &seqExpr{
-							pos: position{line: 381, col: 7, offset: 7211},
+							pos: position{line: 393, col: 7, offset: 7521},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 381, col: 7, offset: 7211},
+									pos:        position{line: 393, col: 7, offset: 7521},
 									val:        "\"",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 381, col: 11, offset: 7215},
+									pos: position{line: 393, col: 11, offset: 7525},
 									expr: &choiceExpr{
-										pos: position{line: 386, col: 5, offset: 7344},
+										pos: position{line: 398, col: 5, offset: 7654},
 										alternatives: []interface{}{
 											&seqExpr{
-												pos: position{line: 386, col: 5, offset: 7344},
+												pos: position{line: 398, col: 5, offset: 7654},
 												exprs: []interface{}{
 													&notExpr{
-														pos: position{line: 386, col: 5, offset: 7344},
+														pos: position{line: 398, col: 5, offset: 7654},
 														expr: &charClassMatcher{
-															pos:        position{line: 386, col: 8, offset: 7347},
+															pos:        position{line: 398, col: 8, offset: 7657},
 															val:        "[\"\\\\\\n]",
 															chars:      []rune{'"', '\\', '\n'},
 															ignoreCase: false,
@@ -4757,44 +5236,44 @@ var g = &grammar{
 														},
 													},
 													&anyMatcher{
-														line: 462, col: 5, offset: 8800,
+														line: 468, col: 5, offset: 8925,
 													},
 												},
 											},
 											&seqExpr{
-												pos: position{line: 387, col: 5, offset: 7381},
+												pos: position{line: 399, col: 5, offset: 7691},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 387, col: 5, offset: 7381},
+														pos:        position{line: 399, col: 5, offset: 7691},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&choiceExpr{
-														pos: position{line: 390, col: 5, offset: 7429},
+														pos: position{line: 402, col: 5, offset: 7739},
 														alternatives: []interface{}{
 															&litMatcher{
-																pos:        position{line: 390, col: 5, offset: 7429},
+																pos:        position{line: 402, col: 5, offset: 7739},
 																val:        "\"",
 																ignoreCase: false,
 															},
 															&actionExpr{
-																pos: position{line: 391, col: 5, offset: 7437},
+																pos: position{line: 403, col: 5, offset: 7747},
 																run: (*parser).callonPrimary37,
 																expr: &choiceExpr{
-																	pos: position{line: 391, col: 7, offset: 7439},
+																	pos: position{line: 403, col: 7, offset: 7749},
 																	alternatives: []interface{}{
 																		&anyMatcher{
-																			line: 462, col: 5, offset: 8800,
+																			line: 468, col: 5, offset: 8925,
 																		},
 																		&litMatcher{
-																			pos:        position{line: 476, col: 5, offset: 8918},
+																			pos:        position{line: 488, col: 5, offset: 9228},
 																			val:        "\n",
 																			ignoreCase: false,
 																		},
 																		&notExpr{
-																			pos: position{line: 479, col: 5, offset: 8932},
+																			pos: position{line: 491, col: 5, offset: 9242},
 																			expr: &anyMatcher{
-																				line: 479, col: 6, offset: 8933,
+																				line: 485, col: 6, offset: 9058,
 																			},
 																		},
 																	},
@@ -4808,17 +5287,17 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 381, col: 31, offset: 7235},
+									pos: position{line: 393, col: 31, offset: 7545},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 476, col: 5, offset: 8918},
+											pos:        position{line: 488, col: 5, offset: 9228},
 											val:        "\n",
 											ignoreCase: false,
 										},
 										&notExpr{
-											pos: position{line: 479, col: 5, offset: 8932},
+											pos: position{line: 491, col: 5, offset: 9242},
 											expr: &anyMatcher{
-												line: 479, col: 6, offset: 8933,
+												line: 485, col: 6, offset: 9058,
 											},
 										},
 									},
@@ -4827,35 +5306,35 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 396, col: 5, offset: 7549},
+						pos: position{line: 408, col: 5, offset: 7859},
 						run: (*parser).callonPrimary47,
 						expr: &seqExpr{
-							pos: position{line: 396, col: 5, offset: 7549},
+							pos: position{line: 408, col: 5, offset: 7859},
 							exprs: []interface{}{
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -4863,7 +5342,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -4873,34 +5352,34 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 396, col: 8, offset: 7552},
+									pos:        position{line: 408, col: 8, offset: 7862},
 									val:        "true",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -4908,7 +5387,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -4921,35 +5400,35 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 399, col: 5, offset: 7623},
+						pos: position{line: 411, col: 5, offset: 7933},
 						run: (*parser).callonPrimary66,
 						expr: &seqExpr{
-							pos: position{line: 399, col: 5, offset: 7623},
+							pos: position{line: 411, col: 5, offset: 7933},
 							exprs: []interface{}{
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -4957,7 +5436,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -4967,34 +5446,34 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 399, col: 8, offset: 7626},
+									pos:        position{line: 411, col: 8, offset: 7936},
 									val:        "false",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 464, col: 5, offset: 8809},
+									pos: position{line: 476, col: 5, offset: 9119},
 									expr: &choiceExpr{
-										pos: position{line: 464, col: 7, offset: 8811},
+										pos: position{line: 476, col: 7, offset: 9121},
 										alternatives: []interface{}{
 											&charClassMatcher{
-												pos:        position{line: 470, col: 5, offset: 8872},
+												pos:        position{line: 482, col: 5, offset: 9182},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
 												inverted:   false,
 											},
 											&seqExpr{
-												pos: position{line: 467, col: 5, offset: 8846},
+												pos: position{line: 479, col: 5, offset: 9156},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 467, col: 5, offset: 8846},
+														pos:        position{line: 479, col: 5, offset: 9156},
 														val:        "//",
 														ignoreCase: false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 467, col: 10, offset: 8851},
+														pos: position{line: 479, col: 10, offset: 9161},
 														expr: &charClassMatcher{
-															pos:        position{line: 467, col: 10, offset: 8851},
+															pos:        position{line: 479, col: 10, offset: 9161},
 															val:        "[^\\r\\n]",
 															chars:      []rune{'\r', '\n'},
 															ignoreCase: false,
@@ -5002,7 +5481,7 @@ var g = &grammar{
 														},
 													},
 													&litMatcher{
-														pos:        position{line: 476, col: 5, offset: 8918},
+														pos:        position{line: 488, col: 5, offset: 9228},
 														val:        "\n",
 														ignoreCase: false,
 													},
@@ -5015,40 +5494,40 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 428, col: 5, offset: 8052},
+						pos: position{line: 440, col: 5, offset: 8362},
 						run: (*parser).callonPrimary85,
 						expr: &seqExpr{
-							pos: position{line: 428, col: 5, offset: 8052},
+							pos: position{line: 440, col: 5, offset: 8362},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 428, col: 5, offset: 8052},
+									pos:        position{line: 440, col: 5, offset: 8362},
 									val:        "/",
 									ignoreCase: false,
 								},
 								&labeledExpr{
-									pos:   position{line: 428, col: 9, offset: 8056},
+									pos:   position{line: 440, col: 9, offset: 8366},
 									label: "pattern",
 									expr: &actionExpr{
-										pos: position{line: 433, col: 5, offset: 8155},
+										pos: position{line: 445, col: 5, offset: 8465},
 										run: (*parser).callonPrimary89,
 										expr: &labeledExpr{
-											pos:   position{line: 433, col: 5, offset: 8155},
+											pos:   position{line: 445, col: 5, offset: 8465},
 											label: "chars",
 											expr: &oneOrMoreExpr{
-												pos: position{line: 433, col: 11, offset: 8161},
+												pos: position{line: 445, col: 11, offset: 8471},
 												expr: &choiceExpr{
-													pos: position{line: 438, col: 5, offset: 8267},
+													pos: position{line: 450, col: 5, offset: 8577},
 													alternatives: []interface{}{
 														&actionExpr{
-															pos: position{line: 438, col: 5, offset: 8267},
+															pos: position{line: 450, col: 5, offset: 8577},
 															run: (*parser).callonPrimary93,
 															expr: &seqExpr{
-																pos: position{line: 438, col: 5, offset: 8267},
+																pos: position{line: 450, col: 5, offset: 8577},
 																exprs: []interface{}{
 																	&notExpr{
-																		pos: position{line: 438, col: 5, offset: 8267},
+																		pos: position{line: 450, col: 5, offset: 8577},
 																		expr: &charClassMatcher{
-																			pos:        position{line: 438, col: 6, offset: 8268},
+																			pos:        position{line: 450, col: 6, offset: 8578},
 																			val:        "[\\\\/]",
 																			chars:      []rune{'\\', '/'},
 																			ignoreCase: false,
@@ -5056,18 +5535,18 @@ var g = &grammar{
 																		},
 																	},
 																	&labeledExpr{
-																		pos:   position{line: 438, col: 12, offset: 8274},
+																		pos:   position{line: 450, col: 12, offset: 8584},
 																		label: "re",
 																		expr: &actionExpr{
-																			pos: position{line: 450, col: 5, offset: 8528},
+																			pos: position{line: 462, col: 5, offset: 8838},
 																			run: (*parser).callonPrimary98,
 																			expr: &seqExpr{
-																				pos: position{line: 450, col: 5, offset: 8528},
+																				pos: position{line: 462, col: 5, offset: 8838},
 																				exprs: []interface{}{
 																					&notExpr{
-																						pos: position{line: 450, col: 5, offset: 8528},
+																						pos: position{line: 462, col: 5, offset: 8838},
 																						expr: &charClassMatcher{
-																							pos:        position{line: 473, col: 5, offset: 8902},
+																							pos:        position{line: 485, col: 5, offset: 9212},
 																							val:        "[\\n\\r]",
 																							chars:      []rune{'\n', '\r'},
 																							ignoreCase: false,
@@ -5075,7 +5554,7 @@ var g = &grammar{
 																						},
 																					},
 																					&anyMatcher{
-																						line: 462, col: 5, offset: 8800,
+																						line: 468, col: 5, offset: 8925,
 																					},
 																				},
 																			},
@@ -5085,32 +5564,32 @@ var g = &grammar{
 															},
 														},
 														&actionExpr{
-															pos: position{line: 444, col: 5, offset: 8416},
+															pos: position{line: 456, col: 5, offset: 8726},
 															run: (*parser).callonPrimary103,
 															expr: &litMatcher{
-																pos:        position{line: 444, col: 5, offset: 8416},
+																pos:        position{line: 456, col: 5, offset: 8726},
 																val:        "\\/",
 																ignoreCase: false,
 															},
 														},
 														&seqExpr{
-															pos: position{line: 447, col: 5, offset: 8456},
+															pos: position{line: 459, col: 5, offset: 8766},
 															exprs: []interface{}{
 																&litMatcher{
-																	pos:        position{line: 447, col: 5, offset: 8456},
+																	pos:        position{line: 459, col: 5, offset: 8766},
 																	val:        "\\",
 																	ignoreCase: false,
 																},
 																&actionExpr{
-																	pos: position{line: 450, col: 5, offset: 8528},
+																	pos: position{line: 462, col: 5, offset: 8838},
 																	run: (*parser).callonPrimary107,
 																	expr: &seqExpr{
-																		pos: position{line: 450, col: 5, offset: 8528},
+																		pos: position{line: 462, col: 5, offset: 8838},
 																		exprs: []interface{}{
 																			&notExpr{
-																				pos: position{line: 450, col: 5, offset: 8528},
+																				pos: position{line: 462, col: 5, offset: 8838},
 																				expr: &charClassMatcher{
-																					pos:        position{line: 473, col: 5, offset: 8902},
+																					pos:        position{line: 485, col: 5, offset: 9212},
 																					val:        "[\\n\\r]",
 																					chars:      []rune{'\n', '\r'},
 																					ignoreCase: false,
@@ -5118,7 +5597,7 @@ var g = &grammar{
 																				},
 																			},
 																			&anyMatcher{
-																				line: 462, col: 5, offset: 8800,
+																				line: 468, col: 5, offset: 8925,
 																			},
 																		},
 																	},
@@ -5132,7 +5611,7 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 428, col: 39, offset: 8086},
+									pos:        position{line: 440, col: 39, offset: 8396},
 									val:        "/",
 									ignoreCase: false,
 								},
@@ -5140,44 +5619,44 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 423, col: 5, offset: 7960},
+						pos: position{line: 435, col: 5, offset: 8270},
 						run: (*parser).callonPrimary113,
 						expr: &litMatcher{
-							pos:        position{line: 423, col: 5, offset: 7960},
+							pos:        position{line: 435, col: 5, offset: 8270},
 							val:        "<-",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 373, col: 5, offset: 7034},
+						pos: position{line: 385, col: 5, offset: 7344},
 						run: (*parser).callonPrimary115,
 						expr: &oneOrMoreExpr{
-							pos: position{line: 373, col: 5, offset: 7034},
+							pos: position{line: 385, col: 5, offset: 7344},
 							expr: &seqExpr{
-								pos: position{line: 370, col: 5, offset: 6991},
+								pos: position{line: 382, col: 5, offset: 7301},
 								exprs: []interface{}{
 									&choiceExpr{
-										pos: position{line: 409, col: 6, offset: 7796},
+										pos: position{line: 421, col: 6, offset: 8106},
 										alternatives: []interface{}{
 											&litMatcher{
-												pos:        position{line: 409, col: 6, offset: 7796},
+												pos:        position{line: 421, col: 6, offset: 8106},
 												val:        "0",
 												ignoreCase: false,
 											},
 											&seqExpr{
-												pos: position{line: 409, col: 12, offset: 7802},
+												pos: position{line: 421, col: 12, offset: 8112},
 												exprs: []interface{}{
 													&charClassMatcher{
-														pos:        position{line: 417, col: 5, offset: 7920},
+														pos:        position{line: 429, col: 5, offset: 8230},
 														val:        "[1-9]",
 														ranges:     []rune{'1', '9'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 409, col: 25, offset: 7815},
+														pos: position{line: 421, col: 25, offset: 8125},
 														expr: &charClassMatcher{
-															pos:        position{line: 420, col: 5, offset: 7937},
+															pos:        position{line: 432, col: 5, offset: 8247},
 															val:        "[0-9]",
 															ranges:     []rune{'0', '9'},
 															ignoreCase: false,
@@ -5189,35 +5668,35 @@ var g = &grammar{
 										},
 									},
 									&choiceExpr{
-										pos: position{line: 361, col: 9, offset: 6841},
+										pos: position{line: 373, col: 9, offset: 7151},
 										alternatives: []interface{}{
 											&litMatcher{
-												pos:        position{line: 342, col: 5, offset: 6674},
+												pos:        position{line: 354, col: 5, offset: 6984},
 												val:        "ns",
 												ignoreCase: false,
 											},
 											&litMatcher{
-												pos:        position{line: 345, col: 6, offset: 6702},
+												pos:        position{line: 357, col: 6, offset: 7012},
 												val:        "us",
 												ignoreCase: false,
 											},
 											&litMatcher{
-												pos:        position{line: 345, col: 13, offset: 6709},
+												pos:        position{line: 357, col: 13, offset: 7019},
 												val:        "µs",
 												ignoreCase: false,
 											},
 											&litMatcher{
-												pos:        position{line: 345, col: 20, offset: 6717},
+												pos:        position{line: 357, col: 20, offset: 7027},
 												val:        "μs",
 												ignoreCase: false,
 											},
 											&litMatcher{
-												pos:        position{line: 348, col: 5, offset: 6746},
+												pos:        position{line: 360, col: 5, offset: 7056},
 												val:        "ms",
 												ignoreCase: false,
 											},
 											&charClassMatcher{
-												pos:        position{line: 351, col: 5, offset: 6768},
+												pos:        position{line: 363, col: 5, offset: 7078},
 												val:        "[smh]",
 												chars:      []rune{'s', 'm', 'h'},
 												ignoreCase: false,
@@ -5230,148 +5709,148 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 337, col: 5, offset: 6586},
+						pos: position{line: 349, col: 5, offset: 6896},
 						run: (*parser).callonPrimary131,
 						expr: &seqExpr{
-							pos: position{line: 337, col: 5, offset: 6586},
+							pos: position{line: 349, col: 5, offset: 6896},
 							exprs: []interface{}{
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 331, col: 18, offset: 6501},
+									pos:        position{line: 343, col: 18, offset: 6811},
 									val:        "-",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 331, col: 32, offset: 6515},
+									pos:        position{line: 343, col: 32, offset: 6825},
 									val:        "-",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 337, col: 14, offset: 6595},
+									pos:        position{line: 349, col: 14, offset: 6905},
 									val:        "T",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 328, col: 14, offset: 6431},
+									pos:        position{line: 340, col: 14, offset: 6741},
 									val:        ":",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&litMatcher{
-									pos:        position{line: 328, col: 29, offset: 6446},
+									pos:        position{line: 340, col: 29, offset: 6756},
 									val:        ":",
 									ignoreCase: false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&charClassMatcher{
-									pos:        position{line: 420, col: 5, offset: 7937},
+									pos:        position{line: 432, col: 5, offset: 8247},
 									val:        "[0-9]",
 									ranges:     []rune{'0', '9'},
 									ignoreCase: false,
 									inverted:   false,
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 328, col: 44, offset: 6461},
+									pos: position{line: 340, col: 44, offset: 6771},
 									expr: &seqExpr{
-										pos: position{line: 319, col: 5, offset: 6301},
+										pos: position{line: 331, col: 5, offset: 6611},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 319, col: 5, offset: 6301},
+												pos:        position{line: 331, col: 5, offset: 6611},
 												val:        ".",
 												ignoreCase: false,
 											},
 											&oneOrMoreExpr{
-												pos: position{line: 319, col: 9, offset: 6305},
+												pos: position{line: 331, col: 9, offset: 6615},
 												expr: &charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
@@ -5382,51 +5861,51 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 325, col: 6, offset: 6384},
+									pos: position{line: 337, col: 6, offset: 6694},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 325, col: 6, offset: 6384},
+											pos:        position{line: 337, col: 6, offset: 6694},
 											val:        "Z",
 											ignoreCase: false,
 										},
 										&seqExpr{
-											pos: position{line: 322, col: 5, offset: 6331},
+											pos: position{line: 334, col: 5, offset: 6641},
 											exprs: []interface{}{
 												&charClassMatcher{
-													pos:        position{line: 322, col: 6, offset: 6332},
+													pos:        position{line: 334, col: 6, offset: 6642},
 													val:        "[+-]",
 													chars:      []rune{'+', '-'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&litMatcher{
-													pos:        position{line: 322, col: 26, offset: 6352},
+													pos:        position{line: 334, col: 26, offset: 6662},
 													val:        ":",
 													ignoreCase: false,
 												},
 												&charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&charClassMatcher{
-													pos:        position{line: 420, col: 5, offset: 7937},
+													pos:        position{line: 432, col: 5, offset: 8247},
 													val:        "[0-9]",
 													ranges:     []rune{'0', '9'},
 													ignoreCase: false,
@@ -5440,33 +5919,33 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 404, col: 5, offset: 7714},
+						pos: position{line: 416, col: 5, offset: 8024},
 						run: (*parser).callonPrimary166,
 						expr: &seqExpr{
-							pos: position{line: 404, col: 5, offset: 7714},
+							pos: position{line: 416, col: 5, offset: 8024},
 							exprs: []interface{}{
 								&choiceExpr{
-									pos: position{line: 409, col: 6, offset: 7796},
+									pos: position{line: 421, col: 6, offset: 8106},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 409, col: 6, offset: 7796},
+											pos:        position{line: 421, col: 6, offset: 8106},
 											val:        "0",
 											ignoreCase: false,
 										},
 										&seqExpr{
-											pos: position{line: 409, col: 12, offset: 7802},
+											pos: position{line: 421, col: 12, offset: 8112},
 											exprs: []interface{}{
 												&charClassMatcher{
-													pos:        position{line: 417, col: 5, offset: 7920},
+													pos:        position{line: 429, col: 5, offset: 8230},
 													val:        "[1-9]",
 													ranges:     []rune{'1', '9'},
 													ignoreCase: false,
 													inverted:   false,
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 409, col: 25, offset: 7815},
+													pos: position{line: 421, col: 25, offset: 8125},
 													expr: &charClassMatcher{
-														pos:        position{line: 420, col: 5, offset: 7937},
+														pos:        position{line: 432, col: 5, offset: 8247},
 														val:        "[0-9]",
 														ranges:     []rune{'0', '9'},
 														ignoreCase: false,
@@ -5478,14 +5957,14 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 404, col: 13, offset: 7722},
+									pos:        position{line: 416, col: 13, offset: 8032},
 									val:        ".",
 									ignoreCase: false,
 								},
 								&oneOrMoreExpr{
-									pos: position{line: 404, col: 17, offset: 7726},
+									pos: position{line: 416, col: 17, offset: 8036},
 									expr: &charClassMatcher{
-										pos:        position{line: 420, col: 5, offset: 7937},
+										pos:        position{line: 432, col: 5, offset: 8247},
 										val:        "[0-9]",
 										ranges:     []rune{'0', '9'},
 										ignoreCase: false,
@@ -5496,30 +5975,30 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 412, col: 5, offset: 7843},
+						pos: position{line: 424, col: 5, offset: 8153},
 						run: (*parser).callonPrimary177,
 						expr: &choiceExpr{
-							pos: position{line: 409, col: 6, offset: 7796},
+							pos: position{line: 421, col: 6, offset: 8106},
 							alternatives: []interface{}{
 								&litMatcher{
-									pos:        position{line: 409, col: 6, offset: 7796},
+									pos:        position{line: 421, col: 6, offset: 8106},
 									val:        "0",
 									ignoreCase: false,
 								},
 								&seqExpr{
-									pos: position{line: 409, col: 12, offset: 7802},
+									pos: position{line: 421, col: 12, offset: 8112},
 									exprs: []interface{}{
 										&charClassMatcher{
-											pos:        position{line: 417, col: 5, offset: 7920},
+											pos:        position{line: 429, col: 5, offset: 8230},
 											val:        "[1-9]",
 											ranges:     []rune{'1', '9'},
 											ignoreCase: false,
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 409, col: 25, offset: 7815},
+											pos: position{line: 421, col: 25, offset: 8125},
 											expr: &charClassMatcher{
-												pos:        position{line: 420, col: 5, offset: 7937},
+												pos:        position{line: 432, col: 5, offset: 8247},
 												val:        "[0-9]",
 												ranges:     []rune{'0', '9'},
 												ignoreCase: false,
@@ -5532,21 +6011,21 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 256, col: 5, offset: 5345},
+						pos:  position{line: 268, col: 5, offset: 5655},
 						name: "CallExpression",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 257, col: 5, offset: 5364},
+						pos:  position{line: 269, col: 5, offset: 5674},
 						name: "MemberExpressions",
 					},
 					&actionExpr{
-						pos: position{line: 456, col: 5, offset: 8719},
+						pos: position{line: 468, col: 5, offset: 9029},
 						run: (*parser).callonPrimary186,
 						expr: &seqExpr{
-							pos: position{line: 456, col: 5, offset: 8719},
+							pos: position{line: 468, col: 5, offset: 9029},
 							exprs: []interface{}{
 								&charClassMatcher{
-									pos:        position{line: 456, col: 5, offset: 8719},
+									pos:        position{line: 468, col: 5, offset: 9029},
 									val:        "[_\\pL]",
 									chars:      []rune{'_'},
 									classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -5554,9 +6033,9 @@ var g = &grammar{
 									inverted:   false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 456, col: 11, offset: 8725},
+									pos: position{line: 468, col: 11, offset: 9035},
 									expr: &charClassMatcher{
-										pos:        position{line: 456, col: 11, offset: 8725},
+										pos:        position{line: 468, col: 11, offset: 9035},
 										val:        "[_0-9\\pL]",
 										chars:      []rune{'_'},
 										ranges:     []rune{'0', '9'},
@@ -5569,15 +6048,15 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 259, col: 5, offset: 5401},
+						pos:  position{line: 271, col: 5, offset: 5711},
 						name: "ObjectExpression",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 260, col: 5, offset: 5422},
+						pos:  position{line: 272, col: 5, offset: 5732},
 						name: "ArrowFunctionExpression",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 261, col: 5, offset: 5450},
+						pos:  position{line: 273, col: 5, offset: 5760},
 						name: "Parens",
 					},
 				},
@@ -5585,42 +6064,42 @@ var g = &grammar{
 		},
 		{
 			name: "Parens",
-			pos:  position{line: 273, col: 1, offset: 5626},
+			pos:  position{line: 285, col: 1, offset: 5936},
 			expr: &actionExpr{
-				pos: position{line: 274, col: 5, offset: 5637},
+				pos: position{line: 286, col: 5, offset: 5947},
 				run: (*parser).callonParens1,
 				expr: &seqExpr{
-					pos: position{line: 274, col: 5, offset: 5637},
+					pos: position{line: 286, col: 5, offset: 5947},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 274, col: 5, offset: 5637},
+							pos:        position{line: 286, col: 5, offset: 5947},
 							val:        "(",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -5628,7 +6107,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -5638,37 +6117,37 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 274, col: 12, offset: 5644},
+							pos:   position{line: 286, col: 12, offset: 5954},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 274, col: 17, offset: 5649},
+								pos:  position{line: 286, col: 17, offset: 5959},
 								name: "Expr",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -5676,7 +6155,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -5686,7 +6165,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 274, col: 25, offset: 5657},
+							pos:        position{line: 286, col: 25, offset: 5967},
 							val:        ")",
 							ignoreCase: false,
 						},
@@ -5696,42 +6175,42 @@ var g = &grammar{
 		},
 		{
 			name: "Array",
-			pos:  position{line: 278, col: 1, offset: 5693},
+			pos:  position{line: 290, col: 1, offset: 6003},
 			expr: &actionExpr{
-				pos: position{line: 279, col: 5, offset: 5703},
+				pos: position{line: 291, col: 5, offset: 6013},
 				run: (*parser).callonArray1,
 				expr: &seqExpr{
-					pos: position{line: 279, col: 5, offset: 5703},
+					pos: position{line: 291, col: 5, offset: 6013},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 279, col: 5, offset: 5703},
+							pos:        position{line: 291, col: 5, offset: 6013},
 							val:        "[",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -5739,7 +6218,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -5749,40 +6228,40 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 279, col: 12, offset: 5710},
+							pos:   position{line: 291, col: 12, offset: 6020},
 							label: "elements",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 279, col: 21, offset: 5719},
+								pos: position{line: 291, col: 21, offset: 6029},
 								expr: &ruleRefExpr{
-									pos:  position{line: 279, col: 21, offset: 5719},
+									pos:  position{line: 291, col: 21, offset: 6029},
 									name: "ArrayElements",
 								},
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -5790,7 +6269,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -5800,7 +6279,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 279, col: 39, offset: 5737},
+							pos:        position{line: 291, col: 39, offset: 6047},
 							val:        "]",
 							ignoreCase: false,
 						},
@@ -5810,45 +6289,45 @@ var g = &grammar{
 		},
 		{
 			name: "ArrayElements",
-			pos:  position{line: 283, col: 1, offset: 5777},
+			pos:  position{line: 295, col: 1, offset: 6087},
 			expr: &actionExpr{
-				pos: position{line: 284, col: 5, offset: 5795},
+				pos: position{line: 296, col: 5, offset: 6105},
 				run: (*parser).callonArrayElements1,
 				expr: &seqExpr{
-					pos: position{line: 284, col: 5, offset: 5795},
+					pos: position{line: 296, col: 5, offset: 6105},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 284, col: 5, offset: 5795},
+							pos:   position{line: 296, col: 5, offset: 6105},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 284, col: 11, offset: 5801},
+								pos:  position{line: 296, col: 11, offset: 6111},
 								name: "Primary",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -5856,7 +6335,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -5866,12 +6345,12 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 284, col: 22, offset: 5812},
+							pos:   position{line: 296, col: 22, offset: 6122},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 284, col: 27, offset: 5817},
+								pos: position{line: 296, col: 27, offset: 6127},
 								expr: &ruleRefExpr{
-									pos:  position{line: 284, col: 27, offset: 5817},
+									pos:  position{line: 296, col: 27, offset: 6127},
 									name: "ArrayRest",
 								},
 							},
@@ -5882,42 +6361,42 @@ var g = &grammar{
 		},
 		{
 			name: "ArrayRest",
-			pos:  position{line: 288, col: 1, offset: 5889},
+			pos:  position{line: 300, col: 1, offset: 6199},
 			expr: &actionExpr{
-				pos: position{line: 289, col: 5, offset: 5903},
+				pos: position{line: 301, col: 5, offset: 6213},
 				run: (*parser).callonArrayRest1,
 				expr: &seqExpr{
-					pos: position{line: 289, col: 5, offset: 5903},
+					pos: position{line: 301, col: 5, offset: 6213},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 289, col: 5, offset: 5903},
+							pos:        position{line: 301, col: 5, offset: 6213},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 464, col: 5, offset: 8809},
+							pos: position{line: 476, col: 5, offset: 9119},
 							expr: &choiceExpr{
-								pos: position{line: 464, col: 7, offset: 8811},
+								pos: position{line: 476, col: 7, offset: 9121},
 								alternatives: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 470, col: 5, offset: 8872},
+										pos:        position{line: 482, col: 5, offset: 9182},
 										val:        "[ \\t\\r\\n]",
 										chars:      []rune{' ', '\t', '\r', '\n'},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&seqExpr{
-										pos: position{line: 467, col: 5, offset: 8846},
+										pos: position{line: 479, col: 5, offset: 9156},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 467, col: 5, offset: 8846},
+												pos:        position{line: 479, col: 5, offset: 9156},
 												val:        "//",
 												ignoreCase: false,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 467, col: 10, offset: 8851},
+												pos: position{line: 479, col: 10, offset: 9161},
 												expr: &charClassMatcher{
-													pos:        position{line: 467, col: 10, offset: 8851},
+													pos:        position{line: 479, col: 10, offset: 9161},
 													val:        "[^\\r\\n]",
 													chars:      []rune{'\r', '\n'},
 													ignoreCase: false,
@@ -5925,7 +6404,7 @@ var g = &grammar{
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 476, col: 5, offset: 8918},
+												pos:        position{line: 488, col: 5, offset: 9228},
 												val:        "\n",
 												ignoreCase: false,
 											},
@@ -5935,10 +6414,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 289, col: 12, offset: 5910},
+							pos:   position{line: 301, col: 12, offset: 6220},
 							label: "element",
 							expr: &ruleRefExpr{
-								pos:  position{line: 289, col: 20, offset: 5918},
+								pos:  position{line: 301, col: 20, offset: 6228},
 								name: "Primary",
 							},
 						},
@@ -6004,6 +6483,61 @@ func (p *parser) callonReturnStatement1() (interface{}, error) {
 	return p.cur.onReturnStatement1(stack["argument"])
 }
 
+func (c *current) onImportStatement27() (interface{}, error) {
+	return nil, errors.New("invalid escape character")
+
+}
+
+func (p *parser) callonImportStatement27() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportStatement27()
+}
+
+func (c *current) onImportStatement14() (interface{}, error) {
+	return stringLiteral(c.text, c.pos)
+
+}
+
+func (p *parser) callonImportStatement14() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportStatement14()
+}
+
+func (c *current) onImportStatement47() (interface{}, error) {
+	return nil, errors.New("invalid escape character")
+
+}
+
+func (p *parser) callonImportStatement47() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportStatement47()
+}
+
+func (c *current) onImportStatement34() (interface{}, error) {
+	return "", errors.New("string literal not terminated")
+
+}
+
+func (p *parser) callonImportStatement34() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportStatement34()
+}
+
+func (c *current) onImportStatement1(path interface{}) (interface{}, error) {
+	return importdecl(path, c.text, c.pos)
+
+}
+
+func (p *parser) callonImportStatement1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onImportStatement1(stack["path"])
+}
+
 func (c *current) onExpressionStatement1(expr interface{}) (interface{}, error) {
 	return exprstmt(expr, c.text, c.pos)
 
@@ -6543,6 +7077,17 @@ func (p *parser) callonProperty1() (interface{}, error) {
 	return p.cur.onProperty1(stack["key"], stack["value"])
 }
 
+func (c *current) onConditionalExpression1(test, tail interface{}) (interface{}, error) {
+	return conditionalExpression(test, tail, c.text, c.pos)
+
+}
+
+func (p *parser) callonConditionalExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onConditionalExpression1(stack["test"], stack["tail"])
+}
+
 func (c *current) onLogicalExpression16() (interface{}, error) {
 	return logicalOp(c.text)
 
@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"regexp"
 	"testing"
 	"time"
 
@@ -48,6 +49,41 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "import",
+			raw: `import "team/helpers"
+			helpers.total()`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ImportDeclaration{
+						Path: &ast.StringLiteral{Value: "team/helpers"},
+					},
+					&ast.ExpressionStatement{
+						Expression: &ast.CallExpression{
+							Callee: &ast.MemberExpression{
+								Object:   &ast.Identifier{Name: "helpers"},
+								Property: &ast.Identifier{Name: "total"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "identifier with import prefix",
+			raw:  `importance()`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.CallExpression{
+							Callee: &ast.Identifier{
+								Name: "importance",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "identifier with number",
 			raw:  `tan2()`,
@@ -527,6 +563,90 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "conditional expression",
+			raw:  `a ? "yes" : "no"`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.ConditionalExpression{
+							Test:       &ast.Identifier{Name: "a"},
+							Consequent: &ast.StringLiteral{Value: "yes"},
+							Alternate:  &ast.StringLiteral{Value: "no"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "regular expression match operators",
+			raw:  `a =~ /^web/ and b !~ /^us-/`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.LogicalExpression{
+							Operator: ast.AndOperator,
+							Left: &ast.BinaryExpression{
+								Operator: ast.RegexpMatchOperator,
+								Left:     &ast.Identifier{Name: "a"},
+								Right:    &ast.RegexpLiteral{Value: regexp.MustCompile("^web")},
+							},
+							Right: &ast.BinaryExpression{
+								Operator: ast.NotRegexpMatchOperator,
+								Left:     &ast.Identifier{Name: "b"},
+								Right:    &ast.RegexpLiteral{Value: regexp.MustCompile("^us-")},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "nested conditional expressions",
+			raw: `r._value > 90 and r.host == "a" ? "crit"
+    : r._value > 80 ? "warn"
+    : "ok"`,
+			want: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.ConditionalExpression{
+							Test: &ast.LogicalExpression{
+								Operator: ast.AndOperator,
+								Left: &ast.BinaryExpression{
+									Operator: ast.GreaterThanOperator,
+									Left: &ast.MemberExpression{
+										Object:   &ast.Identifier{Name: "r"},
+										Property: &ast.Identifier{Name: "_value"},
+									},
+									Right: &ast.IntegerLiteral{Value: 90},
+								},
+								Right: &ast.BinaryExpression{
+									Operator: ast.EqualOperator,
+									Left: &ast.MemberExpression{
+										Object:   &ast.Identifier{Name: "r"},
+										Property: &ast.Identifier{Name: "host"},
+									},
+									Right: &ast.StringLiteral{Value: "a"},
+								},
+							},
+							Consequent: &ast.StringLiteral{Value: "crit"},
+							Alternate: &ast.ConditionalExpression{
+								Test: &ast.BinaryExpression{
+									Operator: ast.GreaterThanOperator,
+									Left: &ast.MemberExpression{
+										Object:   &ast.Identifier{Name: "r"},
+										Property: &ast.Identifier{Name: "_value"},
+									},
+									Right: &ast.IntegerLiteral{Value: 80},
+								},
+								Consequent: &ast.StringLiteral{Value: "warn"},
+								Alternate:  &ast.StringLiteral{Value: "ok"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "unary expressions with too many comments",
 			raw: `// define a
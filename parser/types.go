@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"regexp"
 	"strconv"
 	"strings"
@@ -73,6 +74,17 @@ func returnstmt(argument interface{}, text []byte, pos position) (*ast.ReturnSta
 	}, nil
 }
 
+func importdecl(path interface{}, text []byte, pos position) (*ast.ImportDeclaration, error) {
+	lit, ok := path.(*ast.StringLiteral)
+	if !ok {
+		return nil, errors.New("import path must be a string literal")
+	}
+	return &ast.ImportDeclaration{
+		BaseNode: base(text, pos),
+		Path:     lit,
+	}, nil
+}
+
 func pipeExprs(head, tail interface{}, text []byte, pos position) (*ast.PipeExpression, error) {
 	var arg ast.Expression
 	arg = head.(ast.Expression)
@@ -227,6 +239,19 @@ func logicalExpression(head, tails interface{}, text []byte, pos position) (ast.
 	return res, nil
 }
 
+func conditionalExpression(test, tail interface{}, text []byte, pos position) (ast.Expression, error) {
+	if tail == nil {
+		return test.(ast.Expression), nil
+	}
+	branches := toIfaceSlice(tail)
+	return &ast.ConditionalExpression{
+		Test:       test.(ast.Expression),
+		Consequent: branches[3].(ast.Expression),
+		Alternate:  branches[7].(ast.Expression),
+		BaseNode:   base(text, pos),
+	}, nil
+}
+
 func logicalOp(text []byte) (ast.LogicalOperatorKind, error) {
 	return ast.LogicalOperatorLookup(strings.ToLower(string(text))), nil
 }
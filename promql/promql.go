@@ -41,17 +41,21 @@ var g = &grammar{
 									},
 									&ruleRefExpr{
 										pos:  position{line: 8, col: 32, offset: 91},
+										name: "HistogramQuantileExpression",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 8, col: 62, offset: 121},
 										name: "AggregateExpression",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 8, col: 54, offset: 113},
+										pos:  position{line: 8, col: 84, offset: 143},
 										name: "VectorSelector",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 8, col: 71, offset: 130},
+							pos:  position{line: 8, col: 101, offset: 160},
 							name: "EOF",
 						},
 					},
@@ -60,39 +64,39 @@ var g = &grammar{
 		},
 		{
 			name: "SourceChar",
-			pos:  position{line: 12, col: 1, offset: 163},
+			pos:  position{line: 12, col: 1, offset: 193},
 			expr: &anyMatcher{
-				line: 12, col: 14, offset: 176,
+				line: 12, col: 14, offset: 206,
 			},
 		},
 		{
 			name: "Comment",
-			pos:  position{line: 14, col: 1, offset: 179},
+			pos:  position{line: 14, col: 1, offset: 209},
 			expr: &actionExpr{
-				pos: position{line: 14, col: 11, offset: 189},
+				pos: position{line: 14, col: 11, offset: 219},
 				run: (*parser).callonComment1,
 				expr: &seqExpr{
-					pos: position{line: 14, col: 11, offset: 189},
+					pos: position{line: 14, col: 11, offset: 219},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 14, col: 11, offset: 189},
+							pos:        position{line: 14, col: 11, offset: 219},
 							val:        "#",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 14, col: 15, offset: 193},
+							pos: position{line: 14, col: 15, offset: 223},
 							expr: &seqExpr{
-								pos: position{line: 14, col: 17, offset: 195},
+								pos: position{line: 14, col: 17, offset: 225},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 14, col: 17, offset: 195},
+										pos: position{line: 14, col: 17, offset: 225},
 										expr: &ruleRefExpr{
-											pos:  position{line: 14, col: 18, offset: 196},
+											pos:  position{line: 14, col: 18, offset: 226},
 											name: "EOL",
 										},
 									},
 									&ruleRefExpr{
-										pos:  position{line: 14, col: 22, offset: 200},
+										pos:  position{line: 14, col: 22, offset: 230},
 										name: "SourceChar",
 									},
 								},
@@ -104,15 +108,15 @@ var g = &grammar{
 		},
 		{
 			name: "Identifier",
-			pos:  position{line: 18, col: 1, offset: 260},
+			pos:  position{line: 18, col: 1, offset: 290},
 			expr: &actionExpr{
-				pos: position{line: 18, col: 14, offset: 273},
+				pos: position{line: 18, col: 14, offset: 303},
 				run: (*parser).callonIdentifier1,
 				expr: &labeledExpr{
-					pos:   position{line: 18, col: 14, offset: 273},
+					pos:   position{line: 18, col: 14, offset: 303},
 					label: "ident",
 					expr: &ruleRefExpr{
-						pos:  position{line: 18, col: 20, offset: 279},
+						pos:  position{line: 18, col: 20, offset: 309},
 						name: "IdentifierName",
 					},
 				},
@@ -120,21 +124,21 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierName",
-			pos:  position{line: 26, col: 1, offset: 463},
+			pos:  position{line: 26, col: 1, offset: 493},
 			expr: &actionExpr{
-				pos: position{line: 26, col: 18, offset: 480},
+				pos: position{line: 26, col: 18, offset: 510},
 				run: (*parser).callonIdentifierName1,
 				expr: &seqExpr{
-					pos: position{line: 26, col: 18, offset: 480},
+					pos: position{line: 26, col: 18, offset: 510},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 26, col: 18, offset: 480},
+							pos:  position{line: 26, col: 18, offset: 510},
 							name: "IdentifierStart",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 26, col: 34, offset: 496},
+							pos: position{line: 26, col: 34, offset: 526},
 							expr: &ruleRefExpr{
-								pos:  position{line: 26, col: 34, offset: 496},
+								pos:  position{line: 26, col: 34, offset: 526},
 								name: "IdentifierPart",
 							},
 						},
@@ -144,9 +148,9 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierStart",
-			pos:  position{line: 29, col: 1, offset: 547},
+			pos:  position{line: 29, col: 1, offset: 577},
 			expr: &charClassMatcher{
-				pos:        position{line: 29, col: 19, offset: 565},
+				pos:        position{line: 29, col: 19, offset: 595},
 				val:        "[\\pL_]",
 				chars:      []rune{'_'},
 				classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -156,16 +160,16 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierPart",
-			pos:  position{line: 30, col: 1, offset: 572},
+			pos:  position{line: 30, col: 1, offset: 602},
 			expr: &choiceExpr{
-				pos: position{line: 30, col: 18, offset: 589},
+				pos: position{line: 30, col: 18, offset: 619},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 30, col: 18, offset: 589},
+						pos:  position{line: 30, col: 18, offset: 619},
 						name: "IdentifierStart",
 					},
 					&charClassMatcher{
-						pos:        position{line: 30, col: 36, offset: 607},
+						pos:        position{line: 30, col: 36, offset: 637},
 						val:        "[\\p{Nd}]",
 						classes:    []*unicode.RangeTable{rangeTable("Nd")},
 						ignoreCase: false,
@@ -176,74 +180,74 @@ var g = &grammar{
 		},
 		{
 			name: "StringLiteral",
-			pos:  position{line: 32, col: 1, offset: 617},
+			pos:  position{line: 32, col: 1, offset: 647},
 			expr: &choiceExpr{
-				pos: position{line: 32, col: 17, offset: 633},
+				pos: position{line: 32, col: 17, offset: 663},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 32, col: 17, offset: 633},
+						pos: position{line: 32, col: 17, offset: 663},
 						run: (*parser).callonStringLiteral2,
 						expr: &choiceExpr{
-							pos: position{line: 32, col: 19, offset: 635},
+							pos: position{line: 32, col: 19, offset: 665},
 							alternatives: []interface{}{
 								&seqExpr{
-									pos: position{line: 32, col: 19, offset: 635},
+									pos: position{line: 32, col: 19, offset: 665},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 32, col: 19, offset: 635},
+											pos:        position{line: 32, col: 19, offset: 665},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 32, col: 23, offset: 639},
+											pos: position{line: 32, col: 23, offset: 669},
 											expr: &ruleRefExpr{
-												pos:  position{line: 32, col: 23, offset: 639},
+												pos:  position{line: 32, col: 23, offset: 669},
 												name: "DoubleStringChar",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 32, col: 41, offset: 657},
+											pos:        position{line: 32, col: 41, offset: 687},
 											val:        "\"",
 											ignoreCase: false,
 										},
 									},
 								},
 								&seqExpr{
-									pos: position{line: 32, col: 47, offset: 663},
+									pos: position{line: 32, col: 47, offset: 693},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 32, col: 47, offset: 663},
+											pos:        position{line: 32, col: 47, offset: 693},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 32, col: 51, offset: 667},
+											pos:  position{line: 32, col: 51, offset: 697},
 											name: "SingleStringChar",
 										},
 										&litMatcher{
-											pos:        position{line: 32, col: 68, offset: 684},
+											pos:        position{line: 32, col: 68, offset: 714},
 											val:        "'",
 											ignoreCase: false,
 										},
 									},
 								},
 								&seqExpr{
-									pos: position{line: 32, col: 74, offset: 690},
+									pos: position{line: 32, col: 74, offset: 720},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 32, col: 74, offset: 690},
+											pos:        position{line: 32, col: 74, offset: 720},
 											val:        "`",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 32, col: 78, offset: 694},
+											pos: position{line: 32, col: 78, offset: 724},
 											expr: &ruleRefExpr{
-												pos:  position{line: 32, col: 78, offset: 694},
+												pos:  position{line: 32, col: 78, offset: 724},
 												name: "RawStringChar",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 32, col: 93, offset: 709},
+											pos:        position{line: 32, col: 93, offset: 739},
 											val:        "`",
 											ignoreCase: false,
 										},
@@ -253,35 +257,35 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 38, col: 5, offset: 855},
+						pos: position{line: 38, col: 5, offset: 885},
 						run: (*parser).callonStringLiteral18,
 						expr: &choiceExpr{
-							pos: position{line: 38, col: 7, offset: 857},
+							pos: position{line: 38, col: 7, offset: 887},
 							alternatives: []interface{}{
 								&seqExpr{
-									pos: position{line: 38, col: 9, offset: 859},
+									pos: position{line: 38, col: 9, offset: 889},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 38, col: 9, offset: 859},
+											pos:        position{line: 38, col: 9, offset: 889},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 38, col: 13, offset: 863},
+											pos: position{line: 38, col: 13, offset: 893},
 											expr: &ruleRefExpr{
-												pos:  position{line: 38, col: 13, offset: 863},
+												pos:  position{line: 38, col: 13, offset: 893},
 												name: "DoubleStringChar",
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 38, col: 33, offset: 883},
+											pos: position{line: 38, col: 33, offset: 913},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 38, col: 33, offset: 883},
+													pos:  position{line: 38, col: 33, offset: 913},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 38, col: 39, offset: 889},
+													pos:  position{line: 38, col: 39, offset: 919},
 													name: "EOF",
 												},
 											},
@@ -289,29 +293,29 @@ var g = &grammar{
 									},
 								},
 								&seqExpr{
-									pos: position{line: 38, col: 51, offset: 901},
+									pos: position{line: 38, col: 51, offset: 931},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 38, col: 51, offset: 901},
+											pos:        position{line: 38, col: 51, offset: 931},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 38, col: 55, offset: 905},
+											pos: position{line: 38, col: 55, offset: 935},
 											expr: &ruleRefExpr{
-												pos:  position{line: 38, col: 55, offset: 905},
+												pos:  position{line: 38, col: 55, offset: 935},
 												name: "SingleStringChar",
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 38, col: 75, offset: 925},
+											pos: position{line: 38, col: 75, offset: 955},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 38, col: 75, offset: 925},
+													pos:  position{line: 38, col: 75, offset: 955},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 38, col: 81, offset: 931},
+													pos:  position{line: 38, col: 81, offset: 961},
 													name: "EOF",
 												},
 											},
@@ -319,22 +323,22 @@ var g = &grammar{
 									},
 								},
 								&seqExpr{
-									pos: position{line: 38, col: 91, offset: 941},
+									pos: position{line: 38, col: 91, offset: 971},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 38, col: 91, offset: 941},
+											pos:        position{line: 38, col: 91, offset: 971},
 											val:        "`",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 38, col: 95, offset: 945},
+											pos: position{line: 38, col: 95, offset: 975},
 											expr: &ruleRefExpr{
-												pos:  position{line: 38, col: 95, offset: 945},
+												pos:  position{line: 38, col: 95, offset: 975},
 												name: "RawStringChar",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 38, col: 110, offset: 960},
+											pos:  position{line: 38, col: 110, offset: 990},
 											name: "EOF",
 										},
 									},
@@ -347,51 +351,51 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringChar",
-			pos:  position{line: 42, col: 1, offset: 1031},
+			pos:  position{line: 42, col: 1, offset: 1061},
 			expr: &choiceExpr{
-				pos: position{line: 42, col: 20, offset: 1050},
+				pos: position{line: 42, col: 20, offset: 1080},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 42, col: 20, offset: 1050},
+						pos: position{line: 42, col: 20, offset: 1080},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 42, col: 20, offset: 1050},
+								pos: position{line: 42, col: 20, offset: 1080},
 								expr: &choiceExpr{
-									pos: position{line: 42, col: 23, offset: 1053},
+									pos: position{line: 42, col: 23, offset: 1083},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 42, col: 23, offset: 1053},
+											pos:        position{line: 42, col: 23, offset: 1083},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 42, col: 29, offset: 1059},
+											pos:        position{line: 42, col: 29, offset: 1089},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 42, col: 36, offset: 1066},
+											pos:  position{line: 42, col: 36, offset: 1096},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 42, col: 42, offset: 1072},
+								pos:  position{line: 42, col: 42, offset: 1102},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 42, col: 55, offset: 1085},
+						pos: position{line: 42, col: 55, offset: 1115},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 42, col: 55, offset: 1085},
+								pos:        position{line: 42, col: 55, offset: 1115},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 42, col: 60, offset: 1090},
+								pos:  position{line: 42, col: 60, offset: 1120},
 								name: "DoubleStringEscape",
 							},
 						},
@@ -401,51 +405,51 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringChar",
-			pos:  position{line: 43, col: 1, offset: 1109},
+			pos:  position{line: 43, col: 1, offset: 1139},
 			expr: &choiceExpr{
-				pos: position{line: 43, col: 20, offset: 1128},
+				pos: position{line: 43, col: 20, offset: 1158},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 43, col: 20, offset: 1128},
+						pos: position{line: 43, col: 20, offset: 1158},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 43, col: 20, offset: 1128},
+								pos: position{line: 43, col: 20, offset: 1158},
 								expr: &choiceExpr{
-									pos: position{line: 43, col: 23, offset: 1131},
+									pos: position{line: 43, col: 23, offset: 1161},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 43, col: 23, offset: 1131},
+											pos:        position{line: 43, col: 23, offset: 1161},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 43, col: 29, offset: 1137},
+											pos:        position{line: 43, col: 29, offset: 1167},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 43, col: 36, offset: 1144},
+											pos:  position{line: 43, col: 36, offset: 1174},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 43, col: 42, offset: 1150},
+								pos:  position{line: 43, col: 42, offset: 1180},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 43, col: 55, offset: 1163},
+						pos: position{line: 43, col: 55, offset: 1193},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 43, col: 55, offset: 1163},
+								pos:        position{line: 43, col: 55, offset: 1193},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 43, col: 60, offset: 1168},
+								pos:  position{line: 43, col: 60, offset: 1198},
 								name: "SingleStringEscape",
 							},
 						},
@@ -455,20 +459,20 @@ var g = &grammar{
 		},
 		{
 			name: "RawStringChar",
-			pos:  position{line: 44, col: 1, offset: 1187},
+			pos:  position{line: 44, col: 1, offset: 1217},
 			expr: &seqExpr{
-				pos: position{line: 44, col: 17, offset: 1203},
+				pos: position{line: 44, col: 17, offset: 1233},
 				exprs: []interface{}{
 					&notExpr{
-						pos: position{line: 44, col: 17, offset: 1203},
+						pos: position{line: 44, col: 17, offset: 1233},
 						expr: &litMatcher{
-							pos:        position{line: 44, col: 18, offset: 1204},
+							pos:        position{line: 44, col: 18, offset: 1234},
 							val:        "`",
 							ignoreCase: false,
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 44, col: 22, offset: 1208},
+						pos:  position{line: 44, col: 22, offset: 1238},
 						name: "SourceChar",
 					},
 				},
@@ -476,40 +480,40 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringEscape",
-			pos:  position{line: 46, col: 1, offset: 1220},
+			pos:  position{line: 46, col: 1, offset: 1250},
 			expr: &choiceExpr{
-				pos: position{line: 46, col: 22, offset: 1241},
+				pos: position{line: 46, col: 22, offset: 1271},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 46, col: 24, offset: 1243},
+						pos: position{line: 46, col: 24, offset: 1273},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 46, col: 24, offset: 1243},
+								pos:        position{line: 46, col: 24, offset: 1273},
 								val:        "\"",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 46, col: 30, offset: 1249},
+								pos:  position{line: 46, col: 30, offset: 1279},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 47, col: 7, offset: 1278},
+						pos: position{line: 47, col: 7, offset: 1308},
 						run: (*parser).callonDoubleStringEscape5,
 						expr: &choiceExpr{
-							pos: position{line: 47, col: 9, offset: 1280},
+							pos: position{line: 47, col: 9, offset: 1310},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 47, col: 9, offset: 1280},
+									pos:  position{line: 47, col: 9, offset: 1310},
 									name: "SourceChar",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 47, col: 22, offset: 1293},
+									pos:  position{line: 47, col: 22, offset: 1323},
 									name: "EOL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 47, col: 28, offset: 1299},
+									pos:  position{line: 47, col: 28, offset: 1329},
 									name: "EOF",
 								},
 							},
@@ -520,40 +524,40 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringEscape",
-			pos:  position{line: 50, col: 1, offset: 1364},
+			pos:  position{line: 50, col: 1, offset: 1394},
 			expr: &choiceExpr{
-				pos: position{line: 50, col: 22, offset: 1385},
+				pos: position{line: 50, col: 22, offset: 1415},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 50, col: 24, offset: 1387},
+						pos: position{line: 50, col: 24, offset: 1417},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 50, col: 24, offset: 1387},
+								pos:        position{line: 50, col: 24, offset: 1417},
 								val:        "'",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 50, col: 30, offset: 1393},
+								pos:  position{line: 50, col: 30, offset: 1423},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 51, col: 7, offset: 1422},
+						pos: position{line: 51, col: 7, offset: 1452},
 						run: (*parser).callonSingleStringEscape5,
 						expr: &choiceExpr{
-							pos: position{line: 51, col: 9, offset: 1424},
+							pos: position{line: 51, col: 9, offset: 1454},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 51, col: 9, offset: 1424},
+									pos:  position{line: 51, col: 9, offset: 1454},
 									name: "SourceChar",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 51, col: 22, offset: 1437},
+									pos:  position{line: 51, col: 22, offset: 1467},
 									name: "EOL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 51, col: 28, offset: 1443},
+									pos:  position{line: 51, col: 28, offset: 1473},
 									name: "EOF",
 								},
 							},
@@ -564,28 +568,28 @@ var g = &grammar{
 		},
 		{
 			name: "CommonEscapeSequence",
-			pos:  position{line: 55, col: 1, offset: 1509},
+			pos:  position{line: 55, col: 1, offset: 1539},
 			expr: &choiceExpr{
-				pos: position{line: 55, col: 24, offset: 1532},
+				pos: position{line: 55, col: 24, offset: 1562},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 55, col: 24, offset: 1532},
+						pos:  position{line: 55, col: 24, offset: 1562},
 						name: "SingleCharEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 55, col: 43, offset: 1551},
+						pos:  position{line: 55, col: 43, offset: 1581},
 						name: "OctalEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 55, col: 57, offset: 1565},
+						pos:  position{line: 55, col: 57, offset: 1595},
 						name: "HexEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 55, col: 69, offset: 1577},
+						pos:  position{line: 55, col: 69, offset: 1607},
 						name: "LongUnicodeEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 55, col: 89, offset: 1597},
+						pos:  position{line: 55, col: 89, offset: 1627},
 						name: "ShortUnicodeEscape",
 					},
 				},
@@ -593,47 +597,47 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharEscape",
-			pos:  position{line: 56, col: 1, offset: 1616},
+			pos:  position{line: 56, col: 1, offset: 1646},
 			expr: &choiceExpr{
-				pos: position{line: 56, col: 20, offset: 1635},
+				pos: position{line: 56, col: 20, offset: 1665},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 56, col: 20, offset: 1635},
+						pos:        position{line: 56, col: 20, offset: 1665},
 						val:        "a",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 56, col: 26, offset: 1641},
+						pos:        position{line: 56, col: 26, offset: 1671},
 						val:        "b",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 56, col: 32, offset: 1647},
+						pos:        position{line: 56, col: 32, offset: 1677},
 						val:        "n",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 56, col: 38, offset: 1653},
+						pos:        position{line: 56, col: 38, offset: 1683},
 						val:        "f",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 56, col: 44, offset: 1659},
+						pos:        position{line: 56, col: 44, offset: 1689},
 						val:        "r",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 56, col: 50, offset: 1665},
+						pos:        position{line: 56, col: 50, offset: 1695},
 						val:        "t",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 56, col: 56, offset: 1671},
+						pos:        position{line: 56, col: 56, offset: 1701},
 						val:        "v",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 56, col: 62, offset: 1677},
+						pos:        position{line: 56, col: 62, offset: 1707},
 						val:        "\\",
 						ignoreCase: false,
 					},
@@ -642,50 +646,50 @@ var g = &grammar{
 		},
 		{
 			name: "OctalEscape",
-			pos:  position{line: 57, col: 1, offset: 1682},
+			pos:  position{line: 57, col: 1, offset: 1712},
 			expr: &choiceExpr{
-				pos: position{line: 57, col: 15, offset: 1696},
+				pos: position{line: 57, col: 15, offset: 1726},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 57, col: 15, offset: 1696},
+						pos: position{line: 57, col: 15, offset: 1726},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 57, col: 15, offset: 1696},
+								pos:  position{line: 57, col: 15, offset: 1726},
 								name: "OctalDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 57, col: 26, offset: 1707},
+								pos:  position{line: 57, col: 26, offset: 1737},
 								name: "OctalDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 57, col: 37, offset: 1718},
+								pos:  position{line: 57, col: 37, offset: 1748},
 								name: "OctalDigit",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 58, col: 7, offset: 1735},
+						pos: position{line: 58, col: 7, offset: 1765},
 						run: (*parser).callonOctalEscape6,
 						expr: &seqExpr{
-							pos: position{line: 58, col: 7, offset: 1735},
+							pos: position{line: 58, col: 7, offset: 1765},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 58, col: 7, offset: 1735},
+									pos:  position{line: 58, col: 7, offset: 1765},
 									name: "OctalDigit",
 								},
 								&choiceExpr{
-									pos: position{line: 58, col: 20, offset: 1748},
+									pos: position{line: 58, col: 20, offset: 1778},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 58, col: 20, offset: 1748},
+											pos:  position{line: 58, col: 20, offset: 1778},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 58, col: 33, offset: 1761},
+											pos:  position{line: 58, col: 33, offset: 1791},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 58, col: 39, offset: 1767},
+											pos:  position{line: 58, col: 39, offset: 1797},
 											name: "EOF",
 										},
 									},
@@ -698,52 +702,52 @@ var g = &grammar{
 		},
 		{
 			name: "HexEscape",
-			pos:  position{line: 61, col: 1, offset: 1828},
+			pos:  position{line: 61, col: 1, offset: 1858},
 			expr: &choiceExpr{
-				pos: position{line: 61, col: 13, offset: 1840},
+				pos: position{line: 61, col: 13, offset: 1870},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 61, col: 13, offset: 1840},
+						pos: position{line: 61, col: 13, offset: 1870},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 61, col: 13, offset: 1840},
+								pos:        position{line: 61, col: 13, offset: 1870},
 								val:        "x",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 61, col: 17, offset: 1844},
+								pos:  position{line: 61, col: 17, offset: 1874},
 								name: "HexDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 61, col: 26, offset: 1853},
+								pos:  position{line: 61, col: 26, offset: 1883},
 								name: "HexDigit",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 62, col: 7, offset: 1868},
+						pos: position{line: 62, col: 7, offset: 1898},
 						run: (*parser).callonHexEscape6,
 						expr: &seqExpr{
-							pos: position{line: 62, col: 7, offset: 1868},
+							pos: position{line: 62, col: 7, offset: 1898},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 62, col: 7, offset: 1868},
+									pos:        position{line: 62, col: 7, offset: 1898},
 									val:        "x",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 62, col: 13, offset: 1874},
+									pos: position{line: 62, col: 13, offset: 1904},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 62, col: 13, offset: 1874},
+											pos:  position{line: 62, col: 13, offset: 1904},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 62, col: 26, offset: 1887},
+											pos:  position{line: 62, col: 26, offset: 1917},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 62, col: 32, offset: 1893},
+											pos:  position{line: 62, col: 32, offset: 1923},
 											name: "EOF",
 										},
 									},
@@ -756,80 +760,80 @@ var g = &grammar{
 		},
 		{
 			name: "LongUnicodeEscape",
-			pos:  position{line: 65, col: 1, offset: 1960},
+			pos:  position{line: 65, col: 1, offset: 1990},
 			expr: &choiceExpr{
-				pos: position{line: 66, col: 5, offset: 1985},
+				pos: position{line: 66, col: 5, offset: 2015},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 66, col: 5, offset: 1985},
+						pos: position{line: 66, col: 5, offset: 2015},
 						run: (*parser).callonLongUnicodeEscape2,
 						expr: &seqExpr{
-							pos: position{line: 66, col: 5, offset: 1985},
+							pos: position{line: 66, col: 5, offset: 2015},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 66, col: 5, offset: 1985},
+									pos:        position{line: 66, col: 5, offset: 2015},
 									val:        "U",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 66, col: 9, offset: 1989},
+									pos:  position{line: 66, col: 9, offset: 2019},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 66, col: 18, offset: 1998},
+									pos:  position{line: 66, col: 18, offset: 2028},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 66, col: 27, offset: 2007},
+									pos:  position{line: 66, col: 27, offset: 2037},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 66, col: 36, offset: 2016},
+									pos:  position{line: 66, col: 36, offset: 2046},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 66, col: 45, offset: 2025},
+									pos:  position{line: 66, col: 45, offset: 2055},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 66, col: 54, offset: 2034},
+									pos:  position{line: 66, col: 54, offset: 2064},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 66, col: 63, offset: 2043},
+									pos:  position{line: 66, col: 63, offset: 2073},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 66, col: 72, offset: 2052},
+									pos:  position{line: 66, col: 72, offset: 2082},
 									name: "HexDigit",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 69, col: 7, offset: 2154},
+						pos: position{line: 69, col: 7, offset: 2184},
 						run: (*parser).callonLongUnicodeEscape13,
 						expr: &seqExpr{
-							pos: position{line: 69, col: 7, offset: 2154},
+							pos: position{line: 69, col: 7, offset: 2184},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 69, col: 7, offset: 2154},
+									pos:        position{line: 69, col: 7, offset: 2184},
 									val:        "U",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 69, col: 13, offset: 2160},
+									pos: position{line: 69, col: 13, offset: 2190},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 69, col: 13, offset: 2160},
+											pos:  position{line: 69, col: 13, offset: 2190},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 69, col: 26, offset: 2173},
+											pos:  position{line: 69, col: 26, offset: 2203},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 69, col: 32, offset: 2179},
+											pos:  position{line: 69, col: 32, offset: 2209},
 											name: "EOF",
 										},
 									},
@@ -842,64 +846,64 @@ var g = &grammar{
 		},
 		{
 			name: "ShortUnicodeEscape",
-			pos:  position{line: 72, col: 1, offset: 2242},
+			pos:  position{line: 72, col: 1, offset: 2272},
 			expr: &choiceExpr{
-				pos: position{line: 73, col: 5, offset: 2268},
+				pos: position{line: 73, col: 5, offset: 2298},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 73, col: 5, offset: 2268},
+						pos: position{line: 73, col: 5, offset: 2298},
 						run: (*parser).callonShortUnicodeEscape2,
 						expr: &seqExpr{
-							pos: position{line: 73, col: 5, offset: 2268},
+							pos: position{line: 73, col: 5, offset: 2298},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 73, col: 5, offset: 2268},
+									pos:        position{line: 73, col: 5, offset: 2298},
 									val:        "u",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 73, col: 9, offset: 2272},
+									pos:  position{line: 73, col: 9, offset: 2302},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 73, col: 18, offset: 2281},
+									pos:  position{line: 73, col: 18, offset: 2311},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 73, col: 27, offset: 2290},
+									pos:  position{line: 73, col: 27, offset: 2320},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 73, col: 36, offset: 2299},
+									pos:  position{line: 73, col: 36, offset: 2329},
 									name: "HexDigit",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 76, col: 7, offset: 2401},
+						pos: position{line: 76, col: 7, offset: 2431},
 						run: (*parser).callonShortUnicodeEscape9,
 						expr: &seqExpr{
-							pos: position{line: 76, col: 7, offset: 2401},
+							pos: position{line: 76, col: 7, offset: 2431},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 76, col: 7, offset: 2401},
+									pos:        position{line: 76, col: 7, offset: 2431},
 									val:        "u",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 76, col: 13, offset: 2407},
+									pos: position{line: 76, col: 13, offset: 2437},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 76, col: 13, offset: 2407},
+											pos:  position{line: 76, col: 13, offset: 2437},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 76, col: 26, offset: 2420},
+											pos:  position{line: 76, col: 26, offset: 2450},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 76, col: 32, offset: 2426},
+											pos:  position{line: 76, col: 32, offset: 2456},
 											name: "EOF",
 										},
 									},
@@ -912,9 +916,9 @@ var g = &grammar{
 		},
 		{
 			name: "OctalDigit",
-			pos:  position{line: 80, col: 1, offset: 2490},
+			pos:  position{line: 80, col: 1, offset: 2520},
 			expr: &charClassMatcher{
-				pos:        position{line: 80, col: 14, offset: 2503},
+				pos:        position{line: 80, col: 14, offset: 2533},
 				val:        "[0-7]",
 				ranges:     []rune{'0', '7'},
 				ignoreCase: false,
@@ -923,9 +927,9 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigit",
-			pos:  position{line: 81, col: 1, offset: 2509},
+			pos:  position{line: 81, col: 1, offset: 2539},
 			expr: &charClassMatcher{
-				pos:        position{line: 81, col: 16, offset: 2524},
+				pos:        position{line: 81, col: 16, offset: 2554},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -934,9 +938,9 @@ var g = &grammar{
 		},
 		{
 			name: "HexDigit",
-			pos:  position{line: 82, col: 1, offset: 2530},
+			pos:  position{line: 82, col: 1, offset: 2560},
 			expr: &charClassMatcher{
-				pos:        position{line: 82, col: 12, offset: 2541},
+				pos:        position{line: 82, col: 12, offset: 2571},
 				val:        "[0-9a-f]i",
 				ranges:     []rune{'0', '9', 'a', 'f'},
 				ignoreCase: true,
@@ -945,44 +949,44 @@ var g = &grammar{
 		},
 		{
 			name: "CharClassMatcher",
-			pos:  position{line: 84, col: 1, offset: 2552},
+			pos:  position{line: 84, col: 1, offset: 2582},
 			expr: &choiceExpr{
-				pos: position{line: 84, col: 20, offset: 2571},
+				pos: position{line: 84, col: 20, offset: 2601},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 84, col: 20, offset: 2571},
+						pos: position{line: 84, col: 20, offset: 2601},
 						run: (*parser).callonCharClassMatcher2,
 						expr: &seqExpr{
-							pos: position{line: 84, col: 20, offset: 2571},
+							pos: position{line: 84, col: 20, offset: 2601},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 84, col: 20, offset: 2571},
+									pos:        position{line: 84, col: 20, offset: 2601},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 84, col: 24, offset: 2575},
+									pos: position{line: 84, col: 24, offset: 2605},
 									expr: &choiceExpr{
-										pos: position{line: 84, col: 26, offset: 2577},
+										pos: position{line: 84, col: 26, offset: 2607},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 84, col: 26, offset: 2577},
+												pos:  position{line: 84, col: 26, offset: 2607},
 												name: "ClassCharRange",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 84, col: 43, offset: 2594},
+												pos:  position{line: 84, col: 43, offset: 2624},
 												name: "ClassChar",
 											},
 											&seqExpr{
-												pos: position{line: 84, col: 55, offset: 2606},
+												pos: position{line: 84, col: 55, offset: 2636},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 84, col: 55, offset: 2606},
+														pos:        position{line: 84, col: 55, offset: 2636},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&ruleRefExpr{
-														pos:  position{line: 84, col: 60, offset: 2611},
+														pos:  position{line: 84, col: 60, offset: 2641},
 														name: "UnicodeClassEscape",
 													},
 												},
@@ -991,14 +995,14 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 84, col: 82, offset: 2633},
+									pos:        position{line: 84, col: 82, offset: 2663},
 									val:        "]",
 									ignoreCase: false,
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 84, col: 86, offset: 2637},
+									pos: position{line: 84, col: 86, offset: 2667},
 									expr: &litMatcher{
-										pos:        position{line: 84, col: 86, offset: 2637},
+										pos:        position{line: 84, col: 86, offset: 2667},
 										val:        "i",
 										ignoreCase: false,
 									},
@@ -1007,44 +1011,44 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 86, col: 5, offset: 2679},
+						pos: position{line: 86, col: 5, offset: 2709},
 						run: (*parser).callonCharClassMatcher15,
 						expr: &seqExpr{
-							pos: position{line: 86, col: 5, offset: 2679},
+							pos: position{line: 86, col: 5, offset: 2709},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 86, col: 5, offset: 2679},
+									pos:        position{line: 86, col: 5, offset: 2709},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 86, col: 9, offset: 2683},
+									pos: position{line: 86, col: 9, offset: 2713},
 									expr: &seqExpr{
-										pos: position{line: 86, col: 11, offset: 2685},
+										pos: position{line: 86, col: 11, offset: 2715},
 										exprs: []interface{}{
 											&notExpr{
-												pos: position{line: 86, col: 11, offset: 2685},
+												pos: position{line: 86, col: 11, offset: 2715},
 												expr: &ruleRefExpr{
-													pos:  position{line: 86, col: 14, offset: 2688},
+													pos:  position{line: 86, col: 14, offset: 2718},
 													name: "EOL",
 												},
 											},
 											&ruleRefExpr{
-												pos:  position{line: 86, col: 20, offset: 2694},
+												pos:  position{line: 86, col: 20, offset: 2724},
 												name: "SourceChar",
 											},
 										},
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 86, col: 36, offset: 2710},
+									pos: position{line: 86, col: 36, offset: 2740},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 86, col: 36, offset: 2710},
+											pos:  position{line: 86, col: 36, offset: 2740},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 86, col: 42, offset: 2716},
+											pos:  position{line: 86, col: 42, offset: 2746},
 											name: "EOF",
 										},
 									},
@@ -1057,21 +1061,21 @@ var g = &grammar{
 		},
 		{
 			name: "ClassCharRange",
-			pos:  position{line: 90, col: 1, offset: 2788},
+			pos:  position{line: 90, col: 1, offset: 2818},
 			expr: &seqExpr{
-				pos: position{line: 90, col: 18, offset: 2805},
+				pos: position{line: 90, col: 18, offset: 2835},
 				exprs: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 90, col: 18, offset: 2805},
+						pos:  position{line: 90, col: 18, offset: 2835},
 						name: "ClassChar",
 					},
 					&litMatcher{
-						pos:        position{line: 90, col: 28, offset: 2815},
+						pos:        position{line: 90, col: 28, offset: 2845},
 						val:        "-",
 						ignoreCase: false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 90, col: 32, offset: 2819},
+						pos:  position{line: 90, col: 32, offset: 2849},
 						name: "ClassChar",
 					},
 				},
@@ -1079,51 +1083,51 @@ var g = &grammar{
 		},
 		{
 			name: "ClassChar",
-			pos:  position{line: 91, col: 1, offset: 2829},
+			pos:  position{line: 91, col: 1, offset: 2859},
 			expr: &choiceExpr{
-				pos: position{line: 91, col: 13, offset: 2841},
+				pos: position{line: 91, col: 13, offset: 2871},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 91, col: 13, offset: 2841},
+						pos: position{line: 91, col: 13, offset: 2871},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 91, col: 13, offset: 2841},
+								pos: position{line: 91, col: 13, offset: 2871},
 								expr: &choiceExpr{
-									pos: position{line: 91, col: 16, offset: 2844},
+									pos: position{line: 91, col: 16, offset: 2874},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 91, col: 16, offset: 2844},
+											pos:        position{line: 91, col: 16, offset: 2874},
 											val:        "]",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 91, col: 22, offset: 2850},
+											pos:        position{line: 91, col: 22, offset: 2880},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 91, col: 29, offset: 2857},
+											pos:  position{line: 91, col: 29, offset: 2887},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 91, col: 35, offset: 2863},
+								pos:  position{line: 91, col: 35, offset: 2893},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 91, col: 48, offset: 2876},
+						pos: position{line: 91, col: 48, offset: 2906},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 91, col: 48, offset: 2876},
+								pos:        position{line: 91, col: 48, offset: 2906},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 91, col: 53, offset: 2881},
+								pos:  position{line: 91, col: 53, offset: 2911},
 								name: "CharClassEscape",
 							},
 						},
@@ -1133,51 +1137,51 @@ var g = &grammar{
 		},
 		{
 			name: "CharClassEscape",
-			pos:  position{line: 92, col: 1, offset: 2897},
+			pos:  position{line: 92, col: 1, offset: 2927},
 			expr: &choiceExpr{
-				pos: position{line: 92, col: 19, offset: 2915},
+				pos: position{line: 92, col: 19, offset: 2945},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 92, col: 21, offset: 2917},
+						pos: position{line: 92, col: 21, offset: 2947},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 92, col: 21, offset: 2917},
+								pos:        position{line: 92, col: 21, offset: 2947},
 								val:        "]",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 92, col: 27, offset: 2923},
+								pos:  position{line: 92, col: 27, offset: 2953},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 93, col: 7, offset: 2952},
+						pos: position{line: 93, col: 7, offset: 2982},
 						run: (*parser).callonCharClassEscape5,
 						expr: &seqExpr{
-							pos: position{line: 93, col: 7, offset: 2952},
+							pos: position{line: 93, col: 7, offset: 2982},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 93, col: 7, offset: 2952},
+									pos: position{line: 93, col: 7, offset: 2982},
 									expr: &litMatcher{
-										pos:        position{line: 93, col: 8, offset: 2953},
+										pos:        position{line: 93, col: 8, offset: 2983},
 										val:        "p",
 										ignoreCase: false,
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 93, col: 14, offset: 2959},
+									pos: position{line: 93, col: 14, offset: 2989},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 14, offset: 2959},
+											pos:  position{line: 93, col: 14, offset: 2989},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 27, offset: 2972},
+											pos:  position{line: 93, col: 27, offset: 3002},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 33, offset: 2978},
+											pos:  position{line: 93, col: 33, offset: 3008},
 											name: "EOF",
 										},
 									},
@@ -1190,49 +1194,49 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeClassEscape",
-			pos:  position{line: 97, col: 1, offset: 3044},
+			pos:  position{line: 97, col: 1, offset: 3074},
 			expr: &seqExpr{
-				pos: position{line: 97, col: 22, offset: 3065},
+				pos: position{line: 97, col: 22, offset: 3095},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 97, col: 22, offset: 3065},
+						pos:        position{line: 97, col: 22, offset: 3095},
 						val:        "p",
 						ignoreCase: false,
 					},
 					&choiceExpr{
-						pos: position{line: 98, col: 7, offset: 3078},
+						pos: position{line: 98, col: 7, offset: 3108},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 98, col: 7, offset: 3078},
+								pos:  position{line: 98, col: 7, offset: 3108},
 								name: "SingleCharUnicodeClass",
 							},
 							&actionExpr{
-								pos: position{line: 99, col: 7, offset: 3107},
+								pos: position{line: 99, col: 7, offset: 3137},
 								run: (*parser).callonUnicodeClassEscape5,
 								expr: &seqExpr{
-									pos: position{line: 99, col: 7, offset: 3107},
+									pos: position{line: 99, col: 7, offset: 3137},
 									exprs: []interface{}{
 										&notExpr{
-											pos: position{line: 99, col: 7, offset: 3107},
+											pos: position{line: 99, col: 7, offset: 3137},
 											expr: &litMatcher{
-												pos:        position{line: 99, col: 8, offset: 3108},
+												pos:        position{line: 99, col: 8, offset: 3138},
 												val:        "{",
 												ignoreCase: false,
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 99, col: 14, offset: 3114},
+											pos: position{line: 99, col: 14, offset: 3144},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 99, col: 14, offset: 3114},
+													pos:  position{line: 99, col: 14, offset: 3144},
 													name: "SourceChar",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 99, col: 27, offset: 3127},
+													pos:  position{line: 99, col: 27, offset: 3157},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 99, col: 33, offset: 3133},
+													pos:  position{line: 99, col: 33, offset: 3163},
 													name: "EOF",
 												},
 											},
@@ -1241,26 +1245,26 @@ var g = &grammar{
 								},
 							},
 							&actionExpr{
-								pos: position{line: 100, col: 7, offset: 3204},
+								pos: position{line: 100, col: 7, offset: 3234},
 								run: (*parser).callonUnicodeClassEscape13,
 								expr: &seqExpr{
-									pos: position{line: 100, col: 7, offset: 3204},
+									pos: position{line: 100, col: 7, offset: 3234},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 100, col: 7, offset: 3204},
+											pos:        position{line: 100, col: 7, offset: 3234},
 											val:        "{",
 											ignoreCase: false,
 										},
 										&labeledExpr{
-											pos:   position{line: 100, col: 11, offset: 3208},
+											pos:   position{line: 100, col: 11, offset: 3238},
 											label: "ident",
 											expr: &ruleRefExpr{
-												pos:  position{line: 100, col: 17, offset: 3214},
+												pos:  position{line: 100, col: 17, offset: 3244},
 												name: "IdentifierName",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 100, col: 32, offset: 3229},
+											pos:        position{line: 100, col: 32, offset: 3259},
 											val:        "}",
 											ignoreCase: false,
 										},
@@ -1268,34 +1272,34 @@ var g = &grammar{
 								},
 							},
 							&actionExpr{
-								pos: position{line: 106, col: 7, offset: 3393},
+								pos: position{line: 106, col: 7, offset: 3423},
 								run: (*parser).callonUnicodeClassEscape19,
 								expr: &seqExpr{
-									pos: position{line: 106, col: 7, offset: 3393},
+									pos: position{line: 106, col: 7, offset: 3423},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 106, col: 7, offset: 3393},
+											pos:        position{line: 106, col: 7, offset: 3423},
 											val:        "{",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 106, col: 11, offset: 3397},
+											pos:  position{line: 106, col: 11, offset: 3427},
 											name: "IdentifierName",
 										},
 										&choiceExpr{
-											pos: position{line: 106, col: 28, offset: 3414},
+											pos: position{line: 106, col: 28, offset: 3444},
 											alternatives: []interface{}{
 												&litMatcher{
-													pos:        position{line: 106, col: 28, offset: 3414},
+													pos:        position{line: 106, col: 28, offset: 3444},
 													val:        "]",
 													ignoreCase: false,
 												},
 												&ruleRefExpr{
-													pos:  position{line: 106, col: 34, offset: 3420},
+													pos:  position{line: 106, col: 34, offset: 3450},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 106, col: 40, offset: 3426},
+													pos:  position{line: 106, col: 40, offset: 3456},
 													name: "EOF",
 												},
 											},
@@ -1310,9 +1314,9 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharUnicodeClass",
-			pos:  position{line: 111, col: 1, offset: 3506},
+			pos:  position{line: 111, col: 1, offset: 3536},
 			expr: &charClassMatcher{
-				pos:        position{line: 111, col: 26, offset: 3531},
+				pos:        position{line: 111, col: 26, offset: 3561},
 				val:        "[LMNCPZS]",
 				chars:      []rune{'L', 'M', 'N', 'C', 'P', 'Z', 'S'},
 				ignoreCase: false,
@@ -1321,39 +1325,39 @@ var g = &grammar{
 		},
 		{
 			name: "Number",
-			pos:  position{line: 114, col: 1, offset: 3543},
+			pos:  position{line: 114, col: 1, offset: 3573},
 			expr: &actionExpr{
-				pos: position{line: 114, col: 10, offset: 3552},
+				pos: position{line: 114, col: 10, offset: 3582},
 				run: (*parser).callonNumber1,
 				expr: &seqExpr{
-					pos: position{line: 114, col: 10, offset: 3552},
+					pos: position{line: 114, col: 10, offset: 3582},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 114, col: 10, offset: 3552},
+							pos: position{line: 114, col: 10, offset: 3582},
 							expr: &litMatcher{
-								pos:        position{line: 114, col: 10, offset: 3552},
+								pos:        position{line: 114, col: 10, offset: 3582},
 								val:        "-",
 								ignoreCase: false,
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 114, col: 15, offset: 3557},
+							pos:  position{line: 114, col: 15, offset: 3587},
 							name: "Integer",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 114, col: 23, offset: 3565},
+							pos: position{line: 114, col: 23, offset: 3595},
 							expr: &seqExpr{
-								pos: position{line: 114, col: 25, offset: 3567},
+								pos: position{line: 114, col: 25, offset: 3597},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 114, col: 25, offset: 3567},
+										pos:        position{line: 114, col: 25, offset: 3597},
 										val:        ".",
 										ignoreCase: false,
 									},
 									&oneOrMoreExpr{
-										pos: position{line: 114, col: 29, offset: 3571},
+										pos: position{line: 114, col: 29, offset: 3601},
 										expr: &ruleRefExpr{
-											pos:  position{line: 114, col: 29, offset: 3571},
+											pos:  position{line: 114, col: 29, offset: 3601},
 											name: "Digit",
 										},
 									},
@@ -1366,29 +1370,29 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 118, col: 1, offset: 3623},
+			pos:  position{line: 118, col: 1, offset: 3653},
 			expr: &choiceExpr{
-				pos: position{line: 118, col: 11, offset: 3633},
+				pos: position{line: 118, col: 11, offset: 3663},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 118, col: 11, offset: 3633},
+						pos:        position{line: 118, col: 11, offset: 3663},
 						val:        "0",
 						ignoreCase: false,
 					},
 					&actionExpr{
-						pos: position{line: 118, col: 17, offset: 3639},
+						pos: position{line: 118, col: 17, offset: 3669},
 						run: (*parser).callonInteger3,
 						expr: &seqExpr{
-							pos: position{line: 118, col: 17, offset: 3639},
+							pos: position{line: 118, col: 17, offset: 3669},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 118, col: 17, offset: 3639},
+									pos:  position{line: 118, col: 17, offset: 3669},
 									name: "NonZeroDigit",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 118, col: 30, offset: 3652},
+									pos: position{line: 118, col: 30, offset: 3682},
 									expr: &ruleRefExpr{
-										pos:  position{line: 118, col: 30, offset: 3652},
+										pos:  position{line: 118, col: 30, offset: 3682},
 										name: "Digit",
 									},
 								},
@@ -1400,9 +1404,9 @@ var g = &grammar{
 		},
 		{
 			name: "NonZeroDigit",
-			pos:  position{line: 122, col: 1, offset: 3716},
+			pos:  position{line: 122, col: 1, offset: 3746},
 			expr: &charClassMatcher{
-				pos:        position{line: 122, col: 16, offset: 3731},
+				pos:        position{line: 122, col: 16, offset: 3761},
 				val:        "[1-9]",
 				ranges:     []rune{'1', '9'},
 				ignoreCase: false,
@@ -1411,9 +1415,9 @@ var g = &grammar{
 		},
 		{
 			name: "Digit",
-			pos:  position{line: 123, col: 1, offset: 3737},
+			pos:  position{line: 123, col: 1, offset: 3767},
 			expr: &charClassMatcher{
-				pos:        position{line: 123, col: 9, offset: 3745},
+				pos:        position{line: 123, col: 9, offset: 3775},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -1422,31 +1426,31 @@ var g = &grammar{
 		},
 		{
 			name: "LabelBlock",
-			pos:  position{line: 125, col: 1, offset: 3752},
+			pos:  position{line: 125, col: 1, offset: 3782},
 			expr: &choiceExpr{
-				pos: position{line: 125, col: 14, offset: 3765},
+				pos: position{line: 125, col: 14, offset: 3795},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 125, col: 14, offset: 3765},
+						pos: position{line: 125, col: 14, offset: 3795},
 						run: (*parser).callonLabelBlock2,
 						expr: &seqExpr{
-							pos: position{line: 125, col: 14, offset: 3765},
+							pos: position{line: 125, col: 14, offset: 3795},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 125, col: 14, offset: 3765},
+									pos:        position{line: 125, col: 14, offset: 3795},
 									val:        "{",
 									ignoreCase: false,
 								},
 								&labeledExpr{
-									pos:   position{line: 125, col: 18, offset: 3769},
+									pos:   position{line: 125, col: 18, offset: 3799},
 									label: "block",
 									expr: &ruleRefExpr{
-										pos:  position{line: 125, col: 24, offset: 3775},
+										pos:  position{line: 125, col: 24, offset: 3805},
 										name: "LabelMatches",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 125, col: 37, offset: 3788},
+									pos:        position{line: 125, col: 37, offset: 3818},
 									val:        "}",
 									ignoreCase: false,
 								},
@@ -1454,22 +1458,22 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 127, col: 5, offset: 3820},
+						pos: position{line: 127, col: 5, offset: 3850},
 						run: (*parser).callonLabelBlock8,
 						expr: &seqExpr{
-							pos: position{line: 127, col: 5, offset: 3820},
+							pos: position{line: 127, col: 5, offset: 3850},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 127, col: 5, offset: 3820},
+									pos:        position{line: 127, col: 5, offset: 3850},
 									val:        "{",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 127, col: 9, offset: 3824},
+									pos:  position{line: 127, col: 9, offset: 3854},
 									name: "LabelMatches",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 127, col: 22, offset: 3837},
+									pos:  position{line: 127, col: 22, offset: 3867},
 									name: "EOF",
 								},
 							},
@@ -1480,12 +1484,12 @@ var g = &grammar{
 		},
 		{
 			name: "NanoSecondUnits",
-			pos:  position{line: 131, col: 1, offset: 3902},
+			pos:  position{line: 131, col: 1, offset: 3932},
 			expr: &actionExpr{
-				pos: position{line: 131, col: 19, offset: 3920},
+				pos: position{line: 131, col: 19, offset: 3950},
 				run: (*parser).callonNanoSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 131, col: 19, offset: 3920},
+					pos:        position{line: 131, col: 19, offset: 3950},
 					val:        "ns",
 					ignoreCase: false,
 				},
@@ -1493,25 +1497,25 @@ var g = &grammar{
 		},
 		{
 			name: "MicroSecondUnits",
-			pos:  position{line: 136, col: 1, offset: 4025},
+			pos:  position{line: 136, col: 1, offset: 4055},
 			expr: &actionExpr{
-				pos: position{line: 136, col: 20, offset: 4044},
+				pos: position{line: 136, col: 20, offset: 4074},
 				run: (*parser).callonMicroSecondUnits1,
 				expr: &choiceExpr{
-					pos: position{line: 136, col: 21, offset: 4045},
+					pos: position{line: 136, col: 21, offset: 4075},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 136, col: 21, offset: 4045},
+							pos:        position{line: 136, col: 21, offset: 4075},
 							val:        "us",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 136, col: 28, offset: 4052},
+							pos:        position{line: 136, col: 28, offset: 4082},
 							val:        "µs",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 136, col: 35, offset: 4060},
+							pos:        position{line: 136, col: 35, offset: 4090},
 							val:        "μs",
 							ignoreCase: false,
 						},
@@ -1521,12 +1525,12 @@ var g = &grammar{
 		},
 		{
 			name: "MilliSecondUnits",
-			pos:  position{line: 141, col: 1, offset: 4169},
+			pos:  position{line: 141, col: 1, offset: 4199},
 			expr: &actionExpr{
-				pos: position{line: 141, col: 20, offset: 4188},
+				pos: position{line: 141, col: 20, offset: 4218},
 				run: (*parser).callonMilliSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 141, col: 20, offset: 4188},
+					pos:        position{line: 141, col: 20, offset: 4218},
 					val:        "ms",
 					ignoreCase: false,
 				},
@@ -1534,12 +1538,12 @@ var g = &grammar{
 		},
 		{
 			name: "SecondUnits",
-			pos:  position{line: 146, col: 1, offset: 4295},
+			pos:  position{line: 146, col: 1, offset: 4325},
 			expr: &actionExpr{
-				pos: position{line: 146, col: 15, offset: 4309},
+				pos: position{line: 146, col: 15, offset: 4339},
 				run: (*parser).callonSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 146, col: 15, offset: 4309},
+					pos:        position{line: 146, col: 15, offset: 4339},
 					val:        "s",
 					ignoreCase: false,
 				},
@@ -1547,12 +1551,12 @@ var g = &grammar{
 		},
 		{
 			name: "MinuteUnits",
-			pos:  position{line: 150, col: 1, offset: 4346},
+			pos:  position{line: 150, col: 1, offset: 4376},
 			expr: &actionExpr{
-				pos: position{line: 150, col: 15, offset: 4360},
+				pos: position{line: 150, col: 15, offset: 4390},
 				run: (*parser).callonMinuteUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 150, col: 15, offset: 4360},
+					pos:        position{line: 150, col: 15, offset: 4390},
 					val:        "m",
 					ignoreCase: false,
 				},
@@ -1560,12 +1564,12 @@ var g = &grammar{
 		},
 		{
 			name: "HourUnits",
-			pos:  position{line: 154, col: 1, offset: 4397},
+			pos:  position{line: 154, col: 1, offset: 4427},
 			expr: &actionExpr{
-				pos: position{line: 154, col: 13, offset: 4409},
+				pos: position{line: 154, col: 13, offset: 4439},
 				run: (*parser).callonHourUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 154, col: 13, offset: 4409},
+					pos:        position{line: 154, col: 13, offset: 4439},
 					val:        "h",
 					ignoreCase: false,
 				},
@@ -1573,12 +1577,12 @@ var g = &grammar{
 		},
 		{
 			name: "DayUnits",
-			pos:  position{line: 158, col: 1, offset: 4444},
+			pos:  position{line: 158, col: 1, offset: 4474},
 			expr: &actionExpr{
-				pos: position{line: 158, col: 12, offset: 4455},
+				pos: position{line: 158, col: 12, offset: 4485},
 				run: (*parser).callonDayUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 158, col: 12, offset: 4455},
+					pos:        position{line: 158, col: 12, offset: 4485},
 					val:        "d",
 					ignoreCase: false,
 				},
@@ -1586,12 +1590,12 @@ var g = &grammar{
 		},
 		{
 			name: "WeekUnits",
-			pos:  position{line: 164, col: 1, offset: 4663},
+			pos:  position{line: 164, col: 1, offset: 4693},
 			expr: &actionExpr{
-				pos: position{line: 164, col: 13, offset: 4675},
+				pos: position{line: 164, col: 13, offset: 4705},
 				run: (*parser).callonWeekUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 164, col: 13, offset: 4675},
+					pos:        position{line: 164, col: 13, offset: 4705},
 					val:        "w",
 					ignoreCase: false,
 				},
@@ -1599,12 +1603,12 @@ var g = &grammar{
 		},
 		{
 			name: "YearUnits",
-			pos:  position{line: 170, col: 1, offset: 4886},
+			pos:  position{line: 170, col: 1, offset: 4916},
 			expr: &actionExpr{
-				pos: position{line: 170, col: 13, offset: 4898},
+				pos: position{line: 170, col: 13, offset: 4928},
 				run: (*parser).callonYearUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 170, col: 13, offset: 4898},
+					pos:        position{line: 170, col: 13, offset: 4928},
 					val:        "y",
 					ignoreCase: false,
 				},
@@ -1612,44 +1616,44 @@ var g = &grammar{
 		},
 		{
 			name: "DurationUnits",
-			pos:  position{line: 176, col: 1, offset: 5095},
+			pos:  position{line: 176, col: 1, offset: 5125},
 			expr: &choiceExpr{
-				pos: position{line: 176, col: 18, offset: 5112},
+				pos: position{line: 176, col: 18, offset: 5142},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 18, offset: 5112},
+						pos:  position{line: 176, col: 18, offset: 5142},
 						name: "NanoSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 36, offset: 5130},
+						pos:  position{line: 176, col: 36, offset: 5160},
 						name: "MicroSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 55, offset: 5149},
+						pos:  position{line: 176, col: 55, offset: 5179},
 						name: "MilliSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 74, offset: 5168},
+						pos:  position{line: 176, col: 74, offset: 5198},
 						name: "SecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 88, offset: 5182},
+						pos:  position{line: 176, col: 88, offset: 5212},
 						name: "MinuteUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 102, offset: 5196},
+						pos:  position{line: 176, col: 102, offset: 5226},
 						name: "HourUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 114, offset: 5208},
+						pos:  position{line: 176, col: 114, offset: 5238},
 						name: "DayUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 125, offset: 5219},
+						pos:  position{line: 176, col: 125, offset: 5249},
 						name: "WeekUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 137, offset: 5231},
+						pos:  position{line: 176, col: 137, offset: 5261},
 						name: "YearUnits",
 					},
 				},
@@ -1657,26 +1661,26 @@ var g = &grammar{
 		},
 		{
 			name: "Duration",
-			pos:  position{line: 178, col: 1, offset: 5243},
+			pos:  position{line: 178, col: 1, offset: 5273},
 			expr: &actionExpr{
-				pos: position{line: 178, col: 12, offset: 5254},
+				pos: position{line: 178, col: 12, offset: 5284},
 				run: (*parser).callonDuration1,
 				expr: &seqExpr{
-					pos: position{line: 178, col: 12, offset: 5254},
+					pos: position{line: 178, col: 12, offset: 5284},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 178, col: 12, offset: 5254},
+							pos:   position{line: 178, col: 12, offset: 5284},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 178, col: 16, offset: 5258},
+								pos:  position{line: 178, col: 16, offset: 5288},
 								name: "Integer",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 178, col: 24, offset: 5266},
+							pos:   position{line: 178, col: 24, offset: 5296},
 							label: "units",
 							expr: &ruleRefExpr{
-								pos:  position{line: 178, col: 30, offset: 5272},
+								pos:  position{line: 178, col: 30, offset: 5302},
 								name: "DurationUnits",
 							},
 						},
@@ -1686,82 +1690,82 @@ var g = &grammar{
 		},
 		{
 			name: "Operators",
-			pos:  position{line: 184, col: 1, offset: 5421},
+			pos:  position{line: 184, col: 1, offset: 5451},
 			expr: &choiceExpr{
-				pos: position{line: 184, col: 13, offset: 5433},
+				pos: position{line: 184, col: 13, offset: 5463},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 184, col: 13, offset: 5433},
+						pos:        position{line: 184, col: 13, offset: 5463},
 						val:        "-",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 19, offset: 5439},
+						pos:        position{line: 184, col: 19, offset: 5469},
 						val:        "+",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 25, offset: 5445},
+						pos:        position{line: 184, col: 25, offset: 5475},
 						val:        "*",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 31, offset: 5451},
+						pos:        position{line: 184, col: 31, offset: 5481},
 						val:        "%",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 37, offset: 5457},
+						pos:        position{line: 184, col: 37, offset: 5487},
 						val:        "/",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 43, offset: 5463},
+						pos:        position{line: 184, col: 43, offset: 5493},
 						val:        "==",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 50, offset: 5470},
+						pos:        position{line: 184, col: 50, offset: 5500},
 						val:        "!=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 57, offset: 5477},
+						pos:        position{line: 184, col: 57, offset: 5507},
 						val:        "<=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 64, offset: 5484},
+						pos:        position{line: 184, col: 64, offset: 5514},
 						val:        "<",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 70, offset: 5490},
+						pos:        position{line: 184, col: 70, offset: 5520},
 						val:        ">=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 77, offset: 5497},
+						pos:        position{line: 184, col: 77, offset: 5527},
 						val:        ">",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 83, offset: 5503},
+						pos:        position{line: 184, col: 83, offset: 5533},
 						val:        "=~",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 90, offset: 5510},
+						pos:        position{line: 184, col: 90, offset: 5540},
 						val:        "!~",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 97, offset: 5517},
+						pos:        position{line: 184, col: 97, offset: 5547},
 						val:        "^",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 184, col: 103, offset: 5523},
+						pos:        position{line: 184, col: 103, offset: 5553},
 						val:        "=",
 						ignoreCase: false,
 					},
@@ -1770,42 +1774,42 @@ var g = &grammar{
 		},
 		{
 			name: "LabelOperators",
-			pos:  position{line: 186, col: 1, offset: 5528},
+			pos:  position{line: 186, col: 1, offset: 5558},
 			expr: &choiceExpr{
-				pos: position{line: 186, col: 19, offset: 5546},
+				pos: position{line: 186, col: 19, offset: 5576},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 186, col: 19, offset: 5546},
+						pos: position{line: 186, col: 19, offset: 5576},
 						run: (*parser).callonLabelOperators2,
 						expr: &litMatcher{
-							pos:        position{line: 186, col: 19, offset: 5546},
+							pos:        position{line: 186, col: 19, offset: 5576},
 							val:        "!=",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 188, col: 5, offset: 5582},
+						pos: position{line: 188, col: 5, offset: 5612},
 						run: (*parser).callonLabelOperators4,
 						expr: &litMatcher{
-							pos:        position{line: 188, col: 5, offset: 5582},
+							pos:        position{line: 188, col: 5, offset: 5612},
 							val:        "=~",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 190, col: 5, offset: 5620},
+						pos: position{line: 190, col: 5, offset: 5650},
 						run: (*parser).callonLabelOperators6,
 						expr: &litMatcher{
-							pos:        position{line: 190, col: 5, offset: 5620},
+							pos:        position{line: 190, col: 5, offset: 5650},
 							val:        "!~",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 192, col: 5, offset: 5660},
+						pos: position{line: 192, col: 5, offset: 5690},
 						run: (*parser).callonLabelOperators8,
 						expr: &litMatcher{
-							pos:        position{line: 192, col: 5, offset: 5660},
+							pos:        position{line: 192, col: 5, offset: 5690},
 							val:        "=",
 							ignoreCase: false,
 						},
@@ -1815,57 +1819,57 @@ var g = &grammar{
 		},
 		{
 			name: "Label",
-			pos:  position{line: 196, col: 1, offset: 5691},
+			pos:  position{line: 196, col: 1, offset: 5721},
 			expr: &ruleRefExpr{
-				pos:  position{line: 196, col: 9, offset: 5699},
+				pos:  position{line: 196, col: 9, offset: 5729},
 				name: "Identifier",
 			},
 		},
 		{
 			name: "LabelMatch",
-			pos:  position{line: 197, col: 1, offset: 5710},
+			pos:  position{line: 197, col: 1, offset: 5740},
 			expr: &actionExpr{
-				pos: position{line: 197, col: 14, offset: 5723},
+				pos: position{line: 197, col: 14, offset: 5753},
 				run: (*parser).callonLabelMatch1,
 				expr: &seqExpr{
-					pos: position{line: 197, col: 14, offset: 5723},
+					pos: position{line: 197, col: 14, offset: 5753},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 197, col: 14, offset: 5723},
+							pos:   position{line: 197, col: 14, offset: 5753},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 197, col: 20, offset: 5729},
+								pos:  position{line: 197, col: 20, offset: 5759},
 								name: "Label",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 197, col: 26, offset: 5735},
+							pos:  position{line: 197, col: 26, offset: 5765},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 197, col: 29, offset: 5738},
+							pos:   position{line: 197, col: 29, offset: 5768},
 							label: "op",
 							expr: &ruleRefExpr{
-								pos:  position{line: 197, col: 32, offset: 5741},
+								pos:  position{line: 197, col: 32, offset: 5771},
 								name: "LabelOperators",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 197, col: 47, offset: 5756},
+							pos:  position{line: 197, col: 47, offset: 5786},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 197, col: 50, offset: 5759},
+							pos:   position{line: 197, col: 50, offset: 5789},
 							label: "match",
 							expr: &choiceExpr{
-								pos: position{line: 197, col: 58, offset: 5767},
+								pos: position{line: 197, col: 58, offset: 5797},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 197, col: 58, offset: 5767},
+										pos:  position{line: 197, col: 58, offset: 5797},
 										name: "StringLiteral",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 197, col: 74, offset: 5783},
+										pos:  position{line: 197, col: 74, offset: 5813},
 										name: "Number",
 									},
 								},
@@ -1877,32 +1881,32 @@ var g = &grammar{
 		},
 		{
 			name: "LabelMatches",
-			pos:  position{line: 200, col: 1, offset: 5873},
+			pos:  position{line: 200, col: 1, offset: 5903},
 			expr: &actionExpr{
-				pos: position{line: 200, col: 16, offset: 5888},
+				pos: position{line: 200, col: 16, offset: 5918},
 				run: (*parser).callonLabelMatches1,
 				expr: &seqExpr{
-					pos: position{line: 200, col: 16, offset: 5888},
+					pos: position{line: 200, col: 16, offset: 5918},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 200, col: 16, offset: 5888},
+							pos:   position{line: 200, col: 16, offset: 5918},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 200, col: 22, offset: 5894},
+								pos:  position{line: 200, col: 22, offset: 5924},
 								name: "LabelMatch",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 200, col: 33, offset: 5905},
+							pos:  position{line: 200, col: 33, offset: 5935},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 200, col: 36, offset: 5908},
+							pos:   position{line: 200, col: 36, offset: 5938},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 200, col: 41, offset: 5913},
+								pos: position{line: 200, col: 41, offset: 5943},
 								expr: &ruleRefExpr{
-									pos:  position{line: 200, col: 41, offset: 5913},
+									pos:  position{line: 200, col: 41, offset: 5943},
 									name: "LabelMatchesRest",
 								},
 							},
@@ -1913,27 +1917,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelMatchesRest",
-			pos:  position{line: 204, col: 1, offset: 5992},
+			pos:  position{line: 204, col: 1, offset: 6022},
 			expr: &actionExpr{
-				pos: position{line: 204, col: 21, offset: 6012},
+				pos: position{line: 204, col: 21, offset: 6042},
 				run: (*parser).callonLabelMatchesRest1,
 				expr: &seqExpr{
-					pos: position{line: 204, col: 21, offset: 6012},
+					pos: position{line: 204, col: 21, offset: 6042},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 204, col: 21, offset: 6012},
+							pos:        position{line: 204, col: 21, offset: 6042},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 204, col: 25, offset: 6016},
+							pos:  position{line: 204, col: 25, offset: 6046},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 204, col: 28, offset: 6019},
+							pos:   position{line: 204, col: 28, offset: 6049},
 							label: "match",
 							expr: &ruleRefExpr{
-								pos:  position{line: 204, col: 34, offset: 6025},
+								pos:  position{line: 204, col: 34, offset: 6055},
 								name: "LabelMatch",
 							},
 						},
@@ -1943,27 +1947,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelList",
-			pos:  position{line: 208, col: 1, offset: 6063},
+			pos:  position{line: 208, col: 1, offset: 6093},
 			expr: &choiceExpr{
-				pos: position{line: 208, col: 13, offset: 6075},
+				pos: position{line: 208, col: 13, offset: 6105},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 208, col: 13, offset: 6075},
+						pos: position{line: 208, col: 13, offset: 6105},
 						run: (*parser).callonLabelList2,
 						expr: &seqExpr{
-							pos: position{line: 208, col: 14, offset: 6076},
+							pos: position{line: 208, col: 14, offset: 6106},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 208, col: 14, offset: 6076},
+									pos:        position{line: 208, col: 14, offset: 6106},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 208, col: 18, offset: 6080},
+									pos:  position{line: 208, col: 18, offset: 6110},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 208, col: 21, offset: 6083},
+									pos:        position{line: 208, col: 21, offset: 6113},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -1971,49 +1975,49 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 210, col: 6, offset: 6115},
+						pos: position{line: 210, col: 6, offset: 6145},
 						run: (*parser).callonLabelList7,
 						expr: &seqExpr{
-							pos: position{line: 210, col: 6, offset: 6115},
+							pos: position{line: 210, col: 6, offset: 6145},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 210, col: 6, offset: 6115},
+									pos:        position{line: 210, col: 6, offset: 6145},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 210, col: 10, offset: 6119},
+									pos:  position{line: 210, col: 10, offset: 6149},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 210, col: 13, offset: 6122},
+									pos:   position{line: 210, col: 13, offset: 6152},
 									label: "label",
 									expr: &ruleRefExpr{
-										pos:  position{line: 210, col: 19, offset: 6128},
+										pos:  position{line: 210, col: 19, offset: 6158},
 										name: "Label",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 210, col: 25, offset: 6134},
+									pos:  position{line: 210, col: 25, offset: 6164},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 210, col: 28, offset: 6137},
+									pos:   position{line: 210, col: 28, offset: 6167},
 									label: "rest",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 210, col: 33, offset: 6142},
+										pos: position{line: 210, col: 33, offset: 6172},
 										expr: &ruleRefExpr{
-											pos:  position{line: 210, col: 33, offset: 6142},
+											pos:  position{line: 210, col: 33, offset: 6172},
 											name: "LabelListRest",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 210, col: 48, offset: 6157},
+									pos:  position{line: 210, col: 48, offset: 6187},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 210, col: 51, offset: 6160},
+									pos:        position{line: 210, col: 51, offset: 6190},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2025,27 +2029,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelListRest",
-			pos:  position{line: 214, col: 1, offset: 6226},
+			pos:  position{line: 214, col: 1, offset: 6256},
 			expr: &actionExpr{
-				pos: position{line: 214, col: 18, offset: 6243},
+				pos: position{line: 214, col: 18, offset: 6273},
 				run: (*parser).callonLabelListRest1,
 				expr: &seqExpr{
-					pos: position{line: 214, col: 18, offset: 6243},
+					pos: position{line: 214, col: 18, offset: 6273},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 214, col: 18, offset: 6243},
+							pos:        position{line: 214, col: 18, offset: 6273},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 214, col: 22, offset: 6247},
+							pos:  position{line: 214, col: 22, offset: 6277},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 214, col: 25, offset: 6250},
+							pos:   position{line: 214, col: 25, offset: 6280},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 214, col: 31, offset: 6256},
+								pos:  position{line: 214, col: 31, offset: 6286},
 								name: "Label",
 							},
 						},
@@ -2055,62 +2059,62 @@ var g = &grammar{
 		},
 		{
 			name: "VectorSelector",
-			pos:  position{line: 218, col: 1, offset: 6289},
+			pos:  position{line: 218, col: 1, offset: 6319},
 			expr: &actionExpr{
-				pos: position{line: 218, col: 18, offset: 6306},
+				pos: position{line: 218, col: 18, offset: 6336},
 				run: (*parser).callonVectorSelector1,
 				expr: &seqExpr{
-					pos: position{line: 218, col: 18, offset: 6306},
+					pos: position{line: 218, col: 18, offset: 6336},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 218, col: 18, offset: 6306},
+							pos:   position{line: 218, col: 18, offset: 6336},
 							label: "metric",
 							expr: &ruleRefExpr{
-								pos:  position{line: 218, col: 25, offset: 6313},
+								pos:  position{line: 218, col: 25, offset: 6343},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 218, col: 36, offset: 6324},
+							pos:  position{line: 218, col: 36, offset: 6354},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 218, col: 40, offset: 6328},
+							pos:   position{line: 218, col: 40, offset: 6358},
 							label: "block",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 218, col: 46, offset: 6334},
+								pos: position{line: 218, col: 46, offset: 6364},
 								expr: &ruleRefExpr{
-									pos:  position{line: 218, col: 46, offset: 6334},
+									pos:  position{line: 218, col: 46, offset: 6364},
 									name: "LabelBlock",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 218, col: 58, offset: 6346},
+							pos:  position{line: 218, col: 58, offset: 6376},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 218, col: 61, offset: 6349},
+							pos:   position{line: 218, col: 61, offset: 6379},
 							label: "rng",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 218, col: 65, offset: 6353},
+								pos: position{line: 218, col: 65, offset: 6383},
 								expr: &ruleRefExpr{
-									pos:  position{line: 218, col: 65, offset: 6353},
+									pos:  position{line: 218, col: 65, offset: 6383},
 									name: "Range",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 218, col: 72, offset: 6360},
+							pos:  position{line: 218, col: 72, offset: 6390},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 218, col: 75, offset: 6363},
+							pos:   position{line: 218, col: 75, offset: 6393},
 							label: "offset",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 218, col: 82, offset: 6370},
+								pos: position{line: 218, col: 82, offset: 6400},
 								expr: &ruleRefExpr{
-									pos:  position{line: 218, col: 82, offset: 6370},
+									pos:  position{line: 218, col: 82, offset: 6400},
 									name: "Offset",
 								},
 							},
@@ -2121,36 +2125,36 @@ var g = &grammar{
 		},
 		{
 			name: "Range",
-			pos:  position{line: 222, col: 1, offset: 6448},
+			pos:  position{line: 222, col: 1, offset: 6478},
 			expr: &actionExpr{
-				pos: position{line: 222, col: 9, offset: 6456},
+				pos: position{line: 222, col: 9, offset: 6486},
 				run: (*parser).callonRange1,
 				expr: &seqExpr{
-					pos: position{line: 222, col: 9, offset: 6456},
+					pos: position{line: 222, col: 9, offset: 6486},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 222, col: 9, offset: 6456},
+							pos:        position{line: 222, col: 9, offset: 6486},
 							val:        "[",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 13, offset: 6460},
+							pos:  position{line: 222, col: 13, offset: 6490},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 16, offset: 6463},
+							pos:   position{line: 222, col: 16, offset: 6493},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 222, col: 20, offset: 6467},
+								pos:  position{line: 222, col: 20, offset: 6497},
 								name: "Duration",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 29, offset: 6476},
+							pos:  position{line: 222, col: 29, offset: 6506},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 222, col: 32, offset: 6479},
+							pos:        position{line: 222, col: 32, offset: 6509},
 							val:        "]",
 							ignoreCase: false,
 						},
@@ -2160,27 +2164,27 @@ var g = &grammar{
 		},
 		{
 			name: "Offset",
-			pos:  position{line: 226, col: 1, offset: 6508},
+			pos:  position{line: 226, col: 1, offset: 6538},
 			expr: &actionExpr{
-				pos: position{line: 226, col: 10, offset: 6517},
+				pos: position{line: 226, col: 10, offset: 6547},
 				run: (*parser).callonOffset1,
 				expr: &seqExpr{
-					pos: position{line: 226, col: 10, offset: 6517},
+					pos: position{line: 226, col: 10, offset: 6547},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 226, col: 10, offset: 6517},
+							pos:        position{line: 226, col: 10, offset: 6547},
 							val:        "offset",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 226, col: 20, offset: 6527},
+							pos:  position{line: 226, col: 20, offset: 6557},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 226, col: 23, offset: 6530},
+							pos:   position{line: 226, col: 23, offset: 6560},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 226, col: 27, offset: 6534},
+								pos:  position{line: 226, col: 27, offset: 6564},
 								name: "Duration",
 							},
 						},
@@ -2190,12 +2194,12 @@ var g = &grammar{
 		},
 		{
 			name: "CountValueOperator",
-			pos:  position{line: 230, col: 1, offset: 6568},
+			pos:  position{line: 230, col: 1, offset: 6598},
 			expr: &actionExpr{
-				pos: position{line: 230, col: 22, offset: 6589},
+				pos: position{line: 230, col: 22, offset: 6619},
 				run: (*parser).callonCountValueOperator1,
 				expr: &litMatcher{
-					pos:        position{line: 230, col: 22, offset: 6589},
+					pos:        position{line: 230, col: 22, offset: 6619},
 					val:        "count_values",
 					ignoreCase: true,
 				},
@@ -2203,28 +2207,28 @@ var g = &grammar{
 		},
 		{
 			name: "BinaryAggregateOperators",
-			pos:  position{line: 236, col: 1, offset: 6674},
+			pos:  position{line: 236, col: 1, offset: 6704},
 			expr: &actionExpr{
-				pos: position{line: 236, col: 29, offset: 6702},
+				pos: position{line: 236, col: 29, offset: 6732},
 				run: (*parser).callonBinaryAggregateOperators1,
 				expr: &labeledExpr{
-					pos:   position{line: 236, col: 29, offset: 6702},
+					pos:   position{line: 236, col: 29, offset: 6732},
 					label: "op",
 					expr: &choiceExpr{
-						pos: position{line: 236, col: 33, offset: 6706},
+						pos: position{line: 236, col: 33, offset: 6736},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 236, col: 33, offset: 6706},
+								pos:        position{line: 236, col: 33, offset: 6736},
 								val:        "topk",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 236, col: 43, offset: 6716},
+								pos:        position{line: 236, col: 43, offset: 6746},
 								val:        "bottomk",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 236, col: 56, offset: 6729},
+								pos:        position{line: 236, col: 56, offset: 6759},
 								val:        "quantile",
 								ignoreCase: true,
 							},
@@ -2235,48 +2239,48 @@ var g = &grammar{
 		},
 		{
 			name: "UnaryAggregateOperators",
-			pos:  position{line: 242, col: 1, offset: 6831},
+			pos:  position{line: 242, col: 1, offset: 6861},
 			expr: &actionExpr{
-				pos: position{line: 242, col: 27, offset: 6857},
+				pos: position{line: 242, col: 27, offset: 6887},
 				run: (*parser).callonUnaryAggregateOperators1,
 				expr: &labeledExpr{
-					pos:   position{line: 242, col: 27, offset: 6857},
+					pos:   position{line: 242, col: 27, offset: 6887},
 					label: "op",
 					expr: &choiceExpr{
-						pos: position{line: 242, col: 31, offset: 6861},
+						pos: position{line: 242, col: 31, offset: 6891},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 242, col: 31, offset: 6861},
+								pos:        position{line: 242, col: 31, offset: 6891},
 								val:        "sum",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 242, col: 40, offset: 6870},
+								pos:        position{line: 242, col: 40, offset: 6900},
 								val:        "min",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 242, col: 49, offset: 6879},
+								pos:        position{line: 242, col: 49, offset: 6909},
 								val:        "max",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 242, col: 58, offset: 6888},
+								pos:        position{line: 242, col: 58, offset: 6918},
 								val:        "avg",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 242, col: 67, offset: 6897},
+								pos:        position{line: 242, col: 67, offset: 6927},
 								val:        "stddev",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 242, col: 79, offset: 6909},
+								pos:        position{line: 242, col: 79, offset: 6939},
 								val:        "stdvar",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 242, col: 91, offset: 6921},
+								pos:        position{line: 242, col: 91, offset: 6951},
 								val:        "count",
 								ignoreCase: true,
 							},
@@ -2287,20 +2291,20 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateOperators",
-			pos:  position{line: 248, col: 1, offset: 7020},
+			pos:  position{line: 248, col: 1, offset: 7050},
 			expr: &choiceExpr{
-				pos: position{line: 248, col: 22, offset: 7041},
+				pos: position{line: 248, col: 22, offset: 7071},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 248, col: 22, offset: 7041},
+						pos:  position{line: 248, col: 22, offset: 7071},
 						name: "CountValueOperator",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 248, col: 43, offset: 7062},
+						pos:  position{line: 248, col: 43, offset: 7092},
 						name: "BinaryAggregateOperators",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 248, col: 70, offset: 7089},
+						pos:  position{line: 248, col: 70, offset: 7119},
 						name: "UnaryAggregateOperators",
 					},
 				},
@@ -2308,41 +2312,41 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateBy",
-			pos:  position{line: 250, col: 1, offset: 7114},
+			pos:  position{line: 250, col: 1, offset: 7144},
 			expr: &actionExpr{
-				pos: position{line: 250, col: 15, offset: 7128},
+				pos: position{line: 250, col: 15, offset: 7158},
 				run: (*parser).callonAggregateBy1,
 				expr: &seqExpr{
-					pos: position{line: 250, col: 15, offset: 7128},
+					pos: position{line: 250, col: 15, offset: 7158},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 250, col: 15, offset: 7128},
+							pos:        position{line: 250, col: 15, offset: 7158},
 							val:        "by",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 250, col: 21, offset: 7134},
+							pos:  position{line: 250, col: 21, offset: 7164},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 250, col: 24, offset: 7137},
+							pos:   position{line: 250, col: 24, offset: 7167},
 							label: "labels",
 							expr: &ruleRefExpr{
-								pos:  position{line: 250, col: 31, offset: 7144},
+								pos:  position{line: 250, col: 31, offset: 7174},
 								name: "LabelList",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 250, col: 41, offset: 7154},
+							pos:  position{line: 250, col: 41, offset: 7184},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 250, col: 44, offset: 7157},
+							pos:   position{line: 250, col: 44, offset: 7187},
 							label: "keep",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 250, col: 49, offset: 7162},
+								pos: position{line: 250, col: 49, offset: 7192},
 								expr: &litMatcher{
-									pos:        position{line: 250, col: 49, offset: 7162},
+									pos:        position{line: 250, col: 49, offset: 7192},
 									val:        "keep_common",
 									ignoreCase: true,
 								},
@@ -2354,27 +2358,27 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateWithout",
-			pos:  position{line: 258, col: 1, offset: 7308},
+			pos:  position{line: 258, col: 1, offset: 7338},
 			expr: &actionExpr{
-				pos: position{line: 258, col: 20, offset: 7327},
+				pos: position{line: 258, col: 20, offset: 7357},
 				run: (*parser).callonAggregateWithout1,
 				expr: &seqExpr{
-					pos: position{line: 258, col: 20, offset: 7327},
+					pos: position{line: 258, col: 20, offset: 7357},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 258, col: 20, offset: 7327},
+							pos:        position{line: 258, col: 20, offset: 7357},
 							val:        "without",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 258, col: 31, offset: 7338},
+							pos:  position{line: 258, col: 31, offset: 7368},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 258, col: 34, offset: 7341},
+							pos:   position{line: 258, col: 34, offset: 7371},
 							label: "labels",
 							expr: &ruleRefExpr{
-								pos:  position{line: 258, col: 41, offset: 7348},
+								pos:  position{line: 258, col: 41, offset: 7378},
 								name: "LabelList",
 							},
 						},
@@ -2384,16 +2388,16 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateGroup",
-			pos:  position{line: 265, col: 1, offset: 7460},
+			pos:  position{line: 265, col: 1, offset: 7490},
 			expr: &choiceExpr{
-				pos: position{line: 265, col: 18, offset: 7477},
+				pos: position{line: 265, col: 18, offset: 7507},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 265, col: 18, offset: 7477},
+						pos:  position{line: 265, col: 18, offset: 7507},
 						name: "AggregateBy",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 265, col: 32, offset: 7491},
+						pos:  position{line: 265, col: 32, offset: 7521},
 						name: "AggregateWithout",
 					},
 				},
@@ -2401,86 +2405,86 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateExpression",
-			pos:  position{line: 267, col: 1, offset: 7509},
+			pos:  position{line: 267, col: 1, offset: 7539},
 			expr: &choiceExpr{
-				pos: position{line: 268, col: 1, offset: 7531},
+				pos: position{line: 268, col: 1, offset: 7561},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 268, col: 1, offset: 7531},
+						pos: position{line: 268, col: 1, offset: 7561},
 						run: (*parser).callonAggregateExpression2,
 						expr: &seqExpr{
-							pos: position{line: 268, col: 1, offset: 7531},
+							pos: position{line: 268, col: 1, offset: 7561},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 268, col: 1, offset: 7531},
+									pos:   position{line: 268, col: 1, offset: 7561},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 268, col: 4, offset: 7534},
+										pos:  position{line: 268, col: 4, offset: 7564},
 										name: "CountValueOperator",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 268, col: 24, offset: 7554},
+									pos:  position{line: 268, col: 24, offset: 7584},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 268, col: 27, offset: 7557},
+									pos:        position{line: 268, col: 27, offset: 7587},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 268, col: 31, offset: 7561},
+									pos:  position{line: 268, col: 31, offset: 7591},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 268, col: 34, offset: 7564},
+									pos:   position{line: 268, col: 34, offset: 7594},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 268, col: 40, offset: 7570},
+										pos:  position{line: 268, col: 40, offset: 7600},
 										name: "StringLiteral",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 268, col: 54, offset: 7584},
+									pos:  position{line: 268, col: 54, offset: 7614},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 268, col: 57, offset: 7587},
+									pos:        position{line: 268, col: 57, offset: 7617},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 268, col: 61, offset: 7591},
+									pos:  position{line: 268, col: 61, offset: 7621},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 268, col: 64, offset: 7594},
+									pos:   position{line: 268, col: 64, offset: 7624},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 268, col: 71, offset: 7601},
+										pos:  position{line: 268, col: 71, offset: 7631},
 										name: "VectorSelector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 268, col: 86, offset: 7616},
+									pos:  position{line: 268, col: 86, offset: 7646},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 268, col: 89, offset: 7619},
+									pos:        position{line: 268, col: 89, offset: 7649},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 268, col: 93, offset: 7623},
+									pos:  position{line: 268, col: 93, offset: 7653},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 268, col: 96, offset: 7626},
+									pos:   position{line: 268, col: 96, offset: 7656},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 268, col: 102, offset: 7632},
+										pos: position{line: 268, col: 102, offset: 7662},
 										expr: &ruleRefExpr{
-											pos:  position{line: 268, col: 102, offset: 7632},
+											pos:  position{line: 268, col: 102, offset: 7662},
 											name: "AggregateGroup",
 										},
 									},
@@ -2489,82 +2493,82 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 274, col: 1, offset: 7780},
+						pos: position{line: 274, col: 1, offset: 7810},
 						run: (*parser).callonAggregateExpression22,
 						expr: &seqExpr{
-							pos: position{line: 274, col: 1, offset: 7780},
+							pos: position{line: 274, col: 1, offset: 7810},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 274, col: 1, offset: 7780},
+									pos:   position{line: 274, col: 1, offset: 7810},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 274, col: 4, offset: 7783},
+										pos:  position{line: 274, col: 4, offset: 7813},
 										name: "CountValueOperator",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 274, col: 24, offset: 7803},
+									pos:  position{line: 274, col: 24, offset: 7833},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 274, col: 27, offset: 7806},
+									pos:   position{line: 274, col: 27, offset: 7836},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 274, col: 33, offset: 7812},
+										pos: position{line: 274, col: 33, offset: 7842},
 										expr: &ruleRefExpr{
-											pos:  position{line: 274, col: 33, offset: 7812},
+											pos:  position{line: 274, col: 33, offset: 7842},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 274, col: 49, offset: 7828},
+									pos:  position{line: 274, col: 49, offset: 7858},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 274, col: 52, offset: 7831},
+									pos:        position{line: 274, col: 52, offset: 7861},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 274, col: 56, offset: 7835},
+									pos:  position{line: 274, col: 56, offset: 7865},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 274, col: 59, offset: 7838},
+									pos:   position{line: 274, col: 59, offset: 7868},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 274, col: 65, offset: 7844},
+										pos:  position{line: 274, col: 65, offset: 7874},
 										name: "StringLiteral",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 274, col: 79, offset: 7858},
+									pos:  position{line: 274, col: 79, offset: 7888},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 274, col: 82, offset: 7861},
+									pos:        position{line: 274, col: 82, offset: 7891},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 274, col: 86, offset: 7865},
+									pos:  position{line: 274, col: 86, offset: 7895},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 274, col: 89, offset: 7868},
+									pos:   position{line: 274, col: 89, offset: 7898},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 274, col: 96, offset: 7875},
+										pos:  position{line: 274, col: 96, offset: 7905},
 										name: "VectorSelector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 274, col: 111, offset: 7890},
+									pos:  position{line: 274, col: 111, offset: 7920},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 274, col: 114, offset: 7893},
+									pos:        position{line: 274, col: 114, offset: 7923},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2572,81 +2576,81 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 280, col: 1, offset: 8029},
+						pos: position{line: 280, col: 1, offset: 8059},
 						run: (*parser).callonAggregateExpression42,
 						expr: &seqExpr{
-							pos: position{line: 280, col: 1, offset: 8029},
+							pos: position{line: 280, col: 1, offset: 8059},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 280, col: 1, offset: 8029},
+									pos:   position{line: 280, col: 1, offset: 8059},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 280, col: 4, offset: 8032},
+										pos:  position{line: 280, col: 4, offset: 8062},
 										name: "BinaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 280, col: 30, offset: 8058},
+									pos:  position{line: 280, col: 30, offset: 8088},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 280, col: 33, offset: 8061},
+									pos:        position{line: 280, col: 33, offset: 8091},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 280, col: 37, offset: 8065},
+									pos:  position{line: 280, col: 37, offset: 8095},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 280, col: 41, offset: 8069},
+									pos:   position{line: 280, col: 41, offset: 8099},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 280, col: 47, offset: 8075},
+										pos:  position{line: 280, col: 47, offset: 8105},
 										name: "Number",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 280, col: 54, offset: 8082},
+									pos:  position{line: 280, col: 54, offset: 8112},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 280, col: 57, offset: 8085},
+									pos:        position{line: 280, col: 57, offset: 8115},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 280, col: 61, offset: 8089},
+									pos:  position{line: 280, col: 61, offset: 8119},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 280, col: 64, offset: 8092},
+									pos:   position{line: 280, col: 64, offset: 8122},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 280, col: 71, offset: 8099},
+										pos:  position{line: 280, col: 71, offset: 8129},
 										name: "VectorSelector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 280, col: 86, offset: 8114},
+									pos:  position{line: 280, col: 86, offset: 8144},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 280, col: 89, offset: 8117},
+									pos:        position{line: 280, col: 89, offset: 8147},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 280, col: 93, offset: 8121},
+									pos:  position{line: 280, col: 93, offset: 8151},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 280, col: 96, offset: 8124},
+									pos:   position{line: 280, col: 96, offset: 8154},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 280, col: 102, offset: 8130},
+										pos: position{line: 280, col: 102, offset: 8160},
 										expr: &ruleRefExpr{
-											pos:  position{line: 280, col: 102, offset: 8130},
+											pos:  position{line: 280, col: 102, offset: 8160},
 											name: "AggregateGroup",
 										},
 									},
@@ -2655,82 +2659,82 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 286, col: 1, offset: 8271},
+						pos: position{line: 286, col: 1, offset: 8301},
 						run: (*parser).callonAggregateExpression62,
 						expr: &seqExpr{
-							pos: position{line: 286, col: 1, offset: 8271},
+							pos: position{line: 286, col: 1, offset: 8301},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 286, col: 1, offset: 8271},
+									pos:   position{line: 286, col: 1, offset: 8301},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 286, col: 4, offset: 8274},
+										pos:  position{line: 286, col: 4, offset: 8304},
 										name: "BinaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 30, offset: 8300},
+									pos:  position{line: 286, col: 30, offset: 8330},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 286, col: 33, offset: 8303},
+									pos:   position{line: 286, col: 33, offset: 8333},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 286, col: 39, offset: 8309},
+										pos: position{line: 286, col: 39, offset: 8339},
 										expr: &ruleRefExpr{
-											pos:  position{line: 286, col: 39, offset: 8309},
+											pos:  position{line: 286, col: 39, offset: 8339},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 55, offset: 8325},
+									pos:  position{line: 286, col: 55, offset: 8355},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 286, col: 58, offset: 8328},
+									pos:        position{line: 286, col: 58, offset: 8358},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 62, offset: 8332},
+									pos:  position{line: 286, col: 62, offset: 8362},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 286, col: 66, offset: 8336},
+									pos:   position{line: 286, col: 66, offset: 8366},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 286, col: 72, offset: 8342},
+										pos:  position{line: 286, col: 72, offset: 8372},
 										name: "Number",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 79, offset: 8349},
+									pos:  position{line: 286, col: 79, offset: 8379},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 286, col: 82, offset: 8352},
+									pos:        position{line: 286, col: 82, offset: 8382},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 86, offset: 8356},
+									pos:  position{line: 286, col: 86, offset: 8386},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 286, col: 89, offset: 8359},
+									pos:   position{line: 286, col: 89, offset: 8389},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 286, col: 96, offset: 8366},
+										pos:  position{line: 286, col: 96, offset: 8396},
 										name: "VectorSelector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 111, offset: 8381},
+									pos:  position{line: 286, col: 111, offset: 8411},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 286, col: 114, offset: 8384},
+									pos:        position{line: 286, col: 114, offset: 8414},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2738,60 +2742,60 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 292, col: 1, offset: 8513},
+						pos: position{line: 292, col: 1, offset: 8543},
 						run: (*parser).callonAggregateExpression82,
 						expr: &seqExpr{
-							pos: position{line: 292, col: 1, offset: 8513},
+							pos: position{line: 292, col: 1, offset: 8543},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 292, col: 1, offset: 8513},
+									pos:   position{line: 292, col: 1, offset: 8543},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 292, col: 4, offset: 8516},
+										pos:  position{line: 292, col: 4, offset: 8546},
 										name: "UnaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 292, col: 29, offset: 8541},
+									pos:  position{line: 292, col: 29, offset: 8571},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 292, col: 32, offset: 8544},
+									pos:        position{line: 292, col: 32, offset: 8574},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 292, col: 36, offset: 8548},
+									pos:  position{line: 292, col: 36, offset: 8578},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 292, col: 39, offset: 8551},
+									pos:   position{line: 292, col: 39, offset: 8581},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 292, col: 46, offset: 8558},
+										pos:  position{line: 292, col: 46, offset: 8588},
 										name: "VectorSelector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 292, col: 61, offset: 8573},
+									pos:  position{line: 292, col: 61, offset: 8603},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 292, col: 64, offset: 8576},
+									pos:        position{line: 292, col: 64, offset: 8606},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 292, col: 68, offset: 8580},
+									pos:  position{line: 292, col: 68, offset: 8610},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 292, col: 71, offset: 8583},
+									pos:   position{line: 292, col: 71, offset: 8613},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 292, col: 77, offset: 8589},
+										pos: position{line: 292, col: 77, offset: 8619},
 										expr: &ruleRefExpr{
-											pos:  position{line: 292, col: 77, offset: 8589},
+											pos:  position{line: 292, col: 77, offset: 8619},
 											name: "AggregateGroup",
 										},
 									},
@@ -2800,61 +2804,61 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 296, col: 1, offset: 8682},
+						pos: position{line: 296, col: 1, offset: 8712},
 						run: (*parser).callonAggregateExpression97,
 						expr: &seqExpr{
-							pos: position{line: 296, col: 1, offset: 8682},
+							pos: position{line: 296, col: 1, offset: 8712},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 296, col: 1, offset: 8682},
+									pos:   position{line: 296, col: 1, offset: 8712},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 296, col: 4, offset: 8685},
+										pos:  position{line: 296, col: 4, offset: 8715},
 										name: "UnaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 296, col: 29, offset: 8710},
+									pos:  position{line: 296, col: 29, offset: 8740},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 296, col: 32, offset: 8713},
+									pos:   position{line: 296, col: 32, offset: 8743},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 296, col: 38, offset: 8719},
+										pos: position{line: 296, col: 38, offset: 8749},
 										expr: &ruleRefExpr{
-											pos:  position{line: 296, col: 38, offset: 8719},
+											pos:  position{line: 296, col: 38, offset: 8749},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 296, col: 54, offset: 8735},
+									pos:  position{line: 296, col: 54, offset: 8765},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 296, col: 57, offset: 8738},
+									pos:        position{line: 296, col: 57, offset: 8768},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 296, col: 61, offset: 8742},
+									pos:  position{line: 296, col: 61, offset: 8772},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 296, col: 64, offset: 8745},
+									pos:   position{line: 296, col: 64, offset: 8775},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 296, col: 71, offset: 8752},
+										pos:  position{line: 296, col: 71, offset: 8782},
 										name: "VectorSelector",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 296, col: 86, offset: 8767},
+									pos:  position{line: 296, col: 86, offset: 8797},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 296, col: 89, offset: 8770},
+									pos:        position{line: 296, col: 89, offset: 8800},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2864,24 +2868,93 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "HistogramQuantileExpression",
+			pos:  position{line: 300, col: 1, offset: 8878},
+			expr: &actionExpr{
+				pos: position{line: 300, col: 31, offset: 8908},
+				run: (*parser).callonHistogramQuantileExpression1,
+				expr: &seqExpr{
+					pos: position{line: 300, col: 31, offset: 8908},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 300, col: 31, offset: 8908},
+							val:        "histogram_quantile",
+							ignoreCase: true,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 300, col: 53, offset: 8930},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 300, col: 56, offset: 8933},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 300, col: 60, offset: 8937},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 300, col: 63, offset: 8940},
+							label: "quantile",
+							expr: &ruleRefExpr{
+								pos:  position{line: 300, col: 72, offset: 8949},
+								name: "Number",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 300, col: 79, offset: 8956},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 300, col: 82, offset: 8959},
+							val:        ",",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 300, col: 86, offset: 8963},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 300, col: 89, offset: 8966},
+							label: "vector",
+							expr: &ruleRefExpr{
+								pos:  position{line: 300, col: 96, offset: 8973},
+								name: "VectorSelector",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 300, col: 111, offset: 8988},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 300, col: 114, offset: 8991},
+							val:        ")",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "__",
-			pos:  position{line: 300, col: 1, offset: 8850},
+			pos:  position{line: 304, col: 1, offset: 9078},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 300, col: 6, offset: 8855},
+				pos: position{line: 304, col: 6, offset: 9083},
 				expr: &choiceExpr{
-					pos: position{line: 300, col: 8, offset: 8857},
+					pos: position{line: 304, col: 8, offset: 9085},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 8, offset: 8857},
+							pos:  position{line: 304, col: 8, offset: 9085},
 							name: "Whitespace",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 21, offset: 8870},
+							pos:  position{line: 304, col: 21, offset: 9098},
 							name: "EOL",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 27, offset: 8876},
+							pos:  position{line: 304, col: 27, offset: 9104},
 							name: "Comment",
 						},
 					},
@@ -2890,20 +2963,20 @@ var g = &grammar{
 		},
 		{
 			name: "_",
-			pos:  position{line: 301, col: 1, offset: 8887},
+			pos:  position{line: 305, col: 1, offset: 9115},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 301, col: 5, offset: 8891},
+				pos: position{line: 305, col: 5, offset: 9119},
 				expr: &ruleRefExpr{
-					pos:  position{line: 301, col: 5, offset: 8891},
+					pos:  position{line: 305, col: 5, offset: 9119},
 					name: "Whitespace",
 				},
 			},
 		},
 		{
 			name: "Whitespace",
-			pos:  position{line: 303, col: 1, offset: 8904},
+			pos:  position{line: 307, col: 1, offset: 9132},
 			expr: &charClassMatcher{
-				pos:        position{line: 303, col: 14, offset: 8917},
+				pos:        position{line: 307, col: 14, offset: 9145},
 				val:        "[ \\t\\r]",
 				chars:      []rune{' ', '\t', '\r'},
 				ignoreCase: false,
@@ -2912,62 +2985,62 @@ var g = &grammar{
 		},
 		{
 			name: "EOL",
-			pos:  position{line: 304, col: 1, offset: 8925},
+			pos:  position{line: 308, col: 1, offset: 9153},
 			expr: &litMatcher{
-				pos:        position{line: 304, col: 7, offset: 8931},
+				pos:        position{line: 308, col: 7, offset: 9159},
 				val:        "\n",
 				ignoreCase: false,
 			},
 		},
 		{
 			name: "EOS",
-			pos:  position{line: 305, col: 1, offset: 8936},
+			pos:  position{line: 309, col: 1, offset: 9164},
 			expr: &choiceExpr{
-				pos: position{line: 305, col: 7, offset: 8942},
+				pos: position{line: 309, col: 7, offset: 9170},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 305, col: 7, offset: 8942},
+						pos: position{line: 309, col: 7, offset: 9170},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 305, col: 7, offset: 8942},
+								pos:  position{line: 309, col: 7, offset: 9170},
 								name: "__",
 							},
 							&litMatcher{
-								pos:        position{line: 305, col: 10, offset: 8945},
+								pos:        position{line: 309, col: 10, offset: 9173},
 								val:        ";",
 								ignoreCase: false,
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 305, col: 16, offset: 8951},
+						pos: position{line: 309, col: 16, offset: 9179},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 305, col: 16, offset: 8951},
+								pos:  position{line: 309, col: 16, offset: 9179},
 								name: "_",
 							},
 							&zeroOrOneExpr{
-								pos: position{line: 305, col: 18, offset: 8953},
+								pos: position{line: 309, col: 18, offset: 9181},
 								expr: &ruleRefExpr{
-									pos:  position{line: 305, col: 18, offset: 8953},
+									pos:  position{line: 309, col: 18, offset: 9181},
 									name: "SingleLineComment",
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 305, col: 37, offset: 8972},
+								pos:  position{line: 309, col: 37, offset: 9200},
 								name: "EOL",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 305, col: 43, offset: 8978},
+						pos: position{line: 309, col: 43, offset: 9206},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 305, col: 43, offset: 8978},
+								pos:  position{line: 309, col: 43, offset: 9206},
 								name: "__",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 305, col: 46, offset: 8981},
+								pos:  position{line: 309, col: 46, offset: 9209},
 								name: "EOF",
 							},
 						},
@@ -2977,11 +3050,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 307, col: 1, offset: 8986},
+			pos:  position{line: 311, col: 1, offset: 9214},
 			expr: &notExpr{
-				pos: position{line: 307, col: 7, offset: 8992},
+				pos: position{line: 311, col: 7, offset: 9220},
 				expr: &anyMatcher{
-					line: 307, col: 8, offset: 8993,
+					line: 311, col: 8, offset: 9221,
 				},
 			},
 		},
@@ -3615,6 +3688,16 @@ func (p *parser) callonAggregateExpression97() (interface{}, error) {
 	return p.cur.onAggregateExpression97(stack["op"], stack["group"], stack["vector"])
 }
 
+func (c *current) onHistogramQuantileExpression1(quantile, vector interface{}) (interface{}, error) {
+	return NewHistogramQuantileExpr(quantile.(*Number), vector.(*Selector))
+}
+
+func (p *parser) callonHistogramQuantileExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onHistogramQuantileExpression1(stack["quantile"], stack["vector"])
+}
+
 var (
 	// errNoRule is returned when the grammar to parse has no rule.
 	errNoRule = errors.New("grammar has no rule")
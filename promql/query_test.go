@@ -337,6 +337,17 @@ func TestParsePromQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "histogram quantile",
+			promql: `histogram_quantile(0.9, http_request_duration_seconds_bucket[5m])`,
+			want: &HistogramQuantileExpr{
+				Quantile: 0.9,
+				Selector: &Selector{
+					Name:  "http_request_duration_seconds_bucket",
+					Range: 5 * time.Minute,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -569,6 +580,78 @@ func TestBuild(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "histogram quantile",
+			promql: `histogram_quantile(0.9, http_request_duration_seconds_bucket[5m])`,
+			want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID:   query.OperationID("from"),
+						Spec: &functions.FromOpSpec{Database: "prometheus"},
+					},
+					{
+						ID: query.OperationID("range"),
+						Spec: &functions.RangeOpSpec{
+							Start: query.Time{Relative: -5 * time.Minute},
+						},
+					},
+					{
+						ID: "where",
+						Spec: &functions.FilterOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+								Body: &semantic.BinaryExpression{
+									Operator: ast.EqualOperator,
+									Left: &semantic.MemberExpression{
+										Object: &semantic.IdentifierExpression{
+											Name: "r",
+										},
+										Property: "_metric",
+									},
+									Right: &semantic.StringLiteral{
+										Value: "http_request_duration_seconds_bucket",
+									},
+								},
+							},
+						},
+					},
+					{
+						ID: query.OperationID("merge"),
+						Spec: &functions.GroupOpSpec{
+							Except: []string{"le"},
+							Keep:   []string{"le"},
+						},
+					},
+					{
+						ID: query.OperationID("histogramQuantile"),
+						Spec: &functions.HistogramQuantileOpSpec{
+							Quantile:         0.9,
+							CountColumn:      "_value",
+							UpperBoundColumn: "le",
+							ValueColumn:      "_value",
+						},
+					},
+				},
+				Edges: []query.Edge{
+					{
+						Parent: query.OperationID("from"),
+						Child:  query.OperationID("range"),
+					},
+					{
+						Parent: query.OperationID("range"),
+						Child:  query.OperationID("where"),
+					},
+					{
+						Parent: query.OperationID("where"),
+						Child:  query.OperationID("merge"),
+					},
+					{
+						Parent: query.OperationID("merge"),
+						Child:  query.OperationID("histogramQuantile"),
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
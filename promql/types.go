@@ -477,6 +477,62 @@ func NewAggregateExpr(op *Operator, selector *Selector, group interface{}) (*Agg
 	return expr, nil
 }
 
+// HistogramQuantileExpr computes a quantile from the cumulative buckets of a histogram.
+// The buckets are the series of the selector that only differ by their "le" label.
+type HistogramQuantileExpr struct {
+	Quantile float64   `json:"quantile"`
+	Selector *Selector `json:"selector,omitempty"`
+}
+
+func (h *HistogramQuantileExpr) QuerySpec() (*query.Spec, error) {
+	spec, err := h.Selector.QuerySpec()
+	if err != nil {
+		return nil, err
+	}
+
+	ops := []*query.Operation{
+		{
+			ID: "merge",
+			Spec: &functions.GroupOpSpec{
+				Except: []string{"le"},
+				Keep:   []string{"le"},
+			},
+		},
+		{
+			ID: "histogramQuantile",
+			Spec: &functions.HistogramQuantileOpSpec{
+				Quantile:         h.Quantile,
+				CountColumn:      "_value",
+				UpperBoundColumn: "le",
+				ValueColumn:      "_value",
+			},
+		},
+	}
+	for _, op := range ops {
+		parent := query.OperationID("from")
+		if len(spec.Edges) > 0 {
+			tail := spec.Edges[len(spec.Edges)-1]
+			parent = tail.Child
+		}
+		spec.Operations = append(spec.Operations, op)
+		spec.Edges = append(spec.Edges, query.Edge{
+			Parent: parent,
+			Child:  op.ID,
+		})
+	}
+	return spec, nil
+}
+
+func NewHistogramQuantileExpr(quantile *Number, selector *Selector) (*HistogramQuantileExpr, error) {
+	if quantile.Val < 0 || quantile.Val > 1 {
+		return nil, fmt.Errorf("histogram_quantile quantile must be between 0 and 1, got %v", quantile.Val)
+	}
+	return &HistogramQuantileExpr{
+		Quantile: quantile.Val,
+		Selector: selector,
+	}, nil
+}
+
 type Comment struct {
 	Source string `json:"source,omitempty"`
 }
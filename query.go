@@ -6,6 +6,8 @@ engine.
 package ifql
 
 import (
+	"context"
+	"time"
 
 	// Import functions
 
@@ -14,6 +16,7 @@ import (
 
 	"github.com/influxdata/ifql/query/control"
 	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
 	"github.com/pkg/errors"
 )
 
@@ -23,10 +26,50 @@ func init() {
 
 type Config struct {
 	Hosts []string
+	// Files is a list of line protocol files to query instead of Hosts.
+	Files []string
+	// StorageReader reads the data of queries instead of Hosts or Files when it is set.
+	// Hosts and Files should still describe what it reads, since the shard map is checked against them.
+	StorageReader execute.StorageReader
+
+	// ShardMapFile is the file of the shards of each database on Hosts, see plan.ReadShardMap.
+	// Storage reads are restricted to the hosts of the shards they overlap, and split by shard when cheaper.
+	// Reads are not planned by shard when it is empty.
+	ShardMapFile string
+	// ShardMapRefresh is how often the shard map file is reloaded, zero means it is loaded once.
+	ShardMapRefresh time.Duration
 
 	ConcurrencyQuota int
 	MemoryBytesQuota int
 
+	// SpillDir is the directory where queries write data to disk when they near their memory quota.
+	// Queries fail once they exceed their quota when it is empty.
+	SpillDir string
+
+	// CacheMaxRows is the maximum number of rows held in the result cache, zero disables the cache.
+	CacheMaxRows int
+	// CacheTTL is the duration for which cached results are served.
+	CacheTTL time.Duration
+	// CacheChunk is the minimum duration of the chunks of time in which results are cached,
+	// zero caches each window of a query separately.
+	CacheChunk time.Duration
+
+	// LibDirs are the directories searched for the source files of packages imported by queries.
+	LibDirs []string
+
+	// QueueTimeout is how long queries may wait for resources by default, zero means no timeout.
+	QueueTimeout time.Duration
+	// ExecuteTimeout is how long queries may execute by default, zero means no timeout.
+	ExecuteTimeout time.Duration
+
+	// Authorizer is consulted with the storage reads of each query when it is planned, it may be nil.
+	Authorizer Authorizer
+
+	// TenantQuotas are the quotas of tenants, keyed by name.
+	// Tenants without quotas have DefaultTenantQuota, see NewContextWithTenant.
+	TenantQuotas       map[string]TenantQuota
+	DefaultTenantQuota TenantQuota
+
 	Verbose bool
 }
 
@@ -39,18 +82,85 @@ type Controller = control.Controller
 // Query represents a single request.
 type Query = control.Query
 
-func NewController(conf Config) (*Controller, error) {
-	s, err := execute.NewStorageReader(conf.Hosts)
+// QueryID is an ephemeral unique ID of an active query.
+type QueryID = control.QueryID
+
+// Authorizer decides whether queries may read from storage.
+type Authorizer = control.Authorizer
+
+// TenantQuota limits the resources of the queries of a tenant.
+type TenantQuota = control.TenantQuota
+
+// ErrQueueFull is the cause of the errors of queries rejected because their tenant has too many queued queries.
+var ErrQueueFull = control.ErrQueueFull
+
+// NewContextWithTenant returns a copy of ctx with the tenant, queries submitted with the context belong to the tenant.
+func NewContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return control.NewContextWithTenant(ctx, tenant)
+}
+
+// TenantFromContext returns the tenant of ctx, or the default tenant if there is none.
+func TenantFromContext(ctx context.Context) string {
+	return control.TenantFromContext(ctx)
+}
+
+// NewStorageReader creates a storage reader of the line protocol files, or of the hosts when there are no files.
+func NewStorageReader(hosts, files []string) (execute.StorageReader, error) {
+	var s execute.StorageReader
+	var err error
+	if len(files) > 0 {
+		s, err = execute.NewFileStorageReader(files)
+	} else {
+		s, err = execute.NewStorageReader(hosts)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create storage reader")
 	}
+	return s, nil
+}
+
+func NewController(conf Config) (*Controller, error) {
+	s := conf.StorageReader
+	if s == nil {
+		var err error
+		s, err = NewStorageReader(conf.Hosts, conf.Files)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var storage plan.Storage
+	if conf.ShardMapFile != "" {
+		// Line protocol files are read regardless of the hosts of the shards.
+		hosts := conf.Hosts
+		if len(conf.Files) > 0 {
+			hosts = nil
+		}
+		var err error
+		storage, err = plan.NewHostStorage(hosts, plan.ShardMapFile(conf.ShardMapFile), conf.ShardMapRefresh)
+		if err != nil {
+			return nil, err
+		}
+	}
 	c := control.Config{
 		ConcurrencyQuota: conf.ConcurrencyQuota,
 		MemoryBytesQuota: int64(conf.MemoryBytesQuota),
 		ExecutorConfig: execute.Config{
 			StorageReader: s,
+			SpillDir:      conf.SpillDir,
+		},
+		Cache: control.CacheConfig{
+			MaxRows: conf.CacheMaxRows,
+			TTL:     conf.CacheTTL,
+			Chunk:   conf.CacheChunk,
 		},
-		Verbose: conf.Verbose,
+		Storage:            storage,
+		LibDirs:            conf.LibDirs,
+		QueueTimeout:       conf.QueueTimeout,
+		ExecuteTimeout:     conf.ExecuteTimeout,
+		Authorizer:         conf.Authorizer,
+		TenantQuotas:       conf.TenantQuotas,
+		DefaultTenantQuota: conf.DefaultTenantQuota,
+		Verbose:            conf.Verbose,
 	}
 	return control.New(c), nil
 }
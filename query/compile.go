@@ -27,8 +27,16 @@ func Verbose(v bool) Option {
 	}
 }
 
+// LibDirs sets the directories searched for the source files of imported packages.
+func LibDirs(dirs ...string) Option {
+	return func(o *options) {
+		o.libDirs = dirs
+	}
+}
+
 type options struct {
 	verbose bool
+	libDirs []string
 }
 
 // Compile evaluates an IFQL script producing a query Spec.
@@ -46,8 +54,15 @@ func Compile(ctx context.Context, q string, opts ...Option) (*Spec, error) {
 	s, _ = opentracing.StartSpanFromContext(ctx, "compile")
 	defer s.Finish()
 
+	// Load imported packages and declare their names
+	importer := newPackageImporter(o.libDirs)
+	declarations := builtinDeclarations.Copy()
+	if err := importer.declare(astProg, declarations); err != nil {
+		return nil, err
+	}
+
 	// Convert AST program to a semantic program
-	semProg, err := semantic.New(astProg, builtinDeclarations.Copy())
+	semProg, err := semantic.New(astProg, declarations)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +73,7 @@ func Compile(ctx context.Context, q string, opts ...Option) (*Spec, error) {
 	// Create new query domain
 	d := new(queryDomain)
 
-	if err := interpreter.Eval(semProg, scope, d); err != nil {
+	if err := interpreter.EvalWithImporter(semProg, scope, d, importer); err != nil {
 		return nil, err
 	}
 	spec := d.ToSpec()
@@ -304,7 +319,11 @@ func (a Arguments) GetDuration(name string) (Duration, bool, error) {
 	if !ok {
 		return 0, false, nil
 	}
-	return (Duration)(v.Value().(time.Duration)), ok, nil
+	d, isDuration := v.Value().(time.Duration)
+	if !isDuration {
+		return 0, true, fmt.Errorf("keyword argument %q should be a duration, got %v", name, v.Type())
+	}
+	return Duration(d), ok, nil
 }
 
 func (a Arguments) GetRequiredDuration(name string) (Duration, error) {
@@ -0,0 +1,181 @@
+package query_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/semantic/semantictest"
+)
+
+func init() {
+	query.FinalizeRegistration()
+}
+
+func TestCompile_Imports(t *testing.T) {
+	packages := map[string]string{
+		"team/helpers.ifql": `
+			cpu = (db) => from(db:db) |> range(start:-4h, stop:-2h)
+			top = (table=<-, n=5) => table |> limit(n:n)
+		`,
+		"team/reports.ifql": `
+			import "team/helpers"
+			topCPU = (db) => helpers.cpu(db:db) |> helpers.top(n:1)
+		`,
+		"cycle/a.ifql": `
+			import "cycle/b"
+			x = 1
+		`,
+		"cycle/b.ifql": `
+			import "cycle/a"
+			y = 1
+		`,
+		"bad/statement.ifql": `
+			from(db:"mydb")
+		`,
+	}
+	dir, err := ioutil.TempDir("", "ifql-lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, src := range packages {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rangeSpec := &functions.RangeOpSpec{
+		Start: query.Time{
+			Relative:   -4 * time.Hour,
+			IsRelative: true,
+		},
+		Stop: query.Time{
+			Relative:   -2 * time.Hour,
+			IsRelative: true,
+		},
+	}
+	testCases := []struct {
+		name    string
+		raw     string
+		dirs    []string
+		want    *query.Spec
+		wantErr bool
+	}{
+		{
+			name: "package functions",
+			raw: `import "team/helpers"
+			helpers.cpu(db:"mydb") |> helpers.top(n:2)`,
+			dirs: []string{dir},
+			want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID:   "from0",
+						Spec: &functions.FromOpSpec{Database: "mydb"},
+					},
+					{
+						ID:   "range1",
+						Spec: rangeSpec,
+					},
+					{
+						ID:   "limit2",
+						Spec: &functions.LimitOpSpec{N: 2},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "range1"},
+					{Parent: "range1", Child: "limit2"},
+				},
+			},
+		},
+		{
+			name: "nested import",
+			raw: `import "team/reports"
+			reports.topCPU(db:"mydb")`,
+			dirs: []string{filepath.Join(dir, "missing"), dir},
+			want: &query.Spec{
+				Operations: []*query.Operation{
+					{
+						ID:   "from0",
+						Spec: &functions.FromOpSpec{Database: "mydb"},
+					},
+					{
+						ID:   "range1",
+						Spec: rangeSpec,
+					},
+					{
+						ID:   "limit2",
+						Spec: &functions.LimitOpSpec{N: 1},
+					},
+				},
+				Edges: []query.Edge{
+					{Parent: "from0", Child: "range1"},
+					{Parent: "range1", Child: "limit2"},
+				},
+			},
+		},
+		{
+			name:    "imported packages are not exported",
+			raw:     `import "team/reports" reports.helpers.cpu(db:"mydb")`,
+			dirs:    []string{dir},
+			wantErr: true,
+		},
+		{
+			name:    "unknown package",
+			raw:     `import "team/unknown"`,
+			dirs:    []string{dir},
+			wantErr: true,
+		},
+		{
+			name:    "no library directories",
+			raw:     `import "team/helpers"`,
+			wantErr: true,
+		},
+		{
+			name:    "relative path",
+			raw:     `import "../helpers"`,
+			dirs:    []string{dir},
+			wantErr: true,
+		},
+		{
+			name:    "import cycle",
+			raw:     `import "cycle/a"`,
+			dirs:    []string{dir},
+			wantErr: true,
+		},
+		{
+			name:    "package with statements",
+			raw:     `import "bad/statement"`,
+			dirs:    []string{dir},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := query.Compile(context.Background(), tc.raw, query.LibDirs(tc.dirs...))
+			if !tc.wantErr && err != nil {
+				t.Fatal(err)
+			} else if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			opts := append(semantictest.CmpOptions, ignoreUnexportedQuerySpec)
+			if !cmp.Equal(tc.want, got, opts...) {
+				t.Errorf("unexpected query spec -want/+got:\n%s", cmp.Diff(tc.want, got, opts...))
+			}
+		})
+	}
+}
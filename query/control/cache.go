@@ -0,0 +1,496 @@
+package control
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+)
+
+// CacheConfig configures the result cache of the controller.
+//
+// Queries that window their data and aggregate each window are cached in chunks of time aligned to their windows.
+// A chunk is cached once it is closed, i.e. it ends before the time the query ran.
+// When the same query is issued again, its closed chunks are served from the cache
+// and only the chunks that are not cached, and the head and open tail of its time range, are queried.
+// Consecutive ranges that are not cached are queried together, one range at a time within the resources of the query.
+// The cache assumes that data is not written to closed chunks, the TTL limits how long such writes go unnoticed.
+type CacheConfig struct {
+	// MaxRows is the maximum number of rows held in the cache, the cache is disabled when it is zero.
+	MaxRows int
+	// TTL is the duration for which cached chunks are served, zero means chunks do not expire.
+	TTL time.Duration
+	// Chunk is the minimum duration of a chunk, it is rounded up to a multiple of the window of a query.
+	// Zero means a chunk holds a single window.
+	Chunk time.Duration
+}
+
+type resultCache struct {
+	mu      sync.Mutex
+	maxRows int
+	ttl     time.Duration
+	chunk   time.Duration
+	alloc   *execute.Allocator
+
+	rows    int
+	entries map[string]*cacheEntry
+	// lru orders the entries from the most to the least recently used.
+	lru *list.List
+}
+
+// cacheEntry holds the blocks of a closed chunk of a query.
+type cacheEntry struct {
+	key     string
+	blocks  []execute.Block
+	rows    int
+	expires time.Time
+	elem    *list.Element
+}
+
+func newResultCache(c CacheConfig) *resultCache {
+	return &resultCache{
+		maxRows: c.MaxRows,
+		ttl:     c.TTL,
+		chunk:   c.Chunk,
+		alloc:   &execute.Allocator{Limit: math.MaxInt64},
+		entries: make(map[string]*cacheEntry),
+		lru:     list.New(),
+	}
+}
+
+// lookup returns the blocks of the chunk with the key.
+func (c *resultCache) lookup(key string, now time.Time) ([]execute.Block, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.ttl > 0 && now.After(e.expires) {
+		c.remove(e)
+		return nil, false
+	}
+	c.lru.MoveToFront(e.elem)
+	return e.blocks, true
+}
+
+// store adds the blocks of the chunk with the key to the cache.
+// Chunks without blocks are cached as well, so that their range is not queried again.
+func (c *resultCache) store(key string, blocks []execute.Block, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+	e := &cacheEntry{
+		key:     key,
+		blocks:  blocks,
+		expires: now.Add(c.ttl),
+	}
+	for _, b := range blocks {
+		e.rows += blockRows(b)
+	}
+	if e.rows > c.maxRows {
+		return
+	}
+	for c.rows+e.rows > c.maxRows {
+		c.remove(c.lru.Back().Value.(*cacheEntry))
+	}
+	e.elem = c.lru.PushFront(e)
+	c.entries[key] = e
+	c.rows += e.rows
+}
+
+func (c *resultCache) remove(e *cacheEntry) {
+	c.lru.Remove(e.elem)
+	delete(c.entries, e.key)
+	c.rows -= e.rows
+}
+
+func blockRows(b execute.Block) int {
+	n := 0
+	b.Times().DoTime(func(ts []execute.Time, _ execute.RowReader) {
+		n += len(ts)
+	})
+	return n
+}
+
+// cacheQuery describes how the results of a query are cached.
+type cacheQuery struct {
+	key string
+	// name is the name of the single result of the query.
+	name string
+	// start and stop are the absolute bounds of the query.
+	start, stop execute.Time
+	// chunk is the duration of a chunk and offset the offset of the chunk boundaries from the epoch.
+	chunk, offset execute.Duration
+	// lp is a copy of the logical plan of the query, before it was planned.
+	lp *plan.LogicalPlanSpec
+}
+
+// newCacheQuery returns how the results of the query are cached.
+// False is reported if the results of the query cannot be cached.
+func newCacheQuery(lp *plan.LogicalPlanSpec, p *plan.PlanSpec, minChunk time.Duration) (*cacheQuery, bool) {
+	if len(p.Results) != 1 || p.Bounds.Start.IsZero() {
+		return nil, false
+	}
+	w, ok := cacheWindow(lp)
+	if !ok {
+		return nil, false
+	}
+	key, ok := planKey(lp)
+	if !ok {
+		return nil, false
+	}
+	every := execute.Duration(w.Every)
+	chunk := every
+	if n := execute.Duration(minChunk) / every; n > 1 {
+		chunk = n * every
+		if chunk < execute.Duration(minChunk) {
+			chunk += every
+		}
+	}
+	start := execute.Time(w.Start.Absolute.UnixNano())
+	cq := &cacheQuery{
+		key:    key,
+		start:  execute.Time(p.Bounds.Start.Time(p.Now).UnixNano()),
+		stop:   execute.Time(p.Now.UnixNano()),
+		chunk:  chunk,
+		offset: execute.Duration(start - start.Truncate(every)),
+		lp:     lp,
+	}
+	if !p.Bounds.Stop.IsZero() {
+		cq.stop = execute.Time(p.Bounds.Stop.Time(p.Now).UnixNano())
+	}
+	for name := range p.Results {
+		cq.name = name
+	}
+	return cq, true
+}
+
+// cacheWindow returns the window of a plan whose results can be computed separately for ranges aligned to the window.
+// This is the case when the plan has a single window that is followed by aggregates only,
+// so that each row of the results depends on the data of a single window.
+func cacheWindow(lp *plan.LogicalPlanSpec) (plan.WindowSpec, bool) {
+	var (
+		window *plan.Procedure
+		w      plan.WindowSpec
+	)
+	for _, pr := range lp.Procedures {
+		s, ok := pr.Spec.(plan.WindowedProcedureSpec)
+		if !ok {
+			continue
+		}
+		if window != nil {
+			return w, false
+		}
+		window = pr
+		w = s.TimeWindow()
+	}
+	if window == nil || w.Every <= 0 || w.Period != 0 && w.Period != w.Every || w.Round != 0 || w.Start.IsRelative {
+		return w, false
+	}
+	for pr := window; len(pr.Children) > 0; {
+		if len(pr.Children) != 1 {
+			return w, false
+		}
+		pr = lp.Procedures[pr.Children[0]]
+		switch pr.Spec.(type) {
+		case plan.AggregateProcedureSpec, plan.PartialAggregateProcedureSpec, plan.YieldProcedureSpec:
+		default:
+			return w, false
+		}
+	}
+	return w, true
+}
+
+// chunks returns the starts of the closed chunks within the bounds of the query.
+func (cq *cacheQuery) chunks(now time.Time) []execute.Time {
+	stop := cq.stop
+	if n := execute.Time(now.UnixNano()); n < stop {
+		stop = n
+	}
+	first := (cq.start - execute.Time(cq.offset)).Truncate(cq.chunk).Add(cq.offset)
+	if first < cq.start {
+		first = first.Add(cq.chunk)
+	}
+	var starts []execute.Time
+	for s := first; s.Add(cq.chunk) <= stop; s = s.Add(cq.chunk) {
+		starts = append(starts, s)
+	}
+	return starts
+}
+
+func (cq *cacheQuery) chunkKey(start execute.Time) string {
+	return fmt.Sprintf("%s/%d/%d", cq.key, start, cq.chunk)
+}
+
+// planKey returns a canonical hash of the logical plan, which does not depend on the IDs of its procedures nor on its bounds,
+// since the results of a chunk depend only on the bounds of the chunk.
+// Plans that depend on the time they are run at other than through their bounds, are not cached.
+func planKey(lp *plan.LogicalPlanSpec) (string, bool) {
+	np := lp.Copy()
+	index := make(map[plan.ProcedureID]int, len(np.Order))
+	for i, id := range np.Order {
+		index[id] = i
+	}
+	h := sha256.New()
+	for i, id := range np.Order {
+		pr := np.Procedures[id]
+		if bounded, ok := pr.Spec.(plan.BoundedProcedureSpec); ok {
+			bounds := bounded.TimeBounds()
+			if !bounds.Start.IsZero() || !bounds.Stop.IsZero() {
+				s, ok := pr.Spec.(plan.SetBoundsProcedureSpec)
+				if !ok {
+					return "", false
+				}
+				s.SetTimeBounds(plan.BoundsSpec{})
+			}
+		} else if hasRelativeTime(reflect.ValueOf(pr.Spec)) {
+			return "", false
+		}
+		spec, err := json.Marshal(pr.Spec)
+		if err != nil {
+			return "", false
+		}
+		parents := make([]int, len(pr.Parents))
+		for j, parent := range pr.Parents {
+			parents[j] = index[parent]
+		}
+		fmt.Fprintf(h, "%d %s %v %s\n", i, pr.Spec.Kind(), parents, spec)
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+var queryTimeType = reflect.TypeOf(query.Time{})
+
+// hasRelativeTime reports whether the value holds a relative time.
+func hasRelativeTime(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return !v.IsNil() && hasRelativeTime(v.Elem())
+	case reflect.Struct:
+		if v.Type() == queryTimeType {
+			return v.FieldByName("IsRelative").Bool()
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if hasRelativeTime(v.Field(i)) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if hasRelativeTime(v.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if hasRelativeTime(v.MapIndex(k)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// restrictPlan returns a copy of the logical plan whose bounds are restricted to [start, stop).
+func restrictPlan(lp *plan.LogicalPlanSpec, start, stop execute.Time, now time.Time) (*plan.LogicalPlanSpec, error) {
+	np := lp.Copy()
+	for _, pr := range np.Procedures {
+		bounded, ok := pr.Spec.(plan.BoundedProcedureSpec)
+		if !ok {
+			continue
+		}
+		bounds := bounded.TimeBounds()
+		if bounds.Start.IsZero() && bounds.Stop.IsZero() {
+			continue
+		}
+		s, ok := pr.Spec.(plan.SetBoundsProcedureSpec)
+		if !ok {
+			return nil, fmt.Errorf("cannot restrict the bounds of %q procedures", pr.Spec.Kind())
+		}
+		bStart := execute.Time(bounds.Start.Time(now).UnixNano())
+		bStop := execute.Time(now.UnixNano())
+		if !bounds.Stop.IsZero() {
+			bStop = execute.Time(bounds.Stop.Time(now).UnixNano())
+		}
+		if bStart < start {
+			bStart = start
+		}
+		if bStop > stop {
+			bStop = stop
+		}
+		s.SetTimeBounds(plan.BoundsSpec{
+			Start: query.Time{Absolute: bStart.Time().UTC()},
+			Stop:  query.Time{Absolute: bStop.Time().UTC()},
+		})
+	}
+	return np, nil
+}
+
+// cachePart is a part of the time range of a cached query.
+// A part is either a chunk served from the cache, or a range of consecutive chunks that are not cached,
+// possibly along with the head or the tail of the query, that is executed as a whole.
+type cachePart struct {
+	bounds execute.Bounds
+	// cached reports whether the part is served from the cache, in which case its blocks are set instead of its plan.
+	cached bool
+	blocks []execute.Block
+	plan   *plan.PlanSpec
+	// chunks are the chunks of the part that are not cached yet, they are added to the cache once the part has been read.
+	chunks []cacheChunk
+}
+
+type cacheChunk struct {
+	key    string
+	bounds execute.Bounds
+}
+
+// cachedResult produces the blocks of a query from its parts.
+// The parts that are not cached are executed one at a time, as the blocks of the previous parts have been read.
+// Blocks that span a whole part are merged with the blocks of the same tags of the other parts,
+// so that they span the bounds of the query as they would if it was not split.
+type cachedResult struct {
+	// Result is the result of the part being read, if any.
+	execute.Result
+
+	ctx      context.Context
+	executor execute.Executor
+	name     string
+
+	cache  *resultCache
+	now    time.Time
+	bounds execute.Bounds
+	parts  []*cachePart
+}
+
+func (r *cachedResult) Blocks() execute.BlockIterator {
+	return r
+}
+
+func (r *cachedResult) Do(f func(execute.Block) error) error {
+	return r.DoWithRetractions(f, nil)
+}
+
+func (r *cachedResult) DoWithRetractions(f func(execute.Block) error, retract func(execute.BlockMetadata) error) error {
+	var (
+		builders = make(map[execute.TagsKey]*execute.ColListBlockBuilder)
+		order    []execute.TagsKey
+	)
+	for _, p := range r.parts {
+		blocks := p.blocks
+		if p.plan != nil {
+			results, err := r.executor.Execute(r.ctx, p.plan)
+			if err != nil {
+				return err
+			}
+			r.Result = results[r.name]
+			err = r.Result.DoWithRetractions(func(b execute.Block) error {
+				blocks = append(blocks, execute.CopyBlock(b, r.cache.alloc))
+				return nil
+			}, func(meta execute.BlockMetadata) error {
+				key := execute.ToBlockKey(meta)
+				filtered := blocks[:0]
+				for _, b := range blocks {
+					if execute.ToBlockKey(b) != key {
+						filtered = append(filtered, b)
+					}
+				}
+				blocks = filtered
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, c := range p.chunks {
+				chunk, err := chunkBlocks(blocks, p.bounds, c.bounds, r.cache.alloc)
+				if err != nil {
+					return err
+				}
+				r.cache.store(c.key, chunk, r.now)
+			}
+		}
+		for _, b := range blocks {
+			if b.Bounds() != p.bounds {
+				if err := f(b); err != nil {
+					return err
+				}
+				continue
+			}
+			key := b.Tags().Key()
+			builder, ok := builders[key]
+			if !ok {
+				builder = execute.NewColListBlockBuilder(r.cache.alloc)
+				builder.SetBounds(r.bounds)
+				builders[key] = builder
+				order = append(order, key)
+			}
+			colMap := execute.AddNewCols(b, builder)
+			execute.AppendBlock(b, builder, colMap)
+		}
+	}
+	for _, key := range order {
+		b, err := builders[key].Block()
+		if err != nil {
+			return err
+		}
+		if err := f(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunkBlocks returns the blocks of a part that belong to one of its chunks.
+// Blocks that lie within the chunk belong to it as a whole.
+// Blocks that span the whole part hold a row per window, timed at the stop of the window,
+// their rows are split into a block per chunk with the bounds of the chunk.
+func chunkBlocks(blocks []execute.Block, part, chunk execute.Bounds, a *execute.Allocator) ([]execute.Block, error) {
+	var chunked []execute.Block
+	for _, b := range blocks {
+		bounds := b.Bounds()
+		if bounds != part {
+			if chunk.Start <= bounds.Start && bounds.Stop <= chunk.Stop {
+				chunked = append(chunked, b)
+			}
+			continue
+		}
+		builder := execute.NewColListBlockBuilder(a)
+		builder.SetBounds(chunk)
+		execute.AddBlockCols(b, builder)
+		cols := b.Cols()
+		colMap := make([]int, len(cols))
+		for j := range colMap {
+			colMap[j] = j
+		}
+		b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+			for i, t := range ts {
+				if chunk.Start < t && t <= chunk.Stop {
+					execute.AppendRow(i, rr, builder, colMap)
+				}
+			}
+		})
+		if builder.NRows() == 0 {
+			continue
+		}
+		cb, err := builder.Block()
+		if err != nil {
+			return nil, err
+		}
+		chunked = append(chunked, cb)
+	}
+	return chunked, nil
+}
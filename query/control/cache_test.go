@@ -0,0 +1,193 @@
+package control
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	_ "github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/plan"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func init() {
+	query.FinalizeRegistration()
+}
+
+func counterValue(t *testing.T, c interface {
+	Write(*dto.Metric) error
+}) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := c.Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+// gaugeValue reports the current value of the gauge g.
+func gaugeValue(t *testing.T, g interface {
+	Write(*dto.Metric) error
+}) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := g.Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetGauge().GetValue()
+}
+
+// countingExecutor counts the plans it executes.
+type countingExecutor struct {
+	execute.Executor
+	n int
+}
+
+func (e *countingExecutor) Execute(ctx context.Context, p *plan.PlanSpec) (map[string]execute.Result, error) {
+	e.n++
+	return e.Executor.Execute(ctx, p)
+}
+
+func TestController_Cache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "control_cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Write a point every minute for an hour.
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	var data bytes.Buffer
+	data.WriteString("# DDL\nCREATE DATABASE db0\n# DML\n# CONTEXT-DATABASE: db0\n")
+	for i := 0; i < 60; i++ {
+		fmt.Fprintf(&data, "cpu,host=a usage=%d %d\n", i, start.Add(time.Duration(i)*time.Minute).UnixNano())
+		fmt.Fprintf(&data, "cpu,host=b usage=%d %d\n", 2*i, start.Add(time.Duration(i)*time.Minute).UnixNano())
+	}
+	path := filepath.Join(dir, "data.lp")
+	if err := ioutil.WriteFile(path, data.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	sr, err := execute.NewFileStorageReader([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sr.Close()
+
+	newController := func(cache CacheConfig) *Controller {
+		return New(Config{
+			ConcurrencyQuota: 4,
+			MemoryBytesQuota: math.MaxInt64,
+			ExecutorConfig: execute.Config{
+				StorageReader: sr,
+			},
+			Cache: cache,
+		})
+	}
+	run := func(c *Controller, q string) []*executetest.Block {
+		t.Helper()
+		qry, err := c.QueryWithCompile(context.Background(), q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer qry.Done()
+		results, ok := <-qry.Ready
+		if !ok {
+			t.Fatal(qry.Err())
+		}
+		var blocks []*executetest.Block
+		for _, r := range results {
+			if err := r.Blocks().Do(func(b execute.Block) error {
+				// The order of the rows of aggregates over windows is not defined.
+				block := executetest.ConvertBlock(b)
+				j := execute.TimeIdx(block.ColMeta)
+				sort.Slice(block.Data, func(i, k int) bool {
+					return block.Data[i][j].(execute.Time) < block.Data[k][j].(execute.Time)
+				})
+				blocks = append(blocks, block)
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		sort.Sort(executetest.SortedBlocks(blocks))
+		return blocks
+	}
+
+	// The bounds are not aligned to the windows, so that the queries have a head and a tail.
+	// The chunks that are not cached are executed along with the head and tail of a query when they are consecutive.
+	queries := []struct {
+		q          string
+		executions int
+	}{
+		{
+			q:          `from(db:"db0") |> range(start:2018-01-01T00:00:30Z, stop:2018-01-01T00:55:30Z) |> window(every:10m) |> sum()`,
+			executions: 1,
+		},
+		{
+			q:          `from(db:"db0") |> range(start:2018-01-01T00:05:30Z, stop:2018-01-01T00:59:30Z) |> window(every:10m) |> sum()`,
+			executions: 2,
+		},
+	}
+	uncached := newController(CacheConfig{})
+	c := newController(CacheConfig{
+		MaxRows: 1000,
+		TTL:     time.Hour,
+	})
+	executor := &countingExecutor{Executor: c.executor}
+	c.executor = executor
+
+	hits := counterValue(t, cacheHitsCounter)
+	misses := counterValue(t, cacheMissesCounter)
+
+	for i, q := range queries {
+		want := run(uncached, q.q)
+		executor.n = 0
+		got := run(c, q.q)
+		if !cmp.Equal(want, got) {
+			t.Errorf("unexpected result of query %d -want/+got\n%s", i, cmp.Diff(want, got))
+		}
+		if executor.n != q.executions {
+			t.Errorf("unexpected executions of query %d: want %d got %d", i, q.executions, executor.n)
+		}
+	}
+	if got := counterValue(t, cacheMissesCounter); got != misses+1 {
+		t.Errorf("unexpected cache misses: want %v got %v", misses+1, got)
+	}
+	if got := counterValue(t, cacheHitsCounter); got != hits+1 {
+		t.Errorf("unexpected cache hits: want %v got %v", hits+1, got)
+	}
+	// The second query is served from four of the five chunks of the first query and reads its head and tail only.
+	if len(c.cache.entries) != 5 {
+		t.Errorf("unexpected number of cached chunks: want 5 got %d", len(c.cache.entries))
+	}
+
+	// Chunks of several windows are split from the rows of the windows that were executed together.
+	chunked := newController(CacheConfig{
+		MaxRows: 1000,
+		TTL:     time.Hour,
+		Chunk:   20 * time.Minute,
+	})
+	for i := 0; i < 2; i++ {
+		for j, q := range queries {
+			want := run(uncached, q.q)
+			got := run(chunked, q.q)
+			if !cmp.Equal(want, got) {
+				t.Errorf("unexpected result of query %d with chunks of 20m -want/+got\n%s", j, cmp.Diff(want, got))
+			}
+		}
+	}
+	if len(chunked.cache.entries) == 0 {
+		t.Error("expected cached chunks of 20m")
+	}
+}
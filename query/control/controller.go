@@ -2,6 +2,7 @@ package control
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"math"
 	"sync"
@@ -25,14 +26,22 @@ type Controller struct {
 	cancelRequest chan QueryID
 
 	verbose bool
+	libDirs []string
 
-	lplanner plan.LogicalPlanner
-	pplanner plan.Planner
-	executor execute.Executor
+	lplanner   plan.LogicalPlanner
+	pplanner   plan.Planner
+	executor   execute.Executor
+	storage    plan.Storage
+	cache      *resultCache
+	authorizer Authorizer
+	scheduler  *scheduler
 
 	maxConcurrency       int
 	availableConcurrency int
 	availableMemory      int64
+
+	queueTimeout   time.Duration
+	executeTimeout time.Duration
 }
 
 type Config struct {
@@ -40,6 +49,31 @@ type Config struct {
 	MemoryBytesQuota int64
 	ExecutorConfig   execute.Config
 	Verbose          bool
+	// Storage provides the shard map used to plan storage reads, it may be nil.
+	Storage plan.Storage
+	// Cache configures the result cache, see CacheConfig.
+	Cache CacheConfig
+	// LibDirs are the directories searched for the source files of packages imported by queries.
+	LibDirs []string
+	// QueueTimeout is how long a query may wait for resources before it starts executing,
+	// unless its spec sets its own timeout. A zero value indicates queries wait indefinitely.
+	QueueTimeout time.Duration
+	// ExecuteTimeout is how long a query may execute, unless its spec sets its own timeout.
+	// It does not apply to continuous queries. A zero value indicates queries execute indefinitely.
+	ExecuteTimeout time.Duration
+	// Authorizer is consulted with the storage reads of each query when it is planned, it may be nil.
+	Authorizer Authorizer
+	// TenantQuotas are the quotas of tenants, keyed by name.
+	// Tenants without quotas have DefaultTenantQuota, see NewContextWithTenant.
+	TenantQuotas       map[string]TenantQuota
+	DefaultTenantQuota TenantQuota
+}
+
+// Authorizer decides whether queries may read from storage.
+type Authorizer interface {
+	// AuthorizeRead returns an error if the query whose context is ctx may not read the database from the hosts.
+	// An empty list of hosts means the query reads the hosts of the storage.
+	AuthorizeRead(ctx context.Context, database string, hosts []string) error
 }
 
 type QueryID uint64
@@ -57,6 +91,15 @@ func New(c Config) *Controller {
 		pplanner:             plan.NewPlanner(),
 		executor:             execute.NewExecutor(c.ExecutorConfig),
 		verbose:              c.Verbose,
+		libDirs:              c.LibDirs,
+		storage:              c.Storage,
+		queueTimeout:         c.QueueTimeout,
+		executeTimeout:       c.ExecuteTimeout,
+		authorizer:           c.Authorizer,
+		scheduler:            newScheduler(c.TenantQuotas, c.DefaultTenantQuota),
+	}
+	if c.Cache.MaxRows > 0 {
+		ctrl.cache = newResultCache(c.Cache)
 	}
 	go ctrl.run()
 	return ctrl
@@ -85,25 +128,64 @@ func (c *Controller) Query(ctx context.Context, qSpec *query.Spec) (*Query, erro
 	return q, err
 }
 
+// ContinuousQueryWithCompile submits a continuous query for execution returning immediately.
+// The query will first be compiled before submitting for execution.
+// See ContinuousQuery.
+func (c *Controller) ContinuousQueryWithCompile(ctx context.Context, queryStr string, interval time.Duration) (*Query, error) {
+	if interval <= 0 {
+		return nil, errors.New("continuous query interval must be positive")
+	}
+	q := c.createQuery(ctx)
+	q.continuous = &plan.ContinuousSpec{Interval: interval}
+	err := c.compileQuery(q, queryStr)
+	if err != nil {
+		return nil, err
+	}
+	err = c.enqueueQuery(q)
+	return q, err
+}
+
+// ContinuousQuery submits a continuous query for execution returning immediately.
+// A continuous query does not finish once its bounds have been read,
+// instead it keeps polling storage every interval and advances its watermarks as time passes.
+// Results are delivered incrementally as their triggers fire,
+// until the query is stopped using StopQuery or its context is canceled.
+// The spec must not be modified while the query is still active.
+// Done must be called on any returned Query objects.
+func (c *Controller) ContinuousQuery(ctx context.Context, qSpec *query.Spec, interval time.Duration) (*Query, error) {
+	if interval <= 0 {
+		return nil, errors.New("continuous query interval must be positive")
+	}
+	q := c.createQuery(ctx)
+	q.continuous = &plan.ContinuousSpec{Interval: interval}
+	q.Spec = *qSpec
+	err := c.enqueueQuery(q)
+	return q, err
+}
+
 func (c *Controller) createQuery(ctx context.Context) *Query {
 	id := c.nextID()
 	cctx, cancel := context.WithCancel(ctx)
 	ready := make(chan map[string]execute.Result, 1)
+	now := time.Now().UTC()
 	return &Query{
-		id:        id,
-		state:     Created,
-		c:         c,
-		now:       time.Now().UTC(),
-		ready:     ready,
-		Ready:     ready,
-		parentCtx: cctx,
-		cancel:    cancel,
+		id:          id,
+		tenant:      TenantFromContext(ctx),
+		state:       Created,
+		transitions: []StateTransition{{State: Created, Time: now}},
+		c:           c,
+		now:         now,
+		ready:       ready,
+		Ready:       ready,
+		parentCtx:   cctx,
+		cancel:      cancel,
 	}
 }
 
 func (c *Controller) compileQuery(q *Query, queryStr string) error {
+	q.text = queryStr
 	q.compile()
-	spec, err := query.Compile(q.compilingCtx, queryStr, query.Verbose(c.verbose))
+	spec, err := query.Compile(q.compilingCtx, queryStr, query.Verbose(c.verbose), query.LibDirs(c.libDirs...))
 	if err != nil {
 		return errors.Wrap(err, "failed to compile query")
 	}
@@ -116,9 +198,21 @@ func (c *Controller) enqueueQuery(q *Query) error {
 	if err := q.Spec.Validate(); err != nil {
 		return errors.Wrap(err, "invalid query")
 	}
+	if q.Spec.Resources.QueueTimeout < 0 || q.Spec.Resources.ExecuteTimeout < 0 {
+		return errors.New("invalid query: timeouts must not be negative")
+	}
+	q.queueTimeout = time.Duration(q.Spec.Resources.QueueTimeout)
+	if q.queueTimeout == 0 {
+		q.queueTimeout = c.queueTimeout
+	}
+	q.executeTimeout = time.Duration(q.Spec.Resources.ExecuteTimeout)
+	if q.executeTimeout == 0 && q.continuous == nil {
+		q.executeTimeout = c.executeTimeout
+	}
 	// Add query to the queue
+	q.enqueued = make(chan error, 1)
 	c.newQueries <- q
-	return nil
+	return <-q.enqueued
 }
 
 func (c *Controller) nextID() QueryID {
@@ -143,88 +237,319 @@ func (c *Controller) Queries() []*Query {
 	return queries
 }
 
+// ContinuousQueries reports the active continuous queries.
+func (c *Controller) ContinuousQueries() []*Query {
+	c.queriesMu.RLock()
+	defer c.queriesMu.RUnlock()
+	queries := make([]*Query, 0, len(c.queries))
+	for _, q := range c.queries {
+		if q.Continuous() {
+			queries = append(queries, q)
+		}
+	}
+	return queries
+}
+
+// QueryByID reports the active query with the given id, or nil if there is none.
+func (c *Controller) QueryByID(id QueryID) *Query {
+	c.queriesMu.RLock()
+	defer c.queriesMu.RUnlock()
+	return c.queries[id]
+}
+
+// StopQuery cancels the active query with the given id.
+// Done must still be called on the query to free its resources.
+func (c *Controller) StopQuery(id QueryID) error {
+	c.queriesMu.RLock()
+	_, ok := c.queries[id]
+	c.queriesMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown query %d", id)
+	}
+	c.cancelRequest <- id
+	return nil
+}
+
 func (c *Controller) run() {
-	pq := newPriorityQueue()
 	for {
 		select {
 		// Wait for resources to free
 		case q := <-c.queryDone:
-			c.free(q)
+			if q.admitted {
+				c.free(q)
+				c.scheduler.free(q)
+			} else {
+				c.scheduler.remove(q)
+			}
 			c.queriesMu.Lock()
 			delete(c.queries, q.id)
 			c.queriesMu.Unlock()
 		// Wait for new queries
 		case q := <-c.newQueries:
-			pq.Push(q)
+			if err := c.scheduler.push(q); err != nil {
+				q.reject(err)
+				q.enqueued <- err
+				break
+			}
 			c.queriesMu.Lock()
 			c.queries[q.id] = q
 			c.queriesMu.Unlock()
+			// Start the timeout once the query is known to the controller,
+			// so that it is removed when the query times out.
+			q.startQueueTimeout()
+			q.enqueued <- nil
 		// Wait for cancel query requests
 		case id := <-c.cancelRequest:
 			c.queriesMu.RLock()
 			q := c.queries[id]
 			c.queriesMu.RUnlock()
-			q.Cancel()
+			if q != nil {
+				// Cancel informs the controller the query is done,
+				// so it cannot be called from the run loop directly.
+				go q.Cancel()
+			}
 		}
 
-		// Peek at head of priority queue
-		q := pq.Peek()
-		if q != nil {
-			err := c.processQuery(pq, q)
-			if err != nil {
-				go q.setErr(err)
-			}
+		c.schedule()
+	}
+}
+
+// admission is the outcome of processing a queued query.
+type admission int
+
+const (
+	admitted admission = iota
+	// tenantFull indicates the query does not fit in the remaining quotas of its tenant.
+	tenantFull
+	// controllerFull indicates the query does not fit in the available resources of the controller.
+	controllerFull
+)
+
+// schedule processes the queued queries of the tenants in the order of the scheduler,
+// until the next query does not fit in the available resources of the controller.
+// Tenants whose next query does not fit in their quotas are skipped.
+func (c *Controller) schedule() {
+	for c.scheduleNext() {
+	}
+}
+
+// scheduleNext processes the next query that may be admitted, it reports whether the next query should be processed.
+func (c *Controller) scheduleNext() bool {
+	for _, t := range c.scheduler.waiting() {
+		q := c.scheduler.peek(t)
+		if q == nil {
+			continue
+		}
+		a, err := c.processQuery(t, q)
+		if err != nil {
+			c.scheduler.remove(q)
+			go q.setErr(err)
+			return true
+		}
+		switch a {
+		case admitted:
+			return true
+		case controllerFull:
+			// Queries of other tenants do not overtake the query,
+			// so that it is not starved by smaller queries.
+			return false
 		}
 	}
+	return false
 }
 
-func (c *Controller) processQuery(pq *PriorityQueue, q *Query) error {
+func (c *Controller) processQuery(t *tenant, q *Query) (admission, error) {
 	if q.tryPlan() {
 		// Plan query to determine needed resources
 		lp, err := c.lplanner.Plan(&q.Spec)
 		if err != nil {
-			return errors.Wrap(err, "failed to create logical plan")
+			return 0, errors.Wrap(err, "failed to create logical plan")
 		}
 		if c.verbose {
 			log.Println("logical plan", plan.Formatted(lp))
 		}
+		if c.authorizer != nil {
+			if err := c.authorize(q.parentCtx, lp); err != nil {
+				return 0, errors.Wrap(err, "query is not authorized")
+			}
+		}
+
+		// Planning modifies the logical plan, keep a copy in case parts of the query are served from the cache.
+		var cacheLP *plan.LogicalPlanSpec
+		if c.cache != nil && q.continuous == nil {
+			cacheLP = lp.Copy()
+		}
 
-		p, err := c.pplanner.Plan(lp, nil, q.now)
+		// Shard reads are planned for the absolute bounds of the query,
+		// which do not apply to continuous queries.
+		storage := c.storage
+		if q.continuous != nil {
+			storage = nil
+		}
+		p, err := c.pplanner.Plan(lp, storage, q.now)
 		if err != nil {
-			return errors.Wrap(err, "failed to create physical plan")
+			return 0, errors.Wrap(err, "failed to create physical plan")
 		}
-		q.plan = p
-		q.concurrency = p.Resources.ConcurrencyQuota
-		if q.concurrency > c.maxConcurrency {
-			q.concurrency = c.maxConcurrency
+		p.Continuous = q.continuous
+		p.Resources.ExecuteTimeout = query.Duration(q.executeTimeout)
+		if cacheLP != nil {
+			q.cache, _ = newCacheQuery(cacheLP, p, c.cache.chunk)
+		}
+		concurrency := p.Resources.ConcurrencyQuota
+		if concurrency > c.maxConcurrency {
+			concurrency = c.maxConcurrency
+		}
+		if t.quota.ConcurrencyQuota > 0 && concurrency > t.quota.ConcurrencyQuota {
+			concurrency = t.quota.ConcurrencyQuota
 		}
+		// The plan and resources are reported while the query is active, set them under the lock.
+		q.mu.Lock()
+		q.plan = p
+		q.concurrency = concurrency
 		q.memory = p.Resources.MemoryBytesQuota
+		q.mu.Unlock()
 		if c.verbose {
 			log.Println("physical plan", plan.Formatted(q.plan))
 		}
+		if err := t.validate(q); err != nil {
+			return 0, err
+		}
 	}
 
 	// Check if we have enough resources
-	if c.check(q) {
-		// Update resource gauges
-		c.consume(q)
-
-		// Remove the query from the queue
-		pq.Pop()
-
-		// Execute query
-		if q.tryExec() {
-			r, err := c.executor.Execute(q.executeCtx, q.plan)
-			if err != nil {
-				return errors.Wrap(err, "failed to execute query")
+	if !t.check(q) {
+		q.tryRequeue()
+		return tenantFull, nil
+	}
+	if !c.check(q) {
+		// update state to queueing
+		q.tryRequeue()
+		return controllerFull, nil
+	}
+
+	// Update resource gauges
+	c.consume(q)
+	// Remove the query from the queue
+	c.scheduler.admit(q)
+	q.admitted = true
+
+	// Execute query
+	if q.tryExec() {
+		r, err := c.executeQuery(q)
+		if err != nil {
+			// The query has been admitted, its resources are freed once it has finished.
+			go q.setErr(errors.Wrap(err, "failed to execute query"))
+			return admitted, nil
+		}
+		q.setResults(r)
+	}
+	return admitted, nil
+}
+
+// authorize consults the authorizer with the database and hosts of each storage read of the plan.
+// The hosts are those requested by the query, before the planner restricts them to the shards being read.
+func (c *Controller) authorize(ctx context.Context, lp *plan.LogicalPlanSpec) error {
+	var err error
+	lp.Do(func(pr *plan.Procedure) {
+		if err != nil {
+			return
+		}
+		if s, ok := pr.Spec.(plan.StorageProcedureSpec); ok {
+			err = c.authorizer.AuthorizeRead(ctx, s.StorageDatabase(), s.StorageHosts())
+		}
+	})
+	return err
+}
+
+// executeQuery executes the plan of the query.
+// When the query is cached, only the parts of its range that are not cached are executed.
+func (c *Controller) executeQuery(q *Query) (map[string]execute.Result, error) {
+	cq := q.cache
+	if cq == nil {
+		return c.executor.Execute(q.executeCtx, q.plan)
+	}
+	chunks := cq.chunks(q.now)
+	if len(chunks) == 0 {
+		return c.executor.Execute(q.executeCtx, q.plan)
+	}
+
+	var (
+		parts []*cachePart
+		// part is the last part when its range is not cached, consecutive ranges that are not cached are executed together.
+		part *cachePart
+		hit  bool
+	)
+	add := func(bounds execute.Bounds, key string) {
+		if key != "" {
+			if blocks, ok := c.cache.lookup(key, q.now); ok {
+				parts = append(parts, &cachePart{bounds: bounds, cached: true, blocks: blocks})
+				part = nil
+				hit = true
+				return
 			}
-			q.setResults(r)
 		}
+		if part == nil {
+			part = &cachePart{bounds: bounds}
+			parts = append(parts, part)
+		}
+		part.bounds.Stop = bounds.Stop
+		if key != "" {
+			part.chunks = append(part.chunks, cacheChunk{key: key, bounds: bounds})
+		}
+	}
+	if cq.start < chunks[0] {
+		add(execute.Bounds{Start: cq.start, Stop: chunks[0]}, "")
+	}
+	for _, start := range chunks {
+		add(execute.Bounds{Start: start, Stop: start.Add(cq.chunk)}, cq.chunkKey(start))
+	}
+	if last := chunks[len(chunks)-1].Add(cq.chunk); last < cq.stop {
+		add(execute.Bounds{Start: last, Stop: cq.stop}, "")
+	}
+	if hit {
+		cacheHitsCounter.Inc()
 	} else {
-		// update state to queueing
-		q.tryRequeue()
+		cacheMissesCounter.Inc()
 	}
-	return nil
+
+	for _, p := range parts {
+		if p.cached {
+			continue
+		}
+		pp, err := c.planRange(q, p.bounds.Start, p.bounds.Stop)
+		if err != nil {
+			return nil, err
+		}
+		p.plan = pp
+	}
+	cr := &cachedResult{
+		ctx:      q.executeCtx,
+		executor: c.executor,
+		name:     cq.name,
+		cache:    c.cache,
+		now:      q.now,
+		bounds:   execute.Bounds{Start: cq.start, Stop: cq.stop},
+		parts:    parts,
+	}
+	return map[string]execute.Result{cq.name: cr}, nil
+}
+
+// planRange plans the query restricted to the range [start, stop).
+// The plan is given the resources the query was admitted with,
+// the parts of a query are executed one at a time so that together they do not exceed them.
+func (c *Controller) planRange(q *Query, start, stop execute.Time) (*plan.PlanSpec, error) {
+	lp, err := restrictPlan(q.cache.lp, start, stop, q.now)
+	if err != nil {
+		return nil, err
+	}
+	p, err := c.pplanner.Plan(lp, c.storage, q.now)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create physical plan")
+	}
+	p.Resources = q.plan.Resources
+	p.Resources.ConcurrencyQuota = q.concurrency
+	p.Resources.MemoryBytesQuota = q.memory
+	return p, nil
 }
 
 func (c *Controller) check(q *Query) bool {
@@ -250,8 +575,12 @@ func (c *Controller) free(q *Query) {
 type Query struct {
 	id QueryID
 	c  *Controller
+	// tenant is the tenant the query belongs to, see NewContextWithTenant.
+	tenant string
 
 	Spec query.Spec
+	// text is the IFQL source of the query, it is empty when the query was submitted as a spec.
+	text string
 	now  time.Time
 
 	err error
@@ -262,9 +591,10 @@ type Query struct {
 	// inspected for an error using Err().
 	Ready <-chan map[string]execute.Result
 
-	mu     sync.Mutex
-	state  State
-	cancel func()
+	mu          sync.Mutex
+	state       State
+	transitions []StateTransition
+	cancel      func()
 
 	parentCtx,
 	compilingCtx,
@@ -279,10 +609,22 @@ type Query struct {
 	requeueSpan,
 	executeSpan *span
 
-	plan *plan.PlanSpec
+	plan       *plan.PlanSpec
+	continuous *plan.ContinuousSpec
+	// cache describes how the results of the query are cached, it is nil if they are not.
+	cache *cacheQuery
 
 	concurrency int
 	memory      int64
+	// admitted reports whether the query has been granted its resources, it is only used by the run loop.
+	admitted bool
+	// enqueued receives the error of queueing the query.
+	enqueued chan error
+
+	queueTimeout   time.Duration
+	executeTimeout time.Duration
+	// timer times the query out once it has exceeded the timeout of its current phase.
+	timer *time.Timer
 }
 
 // ID reports an ephemeral unique ID for the query.
@@ -290,14 +632,29 @@ func (q *Query) ID() QueryID {
 	return q.id
 }
 
+// Tenant reports the tenant the query belongs to.
+func (q *Query) Tenant() string {
+	return q.tenant
+}
+
+// Continuous reports whether the query is a continuous query.
+func (q *Query) Continuous() bool {
+	return q.continuous != nil
+}
+
 // Cancel will stop the query execution.
 // Done must still be called to free resources.
 func (q *Query) Cancel() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	switch q.state {
+	case Errored, Finished, Canceled, TimedOut:
+		// The query has already been finished.
+		return
+	}
 	q.cancel()
 	if q.state != Errored {
-		q.state = Canceled
+		q.transition(Canceled)
 	}
 	// Finish the query immediately.
 	// This allows for receiving from the Ready channel in the same goroutine
@@ -307,7 +664,14 @@ func (q *Query) Cancel() {
 
 // finish informs the controller and the Ready channel that the query is finished.
 func (q *Query) finish() {
-	q.c.queryDone <- q
+	if q.timer != nil {
+		q.timer.Stop()
+	}
+	// The query may finish on its own goroutine while the controller is processing it and waiting on q.mu,
+	// so the controller is informed without holding up the caller.
+	go func() {
+		q.c.queryDone <- q
+	}()
 	close(q.ready)
 	q.recordMetrics()
 }
@@ -327,13 +691,16 @@ func (q *Query) Done() {
 		q.executeSpan.Finish()
 		executingGauge.Dec()
 
-		q.state = Finished
+		q.transition(Finished)
 	case Errored:
 		// The query has already been finished in the call to setErr.
 		return
 	case Canceled:
 		// The query has already been finished in the call to Cancel.
 		return
+	case TimedOut:
+		// The query has already been finished when it timed out.
+		return
 	default:
 		panic("unreachable, all states have been accounted for")
 	}
@@ -366,9 +733,59 @@ func (q *Query) State() State {
 	return s
 }
 
+// StateTransition records the time at which a query entered a state.
+type StateTransition struct {
+	State State
+	Time  time.Time
+}
+
+// transition moves the query into state s, q.mu must be held.
+func (q *Query) transition(s State) {
+	q.state = s
+	q.transitions = append(q.transitions, StateTransition{State: s, Time: time.Now().UTC()})
+}
+
+// StateTransitions reports the states the query has been through, in order, with the time each was entered.
+func (q *Query) StateTransitions() []StateTransition {
+	q.mu.Lock()
+	transitions := make([]StateTransition, len(q.transitions))
+	copy(transitions, q.transitions)
+	q.mu.Unlock()
+	return transitions
+}
+
+// Text reports the IFQL source of the query, it is empty when the query was submitted as a spec.
+func (q *Query) Text() string {
+	return q.text
+}
+
+// Plan reports the physical plan of the query, it is nil until the query has been planned.
+func (q *Query) Plan() *plan.PlanSpec {
+	q.mu.Lock()
+	p := q.plan
+	q.mu.Unlock()
+	return p
+}
+
+// Concurrency reports the concurrency allocated to the query.
+func (q *Query) Concurrency() int {
+	q.mu.Lock()
+	n := q.concurrency
+	q.mu.Unlock()
+	return n
+}
+
+// Memory reports the memory in bytes allocated to the query.
+func (q *Query) Memory() int64 {
+	q.mu.Lock()
+	n := q.memory
+	q.mu.Unlock()
+	return n
+}
+
 func (q *Query) isOK() bool {
 	q.mu.Lock()
-	ok := q.state != Canceled && q.state != Errored
+	ok := q.state != Canceled && q.state != Errored && q.state != TimedOut
 	q.mu.Unlock()
 	return ok
 }
@@ -380,11 +797,30 @@ func (q *Query) Err() error {
 	q.mu.Unlock()
 	return err
 }
+
+// setErr finishes the query in the Errored state, unless it has already been finished.
 func (q *Query) setErr(err error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	switch q.state {
+	case Queueing:
+		q.queueSpan.Finish()
+		queueingGauge.Dec()
+	case Planning:
+		q.planSpan.Finish()
+		planningGauge.Dec()
+	case Requeueing:
+		q.requeueSpan.Finish()
+		requeueingGauge.Dec()
+	case Executing:
+		q.executeSpan.Finish()
+		executingGauge.Dec()
+	case Errored, Finished, Canceled, TimedOut:
+		// The query has already been finished, possibly by its timeout while it was being planned.
+		return
+	}
 	q.err = err
-	q.state = Errored
+	q.transition(Errored)
 
 	// Finish the query immediately.
 	// This allows for receiving from the Ready channel in the same goroutine
@@ -392,6 +828,78 @@ func (q *Query) setErr(err error) {
 	q.finish()
 }
 
+// reject finishes a query that was refused a place in the queue.
+func (q *Query) reject(err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queueSpan.Finish()
+	queueingGauge.Dec()
+	q.err = err
+	q.transition(Errored)
+	q.finish()
+}
+
+// Phases of a query that are bounded by a timeout.
+const (
+	queuePhase   = "queue"
+	executePhase = "execute"
+)
+
+// startQueueTimeout starts timing the query out once it has waited longer than its queue timeout.
+func (q *Query) startQueueTimeout() {
+	q.mu.Lock()
+	q.startTimeout(queuePhase, q.queueTimeout)
+	q.mu.Unlock()
+}
+
+// startTimeout times the query out once d has passed, replacing the timeout of the previous phase.
+// A zero duration disables the timeout, q.mu must be held.
+func (q *Query) startTimeout(phase string, d time.Duration) {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	if d > 0 {
+		q.timer = time.AfterFunc(d, func() {
+			q.timeout(phase, d)
+		})
+	}
+}
+
+// timeout finishes the query in the TimedOut state, if it is still in the phase whose timeout of d has passed.
+func (q *Query) timeout(phase string, d time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	switch q.state {
+	case Queueing:
+		q.queueSpan.Finish()
+		queueingGauge.Dec()
+	case Planning:
+		q.planSpan.Finish()
+		planningGauge.Dec()
+	case Requeueing:
+		q.requeueSpan.Finish()
+		requeueingGauge.Dec()
+	case Executing:
+		if phase != executePhase {
+			// The queue timeout fired as the query started executing.
+			return
+		}
+		q.executeSpan.Finish()
+		executingGauge.Dec()
+	default:
+		// The query has already been finished.
+		return
+	}
+	q.cancel()
+	q.err = fmt.Errorf("query exceeded its %s timeout of %v", phase, d)
+	q.transition(TimedOut)
+	timeoutsCounter.WithLabelValues(phase).Inc()
+
+	// Finish the query immediately, as is done when it is canceled.
+	q.finish()
+}
+
 func (q *Query) setResults(r map[string]execute.Result) {
 	q.mu.Lock()
 	if q.state == Executing {
@@ -407,7 +915,7 @@ func (q *Query) compile() {
 	q.compilingSpan, q.compilingCtx = StartSpanFromContext(q.parentCtx, "compiling")
 	compilingGauge.Inc()
 
-	q.state = Compiling
+	q.transition(Compiling)
 	q.mu.Unlock()
 }
 
@@ -421,7 +929,7 @@ func (q *Query) queue() {
 	q.queueSpan, q.queueCtx = StartSpanFromContext(q.parentCtx, "queueing")
 	queueingGauge.Inc()
 
-	q.state = Queueing
+	q.transition(Queueing)
 	q.mu.Unlock()
 }
 
@@ -435,7 +943,7 @@ func (q *Query) tryRequeue() bool {
 		q.requeueSpan, q.requeueCtx = StartSpanFromContext(q.parentCtx, "requeueing")
 		requeueingGauge.Inc()
 
-		q.state = Requeueing
+		q.transition(Requeueing)
 		q.mu.Unlock()
 		return true
 	}
@@ -453,7 +961,7 @@ func (q *Query) tryPlan() bool {
 		q.planSpan, q.planCtx = StartSpanFromContext(q.parentCtx, "planning")
 		planningGauge.Inc()
 
-		q.state = Planning
+		q.transition(Planning)
 		q.mu.Unlock()
 		return true
 	}
@@ -477,7 +985,8 @@ func (q *Query) tryExec() bool {
 		q.executeSpan, q.executeCtx = StartSpanFromContext(q.parentCtx, "executing")
 		executingGauge.Inc()
 
-		q.state = Executing
+		q.transition(Executing)
+		q.startTimeout(executePhase, q.executeTimeout)
 		q.mu.Unlock()
 		return true
 	}
@@ -498,6 +1007,8 @@ const (
 	Errored
 	Finished
 	Canceled
+	// TimedOut is the state of queries that exceeded their queue or execute timeout.
+	TimedOut
 )
 
 func (s State) String() string {
@@ -520,6 +1031,8 @@ func (s State) String() string {
 		return "finished"
 	case Canceled:
 		return "canceled"
+	case TimedOut:
+		return "timed_out"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,329 @@
+package control
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/pkg/errors"
+)
+
+// newTestStorageReader returns a storage reader of a single point of db0 at 2018-01-01T00:00:00Z.
+// The returned function must be called to release it.
+func newTestStorageReader(t *testing.T) (execute.StorageReader, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "control")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := "# DDL\nCREATE DATABASE db0\n# DML\n# CONTEXT-DATABASE: db0\ncpu,host=a usage=1 1514764800000000000\n"
+	path := filepath.Join(dir, "data.lp")
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	sr, err := execute.NewFileStorageReader([]string{path})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return sr, func() {
+		sr.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+const testQuery = `from(db:"db0") |> range(start:2018-01-01T00:00:00Z, stop:2018-01-02T00:00:00Z)`
+
+// waitForState waits until the query has reached the state s.
+func waitForState(t *testing.T, q *Query, s State) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for q.State() != s {
+		if time.Now().After(deadline) {
+			t.Fatalf("query did not reach state %v, state %v", s, q.State())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestController_QueryDetails(t *testing.T) {
+	sr, closeReader := newTestStorageReader(t)
+	defer closeReader()
+
+	c := New(Config{
+		ConcurrencyQuota: 4,
+		MemoryBytesQuota: math.MaxInt64,
+		ExecutorConfig: execute.Config{
+			StorageReader: sr,
+		},
+	})
+
+	q, err := c.QueryWithCompile(context.Background(), testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+	if _, ok := <-q.Ready; !ok {
+		t.Fatal(q.Err())
+	}
+
+	if got := c.QueryByID(q.ID()); got != q {
+		t.Fatalf("unexpected query by id: got %v want %v", got, q)
+	}
+	if got := q.Text(); got != testQuery {
+		t.Errorf("unexpected query text: got %q want %q", got, testQuery)
+	}
+	if q.Plan() == nil {
+		t.Error("expected the physical plan of the query")
+	}
+	if got := q.Concurrency(); got <= 0 || got > 4 {
+		t.Errorf("unexpected concurrency %d", got)
+	}
+	if got, want := q.Memory(), q.Plan().Resources.MemoryBytesQuota; got != want {
+		t.Errorf("unexpected memory: got %d want %d", got, want)
+	}
+
+	// Stop the query, it is canceled asynchronously.
+	if err := c.StopQuery(q.ID()); err != nil {
+		t.Fatal(err)
+	}
+	waitForState(t, q, Canceled)
+
+	var states []State
+	var last time.Time
+	for _, st := range q.StateTransitions() {
+		states = append(states, st.State)
+		if st.Time.Before(last) {
+			t.Errorf("transition to %v at %v is before the previous transition at %v", st.State, st.Time, last)
+		}
+		last = st.Time
+	}
+	wantStates := []State{Created, Compiling, Queueing, Planning, Executing, Canceled}
+	if !cmp.Equal(wantStates, states) {
+		t.Errorf("unexpected state transitions: -want/+got\n%s", cmp.Diff(wantStates, states))
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for c.QueryByID(q.ID()) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("canceled query is still active")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := c.StopQuery(q.ID()); err == nil {
+		t.Error("expected an error stopping an unknown query")
+	}
+}
+
+func TestController_Timeouts(t *testing.T) {
+	sr, closeReader := newTestStorageReader(t)
+	defer closeReader()
+
+	c := New(Config{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+		ExecutorConfig: execute.Config{
+			StorageReader: sr,
+		},
+		ExecuteTimeout: 50 * time.Millisecond,
+	})
+
+	queueTimeouts := counterValue(t, timeoutsCounter.WithLabelValues(queuePhase))
+	executeTimeouts := counterValue(t, timeoutsCounter.WithLabelValues(executePhase))
+
+	// The first query holds the only concurrency slot until it times out.
+	q1, err := c.QueryWithCompile(context.Background(), testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q1.Done()
+	if _, ok := <-q1.Ready; !ok {
+		t.Fatal(q1.Err())
+	}
+
+	// The second query cannot start before its queue timeout passes.
+	spec, err := query.Compile(context.Background(), testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec.Resources.QueueTimeout = query.Duration(10 * time.Millisecond)
+	q2, err := c.Query(context.Background(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q2.Done()
+	if _, ok := <-q2.Ready; ok {
+		t.Fatal("expected no results from a query that timed out in the queue")
+	}
+	if got := q2.State(); got != TimedOut {
+		t.Errorf("unexpected state of queued query: got %v want %v", got, TimedOut)
+	}
+	if err := q2.Err(); err == nil || !strings.Contains(err.Error(), "queue timeout") {
+		t.Errorf("unexpected error of queued query: %v", err)
+	}
+
+	waitForState(t, q1, TimedOut)
+	if err := q1.Err(); err == nil || !strings.Contains(err.Error(), "execute timeout") {
+		t.Errorf("unexpected error of executing query: %v", err)
+	}
+
+	if got, want := counterValue(t, timeoutsCounter.WithLabelValues(queuePhase)), queueTimeouts+1; got != want {
+		t.Errorf("unexpected queue timeouts: got %v want %v", got, want)
+	}
+	if got, want := counterValue(t, timeoutsCounter.WithLabelValues(executePhase)), executeTimeouts+1; got != want {
+		t.Errorf("unexpected execute timeouts: got %v want %v", got, want)
+	}
+}
+
+// blockingAuthorizer rejects every read once it has been released.
+type blockingAuthorizer struct {
+	release chan struct{}
+}
+
+func (a blockingAuthorizer) AuthorizeRead(ctx context.Context, database string, hosts []string) error {
+	<-a.release
+	return errors.New("forbidden")
+}
+
+func TestController_QueueTimeoutDuringPlanning(t *testing.T) {
+	sr, closeReader := newTestStorageReader(t)
+	defer closeReader()
+
+	a := blockingAuthorizer{release: make(chan struct{})}
+	c := New(Config{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: math.MaxInt64,
+		ExecutorConfig: execute.Config{
+			StorageReader: sr,
+		},
+		Authorizer: a,
+	})
+
+	planning := gaugeValue(t, planningGauge)
+
+	spec, err := query.Compile(context.Background(), testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec.Resources.QueueTimeout = query.Duration(10 * time.Millisecond)
+	q, err := c.Query(context.Background(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+
+	// The queue timeout passes while the query is being authorized,
+	// the planning error that follows must not finish the query again.
+	waitForState(t, q, TimedOut)
+	close(a.release)
+	if _, ok := <-q.Ready; ok {
+		t.Fatal("expected no results from a query that timed out while planning")
+	}
+	q.setErr(errors.New("planning error"))
+
+	if got := q.State(); got != TimedOut {
+		t.Errorf("unexpected state: got %v want %v", got, TimedOut)
+	}
+	if err := q.Err(); err == nil || !strings.Contains(err.Error(), "queue timeout") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := gaugeValue(t, planningGauge); got != planning {
+		t.Errorf("unexpected planning gauge: got %v want %v", got, planning)
+	}
+}
+
+// recordingAuthorizer records the reads it is consulted with, and rejects the reads of the forbidden database.
+type recordingAuthorizer struct {
+	forbidden string
+	reads     []string
+}
+
+func (a *recordingAuthorizer) AuthorizeRead(ctx context.Context, database string, hosts []string) error {
+	a.reads = append(a.reads, database+"@"+strings.Join(hosts, ","))
+	if database == a.forbidden {
+		return errors.New("forbidden")
+	}
+	return nil
+}
+
+func TestController_Authorizer(t *testing.T) {
+	sr, closeReader := newTestStorageReader(t)
+	defer closeReader()
+
+	testCases := []struct {
+		name      string
+		query     string
+		wantReads []string
+		wantErr   bool
+	}{
+		{
+			name:      "allowed",
+			query:     testQuery,
+			wantReads: []string{"db0@"},
+		},
+		{
+			name:      "hosts",
+			query:     `from(db:"secret", hosts:["a:8082","b:8082"]) |> range(start:2018-01-01T00:00:00Z, stop:2018-01-02T00:00:00Z)`,
+			wantReads: []string{"secret@a:8082,b:8082"},
+			wantErr:   true,
+		},
+		{
+			name:      "forbidden",
+			query:     `from(db:"secret") |> range(start:2018-01-01T00:00:00Z, stop:2018-01-02T00:00:00Z)`,
+			wantReads: []string{"secret@"},
+			wantErr:   true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			a := &recordingAuthorizer{forbidden: "secret"}
+			c := New(Config{
+				ConcurrencyQuota: 4,
+				MemoryBytesQuota: math.MaxInt64,
+				ExecutorConfig: execute.Config{
+					StorageReader: sr,
+				},
+				Authorizer: a,
+			})
+			planning := gaugeValue(t, planningGauge)
+
+			q, err := c.QueryWithCompile(context.Background(), tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer q.Done()
+			_, ok := <-q.Ready
+			if !cmp.Equal(tc.wantReads, a.reads) {
+				t.Errorf("unexpected authorized reads: -want/+got\n%s", cmp.Diff(tc.wantReads, a.reads))
+			}
+			if !tc.wantErr {
+				if !ok {
+					t.Fatal(q.Err())
+				}
+				return
+			}
+			if ok {
+				t.Fatal("expected the query to be rejected")
+			}
+			if got := q.Err(); got == nil || !strings.Contains(got.Error(), "query is not authorized") {
+				t.Errorf("unexpected error: %v", got)
+			}
+			if got := q.State(); got != Errored {
+				t.Errorf("unexpected state: got %v want %v", got, Errored)
+			}
+			if got := gaugeValue(t, planningGauge); got != planning {
+				t.Errorf("unexpected planning gauge: got %v want %v", got, planning)
+			}
+		})
+	}
+}
@@ -49,6 +49,33 @@ var executingHist = prometheus.NewHistogram(prometheus.HistogramOpts{
 	Buckets: prometheus.ExponentialBuckets(1e-3, 5, 7),
 })
 
+var cacheHitsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "ifql_control_cache_hits",
+	Help: "Number of queries served in part from the result cache",
+})
+var cacheMissesCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "ifql_control_cache_misses",
+	Help: "Number of cacheable queries not found in the result cache",
+})
+
+var timeoutsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ifql_control_timeouts",
+	Help: "Number of queries that exceeded their queue or execute timeout",
+}, []string{"phase"})
+
+var tenantQueueDepthGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ifql_control_tenant_queue_depth",
+	Help: "Number of queries of each tenant waiting for resources",
+}, []string{"tenant"})
+var tenantAdmittedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ifql_control_tenant_admitted",
+	Help: "Number of queries of each tenant admitted for execution",
+}, []string{"tenant"})
+var tenantRejectedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ifql_control_tenant_rejected",
+	Help: "Number of queries of each tenant rejected because the tenant had too many queued queries",
+}, []string{"tenant"})
+
 func init() {
 	prometheus.MustRegister(queueingGauge)
 	prometheus.MustRegister(requeueingGauge)
@@ -59,4 +86,13 @@ func init() {
 	prometheus.MustRegister(requeueingHist)
 	prometheus.MustRegister(planningHist)
 	prometheus.MustRegister(executingHist)
+
+	prometheus.MustRegister(cacheHitsCounter)
+	prometheus.MustRegister(cacheMissesCounter)
+
+	prometheus.MustRegister(timeoutsCounter)
+
+	prometheus.MustRegister(tenantQueueDepthGauge)
+	prometheus.MustRegister(tenantAdmittedCounter)
+	prometheus.MustRegister(tenantRejectedCounter)
 }
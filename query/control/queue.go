@@ -28,6 +28,7 @@ func (pq *priorityQueue) Pop() interface{} {
 	return q
 }
 
+// PriorityQueue orders the queries of a tenant by their priority.
 type PriorityQueue struct {
 	queue priorityQueue
 }
@@ -65,3 +66,18 @@ func (p *PriorityQueue) Pop() *Query {
 		}
 	}
 }
+
+func (p *PriorityQueue) Len() int {
+	return p.queue.Len()
+}
+
+// Remove removes q from the queue, if it is queued.
+func (p *PriorityQueue) Remove(q *Query) bool {
+	for i, e := range p.queue {
+		if e == q {
+			heap.Remove(&p.queue, i)
+			return true
+		}
+	}
+	return false
+}
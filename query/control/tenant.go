@@ -0,0 +1,221 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultTenant is the tenant of queries submitted with a context without a tenant.
+const DefaultTenant = "default"
+
+// ErrQueueFull is the cause of the errors of queries rejected because their tenant has too many queued queries.
+var ErrQueueFull = errors.New("too many queued queries")
+
+type contextKey int
+
+const tenantKey contextKey = iota
+
+// NewContextWithTenant returns a copy of ctx with the tenant, queries submitted with the context belong to the tenant.
+func NewContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+// TenantFromContext returns the tenant of ctx, or DefaultTenant if there is none.
+func TenantFromContext(ctx context.Context) string {
+	if t, ok := ctx.Value(tenantKey).(string); ok && t != "" {
+		return t
+	}
+	return DefaultTenant
+}
+
+// TenantQuota limits the resources of the queries of a tenant, zero values mean no limit.
+type TenantQuota struct {
+	// ConcurrencyQuota is the concurrency the executing queries of the tenant may use in total.
+	ConcurrencyQuota int
+	// MemoryBytesQuota is the memory the executing queries of the tenant may use in total.
+	// Like the memory quota of the controller, it does not apply to queries without a memory quota.
+	MemoryBytesQuota int64
+	// MaxQueued is the number of queries the tenant may have waiting for resources,
+	// further queries are rejected with ErrQueueFull.
+	MaxQueued int
+	// Weight is the share of the resources of the controller the tenant receives relative to other tenants
+	// when they compete for them. A zero weight is a weight of one.
+	Weight int
+}
+
+// tenant is the scheduling state of a tenant with queued or executing queries.
+type tenant struct {
+	name  string
+	quota TenantQuota
+	queue *PriorityQueue
+
+	// pass is the virtual time of the tenant, it advances by the concurrency of each admitted query divided by the weight.
+	pass float64
+
+	executing   int
+	concurrency int
+	memory      int64
+}
+
+// check reports whether the query fits in the remaining quotas of the tenant.
+func (t *tenant) check(q *Query) bool {
+	if t.quota.ConcurrencyQuota > 0 && t.concurrency+q.concurrency > t.quota.ConcurrencyQuota {
+		return false
+	}
+	if t.quota.MemoryBytesQuota > 0 && q.memory != math.MaxInt64 && t.memory+q.memory > t.quota.MemoryBytesQuota {
+		return false
+	}
+	return true
+}
+
+// validate returns an error if the query can never fit in the quotas of the tenant.
+func (t *tenant) validate(q *Query) error {
+	if t.quota.MemoryBytesQuota > 0 && q.memory != math.MaxInt64 && q.memory > t.quota.MemoryBytesQuota {
+		return fmt.Errorf("query memory of %d bytes exceeds the memory quota of tenant %q of %d bytes", q.memory, t.name, t.quota.MemoryBytesQuota)
+	}
+	return nil
+}
+
+// scheduler queues the queries of each tenant, and orders tenants by weighted fair scheduling.
+// A tenant is scheduled before others when its queries have used less of the controller, relative to its weight.
+// Within a tenant, queries are scheduled by their priority.
+// The scheduler is only used from the run loop of the controller.
+type scheduler struct {
+	quotas       map[string]TenantQuota
+	defaultQuota TenantQuota
+
+	tenants map[string]*tenant
+	// vtime is the virtual time of the scheduler, the pass of the tenant that was last admitted.
+	// Tenants that start queueing begin at it, so that they do not accrue a share while they are idle.
+	vtime float64
+}
+
+func newScheduler(quotas map[string]TenantQuota, defaultQuota TenantQuota) *scheduler {
+	return &scheduler{
+		quotas:       quotas,
+		defaultQuota: defaultQuota,
+		tenants:      make(map[string]*tenant),
+	}
+}
+
+func (s *scheduler) tenant(name string) *tenant {
+	t, ok := s.tenants[name]
+	if !ok {
+		quota, ok := s.quotas[name]
+		if !ok {
+			quota = s.defaultQuota
+		}
+		t = &tenant{
+			name:  name,
+			quota: quota,
+			queue: newPriorityQueue(),
+			pass:  s.vtime,
+		}
+		s.tenants[name] = t
+	}
+	return t
+}
+
+// push queues the query, it returns ErrQueueFull if its tenant already has the maximum number of queued queries.
+func (s *scheduler) push(q *Query) error {
+	t := s.tenant(q.tenant)
+	if t.quota.MaxQueued > 0 && t.queue.Len() >= t.quota.MaxQueued {
+		s.release(t)
+		tenantRejectedCounter.WithLabelValues(t.name).Inc()
+		return errors.Wrapf(ErrQueueFull, "tenant %q has %d queued queries", t.name, t.queue.Len())
+	}
+	if t.queue.Len() == 0 && t.pass < s.vtime {
+		t.pass = s.vtime
+	}
+	t.queue.Push(q)
+	tenantQueueDepthGauge.WithLabelValues(t.name).Set(float64(t.queue.Len()))
+	return nil
+}
+
+// waiting reports the tenants with queued queries in the order they are scheduled.
+func (s *scheduler) waiting() []*tenant {
+	tenants := make([]*tenant, 0, len(s.tenants))
+	for _, t := range s.tenants {
+		if t.queue.Len() > 0 {
+			tenants = append(tenants, t)
+		}
+	}
+	sort.Slice(tenants, func(i, j int) bool {
+		if tenants[i].pass != tenants[j].pass {
+			return tenants[i].pass < tenants[j].pass
+		}
+		return tenants[i].name < tenants[j].name
+	})
+	return tenants
+}
+
+// peek reports the next query of the tenant, dropping the queries that have finished while queued.
+func (s *scheduler) peek(t *tenant) *Query {
+	q := t.queue.Peek()
+	tenantQueueDepthGauge.WithLabelValues(t.name).Set(float64(t.queue.Len()))
+	if q == nil {
+		s.release(t)
+	}
+	return q
+}
+
+// remove removes a query from the queue of its tenant, if it is queued.
+func (s *scheduler) remove(q *Query) {
+	t, ok := s.tenants[q.tenant]
+	if !ok || !t.queue.Remove(q) {
+		return
+	}
+	tenantQueueDepthGauge.WithLabelValues(t.name).Set(float64(t.queue.Len()))
+	s.release(t)
+}
+
+// admit removes the query from the queue of its tenant and charges its resources to the tenant.
+func (s *scheduler) admit(q *Query) {
+	t := s.tenants[q.tenant]
+	t.queue.Remove(q)
+	tenantQueueDepthGauge.WithLabelValues(t.name).Set(float64(t.queue.Len()))
+	tenantAdmittedCounter.WithLabelValues(t.name).Inc()
+
+	t.executing++
+	t.concurrency += q.concurrency
+	if q.memory != math.MaxInt64 {
+		t.memory += q.memory
+	}
+	if t.pass > s.vtime {
+		s.vtime = t.pass
+	}
+	weight := t.quota.Weight
+	if weight <= 0 {
+		weight = 1
+	}
+	concurrency := q.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	t.pass += float64(concurrency) / float64(weight)
+}
+
+// free returns the resources of an admitted query to its tenant.
+func (s *scheduler) free(q *Query) {
+	t, ok := s.tenants[q.tenant]
+	if !ok {
+		return
+	}
+	t.executing--
+	t.concurrency -= q.concurrency
+	if q.memory != math.MaxInt64 {
+		t.memory -= q.memory
+	}
+	s.release(t)
+}
+
+// release forgets a tenant without queued or executing queries.
+func (s *scheduler) release(t *tenant) {
+	if t.queue.Len() == 0 && t.executing == 0 {
+		delete(s.tenants, t.name)
+	}
+}
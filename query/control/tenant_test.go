@@ -0,0 +1,272 @@
+package control
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/pkg/errors"
+)
+
+func TestTenantFromContext(t *testing.T) {
+	if got := TenantFromContext(context.Background()); got != DefaultTenant {
+		t.Errorf("unexpected tenant without a tenant: got %q want %q", got, DefaultTenant)
+	}
+	ctx := NewContextWithTenant(context.Background(), "a")
+	if got := TenantFromContext(ctx); got != "a" {
+		t.Errorf("unexpected tenant: got %q want %q", got, "a")
+	}
+}
+
+// newTenantQuery returns a planned query of the tenant.
+func newTenantQuery(tenant string, concurrency int) *Query {
+	return &Query{
+		tenant:      tenant,
+		concurrency: concurrency,
+		memory:      math.MaxInt64,
+	}
+}
+
+// admitNext admits the next query of the scheduler, and returns it.
+func admitNext(t *testing.T, s *scheduler) *Query {
+	t.Helper()
+	for _, tn := range s.waiting() {
+		q := s.peek(tn)
+		if q == nil || !tn.check(q) {
+			continue
+		}
+		s.admit(q)
+		return q
+	}
+	return nil
+}
+
+func TestScheduler_Fairness(t *testing.T) {
+	testCases := []struct {
+		name   string
+		quotas map[string]TenantQuota
+		// queries are the tenants of the queued queries, in the order they are queued.
+		queries []string
+		want    []string
+	}{
+		{
+			name:    "round robin",
+			queries: []string{"a", "a", "a", "a", "b", "b"},
+			want:    []string{"a", "b", "a", "b", "a", "a"},
+		},
+		{
+			name: "weights",
+			quotas: map[string]TenantQuota{
+				"b": {Weight: 2},
+			},
+			queries: []string{"a", "a", "a", "a", "b", "b", "b", "b"},
+			want:    []string{"a", "b", "b", "a", "b", "b", "a", "a"},
+		},
+		{
+			name: "concurrency quota",
+			quotas: map[string]TenantQuota{
+				"a": {ConcurrencyQuota: 2},
+			},
+			queries: []string{"a", "a", "a", "b", "b"},
+			want:    []string{"a", "b", "a", "b"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := newScheduler(tc.quotas, TenantQuota{})
+			for _, tenant := range tc.queries {
+				if err := s.push(newTenantQuery(tenant, 1)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var got []string
+			for q := admitNext(t, s); q != nil; q = admitNext(t, s) {
+				got = append(got, q.tenant)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected order of admitted tenants: -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestScheduler_IdleTenant(t *testing.T) {
+	s := newScheduler(nil, TenantQuota{})
+	for i := 0; i < 4; i++ {
+		if err := s.push(newTenantQuery("a", 1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var admitted []*Query
+	for i := 0; i < 3; i++ {
+		admitted = append(admitted, admitNext(t, s))
+	}
+	for _, q := range admitted {
+		s.free(q)
+	}
+
+	// A tenant that starts queueing does not make up for the time it was idle.
+	for i := 0; i < 2; i++ {
+		if err := s.push(newTenantQuery("b", 1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got []string
+	for q := admitNext(t, s); q != nil; q = admitNext(t, s) {
+		got = append(got, q.tenant)
+	}
+	if want := []string{"b", "a", "b"}; !cmp.Equal(want, got) {
+		t.Errorf("unexpected order of admitted tenants: -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestScheduler_MaxQueued(t *testing.T) {
+	s := newScheduler(map[string]TenantQuota{"a": {MaxQueued: 2}}, TenantQuota{})
+	rejected := counterValue(t, tenantRejectedCounter.WithLabelValues("a"))
+
+	q1, q2 := newTenantQuery("a", 1), newTenantQuery("a", 1)
+	for _, q := range []*Query{q1, q2} {
+		if err := s.push(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.push(newTenantQuery("a", 1)); errors.Cause(err) != ErrQueueFull {
+		t.Fatalf("unexpected error queueing a query of a full tenant: got %v want %v", err, ErrQueueFull)
+	}
+	if err := s.push(newTenantQuery("b", 1)); err != nil {
+		t.Fatalf("unexpected error queueing a query of another tenant: %v", err)
+	}
+	if got, want := counterValue(t, tenantRejectedCounter.WithLabelValues("a")), rejected+1; got != want {
+		t.Errorf("unexpected rejected queries: got %v want %v", got, want)
+	}
+
+	// Removing a queued query makes room for another.
+	s.remove(q2)
+	if err := s.push(newTenantQuery("a", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Admitted queries do not count against the queue.
+	s.admit(q1)
+	if err := s.push(newTenantQuery("a", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tenants are forgotten once they have no queued or admitted queries.
+	s.free(q1)
+	for _, tn := range s.waiting() {
+		for q := s.peek(tn); q != nil; q = s.peek(tn) {
+			s.remove(q)
+		}
+	}
+	if len(s.tenants) != 0 {
+		t.Errorf("unexpected tenants of an empty scheduler: %d", len(s.tenants))
+	}
+}
+
+func TestController_Tenants(t *testing.T) {
+	sr, closeReader := newTestStorageReader(t)
+	defer closeReader()
+
+	c := New(Config{
+		ConcurrencyQuota: 2,
+		MemoryBytesQuota: math.MaxInt64,
+		ExecutorConfig: execute.Config{
+			StorageReader: sr,
+		},
+		DefaultTenantQuota: TenantQuota{
+			ConcurrencyQuota: 1,
+			MaxQueued:        1,
+		},
+	})
+	tenantA := NewContextWithTenant(context.Background(), "controller-tenant-a")
+	tenantB := NewContextWithTenant(context.Background(), "controller-tenant-b")
+	rejected := counterValue(t, tenantRejectedCounter.WithLabelValues("controller-tenant-a"))
+	admittedA := counterValue(t, tenantAdmittedCounter.WithLabelValues("controller-tenant-a"))
+
+	// The first query of tenant a uses the concurrency quota of the tenant.
+	q1, err := c.QueryWithCompile(tenantA, testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-q1.Ready; !ok {
+		t.Fatal(q1.Err())
+	}
+	if got := q1.Tenant(); got != "controller-tenant-a" {
+		t.Errorf("unexpected tenant: got %q want %q", got, "controller-tenant-a")
+	}
+
+	// The second query of tenant a waits, the third is rejected.
+	q2, err := c.QueryWithCompile(tenantA, testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q2.Done()
+	waitForState(t, q2, Requeueing)
+	q3, err := c.QueryWithCompile(tenantA, testQuery)
+	if errors.Cause(err) != ErrQueueFull {
+		t.Fatalf("unexpected error of a query of a full tenant: got %v want %v", err, ErrQueueFull)
+	}
+	if got := q3.State(); got != Errored {
+		t.Errorf("unexpected state of rejected query: got %v want %v", got, Errored)
+	}
+	if got, want := counterValue(t, tenantRejectedCounter.WithLabelValues("controller-tenant-a")), rejected+1; got != want {
+		t.Errorf("unexpected rejected queries: got %v want %v", got, want)
+	}
+
+	// Tenant b executes while tenant a waits.
+	q4, err := c.QueryWithCompile(tenantB, testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-q4.Ready; !ok {
+		t.Fatal(q4.Err())
+	}
+	q4.Done()
+
+	// The waiting query of tenant a executes once the first has finished.
+	q1.Done()
+	if _, ok := <-q2.Ready; !ok {
+		t.Fatal(q2.Err())
+	}
+	if got, want := counterValue(t, tenantAdmittedCounter.WithLabelValues("controller-tenant-a")), admittedA+2; got != want {
+		t.Errorf("unexpected admitted queries: got %v want %v", got, want)
+	}
+}
+
+func TestController_TenantMemoryQuota(t *testing.T) {
+	sr, closeReader := newTestStorageReader(t)
+	defer closeReader()
+
+	c := New(Config{
+		ConcurrencyQuota: 2,
+		MemoryBytesQuota: math.MaxInt64,
+		ExecutorConfig: execute.Config{
+			StorageReader: sr,
+		},
+		TenantQuotas: map[string]TenantQuota{
+			"small": {MemoryBytesQuota: 1024},
+		},
+	})
+	spec, err := query.Compile(context.Background(), testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec.Resources.MemoryBytesQuota = 2048
+	q, err := c.Query(NewContextWithTenant(context.Background(), "small"), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+	if _, ok := <-q.Ready; ok {
+		t.Fatal("expected a query exceeding the memory quota of its tenant to fail")
+	}
+	if err := q.Err(); err == nil || !strings.Contains(err.Error(), "memory quota of tenant") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/pkg/errors"
+)
+
+// ResultDecoder decodes annotated CSV produced by a ResultEncoder back into blocks.
+type ResultDecoder struct {
+	r     *csv.Reader
+	alloc *execute.Allocator
+	line  int
+}
+
+// NewResultDecoder creates a ResultDecoder that reads from r.
+// The allocator is used to build the decoded blocks.
+func NewResultDecoder(r io.Reader, a *execute.Allocator) *ResultDecoder {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	return &ResultDecoder{
+		r:     cr,
+		alloc: a,
+	}
+}
+
+// header describes the columns of the rows that follow it.
+type header struct {
+	cols []execute.ColMeta
+	// defaults are the values of empty fields of each column, an empty default means null.
+	// Empty fields are read as they are when it is nil.
+	defaults []string
+}
+
+// Do calls f for each decoded block in the order they were encoded, along with the name of its result.
+func (d *ResultDecoder) Do(f func(name string, b execute.Block) error) error {
+	var (
+		h         *header
+		datatypes []string
+		groups    []string
+		kinds     []string
+		defaults  []string
+
+		builder *execute.ColListBlockBuilder
+		name    string
+		blockID string
+		// first is the first row of the current block, it holds the values of the group columns.
+		first []string
+	)
+	finish := func() error {
+		if builder == nil {
+			return nil
+		}
+		b, err := builder.Block()
+		if err != nil {
+			return err
+		}
+		builder = nil
+		return f(name, b)
+	}
+
+	for {
+		record, err := d.r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		d.line++
+		if len(record) == 0 {
+			continue
+		}
+		switch record[0] {
+		case datatypeAnnotation:
+			if err := finish(); err != nil {
+				return err
+			}
+			h = nil
+			datatypes = record[1:]
+			defaults = nil
+			continue
+		case groupAnnotation:
+			groups = record[1:]
+			continue
+		case kindAnnotation:
+			kinds = record[1:]
+			continue
+		case defaultAnnotation:
+			defaults = record[1:]
+			continue
+		}
+		if strings.HasPrefix(record[0], "#") {
+			// Ignore unknown annotations
+			continue
+		}
+
+		if h == nil {
+			h, err = newHeader(record[1:], datatypes, groups, kinds, defaults)
+			if err != nil {
+				return d.wrap(err)
+			}
+			continue
+		}
+
+		if len(record) != metaCols+len(h.cols) {
+			return d.wrap(fmt.Errorf("expected %d columns, got %d", metaCols+len(h.cols), len(record)))
+		}
+		if builder == nil || record[1] != name || record[2] != blockID {
+			if err := finish(); err != nil {
+				return err
+			}
+			name = record[1]
+			blockID = record[2]
+			first = record[metaCols:]
+			builder, err = d.newBuilder(h, record)
+			if err != nil {
+				return d.wrap(err)
+			}
+		}
+		if err := appendRow(builder, h, record[metaCols:], first); err != nil {
+			return d.wrap(err)
+		}
+	}
+	return finish()
+}
+
+// Decode reads all blocks, grouping them by result name.
+func (d *ResultDecoder) Decode() (map[string][]execute.Block, error) {
+	results := make(map[string][]execute.Block)
+	err := d.Do(func(name string, b execute.Block) error {
+		results[name] = append(results[name], b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (d *ResultDecoder) wrap(err error) error {
+	return errors.Wrapf(err, "record %d", d.line)
+}
+
+func newHeader(labels, datatypes, groups, kinds, defaults []string) (*header, error) {
+	if len(labels) < metaCols-1 ||
+		labels[0] != resultLabel ||
+		labels[1] != blockLabel ||
+		labels[2] != startLabel ||
+		labels[3] != stopLabel {
+		return nil, fmt.Errorf("header must begin with %s,%s,%s,%s columns", resultLabel, blockLabel, startLabel, stopLabel)
+	}
+	n := len(labels)
+	if len(datatypes) != n || len(groups) != n || len(kinds) != n {
+		return nil, errors.New("header must be preceded by complete datatype, group and kind annotations")
+	}
+	if defaults != nil && len(defaults) != n {
+		return nil, errors.New("default annotation must be complete")
+	}
+	h := &header{
+		cols: make([]execute.ColMeta, 0, n-metaCols+1),
+	}
+	if defaults != nil {
+		h.defaults = defaults[metaCols-1:]
+	}
+	for i := metaCols - 1; i < n; i++ {
+		typ, err := decodeDatatype(datatypes[i])
+		if err != nil {
+			return nil, err
+		}
+		kind, err := decodeKind(kinds[i])
+		if err != nil {
+			return nil, err
+		}
+		common, err := strconv.ParseBool(groups[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid group annotation for column %q", labels[i])
+		}
+		h.cols = append(h.cols, execute.ColMeta{
+			Label:  labels[i],
+			Type:   typ,
+			Kind:   kind,
+			Common: common,
+		})
+	}
+	return h, nil
+}
+
+func (d *ResultDecoder) newBuilder(h *header, record []string) (*execute.ColListBlockBuilder, error) {
+	start, err := parseTime(record[3])
+	if err != nil {
+		return nil, err
+	}
+	stop, err := parseTime(record[4])
+	if err != nil {
+		return nil, err
+	}
+	builder := execute.NewColListBlockBuilder(d.alloc)
+	builder.SetBounds(execute.Bounds{
+		Start: start,
+		Stop:  stop,
+	})
+	values := record[metaCols:]
+	for j, c := range h.cols {
+		builder.AddCol(c)
+		if c.Common && c.Type == execute.TString {
+			builder.SetCommonString(j, values[j])
+		}
+	}
+	return builder, nil
+}
+
+func appendRow(builder *execute.ColListBlockBuilder, h *header, values, first []string) error {
+	for j, c := range h.cols {
+		v := values[j]
+		if v == "" && h.defaults != nil {
+			v = h.defaults[j]
+			if v == "" && !c.Common {
+				builder.AppendNil(j)
+				continue
+			}
+		}
+		switch c.Type {
+		case execute.TBool:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return err
+			}
+			builder.AppendBool(j, b)
+		case execute.TInt:
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return err
+			}
+			builder.AppendInt(j, i)
+		case execute.TUInt:
+			u, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return err
+			}
+			builder.AppendUInt(j, u)
+		case execute.TFloat:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return err
+			}
+			builder.AppendFloat(j, f)
+		case execute.TString:
+			if c.Common && v != first[j] {
+				return fmt.Errorf("value %q of group column %q differs within block", v, c.Label)
+			}
+			builder.AppendString(j, v)
+		case execute.TTime:
+			t, err := parseTime(v)
+			if err != nil {
+				return err
+			}
+			builder.AppendTime(j, t)
+		}
+	}
+	return nil
+}
+
+func decodeDatatype(dt string) (execute.DataType, error) {
+	switch {
+	case dt == boolDatatype:
+		return execute.TBool, nil
+	case dt == intDatatype:
+		return execute.TInt, nil
+	case dt == uintDatatype:
+		return execute.TUInt, nil
+	case dt == floatDatatype:
+		return execute.TFloat, nil
+	case dt == stringDatatype:
+		return execute.TString, nil
+	case dt == timeDatatypeBase || strings.HasPrefix(dt, timeDatatypeBase+":"):
+		return execute.TTime, nil
+	default:
+		return execute.TInvalid, fmt.Errorf("unknown datatype %q", dt)
+	}
+}
+
+func decodeKind(k string) (execute.ColKind, error) {
+	switch k {
+	case "time":
+		return execute.TimeColKind, nil
+	case "tag":
+		return execute.TagColKind, nil
+	case "value":
+		return execute.ValueColKind, nil
+	default:
+		return execute.InvalidColKind, fmt.Errorf("unknown column kind %q", k)
+	}
+}
+
+func parseTime(s string) (execute.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, err
+	}
+	return execute.Time(t.UnixNano()), nil
+}
@@ -0,0 +1,261 @@
+// Package csv encodes and decodes query results as annotated CSV.
+//
+// Each block is written as a set of rows sharing the same header.
+// The header is preceded by annotation rows describing the columns:
+//
+//	#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,double,string
+//	#group,false,false,true,true,false,false,true
+//	#default,,,,,,,
+//	#kind,,,,,time,value,tag
+//	,result,block,_start,_stop,_time,_value,host
+//	,_result,0,2018-01-01T00:00:00Z,2018-01-01T01:00:00Z,2018-01-01T00:00:10Z,1.5,server01
+//	,_result,0,2018-01-01T00:00:00Z,2018-01-01T01:00:00Z,2018-01-01T00:00:20Z,,server01
+//
+// The #default annotation gives the value of empty fields of each column, an empty default means null.
+// Null values are written as empty fields, so empty strings of columns that are not grouped are read as null.
+// The first column is reserved for annotations.
+// The result, block, _start and _stop columns identify the result name,
+// the block within the results and the block bounds.
+// The remaining columns are the columns of the block.
+// A new set of annotations and header is written whenever the columns change between blocks.
+// Blocks without any rows are not encoded.
+//
+// A retraction of the previously encoded blocks of a result with the same bounds and tags
+// is written as a #retract annotation of the result name, the bounds, and the tags as key=value fields:
+//
+//	#retract,_result,,2018-01-01T00:00:00Z,2018-01-01T01:00:00Z,host=server01
+//
+// The blocks that follow it with the same bounds and tags replace the retracted blocks.
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/ifql/query/execute"
+)
+
+const (
+	datatypeAnnotation = "#datatype"
+	groupAnnotation    = "#group"
+	kindAnnotation     = "#kind"
+	defaultAnnotation  = "#default"
+	retractAnnotation  = "#retract"
+
+	resultLabel = "result"
+	blockLabel  = "block"
+	startLabel  = "_start"
+	stopLabel   = "_stop"
+
+	// metaCols is the number of columns preceding the block columns.
+	metaCols = 5
+
+	boolDatatype     = "boolean"
+	intDatatype      = "long"
+	uintDatatype     = "unsignedLong"
+	floatDatatype    = "double"
+	stringDatatype   = "string"
+	timeDatatype     = "dateTime:RFC3339Nano"
+	timeDatatypeBase = "dateTime"
+)
+
+// ResultEncoder encodes results as annotated CSV.
+type ResultEncoder struct {
+	w *csv.Writer
+
+	cols  []execute.ColMeta
+	block int64
+	row   []string
+}
+
+// NewResultEncoder creates a ResultEncoder that writes to w.
+func NewResultEncoder(w io.Writer) *ResultEncoder {
+	return &ResultEncoder{
+		w: csv.NewWriter(w),
+	}
+}
+
+// Encode writes all blocks and retractions of the result, in the order they were produced.
+// The data is flushed to the underlying writer after each block.
+func (e *ResultEncoder) Encode(name string, r execute.Result) error {
+	return r.DoWithRetractions(func(b execute.Block) error {
+		return e.EncodeBlock(name, b)
+	}, func(meta execute.BlockMetadata) error {
+		return e.EncodeRetraction(name, meta)
+	})
+}
+
+// EncodeRetraction writes the retraction of the blocks of the named result with the bounds and tags of meta.
+func (e *ResultEncoder) EncodeRetraction(name string, meta execute.BlockMetadata) error {
+	bounds := meta.Bounds()
+	tags := meta.Tags()
+	keys := tags.Keys()
+
+	record := make([]string, 0, metaCols+len(keys))
+	record = append(record,
+		retractAnnotation,
+		name,
+		"",
+		formatTime(bounds.Start),
+		formatTime(bounds.Stop),
+	)
+	for _, k := range keys {
+		record = append(record, k+"="+tags[k])
+	}
+	if err := e.w.Write(record); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// EncodeBlock writes a single block of the named result.
+// Nothing is written for a block without rows.
+func (e *ResultEncoder) EncodeBlock(name string, b execute.Block) error {
+	cols := b.Cols()
+	bounds := b.Bounds()
+	// The header and the ID of the block are written with its first row.
+	var blockID string
+	start := func() error {
+		if !equalCols(e.cols, cols) {
+			if e.cols != nil {
+				// Separate the blocks with an empty line.
+				e.w.Write(nil)
+			}
+			if err := e.writeHeader(cols); err != nil {
+				return err
+			}
+			e.cols = cols
+		}
+		blockID = strconv.FormatInt(e.block, 10)
+		e.block++
+		return nil
+	}
+
+	var err error
+	b.Times().DoTime(func(ts []execute.Time, rr execute.RowReader) {
+		if err != nil || len(ts) == 0 {
+			return
+		}
+		if blockID == "" {
+			if err = start(); err != nil {
+				return
+			}
+		}
+		for i := range ts {
+			row := e.row[:0]
+			row = append(row,
+				"",
+				name,
+				blockID,
+				formatTime(bounds.Start),
+				formatTime(bounds.Stop),
+			)
+			for j, c := range cols {
+				row = append(row, formatValue(i, j, c.Type, rr))
+			}
+			e.row = row
+			if err = e.w.Write(row); err != nil {
+				return
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *ResultEncoder) writeHeader(cols []execute.ColMeta) error {
+	n := metaCols + len(cols)
+	datatypes := make([]string, 0, n)
+	groups := make([]string, 0, n)
+	defaults := make([]string, n)
+	kinds := make([]string, 0, n)
+	labels := make([]string, 0, n)
+
+	datatypes = append(datatypes, datatypeAnnotation, stringDatatype, intDatatype, timeDatatype, timeDatatype)
+	groups = append(groups, groupAnnotation, "false", "false", "true", "true")
+	kinds = append(kinds, kindAnnotation, "", "", "", "")
+	labels = append(labels, "", resultLabel, blockLabel, startLabel, stopLabel)
+	for _, c := range cols {
+		dt, err := encodeDatatype(c.Type)
+		if err != nil {
+			return err
+		}
+		datatypes = append(datatypes, dt)
+		groups = append(groups, strconv.FormatBool(c.Common))
+		kinds = append(kinds, c.Kind.String())
+		labels = append(labels, c.Label)
+	}
+	// Null values are written as empty fields.
+	defaults[0] = defaultAnnotation
+	for _, r := range [][]string{datatypes, groups, defaults, kinds, labels} {
+		if err := e.w.Write(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func equalCols(a, b []execute.ColMeta) bool {
+	if a == nil || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func encodeDatatype(t execute.DataType) (string, error) {
+	switch t {
+	case execute.TBool:
+		return boolDatatype, nil
+	case execute.TInt:
+		return intDatatype, nil
+	case execute.TUInt:
+		return uintDatatype, nil
+	case execute.TFloat:
+		return floatDatatype, nil
+	case execute.TString:
+		return stringDatatype, nil
+	case execute.TTime:
+		return timeDatatype, nil
+	default:
+		return "", fmt.Errorf("unsupported column type %v", t)
+	}
+}
+
+func formatValue(i, j int, t execute.DataType, rr execute.RowReader) string {
+	if rr.IsNull(i, j) {
+		return ""
+	}
+	switch t {
+	case execute.TBool:
+		return strconv.FormatBool(rr.AtBool(i, j))
+	case execute.TInt:
+		return strconv.FormatInt(rr.AtInt(i, j), 10)
+	case execute.TUInt:
+		return strconv.FormatUint(rr.AtUInt(i, j), 10)
+	case execute.TFloat:
+		return strconv.FormatFloat(rr.AtFloat(i, j), 'f', -1, 64)
+	case execute.TString:
+		return rr.AtString(i, j)
+	case execute.TTime:
+		return formatTime(rr.AtTime(i, j))
+	default:
+		execute.PanicUnknownType(t)
+		return ""
+	}
+}
+
+func formatTime(t execute.Time) string {
+	return t.Time().UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,347 @@
+package csv_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/query/csv"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+)
+
+var cpuCols = []execute.ColMeta{
+	{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+	{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+	{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+	{Label: "cpu", Type: execute.TString, Kind: execute.TagColKind},
+}
+
+var bounds = execute.Bounds{
+	Start: 0,
+	Stop:  60e9,
+}
+
+type namedBlock struct {
+	name  string
+	block *executetest.Block
+}
+
+var testBlocks = []namedBlock{
+	{
+		name: "_result",
+		block: &executetest.Block{
+			Bnds:    bounds,
+			ColMeta: cpuCols,
+			Data: [][]interface{}{
+				{execute.Time(10e9), 1.5, "a", "cpu0"},
+				{execute.Time(20e9), 2.0, "a", "cpu1"},
+			},
+		},
+	},
+	{
+		name: "_result",
+		block: &executetest.Block{
+			Bnds:    bounds,
+			ColMeta: cpuCols,
+			Data: [][]interface{}{
+				{execute.Time(10e9), 3.25, "b, c", "cpu0"},
+			},
+		},
+	},
+	{
+		name: "other",
+		block: &executetest.Block{
+			Bnds: bounds,
+			ColMeta: []execute.ColMeta{
+				{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+				{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+				{Label: "ok", Type: execute.TBool, Kind: execute.ValueColKind},
+				{Label: "n", Type: execute.TUInt, Kind: execute.ValueColKind},
+			},
+			Data: [][]interface{}{
+				{execute.Time(60e9), int64(-4), true, uint64(math.MaxUint64)},
+			},
+		},
+	},
+}
+
+const testCSV = `#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,double,string,string
+#group,false,false,true,true,false,false,true,false
+#default,,,,,,,,
+#kind,,,,,time,value,tag,tag
+,result,block,_start,_stop,_time,_value,host,cpu
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,1.5,a,cpu0
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:20Z,2,a,cpu1
+,_result,1,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,3.25,"b, c",cpu0
+
+#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,long,boolean,unsignedLong
+#group,false,false,true,true,false,false,false,false
+#default,,,,,,,,
+#kind,,,,,time,value,value,value
+,result,block,_start,_stop,_time,_value,ok,n
+,other,2,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:01:00Z,-4,true,18446744073709551615
+`
+
+func TestResultEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := csv.NewResultEncoder(&buf)
+	for _, nb := range testBlocks {
+		if err := enc.EncodeBlock(nb.name, nb.block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := buf.String(); got != testCSV {
+		t.Errorf("unexpected csv -want/+got\n%s", cmp.Diff(testCSV, got))
+	}
+}
+
+func TestResultEncoder_EmptyBlocks(t *testing.T) {
+	empty := func(cols []execute.ColMeta) *executetest.Block {
+		return &executetest.Block{
+			Bnds:    bounds,
+			ColMeta: cols,
+		}
+	}
+	var buf bytes.Buffer
+	enc := csv.NewResultEncoder(&buf)
+	// Empty blocks write neither a header nor a block ID, even when their columns differ.
+	blocks := []namedBlock{
+		{name: "_result", block: empty(cpuCols[:2])},
+		testBlocks[0],
+		{name: "_result", block: empty(cpuCols)},
+		testBlocks[1],
+		{name: "other", block: empty(cpuCols[:2])},
+		testBlocks[2],
+	}
+	for _, nb := range blocks {
+		if err := enc.EncodeBlock(nb.name, nb.block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := buf.String(); got != testCSV {
+		t.Errorf("unexpected csv -want/+got\n%s", cmp.Diff(testCSV, got))
+	}
+}
+
+func TestResultDecoder(t *testing.T) {
+	dec := csv.NewResultDecoder(bytes.NewBufferString(testCSV), &execute.Allocator{Limit: math.MaxInt64})
+
+	var got []namedBlock
+	if err := dec.Do(func(name string, b execute.Block) error {
+		got = append(got, namedBlock{name: name, block: executetest.ConvertBlock(b)})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(testBlocks, got, cmp.AllowUnexported(namedBlock{})) {
+		t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(testBlocks, got, cmp.AllowUnexported(namedBlock{})))
+	}
+}
+
+func TestResultDecoder_Errors(t *testing.T) {
+	testCases := []struct {
+		name string
+		csv  string
+	}{
+		{
+			name: "missing annotations",
+			csv: `,result,block,_start,_stop,_time,_value
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,1.5
+`,
+		},
+		{
+			name: "bad value",
+			csv: `#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,double
+#group,false,false,true,true,false,false
+#kind,,,,,time,value
+,result,block,_start,_stop,_time,_value
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,abc
+`,
+		},
+		{
+			name: "group value changes",
+			csv: `#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,double,string
+#group,false,false,true,true,false,false,true
+#kind,,,,,time,value,tag
+,result,block,_start,_stop,_time,_value,host
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,1.5,a
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:20Z,1.5,b
+`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dec := csv.NewResultDecoder(bytes.NewBufferString(tc.csv), &execute.Allocator{Limit: math.MaxInt64})
+			if _, err := dec.Decode(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+// retractingResult is a result of blocks and retractions, in order.
+type retractingResult struct {
+	execute.Result
+	// events are the blocks and retractions of the result, an *executetest.Block is a block,
+	// any other execute.BlockMetadata is a retraction.
+	events []execute.BlockMetadata
+}
+
+func (r *retractingResult) DoWithRetractions(f func(execute.Block) error, retract func(execute.BlockMetadata) error) error {
+	for _, e := range r.events {
+		var err error
+		if b, ok := e.(*executetest.Block); ok {
+			err = f(b)
+		} else {
+			err = retract(e)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type blockMetadata struct {
+	bounds execute.Bounds
+	tags   execute.Tags
+}
+
+func (m blockMetadata) Bounds() execute.Bounds { return m.bounds }
+func (m blockMetadata) Tags() execute.Tags     { return m.tags }
+
+func TestResultEncoder_Retractions(t *testing.T) {
+	r := &retractingResult{
+		events: []execute.BlockMetadata{
+			testBlocks[0].block,
+			blockMetadata{bounds: bounds, tags: execute.Tags{"host": "a"}},
+			testBlocks[1].block,
+		},
+	}
+	var buf bytes.Buffer
+	if err := csv.NewResultEncoder(&buf).Encode("_result", r); err != nil {
+		t.Fatal(err)
+	}
+	want := `#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,double,string,string
+#group,false,false,true,true,false,false,true,false
+#default,,,,,,,,
+#kind,,,,,time,value,tag,tag
+,result,block,_start,_stop,_time,_value,host,cpu
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,1.5,a,cpu0
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:20Z,2,a,cpu1
+#retract,_result,,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,host=a
+,_result,1,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,3.25,"b, c",cpu0
+`
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected csv -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	// The decoder skips retractions.
+	dec := csv.NewResultDecoder(bytes.NewBufferString(want), &execute.Allocator{Limit: math.MaxInt64})
+	n := 0
+	if err := dec.Do(func(string, execute.Block) error {
+		n++
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("unexpected number of decoded blocks: got %d want 2", n)
+	}
+}
+
+func TestResultEncoder_Nulls(t *testing.T) {
+	block := &executetest.Block{
+		Bnds: bounds,
+		ColMeta: []execute.ColMeta{
+			{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+			{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+			{Label: "ok", Type: execute.TBool, Kind: execute.ValueColKind},
+			{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+			{Label: "cpu", Type: execute.TString, Kind: execute.TagColKind},
+		},
+		Data: [][]interface{}{
+			{execute.Time(10e9), 1.5, nil, "a", "cpu0"},
+			{execute.Time(20e9), nil, true, "a", nil},
+		},
+	}
+	want := `#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,double,boolean,string,string
+#group,false,false,true,true,false,false,false,true,false
+#default,,,,,,,,,
+#kind,,,,,time,value,value,tag,tag
+,result,block,_start,_stop,_time,_value,ok,host,cpu
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,1.5,,a,cpu0
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:20Z,,true,a,
+`
+	var buf bytes.Buffer
+	if err := csv.NewResultEncoder(&buf).EncodeBlock("_result", block); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected csv -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	dec := csv.NewResultDecoder(&buf, &execute.Allocator{Limit: math.MaxInt64})
+	results, err := dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results["_result"]) != 1 {
+		t.Fatalf("unexpected number of decoded blocks: %d", len(results["_result"]))
+	}
+	if got := executetest.ConvertBlock(results["_result"][0]); !cmp.Equal(block, got) {
+		t.Errorf("unexpected decoded block -want/+got\n%s", cmp.Diff(block, got))
+	}
+}
+
+func TestResultDecoder_Defaults(t *testing.T) {
+	// Without a default annotation empty strings are read as they are, and other empty values are invalid.
+	data := `#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,long,string
+#group,false,false,true,true,false,false,false
+#kind,,,,,time,value,tag
+,result,block,_start,_stop,_time,_value,cpu
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,1,
+`
+	dec := csv.NewResultDecoder(bytes.NewBufferString(data), &execute.Allocator{Limit: math.MaxInt64})
+	results, err := dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &executetest.Block{
+		Bnds: bounds,
+		ColMeta: []execute.ColMeta{
+			{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+			{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+			{Label: "cpu", Type: execute.TString, Kind: execute.TagColKind},
+		},
+		Data: [][]interface{}{
+			{execute.Time(10e9), int64(1), ""},
+		},
+	}
+	if got := executetest.ConvertBlock(results["_result"][0]); !cmp.Equal(want, got) {
+		t.Errorf("unexpected decoded block -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	// Non empty defaults replace empty fields.
+	data = `#datatype,string,long,dateTime:RFC3339Nano,dateTime:RFC3339Nano,dateTime:RFC3339Nano,long,string
+#group,false,false,true,true,false,false,false
+#default,,,,,,7,cpu-total
+#kind,,,,,time,value,tag
+,result,block,_start,_stop,_time,_value,cpu
+,_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,,
+`
+	dec = csv.NewResultDecoder(bytes.NewBufferString(data), &execute.Allocator{Limit: math.MaxInt64})
+	results, err = dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Data = [][]interface{}{
+		{execute.Time(10e9), int64(7), "cpu-total"},
+	}
+	if got := executetest.ConvertBlock(results["_result"][0]); !cmp.Equal(want, got) {
+		t.Errorf("unexpected decoded block -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
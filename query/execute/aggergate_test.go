@@ -63,6 +63,42 @@ func TestAggregate_Process(t *testing.T) {
 				}}
 			},
 		},
+		{
+			name: "nulls",
+			bounds: execute.Bounds{
+				Start: 0,
+				Stop:  100,
+			},
+			agg: countAgg,
+			data: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  100,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 0.0},
+					{execute.Time(10), nil},
+					{execute.Time(20), 2.0},
+					{execute.Time(30), nil},
+				},
+			}},
+			want: func(b execute.Bounds) []*executetest.Block {
+				return []*executetest.Block{{
+					Bnds: b,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(100), int64(2)},
+					},
+				}}
+			},
+		},
 		{
 			name: "multiple blocks",
 			bounds: execute.Bounds{
@@ -356,7 +392,7 @@ func TestAggregate_Process(t *testing.T) {
 			c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
 			c.SetTriggerSpec(execute.DefaultTriggerSpec)
 
-			agg := execute.NewAggregateTransformation(d, c, tc.bounds, tc.agg)
+			agg := execute.NewAggregateTransformation(d, c, execute.AccumulatingMode, tc.bounds, tc.agg)
 
 			parentID := executetest.RandomDatasetID()
 			for _, b := range tc.data {
@@ -377,3 +413,189 @@ func TestAggregate_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregate_Retract(t *testing.T) {
+	bounds := execute.Bounds{
+		Start: 0,
+		Stop:  200,
+	}
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+	}
+	window := func(start, stop execute.Time, values ...float64) *executetest.Block {
+		b := &executetest.Block{
+			Bnds: execute.Bounds{
+				Start: start,
+				Stop:  stop,
+			},
+			ColMeta: cols,
+		}
+		for i, v := range values {
+			b.Data = append(b.Data, []interface{}{start + execute.Time(i), v})
+		}
+		return b
+	}
+	outKey := execute.ToBlockKey(&executetest.Block{Bnds: bounds})
+
+	type step struct {
+		block   *executetest.Block
+		retract bool
+	}
+	testCases := []struct {
+		name        string
+		mode        execute.AccumulationMode
+		steps       []step
+		want        []*executetest.Block
+		retractions []execute.BlockKey
+	}{
+		{
+			name: "accumulating replaces rows",
+			mode: execute.AccumulatingMode,
+			steps: []step{
+				{block: window(0, 100, 1, 2)},
+				{block: window(100, 200, 10)},
+				{block: window(0, 100, 1, 2, 3)},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(100), 6.0},
+					{execute.Time(200), 10.0},
+				},
+			}},
+		},
+		{
+			name: "discarding accumulates rows",
+			mode: execute.DiscardingMode,
+			steps: []step{
+				{block: window(0, 100, 1, 2)},
+				{block: window(0, 100, 3)},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(100), 6.0},
+				},
+			}},
+		},
+		{
+			name: "retract",
+			mode: execute.AccumulatingRetractingMode,
+			steps: []step{
+				{block: window(0, 100, 1, 2)},
+				{block: window(100, 200, 10)},
+				{block: window(0, 100), retract: true},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(200), 10.0},
+				},
+			}},
+			retractions: []execute.BlockKey{outKey},
+		},
+		{
+			name: "retract and correct",
+			mode: execute.AccumulatingRetractingMode,
+			steps: []step{
+				{block: window(0, 100, 1, 2)},
+				{block: window(100, 200, 10)},
+				{block: window(0, 100), retract: true},
+				{block: window(0, 100, 1, 2, 3)},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(200), 10.0},
+					{execute.Time(100), 6.0},
+				},
+			}},
+			retractions: []execute.BlockKey{outKey},
+		},
+		{
+			name: "retract unknown block",
+			mode: execute.AccumulatingRetractingMode,
+			steps: []step{
+				{block: window(0, 100, 1, 2)},
+				{block: window(100, 200), retract: true},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(100), 3.0},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+
+			agg := execute.NewAggregateTransformation(d, c, tc.mode, bounds, new(functions.SumAgg))
+
+			parentID := executetest.RandomDatasetID()
+			for _, s := range tc.steps {
+				if s.retract {
+					if err := agg.RetractBlock(parentID, s.block); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				if err := agg.Process(parentID, s.block); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := executetest.BlocksFromCache(c)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+			if !cmp.Equal(tc.retractions, d.Retractions) {
+				t.Errorf("unexpected retractions -want/+got\n%s", cmp.Diff(tc.retractions, d.Retractions))
+			}
+		})
+	}
+}
+
+func BenchmarkAggregate_Windows(b *testing.B) {
+	const windows = 2000
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+	}
+	blocks := make([]*executetest.Block, windows)
+	for i := range blocks {
+		start := execute.Time(i * 10)
+		blocks[i] = &executetest.Block{
+			Bnds:    execute.Bounds{Start: start, Stop: start + 10},
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{start, 1.0},
+				{start + 5, 2.0},
+			},
+		}
+	}
+	bounds := execute.Bounds{Start: 0, Stop: windows * 10}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		d := executetest.NewDataset(executetest.RandomDatasetID())
+		c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+		c.SetTriggerSpec(execute.DefaultTriggerSpec)
+		agg := execute.NewAggregateTransformation(d, c, execute.AccumulatingMode, bounds, new(functions.SumAgg))
+		parentID := executetest.RandomDatasetID()
+		for _, blk := range blocks {
+			if err := agg.Process(parentID, blk); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -1,38 +1,111 @@
 package execute
 
+import (
+	"fmt"
+	"sort"
+)
+
 type aggregateTransformation struct {
 	d      Dataset
 	cache  BlockBuilderCache
+	mode   AccumulationMode
 	bounds Bounds
 	agg    Aggregate
+
+	// blocks holds the intermediate state of each output block.
+	blocks map[BlockKey]*aggregateBlock
+}
+
+// aggregateBlock is the intermediate state of an output block.
+// Each input block contributes a single row to the output block.
+type aggregateBlock struct {
+	partialBlock
+	// rows holds the aggregate state of each input block.
+	rows map[BlockKey]*aggregateRow
+}
+
+type aggregateRow struct {
+	time Time
+	// aggs holds the aggregate of each column of the output block, it is nil for non value columns.
+	aggs []ValueFunc
+}
+
+// release releases the aggregates of the row, whose state is being discarded.
+func (r *aggregateRow) release() {
+	for _, vf := range r.aggs {
+		if rf, ok := vf.(ReleaseValueFunc); ok {
+			rf.Release()
+		}
+	}
 }
 
-func NewAggregateTransformation(d Dataset, c BlockBuilderCache, bounds Bounds, agg Aggregate) *aggregateTransformation {
+// release releases the aggregates of all rows of the block, whose state is being discarded.
+func (ab *aggregateBlock) release() {
+	for _, row := range ab.rows {
+		row.release()
+	}
+}
+
+func NewAggregateTransformation(d Dataset, c BlockBuilderCache, mode AccumulationMode, bounds Bounds, agg Aggregate) *aggregateTransformation {
 	return &aggregateTransformation{
 		d:      d,
 		cache:  c,
+		mode:   mode,
 		bounds: bounds,
 		agg:    agg,
+		blocks: make(map[BlockKey]*aggregateBlock),
 	}
 }
 
 func NewAggregateTransformationAndDataset(id DatasetID, mode AccumulationMode, bounds Bounds, agg Aggregate, a *Allocator) (*aggregateTransformation, Dataset) {
 	cache := NewBlockBuilderCache(a)
 	d := NewDataset(id, mode, cache)
-	return NewAggregateTransformation(d, cache, bounds, agg), d
+	return NewAggregateTransformation(d, cache, mode, bounds, agg), d
 }
 
 func (t *aggregateTransformation) RetractBlock(id DatasetID, meta BlockMetadata) error {
-	//TODO(nathanielc): Store intermediate state for retractions
-	key := ToBlockKey(meta)
-	return t.d.RetractBlock(key)
+	key := ToBlockKey(blockMetadata{
+		bounds: t.bounds,
+		tags:   meta.Tags(),
+	})
+	ab, ok := t.blocks[key]
+	if !ok {
+		return nil
+	}
+	builder, ok := lookupBlockBuilder(t.cache, key)
+	if !ok {
+		// The output block has expired, so its state is no longer needed.
+		ab.release()
+		delete(t.blocks, key)
+		return nil
+	}
+	inKey := ToBlockKey(meta)
+	row, ok := ab.rows[inKey]
+	if !ok {
+		return nil
+	}
+	row.release()
+	delete(ab.rows, inKey)
+	ab.sync(builder)
+	ab.remove(inKey)
+
+	// Retract the output block and rebuild it from the remaining rows,
+	// the corrected block is emitted the next time the block is triggered.
+	if err := t.d.RetractBlock(key); err != nil {
+		return err
+	}
+	t.build(builder, ab)
+	return nil
 }
 
 func (t *aggregateTransformation) Process(id DatasetID, b Block) error {
-	builder, new := t.cache.BlockBuilder(blockMetadata{
+	meta := blockMetadata{
 		bounds: t.bounds,
 		tags:   b.Tags(),
-	})
+	}
+	key := ToBlockKey(meta)
+	ra, rows := t.agg.(RowsAggregate)
+	builder, new := t.cache.BlockBuilder(meta)
 	if new {
 		cols := b.Cols()
 		for j, c := range cols {
@@ -41,10 +114,12 @@ func (t *aggregateTransformation) Process(id DatasetID, b Block) error {
 				builder.AddCol(c)
 			case TagColKind:
 				if c.Common {
-					builder.AddCol(c)
-					builder.SetCommonString(j, b.Tags()[c.Label])
+					builder.SetCommonString(builder.AddCol(c), b.Tags()[c.Label])
 				}
 			case ValueColKind:
+				if rows {
+					continue
+				}
 				var vf ValueFunc
 				switch c.Type {
 				case TBool:
@@ -58,57 +133,261 @@ func (t *aggregateTransformation) Process(id DatasetID, b Block) error {
 				case TString:
 					vf = t.agg.NewStringAgg()
 				}
+				if vf == nil {
+					return fmt.Errorf("cannot aggregate column %q of type %v", c.Label, c.Type)
+				}
 				builder.AddCol(ColMeta{
-					Label: c.Label,
+					Label: cols[j].Label,
 					Type:  vf.Type(),
 					Kind:  ValueColKind,
 				})
 			}
 		}
+		if rows {
+			for _, c := range ra.Cols() {
+				builder.AddCol(c)
+			}
+		}
+		// Any previous state belongs to a block that has expired.
+		if ab, ok := t.blocks[key]; ok {
+			ab.release()
+			delete(t.blocks, key)
+		}
+	}
+	ab, ok := t.blocks[key]
+	if !ok {
+		ab = &aggregateBlock{
+			rows: make(map[BlockKey]*aggregateRow),
+		}
+		t.blocks[key] = ab
 	}
-	// Add row for aggregate values
-	timeIdx := TimeIdx(builder.Cols())
-	builder.AppendTime(timeIdx, b.Bounds().Stop)
 
-	for j, c := range b.Cols() {
+	cols := builder.Cols()
+	inKey := ToBlockKey(b)
+	row, ok := ab.rows[inKey]
+	if !ok || t.mode != DiscardingMode {
+		// Unless discarding, a block contains all the data of the previous blocks with the same key,
+		// so the aggregates are computed anew.
+		if ok {
+			row.release()
+		}
+		row = &aggregateRow{
+			aggs: make([]ValueFunc, len(cols)),
+		}
+		ab.rows[inKey] = row
+	}
+	row.time = b.Bounds().Stop
+
+	// The values of a rows aggregate are held by its first column.
+	first := true
+	for j, c := range cols {
 		if c.Kind != ValueColKind {
 			continue
 		}
+		label := c.Label
+		if rows {
+			if !first {
+				continue
+			}
+			first = false
+			label = ra.Column()
+		}
+		bj := ColIdx(label, b.Cols())
+		if bj < 0 {
+			if rows {
+				return fmt.Errorf("aggregate column %q does not exist", label)
+			}
+			continue
+		}
 
 		// TODO(nathanielc): This reads the block multiple times (once per value column), is that OK?
-		values := b.Col(j)
-		var vf ValueFunc
-		switch c.Type {
+		values := b.Col(bj)
+		switch b.Cols()[bj].Type {
 		case TBool:
-			f := t.agg.NewBoolAgg()
-			values.DoBool(func(vs []bool, _ RowReader) {
-				f.DoBool(vs)
+			f, _ := row.aggs[j].(DoBoolAgg)
+			if f == nil {
+				f = t.agg.NewBoolAgg()
+				if f == nil {
+					return fmt.Errorf("cannot aggregate column %q of type %v", label, b.Cols()[bj].Type)
+				}
+				row.aggs[j] = f
+			}
+			values.DoBool(func(vs []bool, rr RowReader) {
+				f.DoBool(nonNullBools(vs, nonNullRows(rr, bj, len(vs))))
 			})
-			vf = f
 		case TInt:
-			f := t.agg.NewIntAgg()
-			values.DoInt(func(vs []int64, _ RowReader) {
-				f.DoInt(vs)
+			f, _ := row.aggs[j].(DoIntAgg)
+			if f == nil {
+				f = t.agg.NewIntAgg()
+				if f == nil {
+					return fmt.Errorf("cannot aggregate column %q of type %v", label, b.Cols()[bj].Type)
+				}
+				row.aggs[j] = f
+			}
+			values.DoInt(func(vs []int64, rr RowReader) {
+				f.DoInt(nonNullInts(vs, nonNullRows(rr, bj, len(vs))))
 			})
-			vf = f
 		case TUInt:
-			f := t.agg.NewUIntAgg()
-			values.DoUInt(func(vs []uint64, _ RowReader) {
-				f.DoUInt(vs)
+			f, _ := row.aggs[j].(DoUIntAgg)
+			if f == nil {
+				f = t.agg.NewUIntAgg()
+				if f == nil {
+					return fmt.Errorf("cannot aggregate column %q of type %v", label, b.Cols()[bj].Type)
+				}
+				row.aggs[j] = f
+			}
+			values.DoUInt(func(vs []uint64, rr RowReader) {
+				f.DoUInt(nonNullUInts(vs, nonNullRows(rr, bj, len(vs))))
 			})
-			vf = f
 		case TFloat:
-			f := t.agg.NewFloatAgg()
-			values.DoFloat(func(vs []float64, _ RowReader) {
-				f.DoFloat(vs)
+			f, _ := row.aggs[j].(DoFloatAgg)
+			if f == nil {
+				f = t.agg.NewFloatAgg()
+				if f == nil {
+					return fmt.Errorf("cannot aggregate column %q of type %v", label, b.Cols()[bj].Type)
+				}
+				row.aggs[j] = f
+			}
+			values.DoFloat(func(vs []float64, rr RowReader) {
+				f.DoFloat(nonNullFloats(vs, nonNullRows(rr, bj, len(vs))))
 			})
-			vf = f
 		case TString:
-			f := t.agg.NewStringAgg()
-			values.DoString(func(vs []string, _ RowReader) {
-				f.DoString(vs)
+			f, _ := row.aggs[j].(DoStringAgg)
+			if f == nil {
+				f = t.agg.NewStringAgg()
+				if f == nil {
+					return fmt.Errorf("cannot aggregate column %q of type %v", label, b.Cols()[bj].Type)
+				}
+				row.aggs[j] = f
+			}
+			values.DoString(func(vs []string, rr RowReader) {
+				f.DoString(nonNullStrings(vs, nonNullRows(rr, bj, len(vs))))
 			})
-			vf = f
+		}
+	}
+
+	ab.sync(builder)
+	if ab.isPending(inKey) {
+		// The row of the input block in the builder is outdated.
+		t.build(builder, ab)
+		return nil
+	}
+	ab.add(inKey)
+	t.appendRow(builder, row)
+	ab.nrows = builder.NRows()
+	return nil
+}
+
+// nonNullRows returns the indexes of the rows of rr whose value in column j is not null.
+// It returns nil when none of the first n rows are null.
+// The nonNull* functions filter the values of the column to those rows.
+func nonNullRows(rr RowReader, j, n int) []int {
+	var rows []int
+	for i := 0; i < n; i++ {
+		if rr.IsNull(i, j) {
+			if rows == nil {
+				rows = make([]int, 0, n)
+				for k := 0; k < i; k++ {
+					rows = append(rows, k)
+				}
+			}
+			continue
+		}
+		if rows != nil {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+func nonNullBools(vs []bool, rows []int) []bool {
+	if rows == nil {
+		return vs
+	}
+	filtered := make([]bool, len(rows))
+	for k, i := range rows {
+		filtered[k] = vs[i]
+	}
+	return filtered
+}
+
+func nonNullInts(vs []int64, rows []int) []int64 {
+	if rows == nil {
+		return vs
+	}
+	filtered := make([]int64, len(rows))
+	for k, i := range rows {
+		filtered[k] = vs[i]
+	}
+	return filtered
+}
+
+func nonNullUInts(vs []uint64, rows []int) []uint64 {
+	if rows == nil {
+		return vs
+	}
+	filtered := make([]uint64, len(rows))
+	for k, i := range rows {
+		filtered[k] = vs[i]
+	}
+	return filtered
+}
+
+func nonNullFloats(vs []float64, rows []int) []float64 {
+	if rows == nil {
+		return vs
+	}
+	filtered := make([]float64, len(rows))
+	for k, i := range rows {
+		filtered[k] = vs[i]
+	}
+	return filtered
+}
+
+func nonNullStrings(vs []string, rows []int) []string {
+	if rows == nil {
+		return vs
+	}
+	filtered := make([]string, len(rows))
+	for k, i := range rows {
+		filtered[k] = vs[i]
+	}
+	return filtered
+}
+
+// build replaces the rows of the builder with the rows of the pending input blocks.
+func (t *aggregateTransformation) build(builder BlockBuilder, ab *aggregateBlock) {
+	builder.ClearData()
+	for _, k := range ab.keys() {
+		t.appendRow(builder, ab.rows[k])
+	}
+	ab.nrows = builder.NRows()
+}
+
+// appendRow appends the values of the aggregates of the row to the builder.
+func (t *aggregateTransformation) appendRow(builder BlockBuilder, row *aggregateRow) {
+	cols := builder.Cols()
+	timeIdx := TimeIdx(cols)
+	if ra, ok := t.agg.(RowsAggregate); ok {
+		rowCols := make([]int, len(ra.Cols()))
+		for i, c := range ra.Cols() {
+			rowCols[i] = ColIdx(c.Label, cols)
+		}
+		for _, vf := range row.aggs {
+			if vf == nil {
+				continue
+			}
+			n := vf.(RowsValueFunc).AppendRows(builder, rowCols)
+			for i := 0; i < n; i++ {
+				builder.AppendTime(timeIdx, row.time)
+			}
+		}
+		return
+	}
+	builder.AppendTime(timeIdx, row.time)
+	for j, vf := range row.aggs {
+		if vf == nil {
+			continue
 		}
 		switch vf.Type() {
 		case TBool:
@@ -128,11 +407,43 @@ func (t *aggregateTransformation) Process(id DatasetID, b Block) error {
 			builder.AppendString(j, v.ValueString())
 		}
 	}
-	return nil
 }
 
 func (t *aggregateTransformation) UpdateWatermark(id DatasetID, mark Time) error {
-	return t.d.UpdateWatermark(mark)
+	if err := t.d.UpdateWatermark(mark); err != nil {
+		return err
+	}
+	if t.mode == DiscardingMode {
+		t.evict(mark)
+	}
+	return nil
+}
+
+// evict removes the state of the input blocks that have been emitted and that the watermark has passed,
+// as well as the state of output blocks that have expired.
+// Late data for an evicted input block is aggregated into a new row.
+func (t *aggregateTransformation) evict(mark Time) {
+	live := make(map[BlockKey]bool, len(t.blocks))
+	t.cache.ForEachBuilder(func(key BlockKey, builder BlockBuilder) {
+		ab, ok := t.blocks[key]
+		if !ok {
+			return
+		}
+		live[key] = true
+		ab.sync(builder)
+		for k, row := range ab.rows {
+			if row.time <= mark && !ab.isPending(k) {
+				row.release()
+				delete(ab.rows, k)
+			}
+		}
+	})
+	for key, ab := range t.blocks {
+		if !live[key] {
+			ab.release()
+			delete(t.blocks, key)
+		}
+	}
 }
 func (t *aggregateTransformation) UpdateProcessingTime(id DatasetID, pt Time) error {
 	return t.d.UpdateProcessingTime(pt)
@@ -141,6 +452,9 @@ func (t *aggregateTransformation) Finish(id DatasetID, err error) {
 	t.d.Finish(err)
 }
 
+// Aggregate creates the aggregation functions of each type.
+// The state of every input row is kept to support retractions,
+// so each call to a New*Agg method must return a fresh instance that does not share state with the others.
 type Aggregate interface {
 	NewBoolAgg() DoBoolAgg
 	NewIntAgg() DoIntAgg
@@ -152,6 +466,33 @@ type Aggregate interface {
 type ValueFunc interface {
 	Type() DataType
 }
+
+// RowsAggregate is implemented by aggregates of a single column that compute several rows
+// for each input block, such as the buckets of a histogram.
+// The value columns of the output blocks are its columns instead of the value columns of the input blocks,
+// and its value functions implement RowsValueFunc.
+type RowsAggregate interface {
+	Aggregate
+	// Column is the label of the column being aggregated.
+	Column() string
+	// Cols are the value columns of the output blocks.
+	Cols() []ColMeta
+}
+
+// RowsValueFunc is a value function of a RowsAggregate.
+type RowsValueFunc interface {
+	ValueFunc
+	// AppendRows appends the values of the rows of the aggregate to the builder, and returns the number of rows.
+	// The columns of the aggregate are at the indexes cols of the builder.
+	AppendRows(builder BlockBuilder, cols []int) int
+}
+
+// ReleaseValueFunc is implemented by aggregates that hold memory or files,
+// Release is called once the state of the aggregate is discarded.
+type ReleaseValueFunc interface {
+	ValueFunc
+	Release()
+}
 type DoBoolAgg interface {
 	ValueFunc
 	DoBool([]bool)
@@ -188,3 +529,71 @@ type UIntValueFunc interface {
 type StringValueFunc interface {
 	ValueString() string
 }
+
+// partialBlock tracks which input blocks have rows in the builder of an output block.
+// Transformations that keep intermediate state per input block append the rows of new input blocks to the builder,
+// and rebuild their output blocks when the state of an input block that already has rows changes.
+type partialBlock struct {
+	// pending holds the order in which the input blocks whose rows are in the builder were added.
+	pending map[BlockKey]int
+	// next is the order of the next input block to be added.
+	next int
+	// nrows is the number of rows the builder had when rows were last added.
+	nrows int
+}
+
+// sync accounts for changes made to the builder by its dataset.
+// A dataset clears the builder when it discards or retracts the block,
+// in which case the rows of the previous input blocks are no longer pending.
+func (p *partialBlock) sync(builder BlockBuilder) {
+	if builder.NRows() != p.nrows {
+		p.pending = nil
+		p.nrows = 0
+	}
+}
+
+// add records that the input block has rows in the builder.
+func (p *partialBlock) add(key BlockKey) {
+	if p.pending == nil {
+		p.pending = make(map[BlockKey]int)
+	}
+	p.pending[key] = p.next
+	p.next++
+}
+
+func (p *partialBlock) isPending(key BlockKey) bool {
+	_, ok := p.pending[key]
+	return ok
+}
+
+// remove records that the input block no longer has rows in the builder.
+func (p *partialBlock) remove(key BlockKey) {
+	delete(p.pending, key)
+}
+
+// keys returns the pending input blocks in the order in which they were added.
+func (p *partialBlock) keys() []BlockKey {
+	keys := make([]BlockKey, 0, len(p.pending))
+	for k := range p.pending {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return p.pending[keys[i]] < p.pending[keys[j]]
+	})
+	return keys
+}
+
+// lookupBlockBuilder returns the builder for the key if it exists in the cache.
+func lookupBlockBuilder(c BlockBuilderCache, key BlockKey) (BlockBuilder, bool) {
+	if bc, ok := c.(*blockBuilderCache); ok {
+		b, ok := bc.blocks[key]
+		return b.builder, ok
+	}
+	var builder BlockBuilder
+	c.ForEachBuilder(func(k BlockKey, b BlockBuilder) {
+		if k == key {
+			builder = b
+		}
+	})
+	return builder, builder != nil
+}
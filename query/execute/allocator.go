@@ -18,7 +18,11 @@ const (
 // The allocator provides methods similar to make and append, to allocate large slices of data.
 // The allocator also provides a Free method to account for when memory will be freed.
 type Allocator struct {
-	Limit          int64
+	Limit int64
+	// SpillDir is the directory where transformations write data to disk when the allocator nears its limit.
+	// Data is not spilled when it is empty.
+	SpillDir string
+
 	bytesAllocated int64
 	maxAllocated   int64
 }
@@ -43,6 +47,11 @@ func (a *Allocator) Max() int64 {
 	return atomic.LoadInt64(&a.maxAllocated)
 }
 
+// NearLimit reports whether data should be spilled to disk, because the allocated memory nears the limit.
+func (a *Allocator) NearLimit() bool {
+	return a.SpillDir != "" && atomic.LoadInt64(&a.bytesAllocated) >= a.Limit-a.Limit/4
+}
+
 func (a *Allocator) account(n, size int) {
 	if want := a.count(n, size); want > a.Limit {
 		allocated := a.count(-n, size)
@@ -131,6 +131,10 @@ func AppendBlock(b Block, builder BlockBuilder, colMap []int) {
 				continue
 			}
 			for i := range ts {
+				if rr.IsNull(i, colMap[j]) {
+					builder.AppendNil(j)
+					continue
+				}
 				switch c.Type {
 				case TBool:
 					builder.AppendBool(j, rr.AtBool(i, colMap[j]))
@@ -156,6 +160,10 @@ func AppendBlock(b Block, builder BlockBuilder, colMap []int) {
 // The colMap is a map of builder columnm index to rr column index.
 func AppendRow(i int, rr RowReader, builder BlockBuilder, colMap []int) {
 	for j, c := range builder.Cols() {
+		if rr.IsNull(i, colMap[j]) {
+			builder.AppendNil(j)
+			continue
+		}
 		switch c.Type {
 		case TBool:
 			builder.AppendBool(j, rr.AtBool(i, colMap[j]))
@@ -179,6 +187,10 @@ func AppendRow(i int, rr RowReader, builder BlockBuilder, colMap []int) {
 // The colMap is a map of builder columnm index to rr column index.
 func AppendRowForCols(i int, rr RowReader, builder BlockBuilder, cols []ColMeta, colMap []int) {
 	for j, c := range cols {
+		if rr.IsNull(i, colMap[j]) {
+			builder.AppendNil(j)
+			continue
+		}
 		switch c.Type {
 		case TBool:
 			builder.AppendBool(j, rr.AtBool(i, colMap[j]))
@@ -260,6 +272,8 @@ type BlockBuilder interface {
 	SetFloat(i, j int, value float64)
 	SetString(i, j int, value string)
 	SetTime(i, j int, value Time)
+	// SetNil sets the value at the specified coordinates to null.
+	SetNil(i, j int)
 
 	// SetCommonString sets a single value for the entire column.
 	SetCommonString(j int, value string)
@@ -270,6 +284,8 @@ type BlockBuilder interface {
 	AppendFloat(j int, value float64)
 	AppendString(j int, value string)
 	AppendTime(j int, value Time)
+	// AppendNil appends a null value to the column.
+	AppendNil(j int)
 
 	AppendFloats(j int, values []float64)
 	AppendStrings(j int, values []string)
@@ -401,6 +417,9 @@ type RowReader interface {
 	AtString(i, j int) string
 	// AtTime returns the time value of another column and given index.
 	AtTime(i, j int) Time
+	// IsNull reports whether the value of another column and given index is null.
+	// The At* methods return the zero value of the column type for null values.
+	IsNull(i, j int) bool
 }
 
 func TagsForRow(i int, rr RowReader) Tags {
@@ -589,7 +608,9 @@ func (b ColListBlockBuilder) AddCol(c ColMeta) int {
 
 func (b ColListBlockBuilder) SetBool(i int, j int, value bool) {
 	b.checkColType(j, TBool)
-	b.blk.cols[j].(*boolColumn).data[i] = value
+	col := b.blk.cols[j].(*boolColumn)
+	col.data[i] = value
+	col.nulls.set(i, false)
 }
 func (b ColListBlockBuilder) AppendBool(j int, value bool) {
 	b.checkColType(j, TBool)
@@ -606,7 +627,9 @@ func (b ColListBlockBuilder) AppendBools(j int, values []bool) {
 
 func (b ColListBlockBuilder) SetInt(i int, j int, value int64) {
 	b.checkColType(j, TInt)
-	b.blk.cols[j].(*intColumn).data[i] = value
+	col := b.blk.cols[j].(*intColumn)
+	col.data[i] = value
+	col.nulls.set(i, false)
 }
 func (b ColListBlockBuilder) AppendInt(j int, value int64) {
 	b.checkColType(j, TInt)
@@ -623,7 +646,9 @@ func (b ColListBlockBuilder) AppendInts(j int, values []int64) {
 
 func (b ColListBlockBuilder) SetUInt(i int, j int, value uint64) {
 	b.checkColType(j, TUInt)
-	b.blk.cols[j].(*uintColumn).data[i] = value
+	col := b.blk.cols[j].(*uintColumn)
+	col.data[i] = value
+	col.nulls.set(i, false)
 }
 func (b ColListBlockBuilder) AppendUInt(j int, value uint64) {
 	b.checkColType(j, TUInt)
@@ -640,7 +665,9 @@ func (b ColListBlockBuilder) AppendUInts(j int, values []uint64) {
 
 func (b ColListBlockBuilder) SetFloat(i int, j int, value float64) {
 	b.checkColType(j, TFloat)
-	b.blk.cols[j].(*floatColumn).data[i] = value
+	col := b.blk.cols[j].(*floatColumn)
+	col.data[i] = value
+	col.nulls.set(i, false)
 }
 func (b ColListBlockBuilder) AppendFloat(j int, value float64) {
 	b.checkColType(j, TFloat)
@@ -657,7 +684,9 @@ func (b ColListBlockBuilder) AppendFloats(j int, values []float64) {
 
 func (b ColListBlockBuilder) SetString(i int, j int, value string) {
 	b.checkColType(j, TString)
-	b.blk.cols[j].(*stringColumn).data[i] = value
+	col := b.blk.cols[j].(*stringColumn)
+	col.data[i] = value
+	col.nulls.set(i, false)
 }
 func (b ColListBlockBuilder) AppendString(j int, value string) {
 	meta := b.blk.cols[j].Meta()
@@ -696,7 +725,9 @@ func (b ColListBlockBuilder) SetCommonString(j int, value string) {
 
 func (b ColListBlockBuilder) SetTime(i int, j int, value Time) {
 	b.checkColType(j, TTime)
-	b.blk.cols[j].(*timeColumn).data[i] = value
+	col := b.blk.cols[j].(*timeColumn)
+	col.data[i] = value
+	col.nulls.set(i, false)
 }
 func (b ColListBlockBuilder) AppendTime(j int, value Time) {
 	b.checkColType(j, TTime)
@@ -711,6 +742,40 @@ func (b ColListBlockBuilder) AppendTimes(j int, values []Time) {
 	b.blk.nrows = len(col.data)
 }
 
+func (b ColListBlockBuilder) SetNil(i int, j int) {
+	b.blk.cols[j].SetNull(i)
+}
+func (b ColListBlockBuilder) AppendNil(j int) {
+	switch c := b.blk.cols[j].(type) {
+	case *boolColumn:
+		c.data = b.alloc.AppendBools(c.data, false)
+		c.nulls.set(len(c.data)-1, true)
+		b.blk.nrows = len(c.data)
+	case *intColumn:
+		c.data = b.alloc.AppendInts(c.data, 0)
+		c.nulls.set(len(c.data)-1, true)
+		b.blk.nrows = len(c.data)
+	case *uintColumn:
+		c.data = b.alloc.AppendUInts(c.data, 0)
+		c.nulls.set(len(c.data)-1, true)
+		b.blk.nrows = len(c.data)
+	case *floatColumn:
+		c.data = b.alloc.AppendFloats(c.data, 0)
+		c.nulls.set(len(c.data)-1, true)
+		b.blk.nrows = len(c.data)
+	case *stringColumn:
+		c.data = b.alloc.AppendStrings(c.data, "")
+		c.nulls.set(len(c.data)-1, true)
+		b.blk.nrows = len(c.data)
+	case *timeColumn:
+		c.data = b.alloc.AppendTimes(c.data, 0)
+		c.nulls.set(len(c.data)-1, true)
+		b.blk.nrows = len(c.data)
+	default:
+		panic(fmt.Errorf("cannot append a null value to the column %s", c.Meta().Label))
+	}
+}
+
 func (b ColListBlockBuilder) checkColType(j int, typ DataType) {
 	checkColType(b.blk.colMeta[j], typ)
 }
@@ -859,6 +924,10 @@ func (b *ColListBlock) AtTime(i, j int) Time {
 	return b.cols[j].(*timeColumn).data[i]
 }
 
+func (b *ColListBlock) IsNull(i, j int) bool {
+	return b.cols[j].IsNull(i)
+}
+
 func (b *ColListBlock) Copy() *ColListBlock {
 	cpy := new(ColListBlock)
 	cpy.bounds = b.bounds
@@ -948,6 +1017,10 @@ func (itr colListValueIterator) AtTime(i, j int) Time {
 	return itr.cols[j].(*timeColumn).data[i]
 }
 
+func (itr colListValueIterator) IsNull(i, j int) bool {
+	return itr.cols[j].IsNull(i)
+}
+
 type colListBlockSorter struct {
 	cols []int
 	desc bool
@@ -981,14 +1054,74 @@ type column interface {
 	Meta() ColMeta
 	Clear()
 	Copy() column
+	// IsNull reports whether the value of the row is null.
+	IsNull(i int) bool
+	// SetNull marks the value of the row as null.
+	SetNull(i int)
+	// Equal and Less order null values before all other values.
 	Equal(i, j int) bool
 	Less(i, j int) bool
 	Swap(i, j int)
 }
 
+// validity is a bitmap of the rows of a column that are null.
+// Rows past the end of the bitmap are not null,
+// so that columns without null values never allocate a bitmap.
+type validity []uint64
+
+func (v validity) isNull(i int) bool {
+	w := i / 64
+	return w < len(v) && v[w]&(1<<uint(i%64)) != 0
+}
+
+func (v *validity) set(i int, null bool) {
+	w := i / 64
+	if w >= len(*v) {
+		if !null {
+			return
+		}
+		grown := make(validity, w+1)
+		copy(grown, *v)
+		*v = grown
+	}
+	if null {
+		(*v)[w] |= 1 << uint(i%64)
+	} else {
+		(*v)[w] &^= 1 << uint(i%64)
+	}
+}
+
+func (v *validity) swap(i, j int) {
+	ni, nj := v.isNull(i), v.isNull(j)
+	if ni != nj {
+		v.set(i, nj)
+		v.set(j, ni)
+	}
+}
+
+func (v validity) copy() validity {
+	if v == nil {
+		return nil
+	}
+	cpy := make(validity, len(v))
+	copy(cpy, v)
+	return cpy
+}
+
+// compareNulls orders the rows if either is null, nulls sort first.
+// The returned ok is false when neither row is null.
+func (v validity) compareNulls(i, j int) (less, equal, ok bool) {
+	ni, nj := v.isNull(i), v.isNull(j)
+	if !ni && !nj {
+		return false, false, false
+	}
+	return ni && !nj, ni && nj, true
+}
+
 type boolColumn struct {
 	ColMeta
 	data  []bool
+	nulls validity
 	alloc *Allocator
 }
 
@@ -999,6 +1132,7 @@ func (c *boolColumn) Meta() ColMeta {
 func (c *boolColumn) Clear() {
 	c.alloc.Free(len(c.data), boolSize)
 	c.data = c.data[0:0]
+	c.nulls = nil
 }
 func (c *boolColumn) Copy() column {
 	cpy := &boolColumn{
@@ -1008,24 +1142,39 @@ func (c *boolColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Bools(l, l)
 	copy(cpy.data, c.data)
+	cpy.nulls = c.nulls.copy()
 	return cpy
 }
+func (c *boolColumn) IsNull(i int) bool {
+	return c.nulls.isNull(i)
+}
+func (c *boolColumn) SetNull(i int) {
+	c.nulls.set(i, true)
+}
 func (c *boolColumn) Equal(i, j int) bool {
+	if _, equal, ok := c.nulls.compareNulls(i, j); ok {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *boolColumn) Less(i, j int) bool {
+	if less, _, ok := c.nulls.compareNulls(i, j); ok {
+		return less
+	}
 	if c.data[i] == c.data[j] {
 		return false
 	}
 	return c.data[i]
 }
 func (c *boolColumn) Swap(i, j int) {
+	c.nulls.swap(i, j)
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
 type intColumn struct {
 	ColMeta
 	data  []int64
+	nulls validity
 	alloc *Allocator
 }
 
@@ -1036,6 +1185,7 @@ func (c *intColumn) Meta() ColMeta {
 func (c *intColumn) Clear() {
 	c.alloc.Free(len(c.data), int64Size)
 	c.data = c.data[0:0]
+	c.nulls = nil
 }
 func (c *intColumn) Copy() column {
 	cpy := &intColumn{
@@ -1045,21 +1195,36 @@ func (c *intColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Ints(l, l)
 	copy(cpy.data, c.data)
+	cpy.nulls = c.nulls.copy()
 	return cpy
 }
+func (c *intColumn) IsNull(i int) bool {
+	return c.nulls.isNull(i)
+}
+func (c *intColumn) SetNull(i int) {
+	c.nulls.set(i, true)
+}
 func (c *intColumn) Equal(i, j int) bool {
+	if _, equal, ok := c.nulls.compareNulls(i, j); ok {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *intColumn) Less(i, j int) bool {
+	if less, _, ok := c.nulls.compareNulls(i, j); ok {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *intColumn) Swap(i, j int) {
+	c.nulls.swap(i, j)
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
 type uintColumn struct {
 	ColMeta
 	data  []uint64
+	nulls validity
 	alloc *Allocator
 }
 
@@ -1070,6 +1235,7 @@ func (c *uintColumn) Meta() ColMeta {
 func (c *uintColumn) Clear() {
 	c.alloc.Free(len(c.data), uint64Size)
 	c.data = c.data[0:0]
+	c.nulls = nil
 }
 func (c *uintColumn) Copy() column {
 	cpy := &uintColumn{
@@ -1079,21 +1245,36 @@ func (c *uintColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.UInts(l, l)
 	copy(cpy.data, c.data)
+	cpy.nulls = c.nulls.copy()
 	return cpy
 }
+func (c *uintColumn) IsNull(i int) bool {
+	return c.nulls.isNull(i)
+}
+func (c *uintColumn) SetNull(i int) {
+	c.nulls.set(i, true)
+}
 func (c *uintColumn) Equal(i, j int) bool {
+	if _, equal, ok := c.nulls.compareNulls(i, j); ok {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *uintColumn) Less(i, j int) bool {
+	if less, _, ok := c.nulls.compareNulls(i, j); ok {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *uintColumn) Swap(i, j int) {
+	c.nulls.swap(i, j)
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
 type floatColumn struct {
 	ColMeta
 	data  []float64
+	nulls validity
 	alloc *Allocator
 }
 
@@ -1104,6 +1285,7 @@ func (c *floatColumn) Meta() ColMeta {
 func (c *floatColumn) Clear() {
 	c.alloc.Free(len(c.data), float64Size)
 	c.data = c.data[0:0]
+	c.nulls = nil
 }
 func (c *floatColumn) Copy() column {
 	cpy := &floatColumn{
@@ -1113,21 +1295,36 @@ func (c *floatColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Floats(l, l)
 	copy(cpy.data, c.data)
+	cpy.nulls = c.nulls.copy()
 	return cpy
 }
+func (c *floatColumn) IsNull(i int) bool {
+	return c.nulls.isNull(i)
+}
+func (c *floatColumn) SetNull(i int) {
+	c.nulls.set(i, true)
+}
 func (c *floatColumn) Equal(i, j int) bool {
+	if _, equal, ok := c.nulls.compareNulls(i, j); ok {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *floatColumn) Less(i, j int) bool {
+	if less, _, ok := c.nulls.compareNulls(i, j); ok {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *floatColumn) Swap(i, j int) {
+	c.nulls.swap(i, j)
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
 type stringColumn struct {
 	ColMeta
 	data  []string
+	nulls validity
 	alloc *Allocator
 }
 
@@ -1138,6 +1335,7 @@ func (c *stringColumn) Meta() ColMeta {
 func (c *stringColumn) Clear() {
 	c.alloc.Free(len(c.data), stringSize)
 	c.data = c.data[0:0]
+	c.nulls = nil
 }
 func (c *stringColumn) Copy() column {
 	cpy := &stringColumn{
@@ -1148,21 +1346,36 @@ func (c *stringColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Strings(l, l)
 	copy(cpy.data, c.data)
+	cpy.nulls = c.nulls.copy()
 	return cpy
 }
+func (c *stringColumn) IsNull(i int) bool {
+	return c.nulls.isNull(i)
+}
+func (c *stringColumn) SetNull(i int) {
+	c.nulls.set(i, true)
+}
 func (c *stringColumn) Equal(i, j int) bool {
+	if _, equal, ok := c.nulls.compareNulls(i, j); ok {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *stringColumn) Less(i, j int) bool {
+	if less, _, ok := c.nulls.compareNulls(i, j); ok {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *stringColumn) Swap(i, j int) {
+	c.nulls.swap(i, j)
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
 type timeColumn struct {
 	ColMeta
 	data  []Time
+	nulls validity
 	alloc *Allocator
 }
 
@@ -1173,6 +1386,7 @@ func (c *timeColumn) Meta() ColMeta {
 func (c *timeColumn) Clear() {
 	c.alloc.Free(len(c.data), timeSize)
 	c.data = c.data[0:0]
+	c.nulls = nil
 }
 func (c *timeColumn) Copy() column {
 	cpy := &timeColumn{
@@ -1182,15 +1396,29 @@ func (c *timeColumn) Copy() column {
 	l := len(c.data)
 	cpy.data = c.alloc.Times(l, l)
 	copy(cpy.data, c.data)
+	cpy.nulls = c.nulls.copy()
 	return cpy
 }
+func (c *timeColumn) IsNull(i int) bool {
+	return c.nulls.isNull(i)
+}
+func (c *timeColumn) SetNull(i int) {
+	c.nulls.set(i, true)
+}
 func (c *timeColumn) Equal(i, j int) bool {
+	if _, equal, ok := c.nulls.compareNulls(i, j); ok {
+		return equal
+	}
 	return c.data[i] == c.data[j]
 }
 func (c *timeColumn) Less(i, j int) bool {
+	if less, _, ok := c.nulls.compareNulls(i, j); ok {
+		return less
+	}
 	return c.data[i] < c.data[j]
 }
 func (c *timeColumn) Swap(i, j int) {
+	c.nulls.swap(i, j)
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
@@ -1210,6 +1438,12 @@ func (c *commonStrColumn) Copy() column {
 	*cpy = *c
 	return cpy
 }
+func (c *commonStrColumn) IsNull(i int) bool {
+	return false
+}
+func (c *commonStrColumn) SetNull(i int) {
+	panic(fmt.Errorf("cannot set a null value in the column %s, which has all common values", c.Label))
+}
 func (c *commonStrColumn) Equal(i, j int) bool {
 	return true
 }
@@ -1230,6 +1464,10 @@ type blockBuilderCache struct {
 	alloc  *Allocator
 
 	triggerSpec query.TriggerSpec
+
+	// sortCols and desc are the order of the data of the builders, when set spilled data is merged in that order.
+	sortCols []string
+	desc     bool
 }
 
 func NewBlockBuilderCache(a *Allocator) *blockBuilderCache {
@@ -1242,14 +1480,73 @@ func NewBlockBuilderCache(a *Allocator) *blockBuilderCache {
 type blockState struct {
 	builder BlockBuilder
 	trigger Trigger
+	// runs hold the data of the builder that was spilled to disk.
+	runs        []*SpillRun
+	spilledRows int
 }
 
 func (d *blockBuilderCache) SetTriggerSpec(ts query.TriggerSpec) {
 	d.triggerSpec = ts
 }
 
+// SetSortOrder reports that the data of the builders is sorted by the columns,
+// so that the data spilled to disk is merged in that order.
+func (d *blockBuilderCache) SetSortOrder(cols []string, desc bool) {
+	d.sortCols = cols
+	d.desc = desc
+}
+
 func (d *blockBuilderCache) Block(key BlockKey) (Block, error) {
-	return d.blocks[key].builder.Block()
+	b := d.blocks[key]
+	if len(b.runs) == 0 {
+		return b.builder.Block()
+	}
+	if d.sortCols != nil {
+		b.builder.Sort(d.sortCols, d.desc)
+	}
+	mem, err := b.builder.Block()
+	if err != nil {
+		return nil, err
+	}
+	cl, ok := mem.(*ColListBlock)
+	if !ok {
+		return nil, fmt.Errorf("cannot merge spilled data with block of type %T", mem)
+	}
+	return NewSpilledBlock(cl, b.runs, d.sortCols, d.desc)
+}
+
+func (d *blockBuilderCache) SpillIfNeeded() error {
+	if !d.alloc.NearLimit() {
+		return nil
+	}
+	return d.Spill()
+}
+
+// Spill writes the data of all builders to disk and clears them.
+func (d *blockBuilderCache) Spill() error {
+	for key, b := range d.blocks {
+		n := b.builder.NRows()
+		if n == 0 {
+			continue
+		}
+		if d.sortCols != nil {
+			b.builder.Sort(d.sortCols, d.desc)
+		}
+		// Write the data of the builder directly, copying it could exceed the memory quota.
+		raw, ok := b.builder.(*ColListBlockBuilder)
+		if !ok {
+			return fmt.Errorf("cannot spill builder of type %T", b.builder)
+		}
+		run, err := WriteSpillRun(d.alloc.SpillDir, raw.RawBlock())
+		if err != nil {
+			return err
+		}
+		b.builder.ClearData()
+		b.runs = append(b.runs, run)
+		b.spilledRows += n
+		d.blocks[key] = b
+	}
+	return nil
 }
 func (d *blockBuilderCache) BlockMetadata(key BlockKey) BlockMetadata {
 	return d.blocks[key].builder
@@ -1280,7 +1577,12 @@ func (d *blockBuilderCache) ForEachBuilder(f func(BlockKey, BlockBuilder)) {
 }
 
 func (d *blockBuilderCache) DiscardBlock(key BlockKey) {
-	d.blocks[key].builder.ClearData()
+	b := d.blocks[key]
+	b.builder.ClearData()
+	// The runs are not closed, as blocks produced from them may still be read.
+	b.runs = nil
+	b.spilledRows = 0
+	d.blocks[key] = b
 }
 func (d *blockBuilderCache) ExpireBlock(key BlockKey) {
 	d.blocks[key].builder.ClearData()
@@ -1297,7 +1599,7 @@ func (d *blockBuilderCache) ForEachWithContext(f func(BlockKey, Trigger, BlockCo
 	for bk, b := range d.blocks {
 		f(bk, b.trigger, BlockContext{
 			Bounds: b.builder.Bounds(),
-			Count:  b.builder.NRows(),
+			Count:  b.builder.NRows() + b.spilledRows,
 		})
 	}
 }
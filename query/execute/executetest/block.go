@@ -11,7 +11,11 @@ type Block struct {
 	ColMeta []execute.ColMeta
 	// Data is a list of rows, i.e. Data[row][col]
 	// Each row must be a list with length equal to len(ColMeta)
+	// A nil value is a null value.
 	Data [][]interface{}
+	// Tgs are the common tags of a block without rows,
+	// the tags of a block with rows are read from its first row.
+	Tgs execute.Tags
 }
 
 func (b *Block) RefCount(n int) {}
@@ -21,6 +25,9 @@ func (b *Block) Bounds() execute.Bounds {
 }
 
 func (b *Block) Tags() execute.Tags {
+	if len(b.Data) == 0 {
+		return b.Tgs
+	}
 	tags := make(execute.Tags, len(b.ColMeta))
 	for j, c := range b.ColMeta {
 		if c.IsTag() && c.Common {
@@ -64,58 +71,80 @@ func (v *ValueIterator) Cols() []execute.ColMeta {
 }
 func (v *ValueIterator) DoBool(f func([]bool, execute.RowReader)) {
 	for v.row = 0; v.row < len(v.b.Data); v.row++ {
-		f([]bool{v.b.Data[v.row][v.col].(bool)}, v)
+		f([]bool{v.AtBool(v.row, v.col)}, v)
 	}
 }
 func (v *ValueIterator) DoInt(f func([]int64, execute.RowReader)) {
 	for v.row = 0; v.row < len(v.b.Data); v.row++ {
-		f([]int64{v.b.Data[v.row][v.col].(int64)}, v)
+		f([]int64{v.AtInt(v.row, v.col)}, v)
 	}
 }
 func (v *ValueIterator) DoUInt(f func([]uint64, execute.RowReader)) {
 	for v.row = 0; v.row < len(v.b.Data); v.row++ {
-		f([]uint64{v.b.Data[v.row][v.col].(uint64)}, v)
+		f([]uint64{v.AtUInt(v.row, v.col)}, v)
 	}
 }
 func (v *ValueIterator) DoFloat(f func([]float64, execute.RowReader)) {
 	for v.row = 0; v.row < len(v.b.Data); v.row++ {
-		f([]float64{v.b.Data[v.row][v.col].(float64)}, v)
+		f([]float64{v.AtFloat(v.row, v.col)}, v)
 	}
 }
 
 func (v *ValueIterator) DoString(f func([]string, execute.RowReader)) {
 	for v.row = 0; v.row < len(v.b.Data); v.row++ {
-		f([]string{v.b.Data[v.row][v.col].(string)}, v)
+		f([]string{v.AtString(v.row, v.col)}, v)
 	}
 }
 
 func (v *ValueIterator) DoTime(f func([]execute.Time, execute.RowReader)) {
 	for v.row = 0; v.row < len(v.b.Data); v.row++ {
-		f([]execute.Time{v.b.Data[v.row][v.col].(execute.Time)}, v)
+		f([]execute.Time{v.AtTime(v.row, v.col)}, v)
 	}
 }
 
 func (v *ValueIterator) AtBool(i int, j int) bool {
+	if v.IsNull(i, j) {
+		return false
+	}
 	return v.b.Data[v.row][j].(bool)
 }
 func (v *ValueIterator) AtInt(i int, j int) int64 {
+	if v.IsNull(i, j) {
+		return 0
+	}
 	return v.b.Data[v.row][j].(int64)
 }
 func (v *ValueIterator) AtUInt(i int, j int) uint64 {
+	if v.IsNull(i, j) {
+		return 0
+	}
 	return v.b.Data[v.row][j].(uint64)
 }
 func (v *ValueIterator) AtFloat(i int, j int) float64 {
+	if v.IsNull(i, j) {
+		return 0
+	}
 	return v.b.Data[v.row][j].(float64)
 }
 
 func (v *ValueIterator) AtString(i int, j int) string {
+	if v.IsNull(i, j) {
+		return ""
+	}
 	return v.b.Data[v.row][j].(string)
 }
 
 func (v *ValueIterator) AtTime(i int, j int) execute.Time {
+	if v.IsNull(i, j) {
+		return 0
+	}
 	return v.b.Data[v.row][j].(execute.Time)
 }
 
+func (v *ValueIterator) IsNull(i int, j int) bool {
+	return v.b.Data[v.row][j] == nil
+}
+
 func BlocksFromCache(c execute.DataCache) []*Block {
 	var blocks []*Block
 	c.ForEach(func(key execute.BlockKey) {
@@ -139,6 +168,9 @@ func ConvertBlock(b execute.Block) *Block {
 			row := make([]interface{}, len(blk.ColMeta))
 			for j, c := range blk.ColMeta {
 				var v interface{}
+				if rr.IsNull(i, j) {
+					continue
+				}
 				switch c.Type {
 				case execute.TBool:
 					v = rr.AtBool(i, j)
@@ -160,6 +192,9 @@ func ConvertBlock(b execute.Block) *Block {
 			blk.Data = append(blk.Data, row)
 		}
 	})
+	if len(blk.Data) == 0 {
+		blk.Tgs = b.Tags()
+	}
 	return blk
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"sync"
+	"time"
 
 	"github.com/influxdata/ifql/query"
 	"github.com/influxdata/ifql/query/plan"
@@ -20,6 +22,9 @@ type executor struct {
 
 type Config struct {
 	StorageReader StorageReader
+	// SpillDir is the directory where data is written when a query nears its memory quota.
+	// Queries fail once they exceed their quota when it is empty.
+	SpillDir string
 }
 
 func NewExecutor(c Config) Executor {
@@ -38,6 +43,10 @@ type executionState struct {
 	resources query.ResourceManagement
 
 	bounds Bounds
+	// mode is the accumulation mode of all datasets.
+	mode AccumulationMode
+	// triggerSpec is the trigger spec of datasets that do not define their own triggering.
+	triggerSpec query.TriggerSpec
 
 	results map[string]Result
 	sources []Source
@@ -45,13 +54,25 @@ type executionState struct {
 	transports []Transport
 
 	dispatcher *poolDispatcher
+
+	// cancel releases the context of the execution once it has finished.
+	cancel context.CancelFunc
 }
 
 func (e *executor) Execute(ctx context.Context, p *plan.PlanSpec) (map[string]Result, error) {
+	var cancel context.CancelFunc
+	if p.Resources.ExecuteTimeout > 0 {
+		// The deadline reaches the storage reads of the sources through the context.
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(p.Resources.ExecuteTimeout))
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
 	es, err := e.createExecutionState(ctx, p)
 	if err != nil {
+		cancel()
 		return nil, errors.Wrap(err, "failed to initialize execute state")
 	}
+	es.cancel = cancel
 	es.do(ctx)
 	return es.results, nil
 }
@@ -60,6 +81,12 @@ func validatePlan(p *plan.PlanSpec) error {
 	if p.Resources.ConcurrencyQuota == 0 {
 		return errors.New("plan must have a non-zero concurrency quota")
 	}
+	if p.Continuous != nil && p.Continuous.Interval <= 0 {
+		return errors.New("continuous plan must have a positive interval")
+	}
+	if p.Resources.ExecuteTimeout < 0 {
+		return errors.New("plan must have a non-negative execute timeout")
+	}
 	return nil
 }
 
@@ -71,7 +98,8 @@ func (e *executor) createExecutionState(ctx context.Context, p *plan.PlanSpec) (
 		p: p,
 		c: &e.c,
 		alloc: &Allocator{
-			Limit: p.Resources.MemoryBytesQuota,
+			Limit:    p.Resources.MemoryBytesQuota,
+			SpillDir: e.c.SpillDir,
 		},
 		resources: p.Resources,
 		results:   make(map[string]Result, len(p.Results)),
@@ -81,6 +109,16 @@ func (e *executor) createExecutionState(ctx context.Context, p *plan.PlanSpec) (
 			Start: Time(p.Bounds.Start.Time(p.Now).UnixNano()),
 			Stop:  Time(p.Bounds.Stop.Time(p.Now).UnixNano()),
 		},
+		mode:        AccumulatingMode,
+		triggerSpec: DefaultTriggerSpec,
+	}
+	if p.Continuous != nil {
+		// A continuous plan keeps reading data past its stop bound.
+		// Each block is emitted once as it is triggered and then discarded,
+		// so that results are delivered incrementally.
+		es.bounds.Stop = MaxTime
+		es.mode = DiscardingMode
+		es.triggerSpec = DefaultContinuousTriggerSpec
 	}
 	for name, yield := range p.Results {
 		ds, err := es.createNode(ctx, p.Procedures[yield.ID])
@@ -98,6 +136,13 @@ func (e *executor) createExecutionState(ctx context.Context, p *plan.PlanSpec) (
 // whose parent transformation is not a windowing transformation.
 var DefaultTriggerSpec = query.AfterWatermarkTriggerSpec{}
 
+// DefaultContinuousTriggerSpec defines the triggering that should be used for datasets
+// whose parent transformation is not a windowing transformation, when the plan is continuous.
+// The bounds of such datasets never close, so their data is emitted as soon as it arrives.
+var DefaultContinuousTriggerSpec = query.RepeatedTriggerSpec{
+	Trigger: query.AfterAtLeastCountTriggerSpec{Count: 1},
+}
+
 type triggeringSpec interface {
 	TriggerSpec() query.TriggerSpec
 }
@@ -127,13 +172,13 @@ func (es *executionState) createNode(ctx context.Context, pr *plan.Procedure) (N
 	}
 
 	// Create the transformation
-	t, ds, err := createT(DatasetID(pr.ID), AccumulatingMode, pr.Spec, ec)
+	t, ds, err := createT(DatasetID(pr.ID), es.mode, pr.Spec, ec)
 	if err != nil {
 		return nil, err
 	}
 
 	// Setup triggering
-	var ts query.TriggerSpec = DefaultTriggerSpec
+	ts := es.triggerSpec
 	if t, ok := pr.Spec.(triggeringSpec); ok {
 		ts = t.TriggerSpec()
 	}
@@ -160,8 +205,11 @@ func (es *executionState) abort(err error) {
 }
 
 func (es *executionState) do(ctx context.Context) {
+	var sources sync.WaitGroup
+	sources.Add(len(es.sources))
 	for _, src := range es.sources {
 		go func(src Source) {
+			defer sources.Done()
 			// Setup panic handling on the source goroutines
 			defer func() {
 				if e := recover(); e != nil {
@@ -186,7 +234,7 @@ func (es *executionState) do(ctx context.Context) {
 			select {
 			case <-t.Finished():
 			case <-ctx.Done():
-				es.abort(errors.New("context done"))
+				es.abort(errors.Wrap(ctx.Err(), "context done"))
 			case err := <-es.dispatcher.Err():
 				if err != nil {
 					es.abort(err)
@@ -198,6 +246,9 @@ func (es *executionState) do(ctx context.Context) {
 		if err != nil {
 			es.abort(err)
 		}
+		// Sources whose results are not transported may still be running.
+		sources.Wait()
+		es.cancel()
 	}()
 }
 
@@ -225,3 +276,6 @@ func (ec executionContext) Parents() []DatasetID {
 func (ec executionContext) ConvertID(id plan.ProcedureID) DatasetID {
 	return DatasetID(id)
 }
+func (ec executionContext) Continuous() *plan.ContinuousSpec {
+	return ec.es.p.Continuous
+}
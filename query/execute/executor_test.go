@@ -2,7 +2,10 @@ package execute_test
 
 import (
 	"context"
+	"errors"
 	"math"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -376,6 +379,204 @@ func TestExecutor_Execute(t *testing.T) {
 	}
 }
 
+func TestExecutor_ExecuteContinuous(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	start := now.Add(-20 * time.Millisecond)
+	interval := 10 * time.Millisecond
+	p := &plan.PlanSpec{
+		Now: now,
+		Resources: query.ResourceManagement{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+		},
+		Bounds: plan.BoundsSpec{
+			Start: query.Time{Absolute: start},
+			Stop:  query.Time{Absolute: now},
+		},
+		Procedures: map[plan.ProcedureID]*plan.Procedure{
+			plan.ProcedureIDFromOperationID("from"): {
+				ID: plan.ProcedureIDFromOperationID("from"),
+				Spec: &functions.FromProcedureSpec{
+					Database:  "mydb",
+					BoundsSet: true,
+					Bounds: plan.BoundsSpec{
+						Start: query.Time{Absolute: start},
+						Stop:  query.Time{Absolute: now},
+					},
+				},
+				Parents:  nil,
+				Children: []plan.ProcedureID{plan.ProcedureIDFromOperationID("sum")},
+			},
+			plan.ProcedureIDFromOperationID("sum"): {
+				ID:   plan.ProcedureIDFromOperationID("sum"),
+				Spec: &functions.SumProcedureSpec{},
+				Parents: []plan.ProcedureID{
+					plan.ProcedureIDFromOperationID("from"),
+				},
+				Children: nil,
+			},
+		},
+		Results: map[string]plan.YieldSpec{
+			plan.DefaultYieldName: {ID: plan.ProcedureIDFromOperationID("sum")},
+		},
+		Continuous: &plan.ContinuousSpec{
+			Interval: interval,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	exe := execute.NewExecutor(execute.Config{
+		StorageReader: tailingStorageReader{},
+	})
+	results, err := exe.Execute(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each read is emitted as soon as it has been aggregated.
+	cols := []execute.ColMeta{
+		execute.TimeCol,
+		execute.ColMeta{
+			Label: execute.DefaultValueColLabel,
+			Type:  execute.TFloat,
+			Kind:  execute.ValueColKind,
+		},
+	}
+	bounds := execute.Bounds{
+		Start: execute.Time(start.UnixNano()),
+		Stop:  execute.MaxTime,
+	}
+	var want []*executetest.Block
+	for i := 0; i < 3; i++ {
+		stop := now.Add(time.Duration(i) * interval)
+		want = append(want, &executetest.Block{
+			Bnds:    bounds,
+			ColMeta: cols,
+			Data: [][]interface{}{
+				{execute.Time(stop.UnixNano()), 1.0},
+			},
+		})
+	}
+
+	errDone := errors.New("done")
+	var got []*executetest.Block
+	err = results[plan.DefaultYieldName].Blocks().Do(func(b execute.Block) error {
+		got = append(got, executetest.ConvertBlock(b))
+		if len(got) == len(want) {
+			return errDone
+		}
+		return nil
+	})
+	if err != errDone {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(got, want) {
+		t.Error("unexpected results -want/+got", cmp.Diff(want, got))
+	}
+}
+
+func TestExecutor_ExecuteTimeout(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	start := now.Add(-20 * time.Millisecond)
+	p := &plan.PlanSpec{
+		Now: now,
+		Resources: query.ResourceManagement{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+			ExecuteTimeout:   query.Duration(50 * time.Millisecond),
+		},
+		Bounds: plan.BoundsSpec{
+			Start: query.Time{Absolute: start},
+			Stop:  query.Time{Absolute: now},
+		},
+		Procedures: map[plan.ProcedureID]*plan.Procedure{
+			plan.ProcedureIDFromOperationID("from"): {
+				ID: plan.ProcedureIDFromOperationID("from"),
+				Spec: &functions.FromProcedureSpec{
+					Database:  "mydb",
+					BoundsSet: true,
+					Bounds: plan.BoundsSpec{
+						Start: query.Time{Absolute: start},
+						Stop:  query.Time{Absolute: now},
+					},
+				},
+				Parents:  nil,
+				Children: nil,
+			},
+		},
+		Results: map[string]plan.YieldSpec{
+			plan.DefaultYieldName: {ID: plan.ProcedureIDFromOperationID("from")},
+		},
+		Continuous: &plan.ContinuousSpec{
+			Interval: 10 * time.Millisecond,
+		},
+	}
+
+	sr := &deadlineStorageReader{}
+	exe := execute.NewExecutor(execute.Config{
+		StorageReader: sr,
+	})
+	results, err := exe.Execute(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The continuous query only stops once its deadline has passed.
+	err = results[plan.DefaultYieldName].Blocks().Do(func(execute.Block) error {
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Errorf("unexpected error: got %v want %v", err, context.DeadlineExceeded)
+	}
+	if !sr.deadline {
+		t.Error("expected storage reads to have the deadline of the query")
+	}
+}
+
+// deadlineStorageReader records whether the context of the first read has a deadline.
+type deadlineStorageReader struct {
+	tailingStorageReader
+	once     sync.Once
+	deadline bool
+}
+
+func (s *deadlineStorageReader) Read(ctx context.Context, trace map[string]string, rs execute.ReadSpec, start, stop execute.Time) (execute.BlockIterator, error) {
+	s.once.Do(func() {
+		_, s.deadline = ctx.Deadline()
+	})
+	return s.tailingStorageReader.Read(ctx, trace, rs, start, stop)
+}
+
+// tailingStorageReader returns a single point at the start of each read range.
+type tailingStorageReader struct{}
+
+func (s tailingStorageReader) Close() {}
+func (s tailingStorageReader) Read(ctx context.Context, trace map[string]string, rs execute.ReadSpec, start, stop execute.Time) (execute.BlockIterator, error) {
+	return &storageBlockIterator{
+		s: storageReader{
+			blocks: []execute.Block{&executetest.Block{
+				Bnds: execute.Bounds{
+					Start: start,
+					Stop:  stop,
+				},
+				ColMeta: []execute.ColMeta{
+					execute.TimeCol,
+					execute.ColMeta{
+						Label: execute.DefaultValueColLabel,
+						Type:  execute.TFloat,
+						Kind:  execute.ValueColKind,
+					},
+				},
+				Data: [][]interface{}{
+					{start, 1.0},
+				},
+			}},
+		},
+	}, nil
+}
+
 type storageReader struct {
 	blocks []execute.Block
 }
@@ -0,0 +1,843 @@
+package execute
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/ifql/query/execute/storage"
+	"github.com/influxdata/influxdb/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// fileStorageHost is the host name reported by the connection of a file storage reader.
+	fileStorageHost = "file"
+	// fileStoragePointsPerFrame is the maximum number of points sent in a single points frame.
+	fileStoragePointsPerFrame = 1000
+
+	measurementTagKey = "_measurement"
+	fieldTagKey       = "_field"
+)
+
+// NewFileStorageReader creates a StorageReader that serves reads from line protocol files.
+// The files may be in the format produced by `influx_inspect export`,
+// in which case the points of each database are only visible to reads of that database.
+// Points outside of any database context are visible to reads of all databases.
+// All data is loaded into memory when the reader is created.
+func NewFileStorageReader(paths []string) (StorageReader, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("must provide at least one file")
+	}
+	fs := newFileStore()
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, err
+		}
+		err = fs.load(f)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load file %q", p)
+		}
+	}
+	fs.sort()
+	return &fileStorageReader{
+		store: fs,
+	}, nil
+}
+
+type fileStorageReader struct {
+	store *fileStore
+}
+
+func (r *fileStorageReader) Read(ctx context.Context, trace map[string]string, readSpec ReadSpec, start, stop Time) (BlockIterator, error) {
+	var predicate *storage.Predicate
+	if readSpec.Predicate != nil {
+		p, err := ToStoragePredicate(readSpec.Predicate)
+		if err != nil {
+			return nil, err
+		}
+		predicate = p
+	}
+	// All data is local, there are no hosts to filter.
+	readSpec.Hosts = nil
+
+	bi := &storageBlockIterator{
+		ctx:   ctx,
+		trace: trace,
+		bounds: Bounds{
+			Start: start,
+			Stop:  stop,
+		},
+		conns: []connection{{
+			host: fileStorageHost,
+			client: &fileStorageClient{
+				store:    r.store,
+				readSpec: readSpec,
+			},
+		}},
+		readSpec:  readSpec,
+		predicate: predicate,
+	}
+	return bi, nil
+}
+
+func (r *fileStorageReader) Close() {}
+
+// fileSeries is a single series of a measurement field and its values sorted by time.
+type fileSeries struct {
+	database string
+	key      string
+	tags     []storage.Tag
+	tagMap   map[string]string
+	typ      storage.ReadResponse_DataType
+
+	times  []int64
+	floats []float64
+	ints   []int64
+	uints  []uint64
+	bools  []bool
+	strs   []string
+}
+
+func (s *fileSeries) append(t int64, v interface{}) error {
+	var typ storage.ReadResponse_DataType
+	switch v := v.(type) {
+	case float64:
+		typ = storage.DataTypeFloat
+		s.floats = append(s.floats, v)
+	case int64:
+		typ = storage.DataTypeInteger
+		s.ints = append(s.ints, v)
+	case uint64:
+		typ = storage.DataTypeUnsigned
+		s.uints = append(s.uints, v)
+	case bool:
+		typ = storage.DataTypeBoolean
+		s.bools = append(s.bools, v)
+	case string:
+		typ = storage.DataTypeString
+		s.strs = append(s.strs, v)
+	default:
+		return fmt.Errorf("unsupported field type %T", v)
+	}
+	if len(s.times) > 0 && typ != s.typ {
+		return fmt.Errorf("field type conflict for series %q: %v != %v", s.key, typ, s.typ)
+	}
+	s.typ = typ
+	s.times = append(s.times, t)
+	return nil
+}
+
+func (s *fileSeries) Len() int           { return len(s.times) }
+func (s *fileSeries) Less(i, j int) bool { return s.times[i] < s.times[j] }
+func (s *fileSeries) Swap(i, j int) {
+	s.times[i], s.times[j] = s.times[j], s.times[i]
+	switch s.typ {
+	case storage.DataTypeFloat:
+		s.floats[i], s.floats[j] = s.floats[j], s.floats[i]
+	case storage.DataTypeInteger:
+		s.ints[i], s.ints[j] = s.ints[j], s.ints[i]
+	case storage.DataTypeUnsigned:
+		s.uints[i], s.uints[j] = s.uints[j], s.uints[i]
+	case storage.DataTypeBoolean:
+		s.bools[i], s.bools[j] = s.bools[j], s.bools[i]
+	case storage.DataTypeString:
+		s.strs[i], s.strs[j] = s.strs[j], s.strs[i]
+	}
+}
+
+// sort orders the values by time, for duplicate times the last written value wins.
+func (s *fileSeries) sort() {
+	sort.Stable(s)
+	n := 0
+	for i := range s.times {
+		if i+1 < len(s.times) && s.times[i] == s.times[i+1] {
+			continue
+		}
+		s.times[n] = s.times[i]
+		switch s.typ {
+		case storage.DataTypeFloat:
+			s.floats[n] = s.floats[i]
+		case storage.DataTypeInteger:
+			s.ints[n] = s.ints[i]
+		case storage.DataTypeUnsigned:
+			s.uints[n] = s.uints[i]
+		case storage.DataTypeBoolean:
+			s.bools[n] = s.bools[i]
+		case storage.DataTypeString:
+			s.strs[n] = s.strs[i]
+		}
+		n++
+	}
+	s.times = s.times[:n]
+	switch s.typ {
+	case storage.DataTypeFloat:
+		s.floats = s.floats[:n]
+	case storage.DataTypeInteger:
+		s.ints = s.ints[:n]
+	case storage.DataTypeUnsigned:
+		s.uints = s.uints[:n]
+	case storage.DataTypeBoolean:
+		s.bools = s.bools[:n]
+	case storage.DataTypeString:
+		s.strs = s.strs[:n]
+	}
+}
+
+func (s *fileSeries) value(i int) interface{} {
+	switch s.typ {
+	case storage.DataTypeFloat:
+		return s.floats[i]
+	case storage.DataTypeInteger:
+		return s.ints[i]
+	case storage.DataTypeUnsigned:
+		return s.uints[i]
+	case storage.DataTypeBoolean:
+		return s.bools[i]
+	case storage.DataTypeString:
+		return s.strs[i]
+	default:
+		return nil
+	}
+}
+
+// fileStore holds all series loaded from files.
+type fileStore struct {
+	// series is sorted by key once loading has completed.
+	series []*fileSeries
+	lookup map[string]*fileSeries
+}
+
+func newFileStore() *fileStore {
+	return &fileStore{
+		lookup: make(map[string]*fileSeries),
+	}
+}
+
+// load reads line protocol data from r.
+// Comment lines are ignored, except for the `# DDL`, `# DML` and `# CONTEXT-DATABASE:` directives of the export format.
+func (fs *fileStore) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	database := ""
+	ddl := false
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line[0] == '#' {
+			directive := strings.TrimSpace(line[1:])
+			switch {
+			case directive == "DDL":
+				ddl = true
+			case directive == "DML":
+				ddl = false
+			case strings.HasPrefix(directive, "CONTEXT-DATABASE:"):
+				database = strings.TrimSpace(strings.TrimPrefix(directive, "CONTEXT-DATABASE:"))
+			}
+			continue
+		}
+		if ddl {
+			continue
+		}
+		points, err := models.ParsePointsString(line)
+		if err != nil {
+			return errors.Wrapf(err, "line %d", n)
+		}
+		for _, p := range points {
+			if err := fs.addPoint(database, p); err != nil {
+				return errors.Wrapf(err, "line %d", n)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func (fs *fileStore) addPoint(database string, p models.Point) error {
+	fields, err := p.Fields()
+	if err != nil {
+		return err
+	}
+	name := string(p.Name())
+	ptags := p.Tags()
+	t := p.UnixNano()
+	for field, v := range fields {
+		tags := make([]storage.Tag, 0, len(ptags)+2)
+		tags = append(tags,
+			storage.Tag{Key: []byte(measurementTagKey), Value: []byte(name)},
+			storage.Tag{Key: []byte(fieldTagKey), Value: []byte(field)},
+		)
+		for _, tag := range ptags {
+			tags = append(tags, storage.Tag{Key: tag.Key, Value: tag.Value})
+		}
+		sort.Slice(tags, func(i, j int) bool {
+			return bytes.Compare(tags[i].Key, tags[j].Key) < 0
+		})
+
+		var buf bytes.Buffer
+		for i, tag := range tags {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(tag.Key)
+			buf.WriteByte('=')
+			buf.Write(tag.Value)
+		}
+		key := buf.String()
+
+		s, ok := fs.lookup[database+"\x00"+key]
+		if !ok {
+			s = &fileSeries{
+				database: database,
+				key:      key,
+				tags:     tags,
+				tagMap:   make(map[string]string, len(tags)),
+			}
+			for _, tag := range tags {
+				s.tagMap[string(tag.Key)] = string(tag.Value)
+			}
+			fs.lookup[database+"\x00"+key] = s
+			fs.series = append(fs.series, s)
+		}
+		if err := s.append(t, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fs *fileStore) sort() {
+	sort.Slice(fs.series, func(i, j int) bool {
+		if fs.series[i].key == fs.series[j].key {
+			return fs.series[i].database < fs.series[j].database
+		}
+		return fs.series[i].key < fs.series[j].key
+	})
+	for _, s := range fs.series {
+		s.sort()
+	}
+}
+
+// fileStorageClient implements the storage.StorageClient interface on top of a fileStore.
+type fileStorageClient struct {
+	store    *fileStore
+	readSpec ReadSpec
+}
+
+func (c *fileStorageClient) Capabilities(ctx context.Context, in *types.Empty) (*storage.CapabilitiesResponse, error) {
+	return &storage.CapabilitiesResponse{}, nil
+}
+
+func (c *fileStorageClient) Hints(ctx context.Context, in *types.Empty) (*storage.HintsResponse, error) {
+	return &storage.HintsResponse{}, nil
+}
+
+// fileSeriesResult is a series selected by a read request and the values that matched the request.
+type fileSeriesResult struct {
+	groupKey key
+	series   *fileSeries
+	indexes  []int
+}
+
+func (c *fileStorageClient) Read(ctx context.Context, req *storage.ReadRequest) (storage.Storage_ReadClient, error) {
+	eval := newPredicateEvaluator()
+	var results []fileSeriesResult
+	for _, s := range c.store.series {
+		if s.database != "" && s.database != req.Database {
+			continue
+		}
+		start := sort.Search(len(s.times), func(i int) bool {
+			return s.times[i] >= req.TimestampRange.Start
+		})
+		stop := sort.Search(len(s.times), func(i int) bool {
+			return s.times[i] >= req.TimestampRange.End
+		})
+		var indexes []int
+		for i := start; i < stop; i++ {
+			if req.Predicate != nil && req.Predicate.Root != nil {
+				ok, err := eval.match(req.Predicate.Root, s.tagMap, s.value(i))
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+			}
+			indexes = append(indexes, i)
+		}
+		if len(indexes) == 0 {
+			continue
+		}
+		results = append(results, fileSeriesResult{
+			groupKey: appendSeriesKey(nil, &storage.ReadResponse_SeriesFrame{Tags: s.tags}, &c.readSpec),
+			series:   s,
+			indexes:  indexes,
+		})
+	}
+
+	// Series of the same group must be consecutive.
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].groupKey.Compare(results[j].groupKey) < 0
+	})
+
+	if offset := req.SeriesOffset; offset > 0 {
+		if offset > uint64(len(results)) {
+			offset = uint64(len(results))
+		}
+		results = results[offset:]
+	}
+	if limit := req.SeriesLimit; limit > 0 && limit < uint64(len(results)) {
+		results = results[:limit]
+	}
+
+	var aggType storage.Aggregate_AggregateType
+	if req.Aggregate != nil {
+		aggType = req.Aggregate.Type
+	}
+	stream := &fileReadStream{
+		ctx:       ctx,
+		responses: make([]storage.ReadResponse, 0, len(results)),
+	}
+	for _, r := range results {
+		if req.Descending {
+			for i, j := 0, len(r.indexes)-1; i < j; i, j = i+1, j-1 {
+				r.indexes[i], r.indexes[j] = r.indexes[j], r.indexes[i]
+			}
+		}
+		if limit := req.PointsLimit; limit > 0 && limit < uint64(len(r.indexes)) {
+			r.indexes = r.indexes[:limit]
+		}
+		frames, err := seriesFrames(r.series, r.indexes, aggType, req.TimestampRange.End)
+		if err != nil {
+			return nil, err
+		}
+		stream.responses = append(stream.responses, storage.ReadResponse{Frames: frames})
+	}
+	return stream, nil
+}
+
+// seriesFrames produces the series frame and the points frames for the values of s at the given indexes.
+func seriesFrames(s *fileSeries, indexes []int, agg storage.Aggregate_AggregateType, aggTime int64) ([]storage.ReadResponse_Frame, error) {
+	typ := s.typ
+	switch agg {
+	case storage.AggregateTypeNone:
+	case storage.AggregateTypeCount:
+		typ = storage.DataTypeInteger
+	case storage.AggregateTypeSum:
+		switch typ {
+		case storage.DataTypeFloat, storage.DataTypeInteger, storage.DataTypeUnsigned:
+		default:
+			return nil, fmt.Errorf("unsupported aggregate %v for series %q of type %v", agg, s.key, typ)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported aggregate %v", agg)
+	}
+
+	frames := []storage.ReadResponse_Frame{{
+		Data: &storage.ReadResponse_Frame_Series{
+			Series: &storage.ReadResponse_SeriesFrame{
+				Tags:     s.tags,
+				DataType: typ,
+			},
+		},
+	}}
+
+	switch agg {
+	case storage.AggregateTypeCount:
+		frames = append(frames, storage.ReadResponse_Frame{
+			Data: &storage.ReadResponse_Frame_IntegerPoints{
+				IntegerPoints: &storage.ReadResponse_IntegerPointsFrame{
+					Timestamps: []int64{aggTime},
+					Values:     []int64{int64(len(indexes))},
+				},
+			},
+		})
+		return frames, nil
+	case storage.AggregateTypeSum:
+		var frame storage.ReadResponse_Frame
+		switch typ {
+		case storage.DataTypeFloat:
+			var sum float64
+			for _, i := range indexes {
+				sum += s.floats[i]
+			}
+			frame.Data = &storage.ReadResponse_Frame_FloatPoints{
+				FloatPoints: &storage.ReadResponse_FloatPointsFrame{Timestamps: []int64{aggTime}, Values: []float64{sum}},
+			}
+		case storage.DataTypeInteger:
+			var sum int64
+			for _, i := range indexes {
+				sum += s.ints[i]
+			}
+			frame.Data = &storage.ReadResponse_Frame_IntegerPoints{
+				IntegerPoints: &storage.ReadResponse_IntegerPointsFrame{Timestamps: []int64{aggTime}, Values: []int64{sum}},
+			}
+		case storage.DataTypeUnsigned:
+			var sum uint64
+			for _, i := range indexes {
+				sum += s.uints[i]
+			}
+			frame.Data = &storage.ReadResponse_Frame_UnsignedPoints{
+				UnsignedPoints: &storage.ReadResponse_UnsignedPointsFrame{Timestamps: []int64{aggTime}, Values: []uint64{sum}},
+			}
+		}
+		return append(frames, frame), nil
+	}
+
+	for len(indexes) > 0 {
+		l := len(indexes)
+		if l > fileStoragePointsPerFrame {
+			l = fileStoragePointsPerFrame
+		}
+		chunk := indexes[:l]
+		indexes = indexes[l:]
+
+		timestamps := make([]int64, l)
+		for k, i := range chunk {
+			timestamps[k] = s.times[i]
+		}
+		var frame storage.ReadResponse_Frame
+		switch typ {
+		case storage.DataTypeFloat:
+			values := make([]float64, l)
+			for k, i := range chunk {
+				values[k] = s.floats[i]
+			}
+			frame.Data = &storage.ReadResponse_Frame_FloatPoints{
+				FloatPoints: &storage.ReadResponse_FloatPointsFrame{Timestamps: timestamps, Values: values},
+			}
+		case storage.DataTypeInteger:
+			values := make([]int64, l)
+			for k, i := range chunk {
+				values[k] = s.ints[i]
+			}
+			frame.Data = &storage.ReadResponse_Frame_IntegerPoints{
+				IntegerPoints: &storage.ReadResponse_IntegerPointsFrame{Timestamps: timestamps, Values: values},
+			}
+		case storage.DataTypeUnsigned:
+			values := make([]uint64, l)
+			for k, i := range chunk {
+				values[k] = s.uints[i]
+			}
+			frame.Data = &storage.ReadResponse_Frame_UnsignedPoints{
+				UnsignedPoints: &storage.ReadResponse_UnsignedPointsFrame{Timestamps: timestamps, Values: values},
+			}
+		case storage.DataTypeBoolean:
+			values := make([]bool, l)
+			for k, i := range chunk {
+				values[k] = s.bools[i]
+			}
+			frame.Data = &storage.ReadResponse_Frame_BooleanPoints{
+				BooleanPoints: &storage.ReadResponse_BooleanPointsFrame{Timestamps: timestamps, Values: values},
+			}
+		case storage.DataTypeString:
+			values := make([]string, l)
+			for k, i := range chunk {
+				values[k] = s.strs[i]
+			}
+			frame.Data = &storage.ReadResponse_Frame_StringPoints{
+				StringPoints: &storage.ReadResponse_StringPointsFrame{Timestamps: timestamps, Values: values},
+			}
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// fileReadStream implements storage.Storage_ReadClient over a precomputed list of responses.
+// Only the receiving methods are implemented, the embedded interface is nil.
+type fileReadStream struct {
+	storage.Storage_ReadClient
+
+	ctx       context.Context
+	responses []storage.ReadResponse
+}
+
+func (s *fileReadStream) Recv() (*storage.ReadResponse, error) {
+	rep := new(storage.ReadResponse)
+	if err := s.RecvMsg(rep); err != nil {
+		return nil, err
+	}
+	return rep, nil
+}
+
+func (s *fileReadStream) RecvMsg(m interface{}) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if len(s.responses) == 0 {
+		return io.EOF
+	}
+	rep, ok := m.(*storage.ReadResponse)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+	*rep = s.responses[0]
+	s.responses = s.responses[1:]
+	return nil
+}
+
+func (s *fileReadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fileReadStream) CloseSend() error {
+	return nil
+}
+
+// predicateEvaluator evaluates storage predicates against the tags and value of a single point.
+type predicateEvaluator struct {
+	regexps map[string]*regexp.Regexp
+}
+
+func newPredicateEvaluator() *predicateEvaluator {
+	return &predicateEvaluator{
+		regexps: make(map[string]*regexp.Regexp),
+	}
+}
+
+func (e *predicateEvaluator) match(n *storage.Node, tags map[string]string, value interface{}) (bool, error) {
+	v, err := e.eval(n, tags, value)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("predicate node %v does not evaluate to a boolean", n.NodeType)
+	}
+	return b, nil
+}
+
+func (e *predicateEvaluator) eval(n *storage.Node, tags map[string]string, value interface{}) (interface{}, error) {
+	switch n.NodeType {
+	case storage.NodeTypeParenExpression:
+		if len(n.Children) != 1 {
+			return nil, errors.New("paren expression must have exactly one child")
+		}
+		return e.eval(n.Children[0], tags, value)
+	case storage.NodeTypeLogicalExpression:
+		if len(n.Children) != 2 {
+			return nil, errors.New("logical expression must have exactly two children")
+		}
+		l, err := e.match(n.Children[0], tags, value)
+		if err != nil {
+			return nil, err
+		}
+		switch n.GetLogical() {
+		case storage.LogicalAnd:
+			if !l {
+				return false, nil
+			}
+		case storage.LogicalOr:
+			if l {
+				return true, nil
+			}
+		default:
+			return nil, fmt.Errorf("unknown logical operator %v", n.GetLogical())
+		}
+		return e.match(n.Children[1], tags, value)
+	case storage.NodeTypeComparisonExpression:
+		if len(n.Children) != 2 {
+			return nil, errors.New("comparison expression must have exactly two children")
+		}
+		l, err := e.eval(n.Children[0], tags, value)
+		if err != nil {
+			return nil, err
+		}
+		r, err := e.eval(n.Children[1], tags, value)
+		if err != nil {
+			return nil, err
+		}
+		return e.compare(n.GetComparison(), l, r)
+	case storage.NodeTypeTagRef:
+		// Missing tags compare as the empty string.
+		return tags[n.GetTagRefValue()], nil
+	case storage.NodeTypeFieldRef:
+		return value, nil
+	case storage.NodeTypeLiteral:
+		switch v := n.GetValue().(type) {
+		case *storage.Node_StringValue:
+			return v.StringValue, nil
+		case *storage.Node_BooleanValue:
+			return v.BooleanValue, nil
+		case *storage.Node_IntegerValue:
+			return v.IntegerValue, nil
+		case *storage.Node_UnsignedValue:
+			return v.UnsignedValue, nil
+		case *storage.Node_FloatValue:
+			return v.FloatValue, nil
+		case *storage.Node_RegexValue:
+			return e.regexp(v.RegexValue)
+		default:
+			return nil, fmt.Errorf("unknown literal type %T", v)
+		}
+	default:
+		return nil, fmt.Errorf("unknown predicate node type %v", n.NodeType)
+	}
+}
+
+func (e *predicateEvaluator) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := e.regexps[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	e.regexps[pattern] = re
+	return re, nil
+}
+
+// compare applies the comparison operator to the values.
+// Values of incompatible types never match.
+func (e *predicateEvaluator) compare(op storage.Node_Comparison, l, r interface{}) (bool, error) {
+	if re, ok := r.(*regexp.Regexp); ok {
+		s, ok := l.(string)
+		if !ok {
+			return false, nil
+		}
+		switch op {
+		case storage.ComparisonEqual, storage.ComparisonRegex:
+			return re.MatchString(s), nil
+		case storage.ComparisonNotEqual, storage.ComparisonNotRegex:
+			return !re.MatchString(s), nil
+		default:
+			return false, fmt.Errorf("unsupported comparison %v with regular expression", op)
+		}
+	}
+
+	var c int
+	switch l := l.(type) {
+	case string:
+		r, ok := r.(string)
+		if !ok {
+			return false, nil
+		}
+		if op == storage.ComparisonStartsWith {
+			return strings.HasPrefix(l, r), nil
+		}
+		c = strings.Compare(l, r)
+	case bool:
+		r, ok := r.(bool)
+		if !ok {
+			return false, nil
+		}
+		switch op {
+		case storage.ComparisonEqual:
+			return l == r, nil
+		case storage.ComparisonNotEqual:
+			return l != r, nil
+		default:
+			return false, fmt.Errorf("unsupported comparison %v for booleans", op)
+		}
+	case int64, uint64, float64:
+		var ok bool
+		c, ok = compareNumbers(l, r)
+		if !ok {
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+
+	switch op {
+	case storage.ComparisonEqual:
+		return c == 0, nil
+	case storage.ComparisonNotEqual:
+		return c != 0, nil
+	case storage.ComparisonLess:
+		return c < 0, nil
+	case storage.ComparisonLessEqual:
+		return c <= 0, nil
+	case storage.ComparisonGreater:
+		return c > 0, nil
+	case storage.ComparisonGreaterEqual:
+		return c >= 0, nil
+	default:
+		return false, fmt.Errorf("unsupported comparison %v", op)
+	}
+}
+
+// compareNumbers compares two numeric values, reporting false if either value is not a number.
+func compareNumbers(l, r interface{}) (int, bool) {
+	switch l := l.(type) {
+	case int64:
+		switch r := r.(type) {
+		case int64:
+			return compareInts(l, r), true
+		case uint64:
+			if l < 0 {
+				return -1, true
+			}
+			return compareUInts(uint64(l), r), true
+		case float64:
+			return compareFloats(float64(l), r), true
+		}
+	case uint64:
+		switch r := r.(type) {
+		case int64:
+			if r < 0 {
+				return 1, true
+			}
+			return compareUInts(l, uint64(r)), true
+		case uint64:
+			return compareUInts(l, r), true
+		case float64:
+			return compareFloats(float64(l), r), true
+		}
+	case float64:
+		switch r := r.(type) {
+		case int64:
+			return compareFloats(l, float64(r)), true
+		case uint64:
+			return compareFloats(l, float64(r)), true
+		case float64:
+			return compareFloats(l, r), true
+		}
+	}
+	return 0, false
+}
+
+func compareInts(l, r int64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUInts(l, r uint64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloats(l, r float64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
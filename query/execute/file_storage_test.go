@@ -0,0 +1,317 @@
+package execute_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/ast"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/semantic"
+)
+
+const fileStorageData = `# DDL
+CREATE DATABASE db0
+# DML
+# CONTEXT-DATABASE: db0
+cpu,host=a,region=east usage=1.0 10
+cpu,host=b,region=west usage=2.0 10
+cpu,host=a,region=east usage=3.0 20
+cpu,host=b,region=west usage=4.0 20
+mem,host=a used=100i 10
+# CONTEXT-DATABASE: db1
+cpu,host=c,region=east usage=5.0 10
+`
+
+func predicate(body semantic.Expression) *semantic.FunctionExpression {
+	return &semantic.FunctionExpression{
+		Params: []*semantic.FunctionParam{{Key: &semantic.Identifier{Name: "r"}}},
+		Body:   body,
+	}
+}
+
+func member(property string) *semantic.MemberExpression {
+	return &semantic.MemberExpression{
+		Object:   &semantic.IdentifierExpression{Name: "r"},
+		Property: property,
+	}
+}
+
+func TestFileStorageReader_Read(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "data.lp")
+	if err := ioutil.WriteFile(path, []byte(fileStorageData), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	sr, err := execute.NewFileStorageReader([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sr.Close()
+
+	bounds := execute.Bounds{Start: 0, Stop: 100}
+	cpuCols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+		{Label: "_field", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+		{Label: "_measurement", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+		{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+		{Label: "region", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+	}
+	isCPU := &semantic.BinaryExpression{
+		Operator: ast.EqualOperator,
+		Left:     member("_measurement"),
+		Right:    &semantic.StringLiteral{Value: "cpu"},
+	}
+
+	testCases := []struct {
+		name     string
+		readSpec execute.ReadSpec
+		want     []*executetest.Block
+	}{
+		{
+			name:     "all series",
+			readSpec: execute.ReadSpec{Database: "db0"},
+			want: []*executetest.Block{
+				{
+					Bnds:    bounds,
+					ColMeta: cpuCols,
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0, "usage", "cpu", "a", "east"},
+						{execute.Time(20), 3.0, "usage", "cpu", "a", "east"},
+					},
+				},
+				{
+					Bnds:    bounds,
+					ColMeta: cpuCols,
+					Data: [][]interface{}{
+						{execute.Time(10), 2.0, "usage", "cpu", "b", "west"},
+						{execute.Time(20), 4.0, "usage", "cpu", "b", "west"},
+					},
+				},
+				{
+					Bnds: bounds,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+						{Label: "_field", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "_measurement", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+						{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), int64(100), "used", "mem", "a"},
+					},
+				},
+			},
+		},
+		{
+			name:     "other database",
+			readSpec: execute.ReadSpec{Database: "db1"},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cpuCols,
+				Data: [][]interface{}{
+					{execute.Time(10), 5.0, "usage", "cpu", "c", "east"},
+				},
+			}},
+		},
+		{
+			name: "value predicate",
+			readSpec: execute.ReadSpec{
+				Database: "db0",
+				Predicate: predicate(&semantic.LogicalExpression{
+					Operator: ast.AndOperator,
+					Left:     isCPU,
+					Right: &semantic.BinaryExpression{
+						Operator: ast.GreaterThanOperator,
+						Left:     member("_value"),
+						Right:    &semantic.FloatLiteral{Value: 1.5},
+					},
+				}),
+			},
+			want: []*executetest.Block{
+				{
+					Bnds:    bounds,
+					ColMeta: cpuCols,
+					Data: [][]interface{}{
+						{execute.Time(20), 3.0, "usage", "cpu", "a", "east"},
+					},
+				},
+				{
+					Bnds:    bounds,
+					ColMeta: cpuCols,
+					Data: [][]interface{}{
+						{execute.Time(10), 2.0, "usage", "cpu", "b", "west"},
+						{execute.Time(20), 4.0, "usage", "cpu", "b", "west"},
+					},
+				},
+			},
+		},
+		{
+			name: "regex predicate",
+			readSpec: execute.ReadSpec{
+				Database: "db0",
+				Predicate: predicate(&semantic.BinaryExpression{
+					Operator: ast.EqualOperator,
+					Left:     member("region"),
+					Right:    &semantic.RegexpLiteral{Value: regexp.MustCompile("^w")},
+				}),
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cpuCols,
+				Data: [][]interface{}{
+					{execute.Time(10), 2.0, "usage", "cpu", "b", "west"},
+					{execute.Time(20), 4.0, "usage", "cpu", "b", "west"},
+				},
+			}},
+		},
+		{
+			name: "regex not match predicate",
+			readSpec: execute.ReadSpec{
+				Database: "db0",
+				Predicate: predicate(&semantic.LogicalExpression{
+					Operator: ast.AndOperator,
+					Left:     isCPU,
+					Right: &semantic.BinaryExpression{
+						Operator: ast.NotRegexpMatchOperator,
+						Left:     member("region"),
+						Right:    &semantic.RegexpLiteral{Value: regexp.MustCompile("^w")},
+					},
+				}),
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cpuCols,
+				Data: [][]interface{}{
+					{execute.Time(10), 1.0, "usage", "cpu", "a", "east"},
+					{execute.Time(20), 3.0, "usage", "cpu", "a", "east"},
+				},
+			}},
+		},
+		{
+			name: "group keys",
+			readSpec: execute.ReadSpec{
+				Database:  "db0",
+				Predicate: predicate(isCPU),
+				GroupKeys: []string{"region"},
+			},
+			want: []*executetest.Block{
+				{
+					Bnds: bounds,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "region", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0, "east"},
+						{execute.Time(20), 3.0, "east"},
+					},
+				},
+				{
+					Bnds: bounds,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+						{Label: "region", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 2.0, "west"},
+						{execute.Time(20), 4.0, "west"},
+					},
+				},
+			},
+		},
+		{
+			name: "descending points limit",
+			readSpec: execute.ReadSpec{
+				Database:    "db0",
+				Predicate:   predicate(isCPU),
+				Descending:  true,
+				PointsLimit: 1,
+			},
+			want: []*executetest.Block{
+				{
+					Bnds:    bounds,
+					ColMeta: cpuCols,
+					Data: [][]interface{}{
+						{execute.Time(20), 3.0, "usage", "cpu", "a", "east"},
+					},
+				},
+				{
+					Bnds:    bounds,
+					ColMeta: cpuCols,
+					Data: [][]interface{}{
+						{execute.Time(20), 4.0, "usage", "cpu", "b", "west"},
+					},
+				},
+			},
+		},
+		{
+			name: "series limit and offset",
+			readSpec: execute.ReadSpec{
+				Database:     "db0",
+				SeriesLimit:  1,
+				SeriesOffset: 1,
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cpuCols,
+				Data: [][]interface{}{
+					{execute.Time(10), 2.0, "usage", "cpu", "b", "west"},
+					{execute.Time(20), 4.0, "usage", "cpu", "b", "west"},
+				},
+			}},
+		},
+		{
+			name: "count",
+			readSpec: execute.ReadSpec{
+				Database:        "db0",
+				Predicate:       predicate(isCPU),
+				AggregateMethod: "count",
+				MergeAll:        true,
+			},
+			want: []*executetest.Block{{
+				Bnds: bounds,
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TInt, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(100), int64(2)},
+					{execute.Time(100), int64(2)},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bi, err := sr.Read(context.Background(), nil, tc.readSpec, bounds.Start, bounds.Stop)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []*executetest.Block
+			if err := bi.Do(func(b execute.Block) error {
+				got = append(got, executetest.ConvertBlock(b))
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
@@ -204,6 +204,9 @@ func (f *Formatter) writeHeaderSeparator(w *writeToHelper) {
 }
 
 func (f *Formatter) valueBuf(i, j int, typ DataType, rr RowReader) (buf []byte) {
+	if rr.IsNull(i, j) {
+		return append(f.fmtBuf[0:0], "null"...)
+	}
 	switch typ {
 	case TBool:
 		buf = strconv.AppendBool(f.fmtBuf[0:0], rr.AtBool(i, j))
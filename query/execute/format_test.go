@@ -0,0 +1,37 @@
+package execute_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+)
+
+func TestFormatter_Nulls(t *testing.T) {
+	b := &executetest.Block{
+		Bnds: execute.Bounds{Start: 0, Stop: 10},
+		ColMeta: []execute.ColMeta{
+			{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+			{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+			{Label: "cpu", Type: execute.TString, Kind: execute.TagColKind},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), 1.5, nil},
+			{execute.Time(2), nil, "cpu0"},
+		},
+	}
+	var buf bytes.Buffer
+	if _, err := execute.NewFormatter(b, nil).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	rows := lines[len(lines)-2:]
+	// The tags are formatted before the values.
+	for i, want := range [][]string{{"null", "1.5"}, {"cpu0", "null"}} {
+		if got := strings.Fields(rows[i])[1:]; strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("unexpected row %d: got %q want %q", i, got, want)
+		}
+	}
+}
@@ -7,7 +7,14 @@ import (
 )
 
 type Result interface {
+	// Blocks returns an iterator over the blocks of the result.
+	// Retractions are not reported by the iterator, see DoWithRetractions.
 	Blocks() BlockIterator
+	// DoWithRetractions calls f for each block of the result and retract for each retraction,
+	// in the order they were produced.
+	// A retraction reports that the previously produced blocks with the same bounds and tags are no longer valid,
+	// a corrected block may follow.
+	DoWithRetractions(f func(Block) error, retract func(BlockMetadata) error) error
 	abort(error)
 }
 
@@ -22,8 +29,9 @@ type resultSink struct {
 }
 
 type resultMessage struct {
-	block Block
-	err   error
+	block   Block
+	retract BlockMetadata
+	err     error
 }
 
 func newResultSink(plan.YieldSpec) *resultSink {
@@ -35,8 +43,17 @@ func newResultSink(plan.YieldSpec) *resultSink {
 	}
 }
 
-func (s *resultSink) RetractBlock(DatasetID, BlockMetadata) error {
-	//TODO implement
+func (s *resultSink) RetractBlock(id DatasetID, meta BlockMetadata) error {
+	select {
+	case s.blocks <- resultMessage{
+		// Copy the metadata as it may be the builder of the retracted block.
+		retract: blockMetadata{
+			bounds: meta.Bounds(),
+			tags:   meta.Tags().Copy(),
+		},
+	}:
+	case <-s.aborted:
+	}
 	return nil
 }
 
@@ -55,6 +72,10 @@ func (s *resultSink) Blocks() BlockIterator {
 }
 
 func (s *resultSink) Do(f func(Block) error) error {
+	return s.DoWithRetractions(f, nil)
+}
+
+func (s *resultSink) DoWithRetractions(f func(Block) error, retract func(BlockMetadata) error) error {
 	for {
 		select {
 		case err := <-s.abortErr:
@@ -66,6 +87,14 @@ func (s *resultSink) Do(f func(Block) error) error {
 			if msg.err != nil {
 				return msg.err
 			}
+			if msg.retract != nil {
+				if retract != nil {
+					if err := retract(msg.retract); err != nil {
+						return err
+					}
+				}
+				continue
+			}
 			if err := f(msg.block); err != nil {
 				return err
 			}
@@ -48,6 +48,8 @@ func (f *rowFn) prepare(cols []ColMeta) error {
 				f.recordCols[r] = j
 				found = true
 				propertyTypes[r] = ConvertToKind(c.Type)
+				// The property keeps the type of the column when its value is null
+				f.record.SetPropertyType(r, propertyTypes[r])
 				break
 			}
 		}
@@ -143,11 +145,15 @@ func (f *RowPredicateFn) Prepare(cols []ColMeta) error {
 	return nil
 }
 
+// Eval reports whether the row matches the predicate, a null result does not match.
 func (f *RowPredicateFn) Eval(row int, rr RowReader) (bool, error) {
 	v, err := f.rowFn.eval(row, rr)
 	if err != nil {
 		return false, err
 	}
+	if v.Type() == semantic.Nil {
+		return false, nil
+	}
 	return v.Bool(), nil
 }
 
@@ -202,6 +208,9 @@ func (f *RowMapFn) Eval(row int, rr RowReader) (*compiler.Object, error) {
 }
 
 func ValueForRow(i, j int, rr RowReader) compiler.Value {
+	if rr.IsNull(i, j) {
+		return compiler.NewNil()
+	}
 	t := rr.Cols()[j].Type
 	switch t {
 	case TBool:
@@ -222,8 +231,15 @@ func ValueForRow(i, j int, rr RowReader) compiler.Value {
 	}
 }
 
+// AppendValue appends the value to the column, missing and nil values are appended as null values.
 func AppendValue(builder BlockBuilder, j int, v compiler.Value) {
+	if v == nil {
+		builder.AppendNil(j)
+		return
+	}
 	switch k := v.Type().Kind(); k {
+	case semantic.Nil:
+		builder.AppendNil(j)
 	case semantic.Bool:
 		builder.AppendBool(j, v.Bool())
 	case semantic.Int:
@@ -382,6 +398,10 @@ func toComparisonOperator(o ast.OperatorKind) (storage.Node_Comparison, error) {
 		return storage.ComparisonGreater, nil
 	case ast.GreaterThanEqualOperator:
 		return storage.ComparisonGreaterEqual, nil
+	case ast.RegexpMatchOperator:
+		return storage.ComparisonRegex, nil
+	case ast.NotRegexpMatchOperator:
+		return storage.ComparisonNotRegex, nil
 	default:
 		return 0, fmt.Errorf("unknown operator %v", o)
 	}
@@ -7,10 +7,28 @@ import (
 type selectorTransformation struct {
 	d          Dataset
 	cache      BlockBuilderCache
+	mode       AccumulationMode
 	bounds     Bounds
 	useRowTime bool
 
 	colLabel string
+
+	// blocks holds the intermediate state of each output block.
+	blocks map[BlockKey]*selectorBlock
+}
+
+// selectorBlock is the intermediate state of an output block.
+type selectorBlock struct {
+	partialBlock
+	// rows holds the rows selected from each input block.
+	rows map[BlockKey]*selectedRows
+}
+
+type selectedRows struct {
+	stop Time
+	rows []Row
+	// selector is the state of the selector, it is kept so that more data can be selected from in discarding mode.
+	selector interface{}
 }
 
 type rowSelectorTransformation struct {
@@ -25,11 +43,11 @@ type indexSelectorTransformation struct {
 func NewRowSelectorTransformationAndDataset(id DatasetID, mode AccumulationMode, bounds Bounds, selector RowSelector, colLabel string, useRowTime bool, a *Allocator) (*rowSelectorTransformation, Dataset) {
 	cache := NewBlockBuilderCache(a)
 	d := NewDataset(id, mode, cache)
-	return NewRowSelectorTransformation(d, cache, bounds, selector, colLabel, useRowTime), d
+	return NewRowSelectorTransformation(d, cache, mode, bounds, selector, colLabel, useRowTime), d
 }
-func NewRowSelectorTransformation(d Dataset, c BlockBuilderCache, bounds Bounds, selector RowSelector, colLabel string, useRowTime bool) *rowSelectorTransformation {
+func NewRowSelectorTransformation(d Dataset, c BlockBuilderCache, mode AccumulationMode, bounds Bounds, selector RowSelector, colLabel string, useRowTime bool) *rowSelectorTransformation {
 	return &rowSelectorTransformation{
-		selectorTransformation: newSelectorTransformation(d, c, bounds, colLabel, useRowTime),
+		selectorTransformation: newSelectorTransformation(d, c, mode, bounds, colLabel, useRowTime),
 		selector:               selector,
 	}
 }
@@ -37,35 +55,69 @@ func NewRowSelectorTransformation(d Dataset, c BlockBuilderCache, bounds Bounds,
 func NewIndexSelectorTransformationAndDataset(id DatasetID, mode AccumulationMode, bounds Bounds, selector IndexSelector, colLabel string, useRowTime bool, a *Allocator) (*indexSelectorTransformation, Dataset) {
 	cache := NewBlockBuilderCache(a)
 	d := NewDataset(id, mode, cache)
-	return NewIndexSelectorTransformation(d, cache, bounds, selector, colLabel, useRowTime), d
+	return NewIndexSelectorTransformation(d, cache, mode, bounds, selector, colLabel, useRowTime), d
 }
-func NewIndexSelectorTransformation(d Dataset, c BlockBuilderCache, bounds Bounds, selector IndexSelector, colLabel string, useRowTime bool) *indexSelectorTransformation {
+func NewIndexSelectorTransformation(d Dataset, c BlockBuilderCache, mode AccumulationMode, bounds Bounds, selector IndexSelector, colLabel string, useRowTime bool) *indexSelectorTransformation {
 	return &indexSelectorTransformation{
-		selectorTransformation: newSelectorTransformation(d, c, bounds, colLabel, useRowTime),
+		selectorTransformation: newSelectorTransformation(d, c, mode, bounds, colLabel, useRowTime),
 		selector:               selector,
 	}
 }
 
-func newSelectorTransformation(d Dataset, c BlockBuilderCache, bounds Bounds, colLabel string, useRowTime bool) selectorTransformation {
+func newSelectorTransformation(d Dataset, c BlockBuilderCache, mode AccumulationMode, bounds Bounds, colLabel string, useRowTime bool) selectorTransformation {
 	if colLabel == "" {
 		colLabel = DefaultValueColLabel
 	}
 	return selectorTransformation{
 		d:          d,
 		cache:      c,
+		mode:       mode,
 		bounds:     bounds,
 		colLabel:   colLabel,
 		useRowTime: useRowTime,
+		blocks:     make(map[BlockKey]*selectorBlock),
 	}
 }
 
 func (t *selectorTransformation) RetractBlock(id DatasetID, meta BlockMetadata) error {
-	//TODO(nathanielc): Store intermediate state for retractions
-	key := ToBlockKey(meta)
-	return t.d.RetractBlock(key)
+	key := ToBlockKey(blockMetadata{
+		bounds: t.bounds,
+		tags:   meta.Tags(),
+	})
+	sb, ok := t.blocks[key]
+	if !ok {
+		return nil
+	}
+	builder, ok := lookupBlockBuilder(t.cache, key)
+	if !ok {
+		// The output block has expired, so its state is no longer needed.
+		delete(t.blocks, key)
+		return nil
+	}
+	inKey := ToBlockKey(meta)
+	if _, ok := sb.rows[inKey]; !ok {
+		return nil
+	}
+	delete(sb.rows, inKey)
+	sb.sync(builder)
+	sb.remove(inKey)
+
+	// Retract the output block and rebuild it from the remaining rows,
+	// the corrected block is emitted the next time the block is triggered.
+	if err := t.d.RetractBlock(key); err != nil {
+		return err
+	}
+	t.build(builder, sb)
+	return nil
 }
 func (t *selectorTransformation) UpdateWatermark(id DatasetID, mark Time) error {
-	return t.d.UpdateWatermark(mark)
+	if err := t.d.UpdateWatermark(mark); err != nil {
+		return err
+	}
+	if t.mode == DiscardingMode {
+		t.evict(mark)
+	}
+	return nil
 }
 func (t *selectorTransformation) UpdateProcessingTime(id DatasetID, pt Time) error {
 	return t.d.UpdateProcessingTime(pt)
@@ -74,62 +126,129 @@ func (t *selectorTransformation) Finish(id DatasetID, err error) {
 	t.d.Finish(err)
 }
 
-func (t *selectorTransformation) setupBuilder(b Block) (BlockBuilder, int) {
-	builder, new := t.cache.BlockBuilder(blockMetadata{
+// evict removes the state of the input blocks that have been emitted and that the watermark has passed,
+// as well as the state of output blocks that have expired.
+func (t *selectorTransformation) evict(mark Time) {
+	live := make(map[BlockKey]bool, len(t.blocks))
+	t.cache.ForEachBuilder(func(key BlockKey, builder BlockBuilder) {
+		sb, ok := t.blocks[key]
+		if !ok {
+			return
+		}
+		live[key] = true
+		sb.sync(builder)
+		for k, sr := range sb.rows {
+			if sr.stop <= mark && !sb.isPending(k) {
+				delete(sb.rows, k)
+			}
+		}
+	})
+	for key := range t.blocks {
+		if !live[key] {
+			delete(t.blocks, key)
+		}
+	}
+}
+
+// setupBuilder returns the builder of the output block for b, along with the state of the rows selected from b.
+func (t *selectorTransformation) setupBuilder(b Block) (BlockBuilder, *selectorBlock, BlockKey, *selectedRows, int) {
+	meta := blockMetadata{
 		bounds: t.bounds,
 		tags:   b.Tags(),
-	})
+	}
+	key := ToBlockKey(meta)
+	builder, new := t.cache.BlockBuilder(meta)
 	if new {
 		AddBlockCols(b, builder)
+		// Any previous state belongs to a block that has expired.
+		delete(t.blocks, key)
+	}
+	sb, ok := t.blocks[key]
+	if !ok {
+		sb = &selectorBlock{
+			rows: make(map[BlockKey]*selectedRows),
+		}
+		t.blocks[key] = sb
 	}
 
+	inKey := ToBlockKey(b)
+	sr, ok := sb.rows[inKey]
+	if !ok || t.mode != DiscardingMode {
+		// Unless discarding, a block contains all the data of the previous blocks with the same key,
+		// so the rows are selected anew.
+		sr = &selectedRows{}
+		sb.rows[inKey] = sr
+	}
+	sr.stop = b.Bounds().Stop
+
 	cols := builder.Cols()
 	valueIdx := ColIdx(t.colLabel, cols)
-	return builder, valueIdx
+	return builder, sb, inKey, sr, valueIdx
 }
 
 func (t *indexSelectorTransformation) Process(id DatasetID, b Block) error {
-	builder, valueIdx := t.setupBuilder(b)
+	builder, sb, inKey, sr, valueIdx := t.setupBuilder(b)
 	valueCol := builder.Cols()[valueIdx]
 
 	values := b.Col(valueIdx)
 	switch valueCol.Type {
 	case TBool:
-		s := t.selector.NewBoolSelector()
+		s, _ := sr.selector.(DoBoolIndexSelector)
+		if s == nil {
+			s = t.selector.NewBoolSelector()
+			sr.selector = s
+		}
 		values.DoBool(func(vs []bool, rr RowReader) {
-			selected := s.DoBool(vs)
-			t.appendSelected(selected, builder, rr, b.Bounds().Stop)
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			sr.rows = appendSelected(sr.rows, s.DoBool(nonNullBools(vs, rows)), newNonNullRowReader(rr, rows))
 		})
 	case TInt:
-		s := t.selector.NewIntSelector()
+		s, _ := sr.selector.(DoIntIndexSelector)
+		if s == nil {
+			s = t.selector.NewIntSelector()
+			sr.selector = s
+		}
 		values.DoInt(func(vs []int64, rr RowReader) {
-			selected := s.DoInt(vs)
-			t.appendSelected(selected, builder, rr, b.Bounds().Stop)
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			sr.rows = appendSelected(sr.rows, s.DoInt(nonNullInts(vs, rows)), newNonNullRowReader(rr, rows))
 		})
 	case TUInt:
-		s := t.selector.NewUIntSelector()
+		s, _ := sr.selector.(DoUIntIndexSelector)
+		if s == nil {
+			s = t.selector.NewUIntSelector()
+			sr.selector = s
+		}
 		values.DoUInt(func(vs []uint64, rr RowReader) {
-			selected := s.DoUInt(vs)
-			t.appendSelected(selected, builder, rr, b.Bounds().Stop)
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			sr.rows = appendSelected(sr.rows, s.DoUInt(nonNullUInts(vs, rows)), newNonNullRowReader(rr, rows))
 		})
 	case TFloat:
-		s := t.selector.NewFloatSelector()
+		s, _ := sr.selector.(DoFloatIndexSelector)
+		if s == nil {
+			s = t.selector.NewFloatSelector()
+			sr.selector = s
+		}
 		values.DoFloat(func(vs []float64, rr RowReader) {
-			selected := s.DoFloat(vs)
-			t.appendSelected(selected, builder, rr, b.Bounds().Stop)
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			sr.rows = appendSelected(sr.rows, s.DoFloat(nonNullFloats(vs, rows)), newNonNullRowReader(rr, rows))
 		})
 	case TString:
-		s := t.selector.NewStringSelector()
+		s, _ := sr.selector.(DoStringIndexSelector)
+		if s == nil {
+			s = t.selector.NewStringSelector()
+			sr.selector = s
+		}
 		values.DoString(func(vs []string, rr RowReader) {
-			selected := s.DoString(vs)
-			t.appendSelected(selected, builder, rr, b.Bounds().Stop)
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			sr.rows = appendSelected(sr.rows, s.DoString(nonNullStrings(vs, rows)), newNonNullRowReader(rr, rows))
 		})
 	}
+	t.update(builder, sb, inKey, sr)
 	return nil
 }
 
 func (t *rowSelectorTransformation) Process(id DatasetID, b Block) error {
-	builder, valueIdx := t.setupBuilder(b)
+	builder, sb, inKey, sr, valueIdx := t.setupBuilder(b)
 	if valueIdx < 0 {
 		return fmt.Errorf("no column %q exists", t.colLabel)
 	}
@@ -139,64 +258,139 @@ func (t *rowSelectorTransformation) Process(id DatasetID, b Block) error {
 	var rower Rower
 	switch valueCol.Type {
 	case TBool:
-		s := t.selector.NewBoolSelector()
-		values.DoBool(s.DoBool)
+		s, _ := sr.selector.(DoBoolRowSelector)
+		if s == nil {
+			s = t.selector.NewBoolSelector()
+			sr.selector = s
+		}
+		values.DoBool(func(vs []bool, rr RowReader) {
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			s.DoBool(nonNullBools(vs, rows), newNonNullRowReader(rr, rows))
+		})
 		rower = s
 	case TInt:
-		s := t.selector.NewIntSelector()
-		values.DoInt(s.DoInt)
+		s, _ := sr.selector.(DoIntRowSelector)
+		if s == nil {
+			s = t.selector.NewIntSelector()
+			sr.selector = s
+		}
+		values.DoInt(func(vs []int64, rr RowReader) {
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			s.DoInt(nonNullInts(vs, rows), newNonNullRowReader(rr, rows))
+		})
 		rower = s
 	case TUInt:
-		s := t.selector.NewUIntSelector()
-		values.DoUInt(s.DoUInt)
+		s, _ := sr.selector.(DoUIntRowSelector)
+		if s == nil {
+			s = t.selector.NewUIntSelector()
+			sr.selector = s
+		}
+		values.DoUInt(func(vs []uint64, rr RowReader) {
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			s.DoUInt(nonNullUInts(vs, rows), newNonNullRowReader(rr, rows))
+		})
 		rower = s
 	case TFloat:
-		s := t.selector.NewFloatSelector()
-		values.DoFloat(s.DoFloat)
+		s, _ := sr.selector.(DoFloatRowSelector)
+		if s == nil {
+			s = t.selector.NewFloatSelector()
+			sr.selector = s
+		}
+		values.DoFloat(func(vs []float64, rr RowReader) {
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			s.DoFloat(nonNullFloats(vs, rows), newNonNullRowReader(rr, rows))
+		})
 		rower = s
 	case TString:
-		s := t.selector.NewStringSelector()
-		values.DoString(s.DoString)
+		s, _ := sr.selector.(DoStringRowSelector)
+		if s == nil {
+			s = t.selector.NewStringSelector()
+			sr.selector = s
+		}
+		values.DoString(func(vs []string, rr RowReader) {
+			rows := nonNullRows(rr, valueIdx, len(vs))
+			s.DoString(nonNullStrings(vs, rows), newNonNullRowReader(rr, rows))
+		})
 		rower = s
 	}
 
-	rows := rower.Rows()
-	t.appendRows(builder, rows, b.Bounds().Stop)
+	sr.rows = rower.Rows()
+	t.update(builder, sb, inKey, sr)
 	return nil
 }
 
-func (t *indexSelectorTransformation) appendSelected(selected []int, builder BlockBuilder, rr RowReader, stop Time) {
-	if len(selected) == 0 {
+// nonNullRowReader reads the rows of a RowReader whose values are not null,
+// so that selectors do not select null values.
+type nonNullRowReader struct {
+	RowReader
+	// rows are the indexes of the rows of the RowReader.
+	rows []int
+}
+
+// newNonNullRowReader returns a reader of the rows of rr as returned by nonNullRows.
+func newNonNullRowReader(rr RowReader, rows []int) RowReader {
+	if rows == nil {
+		return rr
+	}
+	return nonNullRowReader{
+		RowReader: rr,
+		rows:      rows,
+	}
+}
+
+func (r nonNullRowReader) AtBool(i, j int) bool {
+	return r.RowReader.AtBool(r.rows[i], j)
+}
+func (r nonNullRowReader) AtInt(i, j int) int64 {
+	return r.RowReader.AtInt(r.rows[i], j)
+}
+func (r nonNullRowReader) AtUInt(i, j int) uint64 {
+	return r.RowReader.AtUInt(r.rows[i], j)
+}
+func (r nonNullRowReader) AtFloat(i, j int) float64 {
+	return r.RowReader.AtFloat(r.rows[i], j)
+}
+func (r nonNullRowReader) AtString(i, j int) string {
+	return r.RowReader.AtString(r.rows[i], j)
+}
+func (r nonNullRowReader) AtTime(i, j int) Time {
+	return r.RowReader.AtTime(r.rows[i], j)
+}
+func (r nonNullRowReader) IsNull(i, j int) bool {
+	return r.RowReader.IsNull(r.rows[i], j)
+}
+
+func appendSelected(rows []Row, selected []int, rr RowReader) []Row {
+	for _, i := range selected {
+		rows = append(rows, ReadRow(i, rr))
+	}
+	return rows
+}
+
+// update adds the rows selected from the input block to the builder.
+// The block is rebuilt when the builder already has rows of the input block, as they are outdated.
+func (t *selectorTransformation) update(builder BlockBuilder, sb *selectorBlock, inKey BlockKey, sr *selectedRows) {
+	sb.sync(builder)
+	if sb.isPending(inKey) {
+		t.build(builder, sb)
 		return
 	}
-	cols := builder.Cols()
-	for j, c := range cols {
-		for _, i := range selected {
-			switch c.Type {
-			case TBool:
-				builder.AppendBool(j, rr.AtBool(i, j))
-			case TInt:
-				builder.AppendInt(j, rr.AtInt(i, j))
-			case TUInt:
-				builder.AppendUInt(j, rr.AtUInt(i, j))
-			case TFloat:
-				builder.AppendFloat(j, rr.AtFloat(i, j))
-			case TString:
-				builder.AppendString(j, rr.AtString(i, j))
-			case TTime:
-				time := stop
-				if t.useRowTime {
-					time = rr.AtTime(i, j)
-				}
-				builder.AppendTime(j, time)
-			default:
-				PanicUnknownType(c.Type)
-			}
-		}
+	sb.add(inKey)
+	t.appendRows(builder, sr.rows, sr.stop)
+	sb.nrows = builder.NRows()
+}
+
+// build replaces the rows of the builder with the rows selected from the pending input blocks.
+func (t *selectorTransformation) build(builder BlockBuilder, sb *selectorBlock) {
+	builder.ClearData()
+	for _, k := range sb.keys() {
+		sr := sb.rows[k]
+		t.appendRows(builder, sr.rows, sr.stop)
 	}
+	sb.nrows = builder.NRows()
 }
 
-func (t *rowSelectorTransformation) appendRows(builder BlockBuilder, rows []Row, stop Time) {
+func (t *selectorTransformation) appendRows(builder BlockBuilder, rows []Row, stop Time) {
 	cols := builder.Cols()
 	for j, c := range cols {
 		for _, row := range rows {
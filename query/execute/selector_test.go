@@ -348,6 +348,41 @@ func TestRowSelector_Process(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:       "nulls",
+			useRowTime: true,
+			bounds: execute.Bounds{
+				Start: 0,
+				Stop:  100,
+			},
+			data: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  100,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 5.0},
+					{execute.Time(10), nil},
+					{execute.Time(20), 3.0},
+				},
+			}},
+			want: func(b execute.Bounds) []*executetest.Block {
+				return []*executetest.Block{{
+					Bnds: b,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(20), 3.0},
+					},
+				}}
+			},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -356,7 +391,7 @@ func TestRowSelector_Process(t *testing.T) {
 			c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
 			c.SetTriggerSpec(execute.DefaultTriggerSpec)
 
-			selector := execute.NewRowSelectorTransformation(d, c, tc.bounds, new(functions.MinSelector), tc.colLabel, tc.useRowTime)
+			selector := execute.NewRowSelectorTransformation(d, c, execute.AccumulatingMode, tc.bounds, new(functions.MinSelector), tc.colLabel, tc.useRowTime)
 
 			parentID := executetest.RandomDatasetID()
 			for _, b := range tc.data {
@@ -672,6 +707,41 @@ func TestIndexSelector_Process(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:       "nulls",
+			useRowTime: true,
+			bounds: execute.Bounds{
+				Start: 0,
+				Stop:  100,
+			},
+			data: []*executetest.Block{{
+				Bnds: execute.Bounds{
+					Start: 0,
+					Stop:  100,
+				},
+				ColMeta: []execute.ColMeta{
+					{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+					{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), nil},
+					{execute.Time(10), -3.0},
+					{execute.Time(20), -2.0},
+				},
+			}},
+			want: func(b execute.Bounds) []*executetest.Block {
+				return []*executetest.Block{{
+					Bnds: b,
+					ColMeta: []execute.ColMeta{
+						{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+						{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), -3.0},
+					},
+				}}
+			},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -680,7 +750,7 @@ func TestIndexSelector_Process(t *testing.T) {
 			c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
 			c.SetTriggerSpec(execute.DefaultTriggerSpec)
 
-			selector := execute.NewIndexSelectorTransformation(d, c, tc.bounds, new(functions.FirstSelector), "_value", tc.useRowTime)
+			selector := execute.NewIndexSelectorTransformation(d, c, execute.AccumulatingMode, tc.bounds, new(functions.FirstSelector), "_value", tc.useRowTime)
 
 			parentID := executetest.RandomDatasetID()
 			for _, b := range tc.data {
@@ -701,3 +771,161 @@ func TestIndexSelector_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestSelector_Retract(t *testing.T) {
+	bounds := execute.Bounds{
+		Start: 0,
+		Stop:  200,
+	}
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+	}
+	window := func(start, stop execute.Time, values ...float64) *executetest.Block {
+		b := &executetest.Block{
+			Bnds: execute.Bounds{
+				Start: start,
+				Stop:  stop,
+			},
+			ColMeta: cols,
+		}
+		for i, v := range values {
+			b.Data = append(b.Data, []interface{}{start + execute.Time(i), v})
+		}
+		return b
+	}
+	outKey := execute.ToBlockKey(&executetest.Block{Bnds: bounds})
+
+	type step struct {
+		block   *executetest.Block
+		retract bool
+	}
+	testCases := []struct {
+		name        string
+		mode        execute.AccumulationMode
+		index       bool
+		steps       []step
+		want        []*executetest.Block
+		retractions []execute.BlockKey
+	}{
+		{
+			name: "row accumulating replaces rows",
+			mode: execute.AccumulatingMode,
+			steps: []step{
+				{block: window(0, 100, 5, 4)},
+				{block: window(100, 200, 10)},
+				{block: window(0, 100, 5, 4, 3)},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(2), 3.0},
+					{execute.Time(100), 10.0},
+				},
+			}},
+		},
+		{
+			name: "row discarding accumulates rows",
+			mode: execute.DiscardingMode,
+			steps: []step{
+				{block: window(0, 100, 5, 4)},
+				{block: window(0, 100, 2)},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(0), 2.0},
+				},
+			}},
+		},
+		{
+			name: "row retract",
+			mode: execute.AccumulatingRetractingMode,
+			steps: []step{
+				{block: window(0, 100, 5, 4)},
+				{block: window(100, 200, 10)},
+				{block: window(0, 100), retract: true},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(100), 10.0},
+				},
+			}},
+			retractions: []execute.BlockKey{outKey},
+		},
+		{
+			name:  "index discarding accumulates rows",
+			mode:  execute.DiscardingMode,
+			index: true,
+			steps: []step{
+				{block: window(0, 100, 5, 4)},
+				{block: window(0, 100, 2)},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(0), 5.0},
+				},
+			}},
+		},
+		{
+			name:  "index retract",
+			mode:  execute.AccumulatingRetractingMode,
+			index: true,
+			steps: []step{
+				{block: window(0, 100, 5, 4)},
+				{block: window(100, 200, 10)},
+				{block: window(100, 200), retract: true},
+			},
+			want: []*executetest.Block{{
+				Bnds:    bounds,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(0), 5.0},
+				},
+			}},
+			retractions: []execute.BlockKey{outKey},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewBlockBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+
+			var selector execute.Transformation
+			if tc.index {
+				selector = execute.NewIndexSelectorTransformation(d, c, tc.mode, bounds, new(functions.FirstSelector), "_value", true)
+			} else {
+				selector = execute.NewRowSelectorTransformation(d, c, tc.mode, bounds, new(functions.MinSelector), "_value", true)
+			}
+
+			parentID := executetest.RandomDatasetID()
+			for _, s := range tc.steps {
+				if s.retract {
+					if err := selector.RetractBlock(parentID, s.block); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				if err := selector.Process(parentID, s.block); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := executetest.BlocksFromCache(c)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+			if !cmp.Equal(tc.retractions, d.Retractions) {
+				t.Errorf("unexpected retractions -want/+got\n%s", cmp.Diff(tc.retractions, d.Retractions))
+			}
+		})
+	}
+}
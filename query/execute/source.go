@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/influxdata/ifql/query/plan"
 	"github.com/opentracing/opentracing-go"
@@ -29,6 +30,27 @@ func RegisterSource(k plan.ProcedureKind, c CreateSource) {
 	procedureToSource[k] = c
 }
 
+// emptySource is a source without data, it finishes as soon as it is run.
+type emptySource struct {
+	id DatasetID
+	ts []Transformation
+}
+
+// NewEmptySource creates a source that produces no blocks.
+func NewEmptySource(id DatasetID) Source {
+	return &emptySource{id: id}
+}
+
+func (s *emptySource) AddTransformation(t Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *emptySource) Run(ctx context.Context) {
+	for _, t := range s.ts {
+		t.Finish(s.id, nil)
+	}
+}
+
 // storageSource performs storage reads
 type storageSource struct {
 	id       DatasetID
@@ -40,6 +62,14 @@ type storageSource struct {
 	ts []Transformation
 
 	currentTime Time
+
+	// interval is how often a continuous source polls storage.
+	// It is zero for sources that finish once their bounds have been read.
+	interval Duration
+	// tail indicates the source reads all data since its previous read,
+	// instead of reading window by window.
+	tail     bool
+	lastStop Time
 }
 
 func NewStorageSource(id DatasetID, r StorageReader, readSpec ReadSpec, bounds Bounds, w Window, currentTime Time) Source {
@@ -53,6 +83,32 @@ func NewStorageSource(id DatasetID, r StorageReader, readSpec ReadSpec, bounds B
 	}
 }
 
+// NewContinuousStorageSource creates a source that keeps reading storage as time passes,
+// polling every interval, until its context is canceled.
+// If the window is the zero Window, the bounds are read once and then
+// all data that arrived since the previous read is read every interval.
+// Otherwise storage is read window by window, each window is read once its stop time has passed.
+func NewContinuousStorageSource(id DatasetID, r StorageReader, readSpec ReadSpec, bounds Bounds, w Window, currentTime Time, interval Duration) Source {
+	s := &storageSource{
+		id:          id,
+		reader:      r,
+		readSpec:    readSpec,
+		bounds:      bounds,
+		window:      w,
+		currentTime: currentTime,
+		interval:    interval,
+	}
+	if w.Every == 0 {
+		s.tail = true
+		s.window = Window{
+			Every: interval,
+		}
+		s.lastStop = bounds.Start
+		s.currentTime = bounds.Stop
+	}
+	return s
+}
+
 func (s *storageSource) AddTransformation(t Transformation) {
 	s.ts = append(s.ts, t)
 }
@@ -72,15 +128,26 @@ func (s *storageSource) run(ctx context.Context) error {
 		_ = opentracing.GlobalTracer().Inject(span.Context(), opentracing.TextMap, opentracing.TextMapCarrier(trace))
 	}
 
-	//TODO(nathanielc): Pass through context to actual network I/O.
-	for blocks, mark, ok := s.Next(ctx, trace); ok; blocks, mark, ok = s.Next(ctx, trace) {
+	// The context is passed through to the storage reads,
+	// so that they stop once the query is canceled or its deadline has passed.
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if s.interval > 0 {
+			if err := s.wait(ctx); err != nil {
+				return err
+			}
+		}
+		blocks, mark, ok := s.Next(ctx, trace)
+		if !ok {
+			return nil
+		}
 		err := blocks.Do(func(b Block) error {
 			for _, t := range s.ts {
 				if err := t.Process(s.id, b); err != nil {
 					return err
 				}
-				//TODO(nathanielc): Also add mechanism to send UpdateProcessingTime calls, when no data is arriving.
-				// This is probably not needed for this source, but other sources should do so.
 				if err := t.UpdateProcessingTime(s.id, Now()); err != nil {
 					return err
 				}
@@ -96,15 +163,46 @@ func (s *storageSource) run(ctx context.Context) error {
 			}
 		}
 	}
-	return nil
+}
+
+// wait blocks until the stop time of the next read has passed.
+// While waiting, the processing time of the transformations is updated every interval,
+// so that processing time triggers fire when no data is arriving.
+func (s *storageSource) wait(ctx context.Context) error {
+	d := time.Duration(s.currentTime - Now())
+	if d <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(time.Duration(s.interval))
+	defer ticker.Stop()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case <-ticker.C:
+			now := Now()
+			for _, t := range s.ts {
+				if err := t.UpdateProcessingTime(s.id, now); err != nil {
+					return err
+				}
+			}
+		}
+	}
 }
 
 func (s *storageSource) Next(ctx context.Context, trace map[string]string) (BlockIterator, Time, bool) {
 	start := s.currentTime - Time(s.window.Period)
 	stop := s.currentTime
+	if s.tail {
+		start = s.lastStop
+	}
 
 	s.currentTime = s.currentTime + Time(s.window.Every)
-	if stop > s.bounds.Stop {
+	if s.interval == 0 && stop > s.bounds.Stop {
 		return nil, 0, false
 	}
 	bi, err := s.reader.Read(
@@ -118,5 +216,6 @@ func (s *storageSource) Next(ctx context.Context, trace map[string]string) (Bloc
 		log.Println("E!", err)
 		return nil, 0, false
 	}
+	s.lastStop = stop
 	return bi, stop, true
 }
@@ -0,0 +1,709 @@
+package execute
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// spillBatchSize is the number of rows written to and read from disk at once.
+const spillBatchSize = 1024
+
+// BlockBuilderSpiller is implemented by block builder caches whose data can be written to disk,
+// when the memory used by a query nears its quota.
+type BlockBuilderSpiller interface {
+	// SpillIfNeeded writes the data of all builders to disk and clears them,
+	// if the memory used by the query nears its quota.
+	SpillIfNeeded() error
+}
+
+// SpillIfNeeded spills the data of the cache to disk if the cache supports it and the query nears its memory quota.
+// Transformations that hold on to their input until it is triggered, call it before appending more data.
+func SpillIfNeeded(c BlockBuilderCache) error {
+	if s, ok := c.(BlockBuilderSpiller); ok {
+		return s.SpillIfNeeded()
+	}
+	return nil
+}
+
+// SpillRun is a sequence of rows that were written to a temporary file.
+// The file is removed as soon as it is created, so that it is reclaimed once the run is no longer referenced.
+type SpillRun struct {
+	f    *os.File
+	size int64
+	rows int
+}
+
+// spillBatch is the encoding of a batch of rows of a run.
+// The values of common columns are not encoded, they are taken from the tags of the block.
+type spillBatch struct {
+	Cols    []ColMeta
+	Columns []spillColumn
+	NRows   int
+}
+
+type spillColumn struct {
+	Bools   []bool
+	Ints    []int64
+	UInts   []uint64
+	Floats  []float64
+	Strings []string
+	Times   []Time
+	// Nulls lists the rows of the batch whose values are null.
+	Nulls []int
+}
+
+// SpillWriter writes blocks to a new run.
+type SpillWriter struct {
+	run *SpillRun
+	enc *gob.Encoder
+}
+
+// NewSpillWriter creates a run in the directory.
+func NewSpillWriter(dir string) (*SpillWriter, error) {
+	f, err := ioutil.TempFile(dir, "ifql-spill-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create spill file")
+	}
+	// Remove the file right away, it stays readable until it is closed.
+	os.Remove(f.Name())
+	r := &SpillRun{f: f}
+	runtime.SetFinalizer(r, (*SpillRun).Close)
+	return &SpillWriter{
+		run: r,
+		enc: gob.NewEncoder(f),
+	}, nil
+}
+
+// Write appends the rows of the block to the run.
+func (w *SpillWriter) Write(b Block) error {
+	cols := b.Cols()
+	batch := spillBatch{
+		Cols:    cols,
+		Columns: make([]spillColumn, len(cols)),
+	}
+	var err error
+	doRows(b, func(n int, rr RowReader) {
+		for start := 0; start < n && err == nil; start += spillBatchSize {
+			stop := start + spillBatchSize
+			if stop > n {
+				stop = n
+			}
+			for j, c := range cols {
+				batch.Columns[j] = readSpillColumn(c, j, start, stop, rr)
+			}
+			batch.NRows = stop - start
+			if err = w.enc.Encode(&batch); err != nil {
+				err = errors.Wrap(err, "failed to write spill file")
+				return
+			}
+			w.run.rows += batch.NRows
+		}
+	})
+	if err != nil {
+		w.run.Close()
+	}
+	return err
+}
+
+// doRows calls f with the number of rows of each batch of the block and their reader.
+// The batches are read from the time column, or the first column of blocks without one.
+func doRows(b Block, f func(n int, rr RowReader)) {
+	cols := b.Cols()
+	j := TimeIdx(cols)
+	if j < 0 {
+		j = 0
+	}
+	itr := b.Col(j)
+	switch cols[j].Type {
+	case TBool:
+		itr.DoBool(func(vs []bool, rr RowReader) { f(len(vs), rr) })
+	case TInt:
+		itr.DoInt(func(vs []int64, rr RowReader) { f(len(vs), rr) })
+	case TUInt:
+		itr.DoUInt(func(vs []uint64, rr RowReader) { f(len(vs), rr) })
+	case TFloat:
+		itr.DoFloat(func(vs []float64, rr RowReader) { f(len(vs), rr) })
+	case TString:
+		itr.DoString(func(vs []string, rr RowReader) { f(len(vs), rr) })
+	case TTime:
+		itr.DoTime(func(vs []Time, rr RowReader) { f(len(vs), rr) })
+	default:
+		PanicUnknownType(cols[j].Type)
+	}
+}
+
+// Run completes the run, no more blocks may be written.
+func (w *SpillWriter) Run() (*SpillRun, error) {
+	size, err := w.run.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		w.run.Close()
+		return nil, err
+	}
+	w.run.size = size
+	return w.run, nil
+}
+
+// WriteSpillRun writes the rows of the block to a new run in the directory.
+func WriteSpillRun(dir string, b Block) (*SpillRun, error) {
+	w, err := NewSpillWriter(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Write(b); err != nil {
+		return nil, err
+	}
+	return w.Run()
+}
+
+func readSpillColumn(c ColMeta, j, start, stop int, rr RowReader) spillColumn {
+	var sc spillColumn
+	if c.Common {
+		return sc
+	}
+	for i := start; i < stop; i++ {
+		if rr.IsNull(i, j) {
+			sc.Nulls = append(sc.Nulls, i-start)
+		}
+		switch c.Type {
+		case TBool:
+			sc.Bools = append(sc.Bools, rr.AtBool(i, j))
+		case TInt:
+			sc.Ints = append(sc.Ints, rr.AtInt(i, j))
+		case TUInt:
+			sc.UInts = append(sc.UInts, rr.AtUInt(i, j))
+		case TFloat:
+			sc.Floats = append(sc.Floats, rr.AtFloat(i, j))
+		case TString:
+			sc.Strings = append(sc.Strings, rr.AtString(i, j))
+		case TTime:
+			sc.Times = append(sc.Times, rr.AtTime(i, j))
+		default:
+			PanicUnknownType(c.Type)
+		}
+	}
+	return sc
+}
+
+// Rows reports the number of rows of the run.
+func (r *SpillRun) Rows() int {
+	return r.rows
+}
+
+// Close releases the file of the run.
+func (r *SpillRun) Close() error {
+	runtime.SetFinalizer(r, nil)
+	return r.f.Close()
+}
+
+// Do calls f with the rows of the run in batches, as blocks with the metadata and columns.
+// Columns that did not exist when the run was written are filled with null values.
+func (r *SpillRun) Do(meta BlockMetadata, cols []ColMeta, f func(*ColListBlock) error) error {
+	rd := r.reader(meta, cols)
+	for {
+		batch, err := rd.next()
+		if err != nil {
+			return err
+		}
+		if batch == nil {
+			return nil
+		}
+		if err := f(batch); err != nil {
+			return err
+		}
+	}
+}
+
+// spillReader reads the batches of a run as blocks with the given columns.
+// Columns that did not exist when the run was written are filled with null values.
+type spillReader struct {
+	dec    *gob.Decoder
+	meta   BlockMetadata
+	cols   []ColMeta
+	alloc  *Allocator
+	remain int
+}
+
+var spillAlloc = &Allocator{Limit: math.MaxInt64}
+
+func (r *SpillRun) reader(meta BlockMetadata, cols []ColMeta) *spillReader {
+	return &spillReader{
+		dec:    gob.NewDecoder(io.NewSectionReader(r.f, 0, r.size)),
+		meta:   meta,
+		cols:   cols,
+		alloc:  spillAlloc,
+		remain: r.rows,
+	}
+}
+
+// next returns the next batch of the run, or nil once all batches have been read.
+func (r *spillReader) next() (*ColListBlock, error) {
+	if r.remain == 0 {
+		return nil, nil
+	}
+	var batch spillBatch
+	if err := r.dec.Decode(&batch); err != nil {
+		return nil, errors.Wrap(err, "failed to read spill file")
+	}
+	r.remain -= batch.NRows
+
+	builder := newSpillBatchBuilder(r.meta, r.cols, r.alloc)
+	for j, c := range r.cols {
+		if c.Common {
+			continue
+		}
+		sc, ok := spillColumnFor(c.Label, batch)
+		if !ok {
+			for i := 0; i < batch.NRows; i++ {
+				builder.AppendNil(j)
+			}
+			continue
+		}
+		switch c.Type {
+		case TBool:
+			builder.AppendBools(j, sc.Bools)
+		case TInt:
+			builder.AppendInts(j, sc.Ints)
+		case TUInt:
+			builder.AppendUInts(j, sc.UInts)
+		case TFloat:
+			builder.AppendFloats(j, sc.Floats)
+		case TString:
+			builder.AppendStrings(j, sc.Strings)
+		case TTime:
+			builder.AppendTimes(j, sc.Times)
+		default:
+			PanicUnknownType(c.Type)
+		}
+		for _, i := range sc.Nulls {
+			builder.SetNil(i, j)
+		}
+	}
+	return builder.RawBlock(), nil
+}
+
+func spillColumnFor(label string, batch spillBatch) (spillColumn, bool) {
+	for j, c := range batch.Cols {
+		if c.Label == label {
+			return batch.Columns[j], true
+		}
+	}
+	return spillColumn{}, false
+}
+
+func newSpillBatchBuilder(meta BlockMetadata, cols []ColMeta, a *Allocator) *ColListBlockBuilder {
+	builder := NewColListBlockBuilder(a)
+	builder.SetBounds(meta.Bounds())
+	for j, c := range cols {
+		builder.AddCol(c)
+		if c.Common {
+			builder.SetCommonString(j, meta.Tags()[c.Label])
+		}
+	}
+	return builder
+}
+
+// spilledBlock is a block whose rows are read from spilled runs followed by the rows held in memory.
+// When sorted the rows of the runs and in memory are merged in the order of the sort columns,
+// each of which must be sorted in that order.
+type spilledBlock struct {
+	blockMetadata
+	cols []ColMeta
+	runs []*SpillRun
+	mem  *ColListBlock
+
+	sorted   bool
+	sortCols []int
+	desc     bool
+}
+
+func (b *spilledBlock) Cols() []ColMeta {
+	return b.cols
+}
+
+func (b *spilledBlock) RefCount(n int) {}
+
+func (b *spilledBlock) Col(c int) ValueIterator {
+	return spilledValueIterator{b: b, col: c}
+}
+
+func (b *spilledBlock) Times() ValueIterator {
+	j := TimeIdx(b.cols)
+	if j < 0 {
+		return nil
+	}
+	return b.Col(j)
+}
+
+func (b *spilledBlock) Values() (ValueIterator, error) {
+	j := ValueIdx(b.cols)
+	if j < 0 {
+		return nil, NoDefaultValueColumn
+	}
+	return b.Col(j), nil
+}
+
+// do calls f with each batch of rows of the block in order.
+// Errors reading the runs cannot be reported through the Block interface, so they panic,
+// which the executor reports as an error of the query.
+func (b *spilledBlock) do(f func(*ColListBlock)) {
+	var err error
+	if b.sorted {
+		err = b.merge(f)
+	} else {
+		err = b.concat(f)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (b *spilledBlock) concat(f func(*ColListBlock)) error {
+	for _, run := range b.runs {
+		r := run.reader(b, b.cols)
+		for {
+			batch, err := r.next()
+			if err != nil {
+				return err
+			}
+			if batch == nil {
+				break
+			}
+			f(batch)
+		}
+	}
+	if b.mem.NRows() > 0 {
+		f(b.mem)
+	}
+	return nil
+}
+
+// spillCursor is the position of a merge in one of the sorted sources of a block.
+type spillCursor struct {
+	r     *spillReader
+	batch *ColListBlock
+	i     int
+}
+
+func (c *spillCursor) advance() error {
+	c.i++
+	if c.i < c.batch.NRows() || c.r == nil {
+		return nil
+	}
+	batch, err := c.r.next()
+	if err != nil {
+		return err
+	}
+	c.batch, c.i = batch, 0
+	return nil
+}
+
+func (c *spillCursor) done() bool {
+	return c.batch == nil || c.i >= c.batch.NRows()
+}
+
+func (b *spilledBlock) merge(f func(*ColListBlock)) error {
+	cursors := make([]*spillCursor, 0, len(b.runs)+1)
+	for _, run := range b.runs {
+		r := run.reader(b, b.cols)
+		batch, err := r.next()
+		if err != nil {
+			return err
+		}
+		cursors = append(cursors, &spillCursor{r: r, batch: batch})
+	}
+	cursors = append(cursors, &spillCursor{batch: b.mem})
+
+	colMap := make([]int, len(b.cols))
+	for j := range colMap {
+		colMap[j] = j
+	}
+	out := newSpillBatchBuilder(b, b.cols, spillAlloc)
+	for {
+		var min *spillCursor
+		for _, c := range cursors {
+			if c.done() {
+				continue
+			}
+			if min == nil || b.less(c, min) {
+				min = c
+			}
+		}
+		if min == nil {
+			break
+		}
+		AppendRow(min.i, min.batch, out, colMap)
+		if out.NRows() == spillBatchSize {
+			f(out.RawBlock())
+			out = newSpillBatchBuilder(b, b.cols, spillAlloc)
+		}
+		if err := min.advance(); err != nil {
+			return err
+		}
+	}
+	if out.NRows() > 0 {
+		f(out.RawBlock())
+	}
+	return nil
+}
+
+// less reports whether the row of x sorts before the row of y, consistently with ColListBlockBuilder.Sort.
+func (b *spilledBlock) less(x, y *spillCursor) (less bool) {
+	for _, j := range b.sortCols {
+		if c := compareNullValues(b.cols[j].Type, x.batch, x.i, y.batch, y.i, j); c != 0 {
+			less = c < 0
+			break
+		}
+	}
+	if b.desc {
+		less = !less
+	}
+	return
+}
+
+// compareNullValues compares the values like compareValues, null values sort first.
+func compareNullValues(typ DataType, x *ColListBlock, i int, y *ColListBlock, k int, j int) int {
+	xn, yn := x.IsNull(i, j), y.IsNull(k, j)
+	switch {
+	case xn && yn:
+		return 0
+	case xn:
+		return -1
+	case yn:
+		return 1
+	default:
+		return compareValues(typ, x, i, y, k, j)
+	}
+}
+
+func compareValues(typ DataType, x *ColListBlock, i int, y *ColListBlock, k int, j int) int {
+	var lt, gt bool
+	switch typ {
+	case TBool:
+		// The bool column sorts true before false.
+		a, b := x.AtBool(i, j), y.AtBool(k, j)
+		lt, gt = a && !b, !a && b
+	case TInt:
+		a, b := x.AtInt(i, j), y.AtInt(k, j)
+		lt, gt = a < b, a > b
+	case TUInt:
+		a, b := x.AtUInt(i, j), y.AtUInt(k, j)
+		lt, gt = a < b, a > b
+	case TFloat:
+		a, b := x.AtFloat(i, j), y.AtFloat(k, j)
+		lt, gt = a < b, a > b
+	case TString:
+		a, b := x.AtString(i, j), y.AtString(k, j)
+		lt, gt = a < b, a > b
+	case TTime:
+		a, b := x.AtTime(i, j), y.AtTime(k, j)
+		lt, gt = a < b, a > b
+	default:
+		PanicUnknownType(typ)
+	}
+	switch {
+	case lt:
+		return -1
+	case gt:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type spilledValueIterator struct {
+	b   *spilledBlock
+	col int
+}
+
+func (itr spilledValueIterator) DoBool(f func([]bool, RowReader)) {
+	itr.b.do(func(batch *ColListBlock) { batch.Col(itr.col).DoBool(f) })
+}
+func (itr spilledValueIterator) DoInt(f func([]int64, RowReader)) {
+	itr.b.do(func(batch *ColListBlock) { batch.Col(itr.col).DoInt(f) })
+}
+func (itr spilledValueIterator) DoUInt(f func([]uint64, RowReader)) {
+	itr.b.do(func(batch *ColListBlock) { batch.Col(itr.col).DoUInt(f) })
+}
+func (itr spilledValueIterator) DoFloat(f func([]float64, RowReader)) {
+	itr.b.do(func(batch *ColListBlock) { batch.Col(itr.col).DoFloat(f) })
+}
+func (itr spilledValueIterator) DoString(f func([]string, RowReader)) {
+	itr.b.do(func(batch *ColListBlock) { batch.Col(itr.col).DoString(f) })
+}
+func (itr spilledValueIterator) DoTime(f func([]Time, RowReader)) {
+	itr.b.do(func(batch *ColListBlock) { batch.Col(itr.col).DoTime(f) })
+}
+
+// NewSpilledBlock returns a block that reads the rows of the runs followed by the rows of mem.
+// When sortCols is not nil, the runs and mem must be sorted by the columns and the rows are merged in that order.
+func NewSpilledBlock(mem *ColListBlock, runs []*SpillRun, sortCols []string, desc bool) (Block, error) {
+	b := &spilledBlock{
+		blockMetadata: blockMetadata{
+			bounds: mem.Bounds(),
+			tags:   mem.Tags(),
+		},
+		cols:   mem.Cols(),
+		runs:   runs,
+		mem:    mem,
+		sorted: sortCols != nil,
+		desc:   desc,
+	}
+	for _, label := range sortCols {
+		j := ColIdx(label, b.cols)
+		if j < 0 {
+			return nil, fmt.Errorf("unknown sort column %q", label)
+		}
+		b.sortCols = append(b.sortCols, j)
+	}
+	return b, nil
+}
+
+// sortedFloatsCols are the columns of the runs of SortedFloats.
+var sortedFloatsCols = []ColMeta{{Label: DefaultValueColLabel, Type: TFloat, Kind: ValueColKind}}
+
+// SortedFloats holds float values that are read in ascending order.
+// The values are accounted to the allocator, and when the query nears its memory quota they are sorted
+// and written to disk as a run. Reading the values merges the runs with the values held in memory.
+// A nil allocator holds all values in memory.
+type SortedFloats struct {
+	alloc *Allocator
+	mem   []float64
+	runs  []*SpillRun
+	n     int
+}
+
+// NewSortedFloats creates an empty SortedFloats whose values are accounted to the allocator.
+func NewSortedFloats(a *Allocator) *SortedFloats {
+	return &SortedFloats{alloc: a}
+}
+
+// Len reports the number of values.
+func (s *SortedFloats) Len() int {
+	return s.n
+}
+
+// Append adds the values, spilling the values held in memory if the query nears its memory quota.
+func (s *SortedFloats) Append(vs []float64) error {
+	s.n += len(vs)
+	if s.alloc == nil {
+		s.mem = append(s.mem, vs...)
+		return nil
+	}
+	s.mem = s.alloc.AppendFloats(s.mem, vs...)
+	if len(s.mem) >= spillBatchSize && s.alloc.NearLimit() {
+		return s.spill()
+	}
+	return nil
+}
+
+// spill writes the values held in memory to a new sorted run.
+func (s *SortedFloats) spill() error {
+	sort.Float64s(s.mem)
+	w, err := NewSpillWriter(s.alloc.SpillDir)
+	if err != nil {
+		return err
+	}
+	builder := NewColListBlockBuilder(spillAlloc)
+	builder.AddCol(sortedFloatsCols[0])
+	for start := 0; start < len(s.mem); start += spillBatchSize {
+		stop := start + spillBatchSize
+		if stop > len(s.mem) {
+			stop = len(s.mem)
+		}
+		builder.ClearData()
+		builder.AppendFloats(0, s.mem[start:stop])
+		if err := w.Write(builder.RawBlock()); err != nil {
+			return err
+		}
+	}
+	run, err := w.Run()
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, run)
+	s.alloc.Free(cap(s.mem), float64Size)
+	s.mem = nil
+	return nil
+}
+
+// floatCursor is the position of a merge in one of the sorted sources of SortedFloats.
+type floatCursor struct {
+	r  *spillReader
+	vs []float64
+	i  int
+}
+
+func (c *floatCursor) next() error {
+	c.i++
+	if c.i < len(c.vs) || c.r == nil {
+		return nil
+	}
+	return c.read()
+}
+
+// read reads the next batch of the run of the cursor.
+func (c *floatCursor) read() error {
+	batch, err := c.r.next()
+	if err != nil {
+		return err
+	}
+	c.vs, c.i = nil, 0
+	if batch != nil {
+		batch.Col(0).DoFloat(func(vs []float64, _ RowReader) {
+			c.vs = append(c.vs, vs...)
+		})
+	}
+	return nil
+}
+
+// Do calls f with the values in ascending order, until f returns false.
+func (s *SortedFloats) Do(f func(float64) bool) error {
+	sort.Float64s(s.mem)
+	cursors := make([]*floatCursor, 0, len(s.runs)+1)
+	for _, run := range s.runs {
+		c := &floatCursor{r: run.reader(blockMetadata{}, sortedFloatsCols)}
+		if err := c.read(); err != nil {
+			return err
+		}
+		cursors = append(cursors, c)
+	}
+	cursors = append(cursors, &floatCursor{vs: s.mem})
+	for {
+		var min *floatCursor
+		for _, c := range cursors {
+			if c.i >= len(c.vs) {
+				continue
+			}
+			if min == nil || c.vs[c.i] < min.vs[min.i] {
+				min = c
+			}
+		}
+		if min == nil || !f(min.vs[min.i]) {
+			return nil
+		}
+		if err := min.next(); err != nil {
+			return err
+		}
+	}
+}
+
+// Release closes the runs and frees the memory of the values.
+func (s *SortedFloats) Release() {
+	for _, run := range s.runs {
+		run.Close()
+	}
+	s.runs = nil
+	if s.alloc != nil {
+		s.alloc.Free(cap(s.mem), float64Size)
+	}
+	s.mem = nil
+	s.n = 0
+}
@@ -0,0 +1,176 @@
+package execute_test
+
+import (
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+)
+
+func TestBlockBuilderCache_Spill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cols := []execute.ColMeta{
+		{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+		{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+		{Label: "t1", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+	}
+	parts := [][][]interface{}{
+		{
+			{execute.Time(1), 3.0, "a"},
+			{execute.Time(2), 1.0, "a"},
+		},
+		{
+			{execute.Time(3), 4.0, "a"},
+			{execute.Time(4), 2.0, "a"},
+		},
+		{
+			{execute.Time(5), 0.0, "a"},
+		},
+	}
+	testCases := []struct {
+		name     string
+		sortCols []string
+		desc     bool
+		want     [][]interface{}
+	}{
+		{
+			name: "unsorted",
+			want: [][]interface{}{
+				{execute.Time(1), 3.0, "a"},
+				{execute.Time(2), 1.0, "a"},
+				{execute.Time(3), 4.0, "a"},
+				{execute.Time(4), 2.0, "a"},
+				{execute.Time(5), 0.0, "a"},
+			},
+		},
+		{
+			name:     "sorted",
+			sortCols: []string{"_value"},
+			want: [][]interface{}{
+				{execute.Time(5), 0.0, "a"},
+				{execute.Time(2), 1.0, "a"},
+				{execute.Time(4), 2.0, "a"},
+				{execute.Time(1), 3.0, "a"},
+				{execute.Time(3), 4.0, "a"},
+			},
+		},
+		{
+			name:     "sorted desc",
+			sortCols: []string{"_value"},
+			desc:     true,
+			want: [][]interface{}{
+				{execute.Time(3), 4.0, "a"},
+				{execute.Time(1), 3.0, "a"},
+				{execute.Time(4), 2.0, "a"},
+				{execute.Time(2), 1.0, "a"},
+				{execute.Time(5), 0.0, "a"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := execute.NewBlockBuilderCache(&execute.Allocator{
+				Limit:    math.MaxInt64,
+				SpillDir: dir,
+			})
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			c.SetSortOrder(tc.sortCols, tc.desc)
+
+			bounds := execute.Bounds{Start: 0, Stop: 10}
+			for i, data := range parts {
+				b := &executetest.Block{Bnds: bounds, ColMeta: cols, Data: data}
+				builder, new := c.BlockBuilder(b)
+				if new {
+					execute.AddBlockCols(b, builder)
+				}
+				execute.AppendBlock(b, builder, []int{0, 1, 2})
+				if tc.sortCols != nil {
+					builder.Sort(tc.sortCols, tc.desc)
+				}
+				// Keep the last part in memory.
+				if i < len(parts)-1 {
+					if err := c.Spill(); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			want := []*executetest.Block{{Bnds: bounds, ColMeta: cols, Data: tc.want}}
+			got := executetest.BlocksFromCache(c)
+			if !cmp.Equal(want, got) {
+				t.Errorf("unexpected blocks -want/+got\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestSortedFloats_Spill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The values are spilled once 3/4 of the limit of 8192 values is allocated.
+	alloc := &execute.Allocator{
+		Limit:    8 * 8192,
+		SpillDir: dir,
+	}
+	s := execute.NewSortedFloats(alloc)
+	defer s.Release()
+
+	r := rand.New(rand.NewSource(1))
+	want := make([]float64, 0, 20000)
+	for len(want) < cap(want) {
+		vs := make([]float64, 1000)
+		for i := range vs {
+			vs[i] = r.Float64()
+		}
+		if err := s.Append(vs); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, vs...)
+	}
+	if got := s.Len(); got != len(want) {
+		t.Errorf("unexpected number of values: got %d want %d", got, len(want))
+	}
+	if max := alloc.Max(); max > alloc.Limit {
+		t.Errorf("allocated %d bytes, more than the limit of %d", max, alloc.Limit)
+	}
+
+	sort.Float64s(want)
+	got := make([]float64, 0, len(want))
+	if err := s.Do(func(v float64) bool {
+		got = append(got, v)
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected values -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	// Reading stops when f returns false.
+	n := 0
+	if err := s.Do(func(float64) bool {
+		n++
+		return n < 10
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n != 10 {
+		t.Errorf("unexpected number of values read: got %d want 10", n)
+	}
+}
@@ -151,6 +151,8 @@ func (bi *storageBlockIterator) Do(f func(Block) error) error {
 				continue
 			}
 		}
+		// The context of the iterator carries the deadline of the query,
+		// the stream is abandoned once it expires.
 		stream, err := c.client.Read(bi.ctx, &req)
 		if err != nil {
 			return err
@@ -165,6 +167,9 @@ func (bi *storageBlockIterator) Do(f func(Block) error) error {
 	}
 
 	for ms.more() {
+		if err := bi.ctx.Err(); err != nil {
+			return err
+		}
 		if p := ms.peek(); readFrameType(p) != seriesType {
 			//This means the consumer didn't read all the data off the block
 			return errors.New("internal error: short read")
@@ -183,7 +188,8 @@ func (bi *storageBlockIterator) Do(f func(Block) error) error {
 		// Wait until the block has been read.
 		block.wait()
 	}
-	return nil
+	// Streams end early when the context is done, report it instead of returning partial data.
+	return bi.ctx.Err()
 }
 
 func determineAggregateMethod(agg string) (storage.Aggregate_AggregateType, error) {
@@ -341,6 +347,9 @@ type storageBlock struct {
 
 	ms *mergedStreams
 
+	// combine indicates that the aggregates read from each host are combined into a single point.
+	combine bool
+
 	// The index of the column to iterate
 	col int
 	// colBufs are the buffers for the given columns.
@@ -390,6 +399,7 @@ func newStorageBlock(bounds Bounds, tags, keptTags Tags, tagKey key, ms *mergedS
 		colMeta:  colMeta,
 		readSpec: readSpec,
 		ms:       ms,
+		combine:  readSpec.AggregateMethod != "" && len(ms.streams) > 1,
 		done:     make(chan struct{}),
 	}
 }
@@ -535,7 +545,68 @@ func (b *storageBlock) AtTime(i, j int) Time {
 	return b.colBufs[j].([]Time)[i]
 }
 
+// IsNull reports false, storage does not return null values.
+func (b *storageBlock) IsNull(i, j int) bool {
+	return false
+}
+
+// advance reads the next points of the block.
+// When several hosts are read, the pushed down aggregates of each host are partial
+// aggregates of the series. Counts and sums are combined by adding them.
 func (b *storageBlock) advance() bool {
+	if !b.combine {
+		return b.advanceFrame()
+	}
+	var (
+		read bool
+		t    Time
+		i    int64
+		u    uint64
+		f    float64
+	)
+	for b.advanceFrame() {
+		for _, ts := range b.timeBuf {
+			if !read || ts > t {
+				t = ts
+			}
+			read = true
+		}
+		switch b.colMeta[1].Type {
+		case TInt:
+			for _, v := range b.intBuf {
+				i += v
+			}
+		case TUInt:
+			for _, v := range b.uintBuf {
+				u += v
+			}
+		case TFloat:
+			for _, v := range b.floatBuf {
+				f += v
+			}
+		}
+	}
+	if !read {
+		return false
+	}
+	b.timeBuf = append(b.timeBuf[:0], t)
+	b.colBufs[0] = b.timeBuf
+	switch b.colMeta[1].Type {
+	case TInt:
+		b.intBuf = append(b.intBuf[:0], i)
+		b.colBufs[1] = b.intBuf
+	case TUInt:
+		b.uintBuf = append(b.uintBuf[:0], u)
+		b.colBufs[1] = b.uintBuf
+	case TFloat:
+		b.floatBuf = append(b.floatBuf[:0], f)
+		b.colBufs[1] = b.floatBuf
+	}
+	return true
+}
+
+// advanceFrame reads the next frame of points of the block.
+func (b *storageBlock) advanceFrame() bool {
 	for b.ms.more() {
 		//reset buffers
 		b.timeBuf = b.timeBuf[0:0]
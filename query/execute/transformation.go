@@ -21,6 +21,8 @@ type Administration interface {
 	Allocator() *Allocator
 	Parents() []DatasetID
 	ConvertID(plan.ProcedureID) DatasetID
+	// Continuous reports the continuous spec of the plan, it is nil unless the plan is continuous.
+	Continuous() *plan.ContinuousSpec
 }
 
 type CreateTransformation func(id DatasetID, mode AccumulationMode, spec plan.ProcedureSpec, a Administration) (Transformation, Dataset, error)
@@ -23,6 +23,7 @@ type BlockContext struct {
 	Count  int
 }
 
+// NewTriggerFromSpec creates a trigger from a spec that has passed query.ValidateTriggerSpec.
 func NewTriggerFromSpec(spec query.TriggerSpec) Trigger {
 	switch s := spec.(type) {
 	case query.AfterWatermarkTriggerSpec:
@@ -47,8 +48,8 @@ func NewTriggerFromSpec(spec query.TriggerSpec) Trigger {
 			finally: NewTriggerFromSpec(s.Finally),
 		}
 	default:
-		//TODO(nathanielc): Add proper error handling here.
-		// Maybe separate validation of a spec and creation of a spec so we know we cannot error during creation?
+		// Trigger specs are validated using query.ValidateTriggerSpec when they are compiled and planned,
+		// so an unsupported spec at this point is a programming error.
 		panic(fmt.Sprintf("unsupported trigger spec provided %T", spec))
 	}
 }
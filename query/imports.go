@@ -0,0 +1,174 @@
+package query
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/influxdata/ifql/ast"
+	"github.com/influxdata/ifql/interpreter"
+	"github.com/influxdata/ifql/parser"
+	"github.com/influxdata/ifql/semantic"
+	"github.com/pkg/errors"
+)
+
+// PackageExt is the file extension of IFQL package source files.
+const PackageExt = ".ifql"
+
+// packageImporter loads the packages imported by a query.
+// A package with the import path "team/helpers" is read from the file "team/helpers.ifql"
+// in the first of the library directories that contains it.
+// The values of a package are the variables it declares, imported packages are not exported.
+type packageImporter struct {
+	dirs []string
+
+	packages map[string]*importedPackage
+	loading  map[string]bool
+}
+
+type importedPackage struct {
+	value interpreter.Object
+	// typ is the object type of the package built from the declared types of its variables,
+	// which unlike the types of the values describe the signatures of functions.
+	typ semantic.Type
+}
+
+func newPackageImporter(dirs []string) *packageImporter {
+	return &packageImporter{
+		dirs:     dirs,
+		packages: make(map[string]*importedPackage),
+		loading:  make(map[string]bool),
+	}
+}
+
+// Import loads the package at the import path.
+// Packages are loaded once per importer.
+func (imp *packageImporter) Import(importPath string) (interpreter.Value, error) {
+	pkg, err := imp.load(importPath)
+	if err != nil {
+		return nil, err
+	}
+	return pkg.value, nil
+}
+
+func (imp *packageImporter) load(importPath string) (*importedPackage, error) {
+	if pkg, ok := imp.packages[importPath]; ok {
+		return pkg, nil
+	}
+	if imp.loading[importPath] {
+		return nil, fmt.Errorf("import cycle detected for package %q", importPath)
+	}
+	if err := validateImportPath(importPath); err != nil {
+		return nil, err
+	}
+	src, err := imp.read(importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	imp.loading[importPath] = true
+	defer delete(imp.loading, importPath)
+
+	astProg, err := parser.NewAST(src)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse package %q", importPath)
+	}
+	for _, s := range astProg.Body {
+		switch s.(type) {
+		case *ast.ImportDeclaration, *ast.VariableDeclaration:
+		default:
+			return nil, fmt.Errorf("package %q may only contain import and variable declarations, found %s", importPath, s.Type())
+		}
+	}
+
+	declarations := builtinDeclarations.Copy()
+	if err := imp.declare(astProg, declarations); err != nil {
+		return nil, err
+	}
+	semProg, err := semantic.New(astProg, declarations)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create semantic graph for package %q", importPath)
+	}
+
+	// Packages are evaluated in their own domain,
+	// their functions add operations to the domain of the query that calls them.
+	scope := builtinScope.Nest()
+	if err := interpreter.EvalWithImporter(semProg, scope, new(queryDomain), imp); err != nil {
+		return nil, errors.Wrapf(err, "failed to evaluate package %q", importPath)
+	}
+	values := scope.Values()
+	propertyTypes := make(map[string]semantic.Type, len(values))
+	for _, s := range semProg.Body {
+		switch decl := s.(type) {
+		case *semantic.ImportDeclaration:
+			delete(values, decl.Name.Name)
+		case *semantic.NativeVariableDeclaration:
+			propertyTypes[decl.Identifier.Name] = decl.InitType()
+		}
+	}
+
+	pkg := &importedPackage{
+		value: interpreter.Object{Properties: values},
+		typ:   semantic.NewObjectType(propertyTypes),
+	}
+	imp.packages[importPath] = pkg
+	return pkg, nil
+}
+
+// declare imports the packages of the program and declares their names,
+// so that the functions of a package are typed in the semantic graph of the program.
+func (imp *packageImporter) declare(prog *ast.Program, declarations semantic.DeclarationScope) error {
+	for _, s := range prog.Body {
+		decl, ok := s.(*ast.ImportDeclaration)
+		if !ok || decl.Path == nil {
+			continue
+		}
+		pkg, err := imp.load(decl.Path.Value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to import %q", decl.Path.Value)
+		}
+		name := semantic.PackageName(decl.Path.Value)
+		declarations[name] = semantic.NewExternalVariableDeclaration(name, pkg.typ)
+	}
+	return nil
+}
+
+func (imp *packageImporter) read(importPath string) (string, error) {
+	if len(imp.dirs) == 0 {
+		return "", fmt.Errorf("cannot import %q, no library directories are configured", importPath)
+	}
+	for _, dir := range imp.dirs {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(importPath)+PackageExt))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("package %q not found", importPath)
+}
+
+// validateImportPath checks that the import path is a clean relative path
+// and that its last element is a valid identifier.
+func validateImportPath(importPath string) error {
+	if importPath == "" ||
+		path.IsAbs(importPath) ||
+		path.Clean(importPath) != importPath ||
+		importPath == ".." ||
+		strings.HasPrefix(importPath, "../") ||
+		strings.Contains(importPath, `\`) {
+		return fmt.Errorf("invalid import path %q", importPath)
+	}
+	name := semantic.PackageName(importPath)
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return fmt.Errorf("invalid import path %q, package name %q is not an identifier", importPath, name)
+		}
+	}
+	return nil
+}
@@ -22,6 +22,22 @@ func (lp *LogicalPlanSpec) Do(f func(pr *Procedure)) {
 	}
 }
 
+// Copy returns a deep copy of the plan.
+// The physical planner modifies the procedures of the logical plan, so a copy must be made
+// in order to plan the same logical plan more than once.
+func (lp *LogicalPlanSpec) Copy() *LogicalPlanSpec {
+	np := &LogicalPlanSpec{
+		Procedures: make(map[ProcedureID]*Procedure, len(lp.Procedures)),
+		Order:      make([]ProcedureID, len(lp.Order)),
+		Resources:  lp.Resources,
+	}
+	for id, pr := range lp.Procedures {
+		np.Procedures[id] = pr.Copy()
+	}
+	copy(np.Order, lp.Order)
+	return np
+}
+
 func (lp *LogicalPlanSpec) lookup(id ProcedureID) *Procedure {
 	return lp.Procedures[id]
 }
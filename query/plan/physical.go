@@ -24,6 +24,16 @@ type PlanSpec struct {
 	Results map[string]YieldSpec
 
 	Resources query.ResourceManagement
+
+	// Continuous is set when the plan should keep executing as new data arrives.
+	// A nil value means the plan finishes once its bounds have been read.
+	Continuous *ContinuousSpec
+}
+
+// ContinuousSpec defines how a continuous plan reads new data.
+type ContinuousSpec struct {
+	// Interval is how often storage is polled for new data.
+	Interval time.Duration
 }
 
 // YieldSpec defines how data should be yielded.
@@ -126,6 +136,11 @@ func (p *planner) Plan(lp *LogicalPlanSpec, s Storage, now time.Time) (*PlanSpec
 		}
 	}
 
+	// Restrict storage reads to the shards that hold their data
+	if s != nil {
+		p.planShards(s.ShardMapping(), now)
+	}
+
 	// Now that plan is complete find results and time bounds
 	var leaves []ProcedureID
 	var yields []*Procedure
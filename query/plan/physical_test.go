@@ -1,6 +1,7 @@
 package plan_test
 
 import (
+	"context"
 	"math"
 	"testing"
 	"time"
@@ -735,6 +736,376 @@ func TestPhysicalPlanner_Plan_PushDown_Mixed(t *testing.T) {
 	PhysicalPlanTestHelper(t, lp, want)
 }
 
+type testStorage struct {
+	shards plan.ShardMap
+}
+
+func (s testStorage) ShardMapping() plan.ShardMap {
+	return s.shards
+}
+
+func TestPhysicalPlanner_Plan_Shards(t *testing.T) {
+	now := time.Date(2017, 8, 8, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	storage := testStorage{
+		shards: plan.ShardMap{
+			"mydb": {
+				{Node: "a", Range: plan.TimeRange{Start: now.Add(-3 * day), Stop: now.Add(-2 * day)}},
+				{Node: "b", Range: plan.TimeRange{Start: now.Add(-2 * day), Stop: now.Add(-1 * day)}},
+				{Node: "c", Range: plan.TimeRange{Start: now.Add(-1 * day), Stop: now}},
+			},
+			"otherdb": {
+				{Node: "d", Range: plan.TimeRange{Start: now.Add(-3 * day), Stop: now}},
+			},
+		},
+	}
+	resources := query.ResourceManagement{
+		ConcurrencyQuota: 1,
+		MemoryBytesQuota: 10000,
+	}
+	fromID := plan.ProcedureIDFromOperationID("from")
+	logicalPlan := func(agg plan.ProcedureSpec, start, stop time.Time) *plan.LogicalPlanSpec {
+		aggID := plan.ProcedureIDFromOperationID(query.OperationID(agg.Kind()))
+		return &plan.LogicalPlanSpec{
+			Resources: resources,
+			Procedures: map[plan.ProcedureID]*plan.Procedure{
+				fromID: {
+					ID: fromID,
+					Spec: &functions.FromProcedureSpec{
+						Database: "mydb",
+					},
+					Children: []plan.ProcedureID{plan.ProcedureIDFromOperationID("range")},
+				},
+				plan.ProcedureIDFromOperationID("range"): {
+					ID: plan.ProcedureIDFromOperationID("range"),
+					Spec: &functions.RangeProcedureSpec{
+						Bounds: plan.BoundsSpec{
+							Start: query.Time{Absolute: start},
+							Stop:  query.Time{Absolute: stop},
+						},
+					},
+					Parents:  []plan.ProcedureID{fromID},
+					Children: []plan.ProcedureID{aggID},
+				},
+				aggID: {
+					ID:      aggID,
+					Spec:    agg,
+					Parents: []plan.ProcedureID{plan.ProcedureIDFromOperationID("range")},
+				},
+			},
+			Order: []plan.ProcedureID{
+				fromID,
+				plan.ProcedureIDFromOperationID("range"),
+				aggID,
+			},
+		}
+	}
+	countFromSpec := func(hosts []string, start, stop time.Time) *functions.FromProcedureSpec {
+		return &functions.FromProcedureSpec{
+			Database:  "mydb",
+			Hosts:     hosts,
+			BoundsSet: true,
+			Bounds: plan.BoundsSpec{
+				Start: query.Time{Absolute: start},
+				Stop:  query.Time{Absolute: stop},
+			},
+			AggregateSet:    true,
+			AggregateMethod: "count",
+		}
+	}
+	rawFromSpec := func(hosts []string, start, stop time.Time) *functions.FromProcedureSpec {
+		return &functions.FromProcedureSpec{
+			Database:  "mydb",
+			Hosts:     hosts,
+			BoundsSet: true,
+			Bounds: plan.BoundsSpec{
+				Start: query.Time{Absolute: start},
+				Stop:  query.Time{Absolute: stop},
+			},
+		}
+	}
+	meanID := plan.ProcedureIDFromOperationID(functions.MeanKind)
+	testCases := []struct {
+		name string
+		lp   *plan.LogicalPlanSpec
+		pp   *plan.PlanSpec
+	}{
+		{
+			name: "prune hosts",
+			lp:   logicalPlan(&functions.CountProcedureSpec{}, now.Add(-36*time.Hour), now.Add(-30*time.Hour)),
+			pp: &plan.PlanSpec{
+				Resources: resources,
+				Bounds: plan.BoundsSpec{
+					Start: query.Time{Absolute: now.Add(-36 * time.Hour)},
+					Stop:  query.Time{Absolute: now.Add(-30 * time.Hour)},
+				},
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					fromID: {
+						ID:       fromID,
+						Spec:     countFromSpec([]string{"b"}, now.Add(-36*time.Hour), now.Add(-30*time.Hour)),
+						Children: []plan.ProcedureID{},
+					},
+				},
+				Results: map[string]plan.YieldSpec{
+					plan.DefaultYieldName: {ID: fromID},
+				},
+				Order: []plan.ProcedureID{fromID},
+			},
+		},
+		{
+			name: "no overlapping shards",
+			lp:   logicalPlan(&functions.CountProcedureSpec{}, now.Add(-5*day), now.Add(-4*day)),
+			pp: &plan.PlanSpec{
+				Resources: resources,
+				Bounds: plan.BoundsSpec{
+					Start: query.Time{Absolute: now.Add(-5 * day)},
+					Stop:  query.Time{Absolute: now.Add(-4 * day)},
+				},
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					fromID: {
+						ID: fromID,
+						Spec: func() *functions.FromProcedureSpec {
+							s := countFromSpec(nil, now.Add(-5*day), now.Add(-4*day))
+							s.Empty = true
+							return s
+						}(),
+						Children: []plan.ProcedureID{},
+					},
+				},
+				Results: map[string]plan.YieldSpec{
+					plan.DefaultYieldName: {ID: fromID},
+				},
+				Order: []plan.ProcedureID{fromID},
+			},
+		},
+		{
+			name: "cheaper to read once",
+			lp:   logicalPlan(&functions.CountProcedureSpec{}, now.Add(-25*time.Hour), now.Add(-23*time.Hour)),
+			pp: &plan.PlanSpec{
+				Resources: resources,
+				Bounds: plan.BoundsSpec{
+					Start: query.Time{Absolute: now.Add(-25 * time.Hour)},
+					Stop:  query.Time{Absolute: now.Add(-23 * time.Hour)},
+				},
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					fromID: {
+						ID:       fromID,
+						Spec:     countFromSpec([]string{"b", "c"}, now.Add(-25*time.Hour), now.Add(-23*time.Hour)),
+						Children: []plan.ProcedureID{},
+					},
+				},
+				Results: map[string]plan.YieldSpec{
+					plan.DefaultYieldName: {ID: fromID},
+				},
+				Order: []plan.ProcedureID{fromID},
+			},
+		},
+		{
+			name: "split by shard",
+			lp:   logicalPlan(&functions.CountProcedureSpec{}, now.Add(-60*time.Hour), now),
+			pp: &plan.PlanSpec{
+				Resources: resources,
+				Bounds: plan.BoundsSpec{
+					Start: query.Time{Absolute: now.Add(-60 * time.Hour)},
+					Stop:  query.Time{Absolute: now},
+				},
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					plan.ProcedureIDForShard(fromID, 0): {
+						ID:       plan.ProcedureIDForShard(fromID, 0),
+						Spec:     countFromSpec([]string{"a"}, now.Add(-60*time.Hour), now.Add(-2*day)),
+						Children: []plan.ProcedureID{fromID},
+					},
+					plan.ProcedureIDForShard(fromID, 1): {
+						ID:       plan.ProcedureIDForShard(fromID, 1),
+						Spec:     countFromSpec([]string{"b"}, now.Add(-2*day), now.Add(-1*day)),
+						Children: []plan.ProcedureID{fromID},
+					},
+					plan.ProcedureIDForShard(fromID, 2): {
+						ID:       plan.ProcedureIDForShard(fromID, 2),
+						Spec:     countFromSpec([]string{"c"}, now.Add(-1*day), now),
+						Children: []plan.ProcedureID{fromID},
+					},
+					fromID: {
+						ID: fromID,
+						Spec: &functions.ShardMergeProcedureSpec{
+							Bounds: plan.BoundsSpec{
+								Start: query.Time{Absolute: now.Add(-60 * time.Hour)},
+								Stop:  query.Time{Absolute: now},
+							},
+							AggregateMethod: "count",
+						},
+						Parents: []plan.ProcedureID{
+							plan.ProcedureIDForShard(fromID, 0),
+							plan.ProcedureIDForShard(fromID, 1),
+							plan.ProcedureIDForShard(fromID, 2),
+						},
+						Children: []plan.ProcedureID{},
+					},
+				},
+				Results: map[string]plan.YieldSpec{
+					plan.DefaultYieldName: {ID: fromID},
+				},
+				Order: []plan.ProcedureID{
+					plan.ProcedureIDForShard(fromID, 0),
+					plan.ProcedureIDForShard(fromID, 1),
+					plan.ProcedureIDForShard(fromID, 2),
+					fromID,
+				},
+			},
+		},
+		{
+			name: "split aggregate by shard",
+			lp:   logicalPlan(&functions.MeanProcedureSpec{}, now.Add(-60*time.Hour), now),
+			pp: &plan.PlanSpec{
+				Resources: resources,
+				Bounds: plan.BoundsSpec{
+					Start: query.Time{Absolute: now.Add(-60 * time.Hour)},
+					Stop:  query.Time{Absolute: now},
+				},
+				Procedures: map[plan.ProcedureID]*plan.Procedure{
+					plan.ProcedureIDForShard(fromID, 0): {
+						ID:       plan.ProcedureIDForShard(fromID, 0),
+						Spec:     rawFromSpec([]string{"a"}, now.Add(-60*time.Hour), now.Add(-2*day)),
+						Children: []plan.ProcedureID{plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 0))},
+					},
+					plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 0)): {
+						ID:       plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 0)),
+						Spec:     &functions.PartialProcedureSpec{AggregateKind: functions.MeanKind},
+						Parents:  []plan.ProcedureID{plan.ProcedureIDForShard(fromID, 0)},
+						Children: []plan.ProcedureID{meanID},
+					},
+					plan.ProcedureIDForShard(fromID, 1): {
+						ID:       plan.ProcedureIDForShard(fromID, 1),
+						Spec:     rawFromSpec([]string{"b"}, now.Add(-2*day), now.Add(-1*day)),
+						Children: []plan.ProcedureID{plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 1))},
+					},
+					plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 1)): {
+						ID:       plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 1)),
+						Spec:     &functions.PartialProcedureSpec{AggregateKind: functions.MeanKind},
+						Parents:  []plan.ProcedureID{plan.ProcedureIDForShard(fromID, 1)},
+						Children: []plan.ProcedureID{meanID},
+					},
+					plan.ProcedureIDForShard(fromID, 2): {
+						ID:       plan.ProcedureIDForShard(fromID, 2),
+						Spec:     rawFromSpec([]string{"c"}, now.Add(-1*day), now),
+						Children: []plan.ProcedureID{plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 2))},
+					},
+					plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 2)): {
+						ID:       plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 2)),
+						Spec:     &functions.PartialProcedureSpec{AggregateKind: functions.MeanKind},
+						Parents:  []plan.ProcedureID{plan.ProcedureIDForShard(fromID, 2)},
+						Children: []plan.ProcedureID{meanID},
+					},
+					meanID: {
+						ID:   meanID,
+						Spec: &functions.CombineProcedureSpec{AggregateKind: functions.MeanKind},
+						Parents: []plan.ProcedureID{
+							plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 0)),
+							plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 1)),
+							plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 2)),
+						},
+					},
+				},
+				Results: map[string]plan.YieldSpec{
+					plan.DefaultYieldName: {ID: meanID},
+				},
+				Order: []plan.ProcedureID{
+					plan.ProcedureIDForShard(fromID, 0),
+					plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 0)),
+					plan.ProcedureIDForShard(fromID, 1),
+					plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 1)),
+					plan.ProcedureIDForShard(fromID, 2),
+					plan.ProcedureIDFromParentID(plan.ProcedureIDForShard(fromID, 2)),
+					meanID,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.pp.Now = now
+			planner := plan.NewPlanner()
+			got, err := planner.Plan(tc.lp, storage, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.pp, plantest.CmpOptions...) {
+				t.Log("Want Physical:", plan.Formatted(tc.pp))
+				t.Log("Got  Physical:", plan.Formatted(got))
+				t.Errorf("unexpected physical plan -want/+got:\n%s", cmp.Diff(tc.pp, got, plantest.CmpOptions...))
+			}
+		})
+	}
+}
+
+func TestPhysicalPlanner_Plan_HashJoin(t *testing.T) {
+	testCases := []struct {
+		name string
+		q    string
+		want plan.ProcedureKind
+	}{
+		{
+			name: "aligned",
+			q: `
+a = from(db:"dbA") |> range(start:-1h)
+b = from(db:"dbB") |> range(start:-1h)
+join(tables:{a:a,b:b}, on:["host"], fn: (t) => t.a._value + t.b._value)`,
+			want: functions.MergeJoinKind,
+		},
+		{
+			name: "different bounds",
+			q: `
+a = from(db:"dbA") |> range(start:-1h)
+b = from(db:"dbB") |> range(start:-2h)
+join(tables:{a:a,b:b}, on:["host"], fn: (t) => t.a._value + t.b._value)`,
+			want: functions.HashJoinKind,
+		},
+		{
+			name: "different windows",
+			q: `
+a = from(db:"dbA") |> range(start:-1h) |> window(every:1m) |> sum()
+b = from(db:"dbB") |> range(start:-1h) |> window(every:5m) |> sum()
+join(tables:{a:a,b:b}, on:["host"], fn: (t) => t.a._value + t.b._value)`,
+			want: functions.HashJoinKind,
+		},
+		{
+			name: "shifted",
+			q: `
+a = from(db:"dbA") |> range(start:-1h)
+b = from(db:"dbB") |> range(start:-1h) |> shift(shift:1h)
+join(tables:{a:a,b:b}, on:["host"], fn: (t) => t.a._value + t.b._value)`,
+			want: functions.HashJoinKind,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := query.Compile(context.Background(), tc.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lp, err := plan.NewLogicalPlanner().Plan(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pp, err := plan.NewPlanner().Plan(lp, nil, time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var kinds []plan.ProcedureKind
+			pp.Do(func(pr *plan.Procedure) {
+				if len(pr.Parents) == 2 {
+					kinds = append(kinds, pr.Spec.Kind())
+				}
+			})
+			if len(kinds) != 1 || kinds[0] != tc.want {
+				t.Errorf("unexpected join procedures: want %v got %v", tc.want, kinds)
+			}
+		})
+	}
+}
+
 func PhysicalPlanTestHelper(t *testing.T, lp *plan.LogicalPlanSpec, want *plan.PlanSpec) {
 	t.Helper()
 	// Setup expected now time
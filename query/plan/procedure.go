@@ -77,6 +77,18 @@ type BoundedProcedureSpec interface {
 	TimeBounds() BoundsSpec
 }
 
+// SetBoundsProcedureSpec is a BoundedProcedureSpec whose bounds can be changed,
+// i.e. to restrict a plan to a part of its time range.
+type SetBoundsProcedureSpec interface {
+	BoundedProcedureSpec
+	SetTimeBounds(bounds BoundsSpec)
+}
+
+// WindowedProcedureSpec is implemented by procedures that split their input into windows of time.
+type WindowedProcedureSpec interface {
+	TimeWindow() WindowSpec
+}
+
 type YieldProcedureSpec interface {
 	YieldName() string
 }
@@ -87,6 +99,16 @@ type AggregateProcedureSpec interface {
 	ReAggregateSpec() ProcedureSpec
 }
 
+// PartialAggregateProcedureSpec is implemented by aggregates that can be computed in two phases.
+// Partial states of the aggregate are computed over disjoint parts of the data, i.e. per shard,
+// and are then combined into the final aggregate.
+type PartialAggregateProcedureSpec interface {
+	// PartialSpec specifies the procedure that computes the partial state of the aggregate.
+	PartialSpec() ProcedureSpec
+	// CombineSpec specifies the procedure that combines the partial states into the final aggregate.
+	CombineSpec() ProcedureSpec
+}
+
 type ParentAwareProcedureSpec interface {
 	ParentChanged(old, new ProcedureID)
 }
@@ -0,0 +1,259 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/influxdata/ifql/query"
+	uuid "github.com/satori/go.uuid"
+)
+
+// StorageProcedureSpec is implemented by procedure specs that read from storage.
+// The planner uses the shard map of the storage to restrict such reads to the hosts
+// whose shards overlap the bounds of the read.
+type StorageProcedureSpec interface {
+	BoundedProcedureSpec
+	// StorageDatabase reports the database being read.
+	StorageDatabase() string
+	// StorageHosts reports the hosts being read, an empty list means all hosts.
+	StorageHosts() []string
+	// SetStorageHosts restricts the hosts being read.
+	SetStorageHosts(hosts []string)
+	// SetStorageEmpty makes the read produce no data without reading any host.
+	SetStorageEmpty()
+	// ShardSpec returns a copy of the spec that reads from the hosts within the bounds.
+	ShardSpec(hosts []string, bounds BoundsSpec) ProcedureSpec
+	// MergeSpec returns the spec of a procedure that merges the results of reads created by ShardSpec.
+	// A nil spec indicates that the read cannot be split by shard.
+	MergeSpec() ProcedureSpec
+	// StorageRaw reports whether the read returns the stored points,
+	// without limiting, windowing or aggregating them.
+	StorageRaw() bool
+}
+
+// ShardReadOverhead is the estimated cost of an additional storage read, used when deciding
+// whether to split a read by shard. It is expressed as the duration of shard data that
+// could be read for the same cost.
+var ShardReadOverhead = time.Hour
+
+// readCosts estimates the cost of reading a set of shards.
+// The cost of reading a shard is proportional to the duration of data read from it.
+// A single read reads the shards sequentially.
+// A split read reads each shard concurrently, at the expense of an overhead per shard read.
+func readCosts(overlaps []time.Duration) (single, split time.Duration) {
+	var max time.Duration
+	for _, d := range overlaps {
+		single += d
+		if d > max {
+			max = d
+		}
+	}
+	split = max + time.Duration(len(overlaps))*ShardReadOverhead
+	return
+}
+
+// ProcedureIDForShard returns the ID of the procedure that reads the i-th shard of a read procedure.
+func ProcedureIDForShard(id ProcedureID, i int) ProcedureID {
+	return ProcedureID(uuid.NewV5(RootUUID, fmt.Sprintf("%s/shard/%d", id, i)))
+}
+
+type shardRead struct {
+	node  string
+	start time.Time
+	stop  time.Time
+}
+
+// planShards restricts storage reads to the shards that overlap their bounds,
+// and splits reads by shard when it is cheaper to do so.
+func (p *planner) planShards(sm ShardMap, now time.Time) {
+	// Copy the order since procedures are added while iterating.
+	order := make([]ProcedureID, len(p.plan.Order))
+	copy(order, p.plan.Order)
+	for _, id := range order {
+		pr := p.plan.Procedures[id]
+		if pr == nil {
+			continue
+		}
+		spec, ok := pr.Spec.(StorageProcedureSpec)
+		if !ok {
+			continue
+		}
+		shards := sm[spec.StorageDatabase()]
+		if len(shards) == 0 {
+			// The shards of the database are unknown, there is nothing to restrict the read to.
+			continue
+		}
+		reads := overlappingShards(shards, spec.StorageHosts(), spec.TimeBounds(), now)
+		if len(reads) == 0 {
+			// No shard holds data within the bounds of the read.
+			spec.SetStorageEmpty()
+			continue
+		}
+		spec.SetStorageHosts(readNodes(reads))
+
+		if len(reads) == 1 {
+			continue
+		}
+		overlaps := make([]time.Duration, len(reads))
+		for i, r := range reads {
+			overlaps[i] = r.stop.Sub(r.start)
+		}
+		if single, split := readCosts(overlaps); split >= single {
+			continue
+		}
+		if agg, ok := p.partialAggregate(pr, spec); ok {
+			p.splitAggregate(pr, agg, spec, reads)
+			continue
+		}
+		if mergeSpec := spec.MergeSpec(); mergeSpec != nil {
+			p.splitRead(pr, spec, mergeSpec, reads)
+		}
+	}
+}
+
+// partialAggregate returns the aggregate of the read, if it is the only child of the read and can be computed in two phases.
+func (p *planner) partialAggregate(pr *Procedure, spec StorageProcedureSpec) (*Procedure, bool) {
+	if !spec.StorageRaw() || len(pr.Children) != 1 {
+		return nil, false
+	}
+	agg := p.plan.Procedures[pr.Children[0]]
+	if len(agg.Parents) != 1 {
+		return nil, false
+	}
+	if _, ok := agg.Spec.(PartialAggregateProcedureSpec); !ok {
+		return nil, false
+	}
+	return agg, true
+}
+
+// splitAggregate replaces the read procedure with a procedure per shard, whose results are partially aggregated.
+// The aggregate procedure is replaced with a procedure that combines the partial aggregates, keeping its ID.
+func (p *planner) splitAggregate(pr, agg *Procedure, spec StorageProcedureSpec, reads []shardRead) {
+	p.modified = true
+	aggSpec := agg.Spec.(PartialAggregateProcedureSpec)
+	parents := make([]ProcedureID, len(reads))
+	for i, r := range reads {
+		shardID := ProcedureIDForShard(pr.ID, i)
+		partialID := ProcedureIDFromParentID(shardID)
+		shard := &Procedure{
+			plan:     p.plan,
+			ID:       shardID,
+			Children: []ProcedureID{partialID},
+			Spec: spec.ShardSpec([]string{r.node}, BoundsSpec{
+				Start: query.Time{Absolute: r.start},
+				Stop:  query.Time{Absolute: r.stop},
+			}),
+		}
+		partial := &Procedure{
+			plan:     p.plan,
+			ID:       partialID,
+			Parents:  []ProcedureID{shardID},
+			Children: []ProcedureID{agg.ID},
+			Spec:     aggSpec.PartialSpec(),
+		}
+		p.plan.Procedures[shard.ID] = shard
+		p.plan.Procedures[partial.ID] = partial
+		p.plan.Order = insertBefore(p.plan.Order, pr.ID, shard.ID)
+		p.plan.Order = insertBefore(p.plan.Order, pr.ID, partial.ID)
+		parents[i] = partial.ID
+	}
+	delete(p.plan.Procedures, pr.ID)
+	p.plan.Order = removeID(p.plan.Order, pr.ID)
+	agg.Parents = parents
+	agg.Spec = aggSpec.CombineSpec()
+}
+
+// splitRead replaces the read procedure with a procedure per shard, whose results are merged.
+// The merge procedure keeps the ID of the read procedure, so that its children are unchanged.
+func (p *planner) splitRead(pr *Procedure, spec StorageProcedureSpec, mergeSpec ProcedureSpec, reads []shardRead) {
+	p.modified = true
+	parents := make([]ProcedureID, len(reads))
+	for i, r := range reads {
+		shard := &Procedure{
+			plan:     p.plan,
+			ID:       ProcedureIDForShard(pr.ID, i),
+			Children: []ProcedureID{pr.ID},
+			Spec: spec.ShardSpec([]string{r.node}, BoundsSpec{
+				Start: query.Time{Absolute: r.start},
+				Stop:  query.Time{Absolute: r.stop},
+			}),
+		}
+		p.plan.Procedures[shard.ID] = shard
+		p.plan.Order = insertBefore(p.plan.Order, pr.ID, shard.ID)
+		parents[i] = shard.ID
+	}
+	pr.Parents = parents
+	pr.Spec = mergeSpec
+}
+
+// overlappingShards returns the shards on the hosts that overlap the bounds,
+// with their ranges clamped to the bounds.
+// The shards are ordered by start time and node.
+func overlappingShards(shards []Shard, hosts []string, bounds BoundsSpec, now time.Time) []shardRead {
+	start := bounds.Start.Time(now)
+	stop := now
+	if !bounds.Stop.IsZero() {
+		stop = bounds.Stop.Time(now)
+	}
+	var reads []shardRead
+	for _, s := range shards {
+		if len(hosts) > 0 && !hasHost(hosts, s.Node) {
+			continue
+		}
+		if !s.Range.Start.Before(stop) || !start.Before(s.Range.Stop) {
+			continue
+		}
+		r := shardRead{
+			node:  s.Node,
+			start: s.Range.Start,
+			stop:  s.Range.Stop,
+		}
+		if r.start.Before(start) {
+			r.start = start
+		}
+		if r.stop.After(stop) {
+			r.stop = stop
+		}
+		reads = append(reads, r)
+	}
+	sort.Slice(reads, func(i, j int) bool {
+		if reads[i].start.Equal(reads[j].start) {
+			return reads[i].node < reads[j].node
+		}
+		return reads[i].start.Before(reads[j].start)
+	})
+	return reads
+}
+
+// readNodes returns the sorted unique nodes of the reads.
+func readNodes(reads []shardRead) []string {
+	var nodes []string
+	for _, r := range reads {
+		if !hasHost(nodes, r.node) {
+			nodes = append(nodes, r.node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+func hasHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+func insertBefore(ids []ProcedureID, before, new ProcedureID) []ProcedureID {
+	newIds := make([]ProcedureID, 0, len(ids)+1)
+	for _, id := range ids {
+		if id == before {
+			newIds = append(newIds, new)
+		}
+		newIds = append(newIds, id)
+	}
+	return newIds
+}
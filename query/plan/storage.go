@@ -1,6 +1,15 @@
 package plan
 
-import "time"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
 
 type Storage interface {
 	ShardMapping() ShardMap
@@ -18,3 +27,124 @@ type TimeRange struct {
 	Start time.Time
 	Stop  time.Time
 }
+
+// ShardMapSource loads the shard map of the storage hosts.
+type ShardMapSource interface {
+	LoadShardMap() (ShardMap, error)
+}
+
+// ShardMapFile is a ShardMapSource that reads the file at its path, see ReadShardMap.
+type ShardMapFile string
+
+func (f ShardMapFile) LoadShardMap() (ShardMap, error) {
+	file, err := os.Open(string(f))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	sm, err := ReadShardMap(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", f, err)
+	}
+	return sm, nil
+}
+
+// ReadShardMap reads a shard map, one shard per line.
+// Each line is the database, the host and the RFC3339 start and stop times of the shard, separated by spaces.
+// Empty lines and lines starting with # are ignored.
+func ReadShardMap(r io.Reader) (ShardMap, error) {
+	sm := make(ShardMap)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("line %d: expected database, host, start and stop, got %d fields", n, len(fields))
+		}
+		start, err := time.Parse(time.RFC3339Nano, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid start: %v", n, err)
+		}
+		stop, err := time.Parse(time.RFC3339Nano, fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid stop: %v", n, err)
+		}
+		if !start.Before(stop) {
+			return nil, fmt.Errorf("line %d: start %s is not before stop %s", n, fields[2], fields[3])
+		}
+		sm[fields[0]] = append(sm[fields[0]], Shard{
+			Node:  fields[1],
+			Range: TimeRange{Start: start, Stop: stop},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// HostStorage is the Storage of the hosts read by a storage reader, whose shards are loaded from a ShardMapSource.
+type HostStorage struct {
+	hosts   []string
+	source  ShardMapSource
+	refresh time.Duration
+
+	mu     sync.Mutex
+	shards ShardMap
+	loaded time.Time
+}
+
+// NewHostStorage creates the storage of the hosts, loading its shard map from the source.
+// The shard map is loaded again when it is older than refresh, a zero refresh loads it once.
+// Every shard must be on one of the hosts, an empty list of hosts accepts any host.
+func NewHostStorage(hosts []string, source ShardMapSource, refresh time.Duration) (*HostStorage, error) {
+	s := &HostStorage{
+		hosts:   hosts,
+		source:  source,
+		refresh: refresh,
+	}
+	shards, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	s.shards = shards
+	s.loaded = time.Now()
+	return s, nil
+}
+
+// ShardMapping returns the shard map, reloading it if it is stale.
+// The previous shard map is kept when it fails to reload.
+func (s *HostStorage) ShardMapping() ShardMap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refresh > 0 && time.Since(s.loaded) >= s.refresh {
+		s.loaded = time.Now()
+		shards, err := s.load()
+		if err != nil {
+			log.Println("Error reloading shard map:", err)
+		} else {
+			s.shards = shards
+		}
+	}
+	return s.shards
+}
+
+func (s *HostStorage) load() (ShardMap, error) {
+	shards, err := s.source.LoadShardMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load shard map: %v", err)
+	}
+	if len(s.hosts) > 0 {
+		for db, dbShards := range shards {
+			for _, sh := range dbShards {
+				if !hasHost(s.hosts, sh.Node) {
+					return nil, fmt.Errorf("shard of database %q is on unknown host %q", db, sh.Node)
+				}
+			}
+		}
+	}
+	return shards, nil
+}
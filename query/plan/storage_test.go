@@ -0,0 +1,91 @@
+package plan_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/query/plan"
+)
+
+func TestReadShardMap(t *testing.T) {
+	sm, err := plan.ReadShardMap(strings.NewReader(`
+# database host start stop
+db0 host1:8082 2018-01-01T00:00:00Z 2018-01-02T00:00:00Z
+db0 host2:8082 2018-01-02T00:00:00Z 2018-01-03T00:00:00Z
+db1 host1:8082 2018-01-01T00:00:00Z 2018-01-08T00:00:00Z
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(d int) time.Time {
+		return time.Date(2018, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	want := plan.ShardMap{
+		"db0": {
+			{Node: "host1:8082", Range: plan.TimeRange{Start: day(1), Stop: day(2)}},
+			{Node: "host2:8082", Range: plan.TimeRange{Start: day(2), Stop: day(3)}},
+		},
+		"db1": {
+			{Node: "host1:8082", Range: plan.TimeRange{Start: day(1), Stop: day(8)}},
+		},
+	}
+	if !cmp.Equal(want, sm) {
+		t.Errorf("unexpected shard map -want/+got\n%s", cmp.Diff(want, sm))
+	}
+
+	for _, bad := range []string{
+		"db0 host1:8082 2018-01-01T00:00:00Z",
+		"db0 host1:8082 yesterday 2018-01-02T00:00:00Z",
+		"db0 host1:8082 2018-01-02T00:00:00Z 2018-01-01T00:00:00Z",
+	} {
+		if _, err := plan.ReadShardMap(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected error reading %q", bad)
+		}
+	}
+}
+
+// shardMapSource returns its shard map, or its error.
+type shardMapSource struct {
+	shards plan.ShardMap
+	err    error
+}
+
+func (s *shardMapSource) LoadShardMap() (plan.ShardMap, error) {
+	return s.shards, s.err
+}
+
+func TestHostStorage(t *testing.T) {
+	shards := plan.ShardMap{
+		"db0": {{Node: "host1:8082"}},
+	}
+	if _, err := plan.NewHostStorage([]string{"host2:8082"}, &shardMapSource{shards: shards}, 0); err == nil {
+		t.Error("expected error for a shard on an unknown host")
+	}
+
+	src := &shardMapSource{shards: shards}
+	s, err := plan.NewHostStorage([]string{"host1:8082", "host2:8082"}, src, time.Nanosecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.ShardMapping(); !cmp.Equal(shards, got) {
+		t.Errorf("unexpected shard map -want/+got\n%s", cmp.Diff(shards, got))
+	}
+
+	// The shard map is reloaded once it is stale, and kept when it fails to reload.
+	reloaded := plan.ShardMap{
+		"db0": {{Node: "host2:8082"}},
+	}
+	src.shards = reloaded
+	time.Sleep(time.Millisecond)
+	if got := s.ShardMapping(); !cmp.Equal(reloaded, got) {
+		t.Errorf("unexpected reloaded shard map -want/+got\n%s", cmp.Diff(reloaded, got))
+	}
+	src.err = errors.New("unavailable")
+	time.Sleep(time.Millisecond)
+	if got := s.ShardMapping(); !cmp.Equal(reloaded, got) {
+		t.Errorf("unexpected shard map after a failed reload -want/+got\n%s", cmp.Diff(reloaded, got))
+	}
+}
@@ -20,6 +20,12 @@ type ResourceManagement struct {
 	// There is a small amount of overhead memory being consumed by a query that will not be counted towards this limit.
 	// A zero value indicates unlimited.
 	MemoryBytesQuota int64 `json:"memory_bytes_quota"`
+	// QueueTimeout is how long the query may wait for resources before it starts executing.
+	// A zero value indicates the default timeout of the server is used.
+	QueueTimeout Duration `json:"queue_timeout"`
+	// ExecuteTimeout is how long the query may execute.
+	// A zero value indicates the default timeout of the server is used.
+	ExecuteTimeout Duration `json:"execute_timeout"`
 }
 
 // Priority is an integer that represents the query priority.
@@ -1,5 +1,14 @@
 package query
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/ifql/interpreter"
+	"github.com/influxdata/ifql/semantic"
+	"github.com/pkg/errors"
+)
+
 type TriggerSpec interface {
 	Kind() TriggerKind
 }
@@ -13,10 +22,44 @@ const (
 	OrFinally
 )
 
+// triggerKindNames are the names of the trigger kinds, which are also the names of the trigger functions.
+var triggerKindNames = map[TriggerKind]string{
+	AfterWatermark:      "afterWatermark",
+	Repeated:            "repeated",
+	AfterProcessingTime: "afterProcessingTime",
+	AfterAtLeastCount:   "afterAtLeastCount",
+	OrFinally:           "orFinally",
+}
+
+func (k TriggerKind) String() string {
+	if name, ok := triggerKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("TriggerKind(%d)", int(k))
+}
+
+func (k TriggerKind) MarshalText() ([]byte, error) {
+	name, ok := triggerKindNames[k]
+	if !ok {
+		return nil, fmt.Errorf("unknown trigger kind %d", int(k))
+	}
+	return []byte(name), nil
+}
+
+func (k *TriggerKind) UnmarshalText(data []byte) error {
+	for kind, name := range triggerKindNames {
+		if name == string(data) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown trigger kind %q", string(data))
+}
+
 var DefaultTrigger = AfterWatermarkTriggerSpec{}
 
 type AfterWatermarkTriggerSpec struct {
-	AllowedLateness Duration
+	AllowedLateness Duration `json:"allowedLateness"`
 }
 
 func (AfterWatermarkTriggerSpec) Kind() TriggerKind {
@@ -24,15 +67,42 @@ func (AfterWatermarkTriggerSpec) Kind() TriggerKind {
 }
 
 type RepeatedTriggerSpec struct {
-	Trigger TriggerSpec
+	Trigger TriggerSpec `json:"trigger"`
 }
 
 func (RepeatedTriggerSpec) Kind() TriggerKind {
 	return Repeated
 }
 
+func (s RepeatedTriggerSpec) MarshalJSON() ([]byte, error) {
+	trigger, err := MarshalTriggerSpec(s.Trigger)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Trigger json.RawMessage `json:"trigger"`
+	}{
+		Trigger: trigger,
+	})
+}
+
+func (s *RepeatedTriggerSpec) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Trigger json.RawMessage `json:"trigger"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	t, err := UnmarshalTriggerSpec(raw.Trigger)
+	if err != nil {
+		return err
+	}
+	s.Trigger = t
+	return nil
+}
+
 type AfterProcessingTimeTriggerSpec struct {
-	Duration Duration
+	Duration Duration `json:"duration"`
 }
 
 func (AfterProcessingTimeTriggerSpec) Kind() TriggerKind {
@@ -40,7 +110,7 @@ func (AfterProcessingTimeTriggerSpec) Kind() TriggerKind {
 }
 
 type AfterAtLeastCountTriggerSpec struct {
-	Count int
+	Count int `json:"count"`
 }
 
 func (AfterAtLeastCountTriggerSpec) Kind() TriggerKind {
@@ -48,10 +118,302 @@ func (AfterAtLeastCountTriggerSpec) Kind() TriggerKind {
 }
 
 type OrFinallyTriggerSpec struct {
-	Main    TriggerSpec
-	Finally TriggerSpec
+	Main    TriggerSpec `json:"main"`
+	Finally TriggerSpec `json:"finally"`
 }
 
 func (OrFinallyTriggerSpec) Kind() TriggerKind {
 	return OrFinally
 }
+
+func (s OrFinallyTriggerSpec) MarshalJSON() ([]byte, error) {
+	main, err := MarshalTriggerSpec(s.Main)
+	if err != nil {
+		return nil, err
+	}
+	finally, err := MarshalTriggerSpec(s.Finally)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Main    json.RawMessage `json:"main"`
+		Finally json.RawMessage `json:"finally"`
+	}{
+		Main:    main,
+		Finally: finally,
+	})
+}
+
+func (s *OrFinallyTriggerSpec) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Main    json.RawMessage `json:"main"`
+		Finally json.RawMessage `json:"finally"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	main, err := UnmarshalTriggerSpec(raw.Main)
+	if err != nil {
+		return err
+	}
+	finally, err := UnmarshalTriggerSpec(raw.Finally)
+	if err != nil {
+		return err
+	}
+	s.Main = main
+	s.Finally = finally
+	return nil
+}
+
+// ValidateTriggerSpec reports whether the trigger spec, and any triggers it contains, can be used to create a trigger.
+func ValidateTriggerSpec(spec TriggerSpec) error {
+	switch s := spec.(type) {
+	case AfterWatermarkTriggerSpec:
+		if s.AllowedLateness < 0 {
+			return errors.New("afterWatermark trigger allowed lateness must not be negative")
+		}
+	case RepeatedTriggerSpec:
+		if s.Trigger == nil {
+			return errors.New("repeated trigger requires a trigger")
+		}
+		return ValidateTriggerSpec(s.Trigger)
+	case AfterProcessingTimeTriggerSpec:
+		if s.Duration < 0 {
+			return errors.New("afterProcessingTime trigger duration must not be negative")
+		}
+	case AfterAtLeastCountTriggerSpec:
+		if s.Count < 1 {
+			return errors.New("afterAtLeastCount trigger count must be positive")
+		}
+	case OrFinallyTriggerSpec:
+		if s.Main == nil || s.Finally == nil {
+			return errors.New("orFinally trigger requires both a main and a finally trigger")
+		}
+		if err := ValidateTriggerSpec(s.Main); err != nil {
+			return err
+		}
+		return ValidateTriggerSpec(s.Finally)
+	default:
+		return fmt.Errorf("unsupported trigger spec %T", spec)
+	}
+	return nil
+}
+
+// MarshalTriggerSpec encodes the trigger spec as JSON along with its kind.
+// A nil spec is encoded as null.
+func MarshalTriggerSpec(spec TriggerSpec) ([]byte, error) {
+	if spec == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(struct {
+		Kind TriggerKind `json:"kind"`
+		Spec TriggerSpec `json:"spec"`
+	}{
+		Kind: spec.Kind(),
+		Spec: spec,
+	})
+}
+
+// UnmarshalTriggerSpec decodes a trigger spec encoded with MarshalTriggerSpec.
+func UnmarshalTriggerSpec(data []byte) (TriggerSpec, error) {
+	var raw struct {
+		Kind *TriggerKind    `json:"kind"`
+		Spec json.RawMessage `json:"spec"`
+	}
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Kind == nil {
+		return nil, errors.New("trigger spec is missing a kind")
+	}
+	var spec TriggerSpec
+	var err error
+	switch *raw.Kind {
+	case AfterWatermark:
+		s := AfterWatermarkTriggerSpec{}
+		err = unmarshalTriggerSpec(raw.Spec, &s)
+		spec = s
+	case Repeated:
+		s := RepeatedTriggerSpec{}
+		err = unmarshalTriggerSpec(raw.Spec, &s)
+		spec = s
+	case AfterProcessingTime:
+		s := AfterProcessingTimeTriggerSpec{}
+		err = unmarshalTriggerSpec(raw.Spec, &s)
+		spec = s
+	case AfterAtLeastCount:
+		s := AfterAtLeastCountTriggerSpec{}
+		err = unmarshalTriggerSpec(raw.Spec, &s)
+		spec = s
+	case OrFinally:
+		s := OrFinallyTriggerSpec{}
+		err = unmarshalTriggerSpec(raw.Spec, &s)
+		spec = s
+	default:
+		return nil, fmt.Errorf("unknown trigger kind %v", *raw.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func unmarshalTriggerSpec(data []byte, spec interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, spec)
+}
+
+const triggerKindKey = "kind"
+
+// TriggerObjectType is the type of the values produced by the trigger functions.
+var TriggerObjectType = semantic.NewObjectType(map[string]semantic.Type{triggerKindKey: semantic.String})
+
+// triggerValue is the language value of a trigger spec.
+type triggerValue struct {
+	spec TriggerSpec
+}
+
+func (v triggerValue) Type() semantic.Type {
+	return TriggerObjectType
+}
+func (v triggerValue) Value() interface{} {
+	return v.spec
+}
+func (v triggerValue) Property(name string) (interpreter.Value, error) {
+	if name == triggerKindKey {
+		return interpreter.NewStringValue(v.spec.Kind().String()), nil
+	}
+	return nil, fmt.Errorf("property %q does not exist", name)
+}
+
+// GetTrigger reads a trigger created by one of the trigger functions.
+func (a Arguments) GetTrigger(name string) (TriggerSpec, bool, error) {
+	v, ok := a.Get(name)
+	if !ok {
+		return nil, false, nil
+	}
+	t, isTrigger := v.(triggerValue)
+	if !isTrigger {
+		return nil, true, fmt.Errorf("keyword argument %q must be a trigger, got %v", name, v.Type())
+	}
+	return t.spec, true, nil
+}
+
+func (a Arguments) GetRequiredTrigger(name string) (TriggerSpec, error) {
+	t, ok, err := a.GetTrigger(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("missing required keyword argument %q", name)
+	}
+	return t, nil
+}
+
+type createTriggerSpec func(args Arguments) (TriggerSpec, error)
+
+// triggerFunction is a builtin function that creates a trigger spec.
+type triggerFunction struct {
+	name   string
+	create createTriggerSpec
+}
+
+func (f triggerFunction) Type() semantic.Type {
+	return semantic.Function
+}
+func (f triggerFunction) Value() interface{} {
+	return f
+}
+func (f triggerFunction) Property(name string) (interpreter.Value, error) {
+	return nil, fmt.Errorf("property %q does not exist", name)
+}
+
+func (f triggerFunction) Call(args interpreter.Arguments, d interpreter.Domain) (interpreter.Value, error) {
+	spec, err := f.create(Arguments{Arguments: args})
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTriggerSpec(spec); err != nil {
+		return nil, err
+	}
+	return triggerValue{spec: spec}, nil
+}
+
+func (f triggerFunction) Resolve() (*semantic.FunctionExpression, error) {
+	return nil, fmt.Errorf("function %q cannot be resolved", f.name)
+}
+
+func registerTriggerFunction(kind TriggerKind, params map[string]semantic.Type, c createTriggerSpec) {
+	name := kind.String()
+	builtinScope.Set(name, triggerFunction{
+		name:   name,
+		create: c,
+	})
+	builtinDeclarations[name] = semantic.NewExternalVariableDeclaration(
+		name,
+		semantic.NewFunctionType(semantic.FunctionSignature{
+			Params:     params,
+			ReturnType: TriggerObjectType,
+		}),
+	)
+}
+
+func init() {
+	registerTriggerFunction(AfterWatermark, map[string]semantic.Type{
+		"allowedLateness": semantic.Duration,
+	}, func(args Arguments) (TriggerSpec, error) {
+		spec := AfterWatermarkTriggerSpec{}
+		if lateness, ok, err := args.GetDuration("allowedLateness"); err != nil {
+			return nil, err
+		} else if ok {
+			spec.AllowedLateness = lateness
+		}
+		return spec, nil
+	})
+	registerTriggerFunction(Repeated, map[string]semantic.Type{
+		"trigger": TriggerObjectType,
+	}, func(args Arguments) (TriggerSpec, error) {
+		t, err := args.GetRequiredTrigger("trigger")
+		if err != nil {
+			return nil, err
+		}
+		return RepeatedTriggerSpec{Trigger: t}, nil
+	})
+	registerTriggerFunction(AfterProcessingTime, map[string]semantic.Type{
+		"duration": semantic.Duration,
+	}, func(args Arguments) (TriggerSpec, error) {
+		d, err := args.GetRequiredDuration("duration")
+		if err != nil {
+			return nil, err
+		}
+		return AfterProcessingTimeTriggerSpec{Duration: d}, nil
+	})
+	registerTriggerFunction(AfterAtLeastCount, map[string]semantic.Type{
+		"n": semantic.Int,
+	}, func(args Arguments) (TriggerSpec, error) {
+		n, err := args.GetRequiredInt("n")
+		if err != nil {
+			return nil, err
+		}
+		return AfterAtLeastCountTriggerSpec{Count: int(n)}, nil
+	})
+	registerTriggerFunction(OrFinally, map[string]semantic.Type{
+		"main":    TriggerObjectType,
+		"finally": TriggerObjectType,
+	}, func(args Arguments) (TriggerSpec, error) {
+		main, err := args.GetRequiredTrigger("main")
+		if err != nil {
+			return nil, err
+		}
+		finally, err := args.GetRequiredTrigger("finally")
+		if err != nil {
+			return nil, err
+		}
+		return OrFinallyTriggerSpec{Main: main, Finally: finally}, nil
+	})
+}
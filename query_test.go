@@ -0,0 +1,160 @@
+package ifql_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql"
+	"github.com/influxdata/ifql/functions"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+	"github.com/influxdata/ifql/query/plan"
+)
+
+// shardedData are the points of db0 of two days, whose shards are on a host per day.
+// The days have different numbers of points, so that the mean of their means is not their mean.
+const shardedData = `# DDL
+CREATE DATABASE db0
+# DML
+# CONTEXT-DATABASE: db0
+cpu,host=a usage=1 1514764800000000000
+cpu,host=a usage=2 1514808000000000000
+cpu,host=a usage=3 1514851200000000000
+cpu,host=a usage=6 1514894400000000000
+cpu,host=a usage=9 1514916000000000000
+`
+
+const shardedShardMap = `db0 host1:8082 2018-01-01T00:00:00Z 2018-01-02T00:00:00Z
+db0 host2:8082 2018-01-02T00:00:00Z 2018-01-03T00:00:00Z
+`
+
+// newShardedController returns a controller of shardedData with the shard map shardedShardMap.
+// The returned function must be called to release it.
+func newShardedController(t *testing.T) (*ifql.Controller, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "ifql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataPath := filepath.Join(dir, "data.lp")
+	shardMapPath := filepath.Join(dir, "shards")
+	if err := ioutil.WriteFile(dataPath, []byte(shardedData), 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(shardMapPath, []byte(shardedShardMap), 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	c, err := ifql.NewController(ifql.Config{
+		Files:            []string{dataPath},
+		ShardMapFile:     shardMapPath,
+		ConcurrencyQuota: 4,
+		MemoryBytesQuota: math.MaxInt32,
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return c, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+// runQuery runs the query, and returns its plan and the blocks of its result.
+func runQuery(t *testing.T, c *ifql.Controller, q string) (*plan.PlanSpec, []*executetest.Block) {
+	t.Helper()
+	query, err := c.QueryWithCompile(context.Background(), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer query.Done()
+	results, ok := <-query.Ready
+	if !ok {
+		t.Fatal(query.Err())
+	}
+	var blocks []*executetest.Block
+	if err := results["_result"].Blocks().Do(func(b execute.Block) error {
+		blocks = append(blocks, executetest.ConvertBlock(b))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return query.Plan(), blocks
+}
+
+// procedureKinds counts the procedures of the plan by kind.
+func procedureKinds(p *plan.PlanSpec) map[plan.ProcedureKind]int {
+	kinds := make(map[plan.ProcedureKind]int)
+	for _, pr := range p.Procedures {
+		kinds[pr.Spec.Kind()]++
+	}
+	return kinds
+}
+
+func TestController_ShardMap(t *testing.T) {
+	c, cleanup := newShardedController(t)
+	defer cleanup()
+
+	p, blocks := runQuery(t, c, `from(db:"db0") |> range(start:2018-01-01T00:00:00Z, stop:2018-01-03T00:00:00Z)`)
+
+	// The read is split into a read per shard, whose results are merged.
+	kinds := procedureKinds(p)
+	if got := kinds[functions.FromKind]; got != 2 {
+		t.Errorf("unexpected number of reads: got %d want 2", got)
+	}
+	if got := kinds[functions.ShardMergeKind]; got != 1 {
+		t.Errorf("unexpected number of merges: got %d want 1", got)
+	}
+
+	if len(blocks) != 1 {
+		t.Fatalf("unexpected number of blocks: got %d want 1", len(blocks))
+	}
+	var got []float64
+	for _, row := range blocks[0].Data {
+		for j, c := range blocks[0].ColMeta {
+			if c.Label == execute.DefaultValueColLabel {
+				got = append(got, row[j].(float64))
+			}
+		}
+	}
+	if want := []float64{1, 2, 3, 6, 9}; !cmp.Equal(want, got) {
+		t.Errorf("unexpected values -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestController_ShardMapPartialAggregate(t *testing.T) {
+	c, cleanup := newShardedController(t)
+	defer cleanup()
+
+	p, blocks := runQuery(t, c, `from(db:"db0") |> range(start:2018-01-01T00:00:00Z, stop:2018-01-03T00:00:00Z) |> mean()`)
+
+	// Each shard is read and partially aggregated on its own, and the partial aggregates are combined.
+	kinds := procedureKinds(p)
+	if got := kinds[functions.FromKind]; got != 2 {
+		t.Errorf("unexpected number of reads: got %d want 2", got)
+	}
+	if got := kinds[functions.PartialKind]; got != 2 {
+		t.Errorf("unexpected number of partial aggregates: got %d want 2", got)
+	}
+	if got := kinds[functions.CombineKind]; got != 1 {
+		t.Errorf("unexpected number of combined aggregates: got %d want 1", got)
+	}
+
+	if len(blocks) != 1 {
+		t.Fatalf("unexpected number of blocks: got %d want 1", len(blocks))
+	}
+	b := blocks[0]
+	j := execute.ColIdx(execute.DefaultValueColLabel, b.ColMeta)
+	if len(b.Data) != 1 || j < 0 {
+		t.Fatalf("unexpected mean block: %v", b.Data)
+	}
+	if got, want := b.Data[0][j], 4.2; got != want {
+		t.Errorf("unexpected mean: got %v want %v", got, want)
+	}
+}
@@ -88,6 +88,7 @@ var binaryTypesLookup = map[binarySignature]Kind{
 	{operator: ast.EqualOperator, left: Float, right: UInt}:    Bool,
 	{operator: ast.EqualOperator, left: Float, right: Float}:   Bool,
 	{operator: ast.EqualOperator, left: String, right: String}: Bool,
+	{operator: ast.EqualOperator, left: String, right: Regex}:  Bool,
 
 	// NotEqualOperator
 
@@ -101,4 +102,13 @@ var binaryTypesLookup = map[binarySignature]Kind{
 	{operator: ast.NotEqualOperator, left: Float, right: UInt}:    Bool,
 	{operator: ast.NotEqualOperator, left: Float, right: Float}:   Bool,
 	{operator: ast.NotEqualOperator, left: String, right: String}: Bool,
+	{operator: ast.NotEqualOperator, left: String, right: Regex}:  Bool,
+
+	// RegexpMatchOperator
+
+	{operator: ast.RegexpMatchOperator, left: String, right: Regex}: Bool,
+
+	// NotRegexpMatchOperator
+
+	{operator: ast.NotRegexpMatchOperator, left: String, right: Regex}: Bool,
 }
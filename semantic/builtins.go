@@ -0,0 +1,70 @@
+package semantic
+
+import (
+	"fmt"
+	"sort"
+)
+
+// builtinSignatures holds the signatures of the builtin functions that can be called from compiled functions.
+var builtinSignatures = make(map[string]FunctionSignature)
+
+// RegisterBuiltinFunction registers the signature of a pure builtin function.
+// Calls to builtin functions are type checked against the registered signature.
+// It panics if a function with the same name has already been registered.
+func RegisterBuiltinFunction(name string, sig FunctionSignature) {
+	if _, ok := builtinSignatures[name]; ok {
+		panic(fmt.Errorf("duplicate registration for builtin function %q", name))
+	}
+	builtinSignatures[name] = sig
+}
+
+// LookupBuiltinFunction returns the signature of a registered builtin function.
+func LookupBuiltinFunction(name string) (FunctionSignature, bool) {
+	sig, ok := builtinSignatures[name]
+	return sig, ok
+}
+
+// BuiltinFunctionDeclarations returns a declaration for each registered builtin function.
+func BuiltinFunctionDeclarations() map[string]VariableDeclaration {
+	declarations := make(map[string]VariableDeclaration, len(builtinSignatures))
+	for name, sig := range builtinSignatures {
+		declarations[name] = NewExternalVariableDeclaration(name, NewFunctionType(sig))
+	}
+	return declarations
+}
+
+// CheckArguments reports an error if the argument types cannot be passed to a function with the signature.
+// Integer arguments may be passed to float parameters, and a parameter of the Invalid type accepts any type.
+func (sig FunctionSignature) CheckArguments(args map[string]Type) error {
+	names := make([]string, 0, len(sig.Params))
+	for name := range sig.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		typ, ok := args[name]
+		if !ok {
+			return fmt.Errorf("missing required argument %q", name)
+		}
+		if !assignable(sig.Params[name], typ) {
+			return fmt.Errorf("argument %q has type %v, expected %v", name, typ, sig.Params[name])
+		}
+	}
+	for name := range args {
+		if _, ok := sig.Params[name]; !ok {
+			return fmt.Errorf("unexpected argument %q", name)
+		}
+	}
+	return nil
+}
+
+// assignable reports whether a value of type from can be used where type to is expected.
+func assignable(to, from Type) bool {
+	if to == from || to == Invalid {
+		return true
+	}
+	if to == Float {
+		return from == Int || from == UInt
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
 	"regexp"
 	"strconv"
 	"time"
@@ -23,6 +24,7 @@ func (*Program) node() {}
 
 func (*BlockStatement) node()              {}
 func (*ExpressionStatement) node()         {}
+func (*ImportDeclaration) node()           {}
 func (*ReturnStatement) node()             {}
 func (*NativeVariableDeclaration) node()   {}
 func (*ExternalVariableDeclaration) node() {}
@@ -58,6 +60,7 @@ type Statement interface {
 
 func (*BlockStatement) stmt()              {}
 func (*ExpressionStatement) stmt()         {}
+func (*ImportDeclaration) stmt()           {}
 func (*ReturnStatement) stmt()             {}
 func (*NativeVariableDeclaration) stmt()   {}
 func (*ExternalVariableDeclaration) stmt() {}
@@ -169,6 +172,26 @@ func (s *ExpressionStatement) Copy() Node {
 	return ns
 }
 
+// ImportDeclaration binds the package at Path to the identifier Name.
+type ImportDeclaration struct {
+	Path string      `json:"path"`
+	Name *Identifier `json:"name"`
+}
+
+func (*ImportDeclaration) NodeType() string { return "ImportDeclaration" }
+
+func (d *ImportDeclaration) Copy() Node {
+	if d == nil {
+		return d
+	}
+	nd := new(ImportDeclaration)
+	*nd = *d
+
+	nd.Name = d.Name.Copy().(*Identifier)
+
+	return nd
+}
+
 type ReturnStatement struct {
 	Argument Expression `json:"argument"`
 }
@@ -392,7 +415,11 @@ type CallExpression struct {
 
 func (*CallExpression) NodeType() string { return "CallExpression" }
 func (e *CallExpression) Type() Type {
-	return e.Callee.Type()
+	t := e.Callee.Type()
+	if t.Kind() != Function {
+		return Invalid
+	}
+	return t.ReturnType()
 }
 
 func (e *CallExpression) Copy() Node {
@@ -416,6 +443,26 @@ type ConditionalExpression struct {
 
 func (*ConditionalExpression) NodeType() string { return "ConditionalExpression" }
 
+// Type is the type of the branches of the expression.
+// Branches of type int or uint and float are unified to float,
+// any other branches of different types are invalid.
+func (e *ConditionalExpression) Type() Type {
+	return unifyTypes(e.Consequent.Type(), e.Alternate.Type())
+}
+
+func unifyTypes(a, b Type) Type {
+	if a == b {
+		return a
+	}
+	switch ak, bk := a.Kind(), b.Kind(); {
+	case ak == Float && (bk == Int || bk == UInt),
+		bk == Float && (ak == Int || ak == UInt):
+		return Float
+	default:
+		return Invalid
+	}
+}
+
 func (e *ConditionalExpression) Copy() Node {
 	if e == nil {
 		return e
@@ -758,8 +805,19 @@ func analyzeProgram(prog *ast.Program, declarations DeclarationScope) (*Program,
 	p := &Program{
 		Body: make([]Statement, len(prog.Body)),
 	}
+	imports := true
 	for i, s := range prog.Body {
-		n, err := analyzeStatment(s, declarations)
+		var n Statement
+		var err error
+		if decl, ok := s.(*ast.ImportDeclaration); ok {
+			if !imports {
+				return nil, errors.New("import declarations must precede all other statements")
+			}
+			n, err = analyzeImportDeclaration(decl, declarations)
+		} else {
+			imports = false
+			n, err = analyzeStatment(s, declarations)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -787,6 +845,8 @@ func analyzeStatment(s ast.Statement, declarations DeclarationScope) (Statement,
 		return analyzeExpressionStatement(s, declarations)
 	case *ast.ReturnStatement:
 		return analyzeReturnStatement(s, declarations)
+	case *ast.ImportDeclaration:
+		return nil, errors.New("import declarations are only allowed at the top level of a program")
 	case *ast.VariableDeclaration:
 		// Expect a single declaration
 		if len(s.Declarations) != 1 {
@@ -837,6 +897,23 @@ func analyzeReturnStatement(ret *ast.ReturnStatement, declarations DeclarationSc
 	}, nil
 }
 
+// PackageName reports the name an imported package is bound to, which is the last element of its path.
+func PackageName(importPath string) string {
+	return path.Base(importPath)
+}
+
+// analyzeImportDeclaration does not declare the package name,
+// the caller of New is expected to provide a declaration for each imported package.
+func analyzeImportDeclaration(decl *ast.ImportDeclaration, declarations DeclarationScope) (*ImportDeclaration, error) {
+	if decl.Path == nil || decl.Path.Value == "" {
+		return nil, errors.New("import declaration is missing a path")
+	}
+	return &ImportDeclaration{
+		Path: decl.Path.Value,
+		Name: &Identifier{Name: PackageName(decl.Path.Value)},
+	}, nil
+}
+
 func analyzeVariableDeclaration(decl *ast.VariableDeclarator, declarations DeclarationScope) (*NativeVariableDeclaration, error) {
 	id, err := analyzeIdentifier(decl.ID, declarations)
 	if err != nil {
@@ -870,6 +947,8 @@ func analyzeExpression(expr ast.Expression, declarations DeclarationScope) (Expr
 		return analyzeUnaryExpression(expr, declarations)
 	case *ast.LogicalExpression:
 		return analyzeLogicalExpression(expr, declarations)
+	case *ast.ConditionalExpression:
+		return analyzeConditionalExpression(expr, declarations)
 	case *ast.ObjectExpression:
 		return analyzeObjectExpression(expr, declarations)
 	case *ast.ArrayExpression:
@@ -1121,6 +1200,13 @@ func resolveDeclaration(n Node) (VariableDeclaration, error) {
 			return resolveDeclaration(i)
 		}
 		return n, nil
+	case *MemberExpression:
+		// Members of objects, i.e. functions of imported packages, are declared by their type.
+		typ := n.Type()
+		if _, ok := typ.(Kind); ok {
+			return nil, fmt.Errorf("member %q has no declaration", n.Property)
+		}
+		return NewExternalVariableDeclaration(n.Property, typ), nil
 	}
 	return nil, errors.New("no declaration found")
 }
@@ -1177,6 +1263,25 @@ func analyzeLogicalExpression(logical *ast.LogicalExpression, declarations Decla
 		Right:    right,
 	}, nil
 }
+func analyzeConditionalExpression(cond *ast.ConditionalExpression, declarations DeclarationScope) (*ConditionalExpression, error) {
+	test, err := analyzeExpression(cond.Test, declarations)
+	if err != nil {
+		return nil, err
+	}
+	consequent, err := analyzeExpression(cond.Consequent, declarations)
+	if err != nil {
+		return nil, err
+	}
+	alternate, err := analyzeExpression(cond.Alternate, declarations)
+	if err != nil {
+		return nil, err
+	}
+	return &ConditionalExpression{
+		Test:       test,
+		Consequent: consequent,
+		Alternate:  alternate,
+	}, nil
+}
 func analyzeObjectExpression(obj *ast.ObjectExpression, declarations DeclarationScope) (*ObjectExpression, error) {
 	o := &ObjectExpression{
 		Properties: make([]*Property, len(obj.Properties)),
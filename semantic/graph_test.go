@@ -50,6 +50,62 @@ func TestNew(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "import declaration",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ImportDeclaration{
+						Path: &ast.StringLiteral{Value: "team/helpers"},
+					},
+					&ast.ExpressionStatement{
+						Expression: &ast.Identifier{Name: "helpers"},
+					},
+				},
+			},
+			want: &semantic.Program{
+				Body: []semantic.Statement{
+					&semantic.ImportDeclaration{
+						Path: "team/helpers",
+						Name: &semantic.Identifier{Name: "helpers"},
+					},
+					&semantic.ExpressionStatement{
+						Expression: &semantic.IdentifierExpression{Name: "helpers"},
+					},
+				},
+			},
+		},
+		{
+			name: "import after statement",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.ExpressionStatement{
+						Expression: &ast.StringLiteral{Value: "hello"},
+					},
+					&ast.ImportDeclaration{
+						Path: &ast.StringLiteral{Value: "team/helpers"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "import in block",
+			program: &ast.Program{
+				Body: []ast.Statement{
+					&ast.BlockStatement{
+						Body: []ast.Statement{
+							&ast.ImportDeclaration{
+								Path: &ast.StringLiteral{Value: "team/helpers"},
+							},
+							&ast.ReturnStatement{
+								Argument: &ast.StringLiteral{Value: "hello"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "function",
 			program: &ast.Program{
@@ -178,6 +234,33 @@ func TestExpression_Kind(t *testing.T) {
 			expr: &semantic.DurationLiteral{},
 			want: semantic.Duration,
 		},
+		{
+			name: "conditional",
+			expr: &semantic.ConditionalExpression{
+				Test:       &semantic.BooleanLiteral{},
+				Consequent: &semantic.StringLiteral{},
+				Alternate:  &semantic.StringLiteral{},
+			},
+			want: semantic.String,
+		},
+		{
+			name: "conditional int and float",
+			expr: &semantic.ConditionalExpression{
+				Test:       &semantic.BooleanLiteral{},
+				Consequent: &semantic.IntegerLiteral{},
+				Alternate:  &semantic.FloatLiteral{},
+			},
+			want: semantic.Float,
+		},
+		{
+			name: "conditional different types",
+			expr: &semantic.ConditionalExpression{
+				Test:       &semantic.BooleanLiteral{},
+				Consequent: &semantic.StringLiteral{},
+				Alternate:  &semantic.IntegerLiteral{},
+			},
+			want: semantic.Invalid,
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -48,6 +48,14 @@ func TestJSONMarshal(t *testing.T) {
 			},
 			want: `{"type":"ExpressionStatement","expression":{"type":"StringLiteral","value":"hello"}}`,
 		},
+		{
+			name: "import declaration",
+			node: &semantic.ImportDeclaration{
+				Path: "team/helpers",
+				Name: &semantic.Identifier{Name: "helpers"},
+			},
+			want: `{"type":"ImportDeclaration","path":"team/helpers","name":{"type":"Identifier","name":"helpers"}}`,
+		},
 		{
 			name: "return statement",
 			node: &semantic.ReturnStatement{
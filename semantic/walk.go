@@ -31,6 +31,11 @@ func walk(v Visitor, n Node) {
 		if w != nil {
 			walk(w, n.Expression)
 		}
+	case *ImportDeclaration:
+		w := v.Visit(n)
+		if w != nil {
+			walk(w, n.Name)
+		}
 	case *ReturnStatement:
 		w := v.Visit(n)
 		if w != nil {
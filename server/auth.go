@@ -0,0 +1,330 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/ifql"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrUnauthenticated is the cause of the errors of requests without valid credentials.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is the cause of the errors of queries that read storage their user may not read.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// AllowAll is the grant of all databases or all hosts.
+const AllowAll = "*"
+
+// User is an authenticated user of the server.
+type User struct {
+	Name string
+	// Databases are the databases the user may read, AllowAll grants all databases.
+	Databases []string
+	// Hosts are the storage hosts the user may name in the hosts of a read, AllowAll grants all hosts.
+	// Reads that do not name hosts read the hosts of the server, they are not restricted.
+	Hosts []string
+}
+
+// CanRead reports whether the user may read the database.
+func (u *User) CanRead(database string) bool {
+	return granted(u.Databases, database)
+}
+
+// CanReadFrom reports whether the user may read from the storage host.
+func (u *User) CanReadFrom(host string) bool {
+	return granted(u.Hosts, host)
+}
+
+func granted(grants []string, name string) bool {
+	for _, g := range grants {
+		if g == AllowAll || g == name {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey int
+
+const userKey contextKey = iota
+
+// NewContextWithUser returns a copy of ctx with the user, queries started with the context are made by the user.
+func NewContextWithUser(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, userKey, u)
+}
+
+// UserFromContext returns the user of ctx, or nil if there is none.
+func UserFromContext(ctx context.Context) *User {
+	u, _ := ctx.Value(userKey).(*User)
+	return u
+}
+
+// Authenticator identifies the users making requests.
+type Authenticator interface {
+	// Authenticate returns the user making the request.
+	// The cause of the error is ErrUnauthenticated when the request does not have valid credentials.
+	Authenticate(req *http.Request) (*User, error)
+	// Challenge is the WWW-Authenticate header of the responses to requests without valid credentials.
+	Challenge() string
+}
+
+// MultiAuthenticator authenticates requests with the first of its authenticators that accepts their credentials.
+type MultiAuthenticator []Authenticator
+
+func (as MultiAuthenticator) Authenticate(req *http.Request) (*User, error) {
+	for _, a := range as {
+		u, err := a.Authenticate(req)
+		if err == nil {
+			return u, nil
+		}
+		if errors.Cause(err) != ErrUnauthenticated {
+			return nil, err
+		}
+	}
+	return nil, ErrUnauthenticated
+}
+
+func (as MultiAuthenticator) Challenge() string {
+	challenges := make([]string, len(as))
+	for i, a := range as {
+		challenges[i] = a.Challenge()
+	}
+	return strings.Join(challenges, ", ")
+}
+
+// TokenAuthenticator authenticates requests by the bearer token of their Authorization header.
+type TokenAuthenticator struct {
+	users map[string]*User
+}
+
+// NewTokenAuthenticator reads the tokens of users from the file at path.
+// Each line of the file is a token, the name of its user, and the comma separated lists of
+// the databases and hosts the user may read, separated by spaces. The lists may be omitted.
+// Empty lines and lines starting with # are ignored.
+func NewTokenAuthenticator(path string) (*TokenAuthenticator, error) {
+	a := &TokenAuthenticator{
+		users: make(map[string]*User),
+	}
+	err := readAuthFile(path, func(token, name string, databases, hosts []string) error {
+		if _, ok := a.users[token]; ok {
+			return fmt.Errorf("duplicate token of user %q", name)
+		}
+		a.users[token] = &User{
+			Name:      name,
+			Databases: databases,
+			Hosts:     hosts,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *TokenAuthenticator) Authenticate(req *http.Request) (*User, error) {
+	const prefix = "Bearer "
+	h := req.Header.Get("Authorization")
+	if !strings.HasPrefix(h, prefix) {
+		return nil, ErrUnauthenticated
+	}
+	u, ok := a.users[strings.TrimPrefix(h, prefix)]
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	return u, nil
+}
+
+func (a *TokenAuthenticator) Challenge() string {
+	return "Bearer"
+}
+
+// credentialsTTL is the duration for which the verified credentials of a user are accepted without comparing their hash again.
+const credentialsTTL = time.Minute
+
+// BasicAuthenticator authenticates requests by HTTP basic authentication.
+// Comparing a password with its bcrypt hash is slow by design, so the credentials of a user are cached
+// for a short time once they have been verified. Requests of unknown users compare the password with a dummy hash,
+// so that their response time does not reveal which users exist.
+type BasicAuthenticator struct {
+	users map[string]basicUser
+	dummy []byte
+
+	mu sync.Mutex
+	// verified holds the last verified credentials of each user.
+	verified map[string]verifiedCredentials
+}
+
+type basicUser struct {
+	user *User
+	hash []byte
+}
+
+type verifiedCredentials struct {
+	// sum is the SHA-256 sum of the password.
+	sum     [sha256.Size]byte
+	expires time.Time
+}
+
+// NewBasicAuthenticator reads users from the file at path.
+// Each line of the file is the name of a user, the hash of its password, and the comma separated lists of
+// the databases and hosts the user may read, separated by spaces. The lists may be omitted.
+// The bcrypt hash of a password is created with HashPassword.
+// Empty lines and lines starting with # are ignored.
+func NewBasicAuthenticator(path string) (*BasicAuthenticator, error) {
+	dummy, err := bcrypt.GenerateFromPassword([]byte("dummy"), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	a := &BasicAuthenticator{
+		users:    make(map[string]basicUser),
+		dummy:    dummy,
+		verified: make(map[string]verifiedCredentials),
+	}
+	err = readAuthFile(path, func(name, password string, databases, hosts []string) error {
+		if _, ok := a.users[name]; ok {
+			return fmt.Errorf("duplicate user %q", name)
+		}
+		hash := []byte(password)
+		if _, err := bcrypt.Cost(hash); err != nil {
+			return errors.Wrapf(err, "invalid password hash of user %q", name)
+		}
+		a.users[name] = basicUser{
+			user: &User{
+				Name:      name,
+				Databases: databases,
+				Hosts:     hosts,
+			},
+			hash: hash,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *BasicAuthenticator) Authenticate(req *http.Request) (*User, error) {
+	name, password, ok := req.BasicAuth()
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	bu, ok := a.users[name]
+	if !ok {
+		bcrypt.CompareHashAndPassword(a.dummy, []byte(password))
+		return nil, ErrUnauthenticated
+	}
+	sum := sha256.Sum256([]byte(password))
+	now := time.Now()
+	a.mu.Lock()
+	v, ok := a.verified[name]
+	a.mu.Unlock()
+	if ok && now.Before(v.expires) && subtle.ConstantTimeCompare(v.sum[:], sum[:]) == 1 {
+		return bu.user, nil
+	}
+	if err := bcrypt.CompareHashAndPassword(bu.hash, []byte(password)); err != nil {
+		return nil, ErrUnauthenticated
+	}
+	a.mu.Lock()
+	a.verified[name] = verifiedCredentials{sum: sum, expires: now.Add(credentialsTTL)}
+	a.mu.Unlock()
+	return bu.user, nil
+}
+
+func (a *BasicAuthenticator) Challenge() string {
+	return `Basic realm="ifqld"`
+}
+
+// HashPassword returns the bcrypt hash of the password, in the format of the users file of a BasicAuthenticator.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// readAuthFile calls f with the first two fields and the granted databases and hosts of each line of the file at path.
+func readAuthFile(path string, f func(first, second string, databases, hosts []string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || len(fields) > 4 {
+			return fmt.Errorf("%s:%d: expected 2 to 4 fields, got %d", path, n, len(fields))
+		}
+		var databases, hosts []string
+		if len(fields) > 2 {
+			databases = strings.Split(fields[2], ",")
+		}
+		if len(fields) > 3 {
+			hosts = strings.Split(fields[3], ",")
+		}
+		if err := f(fields[0], fields[1], databases, hosts); err != nil {
+			return errors.Wrapf(err, "%s:%d", path, n)
+		}
+	}
+	return scanner.Err()
+}
+
+// UserAuthorizer authorizes the reads of the databases and hosts granted to the user in the context of a query.
+// Queries without a user are unauthenticated.
+type UserAuthorizer struct{}
+
+func (UserAuthorizer) AuthorizeRead(ctx context.Context, database string, hosts []string) error {
+	u := UserFromContext(ctx)
+	if u == nil {
+		return ErrUnauthenticated
+	}
+	if !u.CanRead(database) {
+		return errors.Wrapf(ErrForbidden, "user %q may not read database %q", u.Name, database)
+	}
+	for _, h := range hosts {
+		if !u.CanReadFrom(h) {
+			return errors.Wrapf(ErrForbidden, "user %q may not read from host %q", u.Name, h)
+		}
+	}
+	return nil
+}
+
+// authenticate serves the requests of the users authenticated by a, and rejects other requests.
+// The queries of a user belong to the tenant of the same name.
+func authenticate(a Authenticator, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		u, err := a.Authenticate(req)
+		if err != nil {
+			status := errorStatus(err)
+			if status == http.StatusUnauthorized {
+				w.Header().Set("WWW-Authenticate", a.Challenge())
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		ctx := NewContextWithUser(req.Context(), u)
+		ctx = ifql.NewContextWithTenant(ctx, u.Name)
+		h.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
@@ -0,0 +1,291 @@
+package server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/ifql/server"
+	"github.com/pkg/errors"
+)
+
+// writeAuthFile writes the lines to a temporary file and returns its path.
+// The returned function must be called to remove it.
+func writeAuthFile(t *testing.T, lines ...string) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "auth")
+	if err := ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+// hashPassword returns the hash of the password for the users file of a BasicAuthenticator.
+func hashPassword(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := server.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	path, remove := writeAuthFile(t,
+		"# token user databases hosts",
+		"",
+		"t0 alice db0,db1 h0:8082",
+		"t1 bob *",
+		"t2 carol",
+	)
+	defer remove()
+	a, err := server.NewTokenAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		authorization string
+		want          *server.User
+	}{
+		{
+			name:          "grants",
+			authorization: "Bearer t0",
+			want:          &server.User{Name: "alice", Databases: []string{"db0", "db1"}, Hosts: []string{"h0:8082"}},
+		},
+		{
+			name:          "all databases",
+			authorization: "Bearer t1",
+			want:          &server.User{Name: "bob", Databases: []string{"*"}},
+		},
+		{
+			name:          "no grants",
+			authorization: "Bearer t2",
+			want:          &server.User{Name: "carol"},
+		},
+		{
+			name:          "unknown token",
+			authorization: "Bearer t3",
+		},
+		{
+			name:          "basic",
+			authorization: "Basic dDA6",
+		},
+		{
+			name: "missing",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/query", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			got, err := a.Authenticate(req)
+			if tc.want == nil {
+				if errors.Cause(err) != server.ErrUnauthenticated {
+					t.Fatalf("unexpected error: got %v want %v", err, server.ErrUnauthenticated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected user: -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestBasicAuthenticator(t *testing.T) {
+	path, remove := writeAuthFile(t,
+		"alice "+hashPassword(t, "secret")+" db0",
+		"bob "+hashPassword(t, "secret"),
+	)
+	defer remove()
+	a, err := server.NewBasicAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		user     string
+		password string
+		want     *server.User
+	}{
+		{
+			name:     "valid",
+			user:     "alice",
+			password: "secret",
+			want:     &server.User{Name: "alice", Databases: []string{"db0"}},
+		},
+		{
+			name:     "same password",
+			user:     "bob",
+			password: "secret",
+			want:     &server.User{Name: "bob"},
+		},
+		{
+			name:     "wrong password",
+			user:     "alice",
+			password: "guess",
+		},
+		{
+			name:     "valid again",
+			user:     "alice",
+			password: "secret",
+			want:     &server.User{Name: "alice", Databases: []string{"db0"}},
+		},
+		{
+			name:     "wrong password of verified user",
+			user:     "alice",
+			password: "secret ",
+		},
+		{
+			name:     "unknown user",
+			user:     "carol",
+			password: "secret",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/query", nil)
+			req.SetBasicAuth(tc.user, tc.password)
+			got, err := a.Authenticate(req)
+			if tc.want == nil {
+				if errors.Cause(err) != server.ErrUnauthenticated {
+					t.Fatalf("unexpected error: got %v want %v", err, server.ErrUnauthenticated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected user: -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestAuthenticator_InvalidFile(t *testing.T) {
+	testCases := []struct {
+		name  string
+		new   func(path string) error
+		lines []string
+	}{
+		{
+			name:  "token fields",
+			new:   func(path string) error { _, err := server.NewTokenAuthenticator(path); return err },
+			lines: []string{"t0"},
+		},
+		{
+			name:  "duplicate token",
+			new:   func(path string) error { _, err := server.NewTokenAuthenticator(path); return err },
+			lines: []string{"t0 alice", "t0 bob"},
+		},
+		{
+			name:  "basic fields",
+			new:   func(path string) error { _, err := server.NewBasicAuthenticator(path); return err },
+			lines: []string{"alice " + hashPassword(t, "a") + " db0 h0 extra"},
+		},
+		{
+			name:  "basic hash",
+			new:   func(path string) error { _, err := server.NewBasicAuthenticator(path); return err },
+			lines: []string{"alice secret"},
+		},
+		{
+			name:  "duplicate user",
+			new:   func(path string) error { _, err := server.NewBasicAuthenticator(path); return err },
+			lines: []string{"alice " + hashPassword(t, "a"), "alice " + hashPassword(t, "b")},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			path, remove := writeAuthFile(t, tc.lines...)
+			defer remove()
+			if err := tc.new(path); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestUserAuthorizer(t *testing.T) {
+	user := &server.User{
+		Name:      "alice",
+		Databases: []string{"db0"},
+		Hosts:     []string{"h0:8082"},
+	}
+	testCases := []struct {
+		name     string
+		user     *server.User
+		database string
+		hosts    []string
+		want     error
+	}{
+		{
+			name:     "allowed",
+			user:     user,
+			database: "db0",
+		},
+		{
+			name:     "allowed hosts",
+			user:     user,
+			database: "db0",
+			hosts:    []string{"h0:8082"},
+		},
+		{
+			name:     "all databases",
+			user:     &server.User{Name: "bob", Databases: []string{server.AllowAll}},
+			database: "db1",
+		},
+		{
+			name:     "forbidden database",
+			user:     user,
+			database: "db1",
+			want:     server.ErrForbidden,
+		},
+		{
+			name:     "forbidden host",
+			user:     user,
+			database: "db0",
+			hosts:    []string{"h0:8082", "h1:8082"},
+			want:     server.ErrForbidden,
+		},
+		{
+			name:     "unauthenticated",
+			database: "db0",
+			want:     server.ErrUnauthenticated,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.user != nil {
+				ctx = server.NewContextWithUser(ctx, tc.user)
+			}
+			err := server.UserAuthorizer{}.AuthorizeRead(ctx, tc.database, tc.hosts)
+			if got := errors.Cause(err); got != tc.want {
+				t.Errorf("unexpected error: got %v want %v", err, tc.want)
+			}
+		})
+	}
+}
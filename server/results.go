@@ -0,0 +1,249 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/influxdata/ifql/query/csv"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/influxdb/models"
+)
+
+// iterateResults calls f for each row of the result, and retract for each retraction of its blocks.
+// Null tags are omitted, and rows without a value that is not null are skipped.
+func iterateResults(r execute.Result, f func(measurement, fieldName string, tags map[string]string, value interface{}, t time.Time), retract func(execute.BlockMetadata)) {
+	err := r.DoWithRetractions(func(b execute.Block) error {
+
+		times := b.Times()
+		times.DoTime(func(ts []execute.Time, rr execute.RowReader) {
+			for i, time := range ts {
+				var measurement, fieldName string
+				tags := map[string]string{}
+				var value interface{}
+
+				for j, c := range rr.Cols() {
+					if rr.IsNull(i, j) {
+						continue
+					}
+					if c.IsTag() {
+						if c.Label == "_measurement" {
+							measurement = rr.AtString(i, j)
+						} else if c.Label == "_field" {
+							fieldName = rr.AtString(i, j)
+						} else {
+							tags[c.Label] = rr.AtString(i, j)
+						}
+					} else if c.IsValue() {
+						switch c.Type {
+						case execute.TBool:
+							value = rr.AtBool(i, j)
+						case execute.TInt:
+							value = rr.AtInt(i, j)
+						case execute.TUInt:
+							value = rr.AtUInt(i, j)
+						case execute.TFloat:
+							value = rr.AtFloat(i, j)
+						case execute.TString:
+							value = rr.AtString(i, j)
+						case execute.TTime:
+							value = rr.AtTime(i, j)
+						default:
+							value = "unknown"
+						}
+					}
+				}
+
+				if value == nil {
+					continue
+				}
+				if measurement == "" {
+					measurement = "measurement"
+				}
+				if fieldName == "" {
+					fieldName = "value"
+				}
+				f(measurement, fieldName, tags, value, time.Time())
+			}
+		})
+		return nil
+	}, func(meta execute.BlockMetadata) error {
+		retract(meta)
+		return nil
+	})
+	if err != nil {
+		log.Println("Error iterating through results:", err)
+	}
+}
+
+type header struct {
+	Result   string            `json:"result"`
+	SeriesID int64             `json:"seriesID"`
+	Tags     map[string]string `json:"tags"`
+}
+
+// retraction reports that the previously written series of the result with the same bounds and tags are no longer valid.
+type retraction struct {
+	Result  string            `json:"result"`
+	Retract bool              `json:"retract"`
+	Tags    map[string]string `json:"tags"`
+	Start   int64             `json:"start"`
+	Stop    int64             `json:"stop"`
+}
+
+type chunk struct {
+	Points []point `json:"points"`
+}
+
+type point struct {
+	Value   interface{}       `json:"value"`
+	Time    int64             `json:"time"`
+	Context map[string]string `json:"context,omitempty"`
+}
+
+func writeJSONChunks(results map[string]execute.Result, w http.ResponseWriter) {
+	seriesID := int64(0)
+	for name, r := range results {
+		err := r.DoWithRetractions(func(b execute.Block) error {
+			seriesID++
+
+			// output header
+			h := header{Result: name, SeriesID: seriesID, Tags: b.Tags()}
+			bb, err := json.Marshal(h)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(bb)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write([]byte("\n"))
+			if err != nil {
+				return err
+			}
+
+			times := b.Times()
+			times.DoTime(func(ts []execute.Time, rr execute.RowReader) {
+				ch := chunk{Points: make([]point, len(ts))}
+				for i, time := range ts {
+					ch.Points[i].Time = time.Time().UnixNano()
+
+					for j, c := range rr.Cols() {
+						if rr.IsNull(i, j) {
+							// Null values are written as null, and null context is omitted.
+							if c.IsValue() {
+								ch.Points[i].Value = nil
+							}
+							continue
+						}
+						if !c.Common && c.Type == execute.TString {
+							if ch.Points[i].Context == nil {
+								ch.Points[i].Context = make(map[string]string)
+							}
+							ch.Points[i].Context[c.Label] = rr.AtString(i, j)
+						} else if c.IsValue() {
+							switch c.Type {
+							case execute.TFloat:
+								ch.Points[i].Value = rr.AtFloat(i, j)
+							case execute.TInt:
+								ch.Points[i].Value = rr.AtInt(i, j)
+							case execute.TString:
+								ch.Points[i].Value = rr.AtString(i, j)
+							case execute.TUInt:
+								ch.Points[i].Value = rr.AtUInt(i, j)
+							case execute.TBool:
+								ch.Points[i].Value = rr.AtBool(i, j)
+							default:
+								ch.Points[i].Value = "unknown"
+							}
+						}
+					}
+				}
+
+				// write it out
+				b, err := json.Marshal(ch)
+				if err != nil {
+					log.Println("error marshaling chunk: ", err.Error())
+					return
+				}
+				_, err = w.Write(b)
+				if err != nil {
+					log.Println("error writing chunk: ", err.Error())
+					return
+				}
+				_, err = w.Write([]byte("\n"))
+				if err != nil {
+					log.Println("error writing newline: ", err.Error())
+					return
+				}
+				w.(http.Flusher).Flush()
+			})
+			return nil
+		}, func(meta execute.BlockMetadata) error {
+			bounds := meta.Bounds()
+			bb, err := json.Marshal(retraction{
+				Result:  name,
+				Retract: true,
+				Tags:    meta.Tags(),
+				Start:   bounds.Start.Time().UnixNano(),
+				Stop:    bounds.Stop.Time().UnixNano(),
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(append(bb, '\n')); err != nil {
+				return err
+			}
+			w.(http.Flusher).Flush()
+			return nil
+		})
+		if err != nil {
+			log.Println("Error iterating through results:", err)
+		}
+	}
+}
+
+func writeCSVResults(results map[string]execute.Result, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	enc := csv.NewResultEncoder(w)
+	for _, name := range names {
+		if err := enc.Encode(name, results[name]); err != nil {
+			log.Println("Error encoding results:", err)
+			return
+		}
+	}
+}
+
+// writeLineResults writes the results as line protocol.
+// Retractions are written as comments of the result name, the bounds and the tags of the retracted series.
+func writeLineResults(results map[string]execute.Result, w http.ResponseWriter) {
+	for name, r := range results {
+		iterateResults(r, func(m, f string, tags map[string]string, val interface{}, t time.Time) {
+			p, err := models.NewPoint(m, models.NewTags(tags), map[string]interface{}{f: val}, t)
+			if err != nil {
+				log.Println("error creating new point", err)
+				return
+			}
+			w.Write([]byte(p.String()))
+			w.Write([]byte("\n"))
+		}, func(meta execute.BlockMetadata) {
+			bounds := meta.Bounds()
+			tags := meta.Tags()
+			line := fmt.Sprintf("# retract result=%s start=%d stop=%d", name, bounds.Start.Time().UnixNano(), bounds.Stop.Time().UnixNano())
+			for _, k := range tags.Keys() {
+				line += " " + k + "=" + tags[k]
+			}
+			w.Write([]byte(line + "\n"))
+		})
+	}
+}
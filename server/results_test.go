@@ -0,0 +1,138 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/execute/executetest"
+)
+
+// testResult is a result of blocks followed by retractions.
+type testResult struct {
+	execute.Result
+	blocks      []*executetest.Block
+	retractions []execute.BlockMetadata
+}
+
+func (r *testResult) DoWithRetractions(f func(execute.Block) error, retract func(execute.BlockMetadata) error) error {
+	for _, b := range r.blocks {
+		if err := f(b); err != nil {
+			return err
+		}
+	}
+	for _, meta := range r.retractions {
+		if err := retract(meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var testCols = []execute.ColMeta{
+	{Label: "_time", Type: execute.TTime, Kind: execute.TimeColKind},
+	{Label: "_value", Type: execute.TFloat, Kind: execute.ValueColKind},
+	{Label: "_measurement", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+	{Label: "host", Type: execute.TString, Kind: execute.TagColKind, Common: true},
+	{Label: "cpu", Type: execute.TString, Kind: execute.TagColKind},
+}
+
+// newRetractingResult returns a result of a block followed by the retraction of its series.
+func newRetractingResult() *testResult {
+	b := &executetest.Block{
+		Bnds:    execute.Bounds{Start: 0, Stop: 60e9},
+		ColMeta: testCols,
+		Data: [][]interface{}{
+			{execute.Time(10e9), 1.5, "cpu", "a", "cpu0"},
+		},
+	}
+	return &testResult{
+		blocks:      []*executetest.Block{b},
+		retractions: []execute.BlockMetadata{b},
+	}
+}
+
+// newNullResult returns a result of a block with null values and tags.
+func newNullResult() *testResult {
+	return &testResult{
+		blocks: []*executetest.Block{{
+			Bnds:    execute.Bounds{Start: 0, Stop: 60e9},
+			ColMeta: testCols,
+			Data: [][]interface{}{
+				{execute.Time(10e9), nil, "cpu", "a", "cpu0"},
+				{execute.Time(20e9), 2.5, "cpu", "a", nil},
+			},
+		}},
+	}
+}
+
+func TestWriteResults(t *testing.T) {
+	testCases := []struct {
+		name   string
+		result *testResult
+		write  func(map[string]execute.Result, *httptest.ResponseRecorder)
+		want   string
+	}{
+		{
+			name:   "json retraction",
+			result: newRetractingResult(),
+			write: func(results map[string]execute.Result, w *httptest.ResponseRecorder) {
+				writeJSONChunks(results, w)
+			},
+			want: `{"result":"_result","retract":true,"tags":{"_measurement":"cpu","host":"a"},"start":0,"stop":60000000000}` + "\n",
+		},
+		{
+			name:   "csv retraction",
+			result: newRetractingResult(),
+			write: func(results map[string]execute.Result, w *httptest.ResponseRecorder) {
+				writeCSVResults(results, w)
+			},
+			want: "#retract,_result,,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,_measurement=cpu,host=a\n",
+		},
+		{
+			name:   "line retraction",
+			result: newRetractingResult(),
+			write: func(results map[string]execute.Result, w *httptest.ResponseRecorder) {
+				writeLineResults(results, w)
+			},
+			want: "cpu,cpu=cpu0,host=a value=1.5 10000000000\n# retract result=_result start=0 stop=60000000000 _measurement=cpu host=a\n",
+		},
+		{
+			name:   "json nulls",
+			result: newNullResult(),
+			write: func(results map[string]execute.Result, w *httptest.ResponseRecorder) {
+				writeJSONChunks(results, w)
+			},
+			want: `{"points":[{"value":null,"time":10000000000,"context":{"cpu":"cpu0"}}]}` + "\n" +
+				`{"points":[{"value":2.5,"time":20000000000}]}` + "\n",
+		},
+		{
+			name:   "csv nulls",
+			result: newNullResult(),
+			write: func(results map[string]execute.Result, w *httptest.ResponseRecorder) {
+				writeCSVResults(results, w)
+			},
+			want: ",_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:10Z,,cpu,a,cpu0\n" +
+				",_result,0,1970-01-01T00:00:00Z,1970-01-01T00:01:00Z,1970-01-01T00:00:20Z,2.5,cpu,a,\n",
+		},
+		{
+			name:   "line nulls",
+			result: newNullResult(),
+			write: func(results map[string]execute.Result, w *httptest.ResponseRecorder) {
+				writeLineResults(results, w)
+			},
+			want: "cpu,host=a value=2.5 20000000000\n",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tc.write(map[string]execute.Result{"_result": tc.result}, w)
+			if got := w.Body.String(); !strings.HasSuffix(got, tc.want) {
+				t.Errorf("unexpected end of results: got\n%s\nwant suffix\n%s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,422 @@
+// Package server provides the HTTP API of the IFQL query engine.
+// It is served by ifqld and can be embedded in other binaries.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/ifql"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/query/plan"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var functionCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "ifql_function_count",
+		Help: "How times a function was used in a query",
+	},
+	[]string{"function"},
+)
+
+var queryCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "ifql_query_count",
+	Help: "Number of queries executed",
+})
+
+func init() {
+	prometheus.MustRegister(functionCounter)
+	prometheus.MustRegister(queryCounter)
+}
+
+// Middleware wraps a handler, to act on requests before or after it.
+type Middleware func(http.Handler) http.Handler
+
+type Config struct {
+	// Controller executes the queries of the server.
+	Controller *ifql.Controller
+	// StorageReader is closed once the server has shut down, it may be nil.
+	StorageReader execute.StorageReader
+
+	// LibDirs are the directories searched for the source files of packages imported by analyzed queries.
+	LibDirs []string
+
+	// Authenticator authenticates all requests, it may be nil.
+	// The user of a request is available to the authorizer of the controller in the context of its query,
+	// see UserAuthorizer.
+	Authenticator Authenticator
+
+	// Handlers are served in addition to the endpoints of the server, keyed by their http.ServeMux pattern.
+	// A handler replaces the endpoint of the server with the same pattern, a nil handler removes it.
+	Handlers map[string]http.Handler
+	// Middleware wraps all handlers, the first middleware is the outermost.
+	Middleware []Middleware
+
+	Verbose bool
+}
+
+// Server serves the HTTP API of a query controller.
+type Server struct {
+	controller *ifql.Controller
+	reader     execute.StorageReader
+	libDirs    []string
+	verbose    bool
+
+	handler http.Handler
+
+	queryCount int64
+
+	mu         sync.Mutex
+	closing    bool
+	httpServer *http.Server
+	// inflight tracks the queries being served, so that they can drain on shutdown.
+	inflight sync.WaitGroup
+}
+
+// New creates a server of the endpoints:
+//
+//	/query         executes a query
+//	/queries       lists the active queries
+//	/queries/{id}  reports the details of an active query on GET, and cancels it on DELETE
+//	/metrics       exposes the Prometheus metrics of the process
+func New(c Config) *Server {
+	s := &Server{
+		controller: c.Controller,
+		reader:     c.StorageReader,
+		libDirs:    c.LibDirs,
+		verbose:    c.Verbose,
+	}
+
+	handlers := map[string]http.Handler{
+		"/metrics":  promhttp.Handler(),
+		"/query":    http.HandlerFunc(s.handleQuery),
+		"/queries":  http.HandlerFunc(s.handleQueries),
+		"/queries/": http.HandlerFunc(s.handleQueryByID),
+	}
+	for pattern, h := range c.Handlers {
+		handlers[pattern] = h
+	}
+	mux := http.NewServeMux()
+	for pattern, h := range handlers {
+		if h != nil {
+			mux.Handle(pattern, h)
+		}
+	}
+	var h http.Handler = mux
+	if c.Authenticator != nil {
+		h = authenticate(c.Authenticator, h)
+	}
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		h = c.Middleware[i](h)
+	}
+	s.handler = h
+	return s
+}
+
+// ServeHTTP serves the endpoints of the server.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.handler.ServeHTTP(w, req)
+}
+
+// ListenAndServe serves the endpoints on the TCP address addr until the server is shut down,
+// at which point it returns http.ErrServerClosed.
+func (s *Server) ListenAndServe(addr string) error {
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		return http.ErrServerClosed
+	}
+	s.httpServer = &http.Server{
+		Addr:    addr,
+		Handler: s,
+	}
+	hs := s.httpServer
+	s.mu.Unlock()
+	return hs.ListenAndServe()
+}
+
+// Shutdown stops accepting queries and waits for the queries being served to finish, then closes the storage reader.
+// If ctx is done before the queries have finished, they are canceled and the error of ctx is returned
+// once they have returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closing = true
+	hs := s.httpServer
+	s.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+		for _, q := range s.controller.Queries() {
+			q.Cancel()
+		}
+	}
+	if hs != nil {
+		// Close the listener and the idle connections.
+		if herr := hs.Shutdown(ctx); err == nil {
+			err = herr
+		}
+	}
+	// Canceled queries read from the storage reader until their handlers have returned.
+	<-drained
+	if s.reader != nil {
+		s.reader.Close()
+	}
+	return err
+}
+
+// QueryCount reports the number of queries the server has received.
+func (s *Server) QueryCount() int64 {
+	return atomic.LoadInt64(&s.queryCount)
+}
+
+// startQuery registers a query being served, it reports false when the server is shutting down.
+// The returned function must be called once the query has been served.
+func (s *Server) startQuery() (func(), bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closing {
+		return nil, false
+	}
+	s.inflight.Add(1)
+	return s.inflight.Done, true
+}
+
+// handleQuery interprets and executes ifql syntax and returns results
+func (s *Server) handleQuery(w http.ResponseWriter, req *http.Request) {
+	done, ok := s.startQuery()
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("server is shutting down"))
+		return
+	}
+	defer done()
+
+	span, ctx := opentracing.StartSpanFromContext(req.Context(), "query")
+	defer span.Finish()
+
+	atomic.AddInt64(&s.queryCount, 1)
+	queryCounter.Inc()
+
+	var (
+		q   *ifql.Query
+		err error
+	)
+	if req.Header.Get("Content-type") == "application/json" {
+		spec := new(query.Spec)
+		if err := json.NewDecoder(req.Body).Decode(spec); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Error parsing query spec %s", err.Error())))
+			log.Println("Error:", err)
+			return
+		}
+
+		q, err = s.controller.Query(ctx, spec)
+	} else {
+		queryStr := req.FormValue("q")
+		if queryStr == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("must pass query in q parameter"))
+			return
+		}
+		if s.verbose {
+			log.Print(queryStr)
+		}
+
+		analyze := req.FormValue("analyze") != ""
+		if analyze {
+			spec, err := query.Compile(ctx, queryStr, query.LibDirs(s.libDirs...))
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Error compiling query %s", err.Error())))
+				return
+			}
+			encodeJSON(w, http.StatusOK, spec)
+			return
+		}
+
+		q, err = s.controller.QueryWithCompile(ctx, queryStr)
+	}
+	if err != nil {
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(fmt.Sprintf("Error constructing query %s", err.Error())))
+		return
+	}
+	defer q.Done()
+
+	funcs, err := q.Spec.Functions()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf("Error analyzing query %s", err.Error())))
+		return
+	}
+
+	if s.verbose {
+		if octets, err := json.MarshalIndent(q.Spec, "", "    "); err == nil {
+			log.Print(string(octets))
+		}
+	}
+
+	for _, f := range funcs {
+		functionCounter.WithLabelValues(f).Inc()
+	}
+
+	results, ok := <-q.Ready
+	if !ok {
+		err := q.Err()
+		w.WriteHeader(errorStatus(err))
+		w.Write([]byte(fmt.Sprintf("Error executing query %s", err.Error())))
+		return
+	}
+	switch req.Header.Get("Accept") {
+	case "application/json":
+		writeJSONChunks(results, w)
+	case "text/csv":
+		writeCSVResults(results, w)
+	default:
+		writeLineResults(results, w)
+	}
+}
+
+type QueriesResponse struct {
+	Queries []Query
+}
+
+type Query struct {
+	ID    string
+	State string
+}
+
+// handleQueries returns the running queries of the tenant of the request.
+func (s *Server) handleQueries(w http.ResponseWriter, req *http.Request) {
+	tenant := ifql.TenantFromContext(req.Context())
+	var queries QueriesResponse
+	queries.Queries = []Query{}
+	for _, q := range s.controller.Queries() {
+		if q.Tenant() != tenant {
+			continue
+		}
+		queries.Queries = append(queries.Queries, Query{
+			ID:    strconv.FormatUint(uint64(q.ID()), 10),
+			State: q.State().String(),
+		})
+	}
+	err := json.NewEncoder(w).Encode(queries)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type QueryDetails struct {
+	ID          string
+	Tenant      string
+	State       string
+	Query       string
+	Spec        query.Spec
+	Plan        string
+	Transitions []StateTransition
+	Concurrency int
+	MemoryBytes int64
+}
+
+type StateTransition struct {
+	State string
+	Time  time.Time
+}
+
+// handleQueryByID returns the details of the running query with the id in the path on GET,
+// and cancels it on DELETE.
+func (s *Server) handleQueryByID(w http.ResponseWriter, req *http.Request) {
+	id, err := strconv.ParseUint(strings.TrimPrefix(req.URL.Path, "/queries/"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("invalid query id %s", err.Error())))
+		return
+	}
+	// The queries of other tenants are reported as unknown.
+	q := s.controller.QueryByID(ifql.QueryID(id))
+	if q == nil || q.Tenant() != ifql.TenantFromContext(req.Context()) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("unknown query %d", id)))
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		details := QueryDetails{
+			ID:          strconv.FormatUint(id, 10),
+			Tenant:      q.Tenant(),
+			State:       q.State().String(),
+			Query:       q.Text(),
+			Spec:        q.Spec,
+			Concurrency: q.Concurrency(),
+			MemoryBytes: q.Memory(),
+		}
+		if p := q.Plan(); p != nil {
+			details.Plan = fmt.Sprint(plan.Formatted(p))
+		}
+		for _, t := range q.StateTransitions() {
+			details.Transitions = append(details.Transitions, StateTransition{
+				State: t.State.String(),
+				Time:  t.Time,
+			})
+		}
+		encodeJSON(w, http.StatusOK, details)
+	case http.MethodDelete:
+		if err := s.controller.StopQuery(q.ID()); err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// errorStatus is the status of the response to a request that failed with err.
+func errorStatus(err error) int {
+	switch errors.Cause(err) {
+	case ErrUnauthenticated:
+		return http.StatusUnauthorized
+	case ErrForbidden:
+		return http.StatusForbidden
+	case ifql.ErrQueueFull:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func encodeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// already wrote to client
+	_ = json.NewEncoder(w).Encode(v)
+}
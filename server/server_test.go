@@ -0,0 +1,683 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/ifql"
+	"github.com/influxdata/ifql/query"
+	"github.com/influxdata/ifql/query/execute"
+	"github.com/influxdata/ifql/server"
+)
+
+const testQuery = `from(db:"db0") |> range(start:2018-01-01T00:00:00Z, stop:2018-01-02T00:00:00Z)`
+
+// newFileStorageReader returns a storage reader of a single point of db0 at 2018-01-01T00:00:00Z.
+// The returned function must be called to release it.
+func newFileStorageReader(t *testing.T) (execute.StorageReader, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := "# DDL\nCREATE DATABASE db0\n# DML\n# CONTEXT-DATABASE: db0\ncpu,host=a usage=1 1514764800000000000\n"
+	path := filepath.Join(dir, "data.lp")
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	sr, err := execute.NewFileStorageReader([]string{path})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return sr, func() {
+		sr.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func newController(t *testing.T, sr execute.StorageReader) *ifql.Controller {
+	t.Helper()
+	c, err := ifql.NewController(ifql.Config{
+		StorageReader:    sr,
+		ConcurrencyQuota: 4,
+		MemoryBytesQuota: math.MaxInt32,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func serve(s *server.Server, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Query(t *testing.T) {
+	sr, closeReader := newFileStorageReader(t)
+	defer closeReader()
+	s := server.New(server.Config{
+		Controller: newController(t, sr),
+	})
+
+	form := func(q string) url.Values {
+		return url.Values{"q": []string{q}}
+	}
+	spec, err := query.Compile(context.Background(), testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name        string
+		req         *http.Request
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name:   "line protocol",
+			req:    httptest.NewRequest("POST", "/query?"+form(testQuery).Encode(), nil),
+			status: http.StatusOK,
+			body:   "cpu,host=a usage=1 1514764800000000000\n",
+		},
+		{
+			name: "csv",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "/query?"+form(testQuery).Encode(), nil)
+				req.Header.Set("Accept", "text/csv")
+				return req
+			}(),
+			status:      http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+		},
+		{
+			name: "json chunks",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "/query?"+form(testQuery).Encode(), nil)
+				req.Header.Set("Accept", "application/json")
+				return req
+			}(),
+			status: http.StatusOK,
+			body: `{"result":"_result","seriesID":1,"tags":{"_field":"usage","_measurement":"cpu","host":"a"}}` + "\n" +
+				`{"points":[{"value":1,"time":1514764800000000000}]}` + "\n",
+		},
+		{
+			name: "spec",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "/query", strings.NewReader(string(specJSON)))
+				req.Header.Set("Content-type", "application/json")
+				return req
+			}(),
+			status: http.StatusOK,
+			body:   "cpu,host=a usage=1 1514764800000000000\n",
+		},
+		{
+			name:        "analyze",
+			req:         httptest.NewRequest("POST", "/query?analyze=true&"+form(testQuery).Encode(), nil),
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        string(specJSON) + "\n",
+		},
+		{
+			name:   "missing query",
+			req:    httptest.NewRequest("POST", "/query", nil),
+			status: http.StatusBadRequest,
+			body:   "must pass query in q parameter",
+		},
+		{
+			name:   "invalid query",
+			req:    httptest.NewRequest("POST", "/query?"+form(`from(`).Encode(), nil),
+			status: http.StatusInternalServerError,
+		},
+		{
+			name: "invalid spec",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "/query", strings.NewReader("{"))
+				req.Header.Set("Content-type", "application/json")
+				return req
+			}(),
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(s, tc.req)
+			if rec.Code != tc.status {
+				t.Fatalf("unexpected status: got %d want %d: %s", rec.Code, tc.status, rec.Body.String())
+			}
+			if tc.contentType != "" {
+				if got := rec.Header().Get("Content-Type"); got != tc.contentType {
+					t.Errorf("unexpected content type: got %q want %q", got, tc.contentType)
+				}
+			}
+			if tc.body != "" {
+				if got := rec.Body.String(); got != tc.body {
+					t.Errorf("unexpected body:\ngot  %q\nwant %q", got, tc.body)
+				}
+			}
+		})
+	}
+	if got, want := s.QueryCount(), int64(len(testCases)); got != want {
+		t.Errorf("unexpected query count: got %d want %d", got, want)
+	}
+}
+
+func TestServer_Queries(t *testing.T) {
+	sr, closeReader := newFileStorageReader(t)
+	defer closeReader()
+	c := newController(t, sr)
+	s := server.New(server.Config{
+		Controller: c,
+	})
+
+	// The query stays active until Done is called.
+	q, err := c.QueryWithCompile(context.Background(), testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+	if _, ok := <-q.Ready; !ok {
+		t.Fatal(q.Err())
+	}
+	id := strconv.FormatUint(uint64(q.ID()), 10)
+
+	rec := serve(s, httptest.NewRequest("GET", "/queries", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusOK)
+	}
+	var queries server.QueriesResponse
+	if err := json.NewDecoder(rec.Body).Decode(&queries); err != nil {
+		t.Fatal(err)
+	}
+	want := server.Query{ID: id, State: "executing"}
+	if len(queries.Queries) != 1 || queries.Queries[0] != want {
+		t.Errorf("unexpected queries: got %v want [%v]", queries.Queries, want)
+	}
+
+	rec = serve(s, httptest.NewRequest("GET", "/queries/"+id, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusOK)
+	}
+	var details server.QueryDetails
+	if err := json.NewDecoder(rec.Body).Decode(&details); err != nil {
+		t.Fatal(err)
+	}
+	if details.ID != id || details.State != "executing" || details.Query != testQuery {
+		t.Errorf("unexpected details: %+v", details)
+	}
+	if len(details.Spec.Operations) != 2 {
+		t.Errorf("unexpected spec operations: %v", details.Spec.Operations)
+	}
+	if details.Plan == "" {
+		t.Error("expected the physical plan of the query")
+	}
+	if details.Concurrency <= 0 || details.MemoryBytes <= 0 {
+		t.Errorf("unexpected resources: concurrency %d memory %d", details.Concurrency, details.MemoryBytes)
+	}
+	var states []string
+	for _, st := range details.Transitions {
+		states = append(states, st.State)
+	}
+	if got, want := strings.Join(states, ","), "created,compiling,queueing,planning,executing"; got != want {
+		t.Errorf("unexpected transitions: got %s want %s", got, want)
+	}
+
+	for _, tc := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{method: "GET", path: "/queries/x", status: http.StatusBadRequest},
+		{method: "GET", path: "/queries/0", status: http.StatusNotFound},
+		{method: "DELETE", path: "/queries/0", status: http.StatusNotFound},
+		{method: "POST", path: "/queries/" + id, status: http.StatusMethodNotAllowed},
+		{method: "DELETE", path: "/queries/" + id, status: http.StatusNoContent},
+	} {
+		rec := serve(s, httptest.NewRequest(tc.method, tc.path, nil))
+		if rec.Code != tc.status {
+			t.Errorf("%s %s: unexpected status: got %d want %d", tc.method, tc.path, rec.Code, tc.status)
+		}
+	}
+
+	// The query is canceled asynchronously.
+	deadline := time.Now().Add(5 * time.Second)
+	for q.State().String() != "canceled" {
+		if time.Now().After(deadline) {
+			t.Fatalf("query was not canceled, state %v", q.State())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestServer_QueriesOfOtherTenants(t *testing.T) {
+	sr, closeReader := newFileStorageReader(t)
+	defer closeReader()
+	path, remove := writeAuthFile(t, "t0 alice *", "t1 bob *")
+	defer remove()
+	a, err := server.NewTokenAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newController(t, sr)
+	s := server.New(server.Config{
+		Controller:    c,
+		Authenticator: a,
+	})
+
+	q, err := c.QueryWithCompile(ifql.NewContextWithTenant(context.Background(), "alice"), testQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+	if _, ok := <-q.Ready; !ok {
+		t.Fatal(q.Err())
+	}
+	id := strconv.FormatUint(uint64(q.ID()), 10)
+	newRequest := func(method, path, token string) *http.Request {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+	listQueries := func(token string) []server.Query {
+		t.Helper()
+		rec := serve(s, newRequest("GET", "/queries", token))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusOK)
+		}
+		var queries server.QueriesResponse
+		if err := json.NewDecoder(rec.Body).Decode(&queries); err != nil {
+			t.Fatal(err)
+		}
+		return queries.Queries
+	}
+
+	// The query is unknown to other tenants.
+	if got := listQueries("t1"); len(got) != 0 {
+		t.Errorf("unexpected queries of other tenant: %v", got)
+	}
+	for _, method := range []string{"GET", "DELETE"} {
+		if rec := serve(s, newRequest(method, "/queries/"+id, "t1")); rec.Code != http.StatusNotFound {
+			t.Errorf("%s by other tenant: unexpected status: got %d want %d", method, rec.Code, http.StatusNotFound)
+		}
+	}
+	if got := q.State().String(); got != "executing" {
+		t.Fatalf("query was stopped by other tenant, state %s", got)
+	}
+
+	want := server.Query{ID: id, State: "executing"}
+	if got := listQueries("t0"); len(got) != 1 || got[0] != want {
+		t.Errorf("unexpected queries: got %v want [%v]", got, want)
+	}
+	if rec := serve(s, newRequest("GET", "/queries/"+id, "t0")); rec.Code != http.StatusOK {
+		t.Errorf("GET: unexpected status: got %d want %d", rec.Code, http.StatusOK)
+	}
+	if rec := serve(s, newRequest("DELETE", "/queries/"+id, "t0")); rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE: unexpected status: got %d want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestServer_Metrics(t *testing.T) {
+	s := server.New(server.Config{})
+	rec := serve(s, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got %d want %d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), "ifql_query_count") {
+		t.Error("expected the query count metric")
+	}
+}
+
+func TestServer_HandlersAndMiddleware(t *testing.T) {
+	var order []string
+	middleware := func(name string) server.Middleware {
+		return func(h http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				order = append(order, name)
+				h.ServeHTTP(w, req)
+			})
+		}
+	}
+	s := server.New(server.Config{
+		Handlers: map[string]http.Handler{
+			"/ping": http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+			"/metrics": nil,
+		},
+		Middleware: []server.Middleware{middleware("outer"), middleware("inner")},
+	})
+
+	if rec := serve(s, httptest.NewRequest("GET", "/ping", nil)); rec.Code != http.StatusNoContent {
+		t.Errorf("unexpected status of added handler: got %d want %d", rec.Code, http.StatusNoContent)
+	}
+	if got, want := strings.Join(order, ","), "outer,inner"; got != want {
+		t.Errorf("unexpected middleware order: got %s want %s", got, want)
+	}
+	if rec := serve(s, httptest.NewRequest("GET", "/metrics", nil)); rec.Code != http.StatusNotFound {
+		t.Errorf("unexpected status of removed handler: got %d want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestServer_Authorization(t *testing.T) {
+	sr, closeReader := newFileStorageReader(t)
+	defer closeReader()
+	path, remove := writeAuthFile(t,
+		"t0 alice db0",
+		"t1 bob db0 h0:8082",
+		"t2 carol db1",
+	)
+	defer remove()
+	a, err := server.NewTokenAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := ifql.NewController(ifql.Config{
+		StorageReader:    sr,
+		ConcurrencyQuota: 4,
+		MemoryBytesQuota: math.MaxInt32,
+		Authorizer:       server.UserAuthorizer{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server.New(server.Config{
+		Controller:    c,
+		Authenticator: a,
+	})
+
+	testCases := []struct {
+		name  string
+		token string
+		query string
+		want  int
+	}{
+		{
+			name:  "missing token",
+			query: testQuery,
+			want:  http.StatusUnauthorized,
+		},
+		{
+			name:  "unknown token",
+			token: "t3",
+			query: testQuery,
+			want:  http.StatusUnauthorized,
+		},
+		{
+			name:  "allowed",
+			token: "t0",
+			query: testQuery,
+			want:  http.StatusOK,
+		},
+		{
+			name:  "forbidden database",
+			token: "t2",
+			query: testQuery,
+			want:  http.StatusForbidden,
+		},
+		{
+			name:  "allowed hosts",
+			token: "t1",
+			query: `from(db:"db0", hosts:["h0:8082"]) |> range(start:2018-01-01T00:00:00Z, stop:2018-01-02T00:00:00Z)`,
+			want:  http.StatusOK,
+		},
+		{
+			name:  "forbidden hosts",
+			token: "t0",
+			query: `from(db:"db0", hosts:["h0:8082"]) |> range(start:2018-01-01T00:00:00Z, stop:2018-01-02T00:00:00Z)`,
+			want:  http.StatusForbidden,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/query", strings.NewReader(url.Values{"q": []string{tc.query}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := serve(s, req)
+			if rec.Code != tc.want {
+				t.Fatalf("unexpected status: got %d want %d: %s", rec.Code, tc.want, rec.Body.String())
+			}
+			if got := rec.Header().Get("WWW-Authenticate"); tc.want == http.StatusUnauthorized && got != "Bearer" {
+				t.Errorf("unexpected challenge: got %q want %q", got, "Bearer")
+			}
+		})
+	}
+}
+
+func TestServer_TooManyQueries(t *testing.T) {
+	sr := newBlockingStorageReader()
+	path, remove := writeAuthFile(t, "t0 alice *")
+	defer remove()
+	a, err := server.NewTokenAuthenticator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := ifql.NewController(ifql.Config{
+		StorageReader:    sr,
+		ConcurrencyQuota: 4,
+		MemoryBytesQuota: math.MaxInt32,
+		DefaultTenantQuota: ifql.TenantQuota{
+			ConcurrencyQuota: 1,
+			MaxQueued:        1,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server.New(server.Config{
+		Controller:    c,
+		Authenticator: a,
+	})
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest("POST", "/query?"+url.Values{"q": []string{testQuery}}.Encode(), nil)
+		req.Header.Set("Authorization", "Bearer t0")
+		return req
+	}
+
+	// The first query executes and the second waits for the concurrency quota of the tenant.
+	responses := make(chan *httptest.ResponseRecorder, 2)
+	go func() {
+		responses <- serve(s, newRequest())
+	}()
+	<-sr.started
+	go func() {
+		responses <- serve(s, newRequest())
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(c.Queries()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("second query was not queued")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for _, q := range c.Queries() {
+		if got := q.Tenant(); got != "alice" {
+			t.Errorf("unexpected tenant: got %q want %q", got, "alice")
+		}
+	}
+
+	// The queue of the tenant is full.
+	if rec := serve(s, newRequest()); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("unexpected status: got %d want %d: %s", rec.Code, http.StatusTooManyRequests, rec.Body.String())
+	}
+
+	close(sr.release)
+	for i := 0; i < 2; i++ {
+		if rec := <-responses; rec.Code != http.StatusOK {
+			t.Errorf("unexpected status of queued query: got %d want %d", rec.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	sr := newBlockingStorageReader()
+	s := server.New(server.Config{
+		Controller:    newController(t, sr),
+		StorageReader: sr,
+	})
+
+	responses := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		responses <- serve(s, httptest.NewRequest("POST", "/query?"+url.Values{"q": []string{testQuery}}.Encode(), nil))
+	}()
+	<-sr.started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- s.Shutdown(context.Background())
+	}()
+
+	// New queries are rejected while the query being served drains.
+	// The request has no query, so that it fails immediately until the shutdown has started.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		rec := serve(s, httptest.NewRequest("POST", "/query", nil))
+		if rec.Code == http.StatusServiceUnavailable {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("query was not rejected, status %d", rec.Code)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-shutdown:
+		t.Fatal("shutdown returned before the query finished")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if sr.isClosed() {
+		t.Fatal("storage reader closed before the query finished")
+	}
+
+	close(sr.release)
+	if err := <-shutdown; err != nil {
+		t.Fatal(err)
+	}
+	if rec := <-responses; rec.Code != http.StatusOK {
+		t.Errorf("unexpected status of drained query: got %d want %d", rec.Code, http.StatusOK)
+	}
+	if !sr.isClosed() {
+		t.Error("expected the storage reader to be closed")
+	}
+}
+
+func TestServer_ShutdownTimeout(t *testing.T) {
+	sr := newBlockingStorageReader()
+	s := server.New(server.Config{
+		Controller:    newController(t, sr),
+		StorageReader: sr,
+	})
+
+	responses := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		responses <- serve(s, httptest.NewRequest("POST", "/query?"+url.Values{"q": []string{testQuery}}.Encode(), nil))
+	}()
+	<-sr.started
+
+	// The query never finishes on its own, it is canceled once the shutdown times out.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("unexpected error: got %v want %v", err, context.DeadlineExceeded)
+	}
+	select {
+	case <-responses:
+	case <-time.After(5 * time.Second):
+		t.Fatal("canceled query was not finished")
+	}
+	if sr.closedDuringRead() {
+		t.Error("storage reader closed before the canceled query finished reading")
+	}
+	if !sr.isClosed() {
+		t.Error("expected the storage reader to be closed")
+	}
+}
+
+// blockingStorageReader blocks reads until it is released or their context is done.
+type blockingStorageReader struct {
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+	// readAfterClose records whether a read returned after the reader was closed.
+	readAfterClose bool
+}
+
+func newBlockingStorageReader() *blockingStorageReader {
+	return &blockingStorageReader{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (s *blockingStorageReader) Read(ctx context.Context, trace map[string]string, rs execute.ReadSpec, start, stop execute.Time) (execute.BlockIterator, error) {
+	return blockingBlockIterator{ctx: ctx, s: s}, nil
+}
+
+func (s *blockingStorageReader) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+}
+
+func (s *blockingStorageReader) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *blockingStorageReader) closedDuringRead() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readAfterClose
+}
+
+// readDone records that a read has returned.
+func (s *blockingStorageReader) readDone() {
+	s.mu.Lock()
+	s.readAfterClose = s.readAfterClose || s.closed
+	s.mu.Unlock()
+}
+
+type blockingBlockIterator struct {
+	ctx context.Context
+	s   *blockingStorageReader
+}
+
+func (bi blockingBlockIterator) Do(f func(execute.Block) error) error {
+	bi.s.once.Do(func() {
+		close(bi.s.started)
+	})
+	defer bi.s.readDone()
+	select {
+	case <-bi.s.release:
+		return nil
+	case <-bi.ctx.Done():
+		return bi.ctx.Err()
+	}
+}